@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// APIKeyHeader is the request header carrying the API key.
+const APIKeyHeader = "X-API-Key"
+
+// hashPrefix marks a configured key that is already a SHA-256 hash.
+const hashPrefix = "sha256:"
+
+// HashKey returns the hex encoded SHA-256 hash of an API key.
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseAPIKeys parses a comma separated list of API keys in the format clientID:scope1|scope2:key.
+// The key is either the plain key, which is hashed, or its hash prefixed with "sha256:".
+func ParseAPIKeys(value string) ([]model.APIKey, error) {
+	var keys []model.APIKey
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("API key entry for %q must have the format clientID:scopes:key", parts[0])
+		}
+
+		scopes := strings.Split(parts[1], "|")
+		for _, scope := range scopes {
+			if scope != model.ScopeSubmit && scope != model.ScopeRead && scope != model.ScopeAdmin {
+				return nil, fmt.Errorf("API key entry for %q has unknown scope %q", parts[0], scope)
+			}
+		}
+
+		keyHash := HashKey(parts[2])
+		if strings.HasPrefix(parts[2], hashPrefix) {
+			keyHash = strings.ToLower(strings.TrimPrefix(parts[2], hashPrefix))
+			if decoded, err := hex.DecodeString(keyHash); err != nil || len(decoded) != sha256.Size {
+				return nil, fmt.Errorf("API key entry for %q has an invalid sha256 hash", parts[0])
+			}
+		}
+
+		keys = append(keys, model.APIKey{ClientID: parts[0], KeyHash: keyHash, Scopes: scopes})
+	}
+
+	return keys, nil
+}
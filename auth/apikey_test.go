@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestParseAPIKeys(t *testing.T) {
+	hash := HashKey("s3cret")
+
+	testCases := []struct {
+		id            int
+		useCase       string
+		value         string
+		expectedKeys  []model.APIKey
+		expectedError string
+	}{
+		{
+			id: 1, useCase: "Positive case: empty value",
+			value:        "",
+			expectedKeys: nil,
+		},
+		{
+			id: 2, useCase: "Positive case: plain key is hashed",
+			value:        "partner-a:submit|read:s3cret",
+			expectedKeys: []model.APIKey{{ClientID: "partner-a", KeyHash: hash, Scopes: []string{"submit", "read"}}},
+		},
+		{
+			id: 3, useCase: "Positive case: pre hashed key and several entries",
+			value: fmt.Sprintf("partner-a:read:s3cret, ops:admin:sha256:%v", hash),
+			expectedKeys: []model.APIKey{
+				{ClientID: "partner-a", KeyHash: hash, Scopes: []string{"read"}},
+				{ClientID: "ops", KeyHash: hash, Scopes: []string{"admin"}},
+			},
+		},
+		{
+			id: 4, useCase: "Negative case: missing key",
+			value:         "partner-a:read",
+			expectedError: "API key entry for \"partner-a\" must have the format clientID:scopes:key",
+		},
+		{
+			id: 5, useCase: "Negative case: unknown scope",
+			value:         "partner-a:delete:s3cret",
+			expectedError: "API key entry for \"partner-a\" has unknown scope \"delete\"",
+		},
+		{
+			id: 6, useCase: "Negative case: invalid hash",
+			value:         "partner-a:read:sha256:abc",
+			expectedError: "API key entry for \"partner-a\" has an invalid sha256 hash",
+		},
+	}
+
+	for _, tc := range testCases {
+		keys, err := ParseAPIKeys(tc.value)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		} else {
+			assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+
+		assert.Equal(t, tc.expectedKeys, keys, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
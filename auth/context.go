@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"context"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+type contextKey string
+
+const principalKey contextKey = "principal"
+
+// NewContext returns a copy of ctx carrying the authenticated principal of the request.
+func NewContext(ctx context.Context, p *model.Principal) context.Context {
+	return context.WithValue(ctx, principalKey, p)
+}
+
+// FromContext returns the principal stored in ctx, or nil when the request is not authenticated.
+func FromContext(ctx context.Context) *model.Principal {
+	p, _ := ctx.Value(principalKey).(*model.Principal)
+	return p
+}
@@ -0,0 +1,299 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	er "errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// VerifierConfig lists the key files and claim requirements of a Verifier.
+type VerifierConfig struct {
+	HMACSecretFile   string        // File holding the shared HS256 secret.
+	RSAPublicKeyFile string        // PEM file holding an RS256 public key, used for tokens without a kid.
+	JWKSFile         string        // JSON Web Key Set file holding RS256 public keys selected by kid.
+	Audience         string        // Required aud claim, not checked when empty.
+	Issuer           string        // Required iss claim, not checked when empty.
+	RolesClaim       string        // Name of the claim holding the roles of the user, defaults to "roles".
+	Leeway           time.Duration // Clock skew tolerated when checking exp and nbf.
+}
+
+// Verifier validates HS256 and RS256 signed JSON Web Tokens.
+type Verifier struct {
+	hmacSecret []byte
+	rsaKey     *rsa.PublicKey
+	rsaKeys    map[string]*rsa.PublicKey // RSA keys of the JWKS file by kid.
+	audience   string
+	issuer     string
+	rolesClaim string
+	leeway     time.Duration
+	now        func() time.Time
+}
+
+// Claims are the registered and custom claims read from a token.
+type Claims struct {
+	Subject   string
+	ClientID  string
+	Audience  []string
+	Issuer    string
+	ExpiresAt time.Time
+	NotBefore time.Time
+	Roles     []string
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// NewVerifier loads the configured key files and returns a Verifier.
+// It returns nil and no error when no key file is configured, as JWT authentication is then disabled.
+func NewVerifier(cfg VerifierConfig) (*Verifier, error) {
+	if cfg.HMACSecretFile == "" && cfg.RSAPublicKeyFile == "" && cfg.JWKSFile == "" {
+		return nil, nil
+	}
+
+	v := &Verifier{
+		rsaKeys:    make(map[string]*rsa.PublicKey),
+		audience:   cfg.Audience,
+		issuer:     cfg.Issuer,
+		rolesClaim: cfg.RolesClaim,
+		leeway:     cfg.Leeway,
+		now:        time.Now,
+	}
+
+	if v.rolesClaim == "" {
+		v.rolesClaim = "roles"
+	}
+
+	if cfg.HMACSecretFile != "" {
+		secret, err := os.ReadFile(cfg.HMACSecretFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading HMAC secret file: %w", err)
+		}
+
+		v.hmacSecret = []byte(strings.TrimSpace(string(secret)))
+		if len(v.hmacSecret) < 32 {
+			return nil, er.New("HMAC secret must be at least 32 bytes long")
+		}
+	}
+
+	if cfg.RSAPublicKeyFile != "" {
+		key, err := readRSAPublicKey(cfg.RSAPublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		v.rsaKey = key
+	}
+
+	if cfg.JWKSFile != "" {
+		keys, err := readJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+
+		v.rsaKeys = keys
+	}
+
+	return v, nil
+}
+
+// Verify checks the signature, exp, nbf, aud and iss of a compact serialized token and returns its claims.
+// Errors wrap those of the jwt package, e.g. jwt.ErrTokenExpired.
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(v.methods()),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(v.leeway),
+		jwt.WithTimeFunc(v.now),
+	}
+
+	if v.audience != "" {
+		opts = append(opts, jwt.WithAudience(v.audience))
+	}
+
+	if v.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(v.issuer))
+	}
+
+	raw := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(token, raw, v.key, opts...); err != nil {
+		return nil, err
+	}
+
+	return v.parseClaims(raw)
+}
+
+// Principal maps the claims of a token to the principal of the request, the roles of the user become its scopes.
+func (c *Claims) Principal() *model.Principal {
+	clientID := c.ClientID
+	if clientID == "" {
+		clientID = c.Subject
+	}
+
+	return &model.Principal{ClientID: clientID, UserID: c.Subject, Scopes: c.Roles}
+}
+
+// methods returns the signing algorithms of the configured keys.
+func (v *Verifier) methods() []string {
+	var methods []string
+	if v.hmacSecret != nil {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+
+	if v.rsaKey != nil || len(v.rsaKeys) > 0 {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	return methods
+}
+
+// key returns the key verifying the signature of token. The algorithm decides the key type, so an RSA public key can
+// never be used as an HMAC secret.
+func (v *Verifier) key(token *jwt.Token) (interface{}, error) {
+	if token.Method == jwt.SigningMethodHS256 {
+		return v.hmacSecret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+
+	return v.rsaKeyFor(kid)
+}
+
+func (v *Verifier) rsaKeyFor(kid string) (*rsa.PublicKey, error) {
+	if kid != "" {
+		if key, ok := v.rsaKeys[kid]; ok {
+			return key, nil
+		}
+
+		return nil, fmt.Errorf("no key found for kid %q", kid)
+	}
+
+	if v.rsaKey == nil {
+		return nil, er.New("RS256 tokens are not accepted")
+	}
+
+	return v.rsaKey, nil
+}
+
+func (v *Verifier) parseClaims(raw jwt.MapClaims) (*Claims, error) {
+	claims := &Claims{}
+
+	claims.Subject, _ = raw.GetSubject()
+	if claims.Subject == "" {
+		return nil, er.New("token has no sub claim")
+	}
+
+	claims.Issuer, _ = raw.GetIssuer()
+	claims.Audience, _ = raw.GetAudience()
+
+	if clientID, ok := raw["client_id"].(string); ok {
+		claims.ClientID = clientID
+	} else if azp, ok := raw["azp"].(string); ok {
+		claims.ClientID = azp
+	}
+
+	if exp, _ := raw.GetExpirationTime(); exp != nil {
+		claims.ExpiresAt = exp.Time
+	}
+
+	if nbf, _ := raw.GetNotBefore(); nbf != nil {
+		claims.NotBefore = nbf.Time
+	}
+
+	// Roles are either a JSON array or a space separated string as used by the OAuth scope claim.
+	switch roles := raw[v.rolesClaim].(type) {
+	case string:
+		claims.Roles = strings.Fields(roles)
+	case []interface{}:
+		for _, r := range roles {
+			if s, ok := r.(string); ok {
+				claims.Roles = append(claims.Roles, s)
+			}
+		}
+	}
+
+	return claims, nil
+}
+
+func readRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading RSA public key file: %w", err)
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, er.New("RSA public key file has no PEM block")
+	}
+
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing RSA public key: %w", err)
+	}
+
+	key, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, er.New("public key is not an RSA key")
+	}
+
+	return key, nil
+}
+
+func readJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading JWKS file: %w", err)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err = json.Unmarshal(b, &set); err != nil {
+		return nil, fmt.Errorf("parsing JWKS file: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range set.Keys {
+		// Only RSA signing keys are used, other keys of the set are skipped.
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("JWKS key %q has an invalid modulus", k.Kid)
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("JWKS key %q has an invalid exponent", k.Kid)
+		}
+
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+
+	if len(keys) == 0 {
+		return nil, er.New("JWKS file has no RSA signing keys")
+	}
+
+	return keys, nil
+}
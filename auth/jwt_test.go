@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+const testHMACSecret = "0123456789abcdef0123456789abcdef"
+
+// signToken builds a compact serialized token, key is a []byte HMAC secret or an *rsa.PrivateKey.
+func signToken(t *testing.T, header, claims map[string]interface{}, key interface{}) string {
+	h, _ := json.Marshal(header)
+	c, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+
+	var signature []byte
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256([]byte(signed))
+		var err error
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+		assert.NoError(t, err)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// setUpKeys writes an HMAC secret, an RSA public key and a JWKS file to a temporary directory.
+func setUpKeys(t *testing.T) (VerifierConfig, *rsa.PrivateKey, *rsa.PrivateKey) {
+	dir := t.TempDir()
+
+	pemKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	jwksKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	secretFile := filepath.Join(dir, "hmac.secret")
+	assert.NoError(t, os.WriteFile(secretFile, []byte(testHMACSecret+"\n"), 0600))
+
+	der, _ := x509.MarshalPKIXPublicKey(&pemKey.PublicKey)
+	pemFile := filepath.Join(dir, "public.pem")
+	assert.NoError(t, os.WriteFile(pemFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+
+	jwks, _ := json.Marshal(map[string]interface{}{"keys": []map[string]string{
+		{"kty": "EC", "kid": "ec-1", "crv": "P-256"},
+		{
+			"kty": "RSA", "kid": "gateway-1", "use": "sig",
+			"n": base64.RawURLEncoding.EncodeToString(jwksKey.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(jwksKey.E)).Bytes()),
+		},
+	}})
+	jwksFile := filepath.Join(dir, "jwks.json")
+	assert.NoError(t, os.WriteFile(jwksFile, jwks, 0600))
+
+	cfg := VerifierConfig{
+		HMACSecretFile:   secretFile,
+		RSAPublicKeyFile: pemFile,
+		JWKSFile:         jwksFile,
+		Audience:         "receipts",
+		Issuer:           "https://gateway.example.com",
+		Leeway:           30 * time.Second,
+	}
+
+	return cfg, pemKey, jwksKey
+}
+
+func TestVerifierVerify(t *testing.T) {
+	cfg, pemKey, jwksKey := setUpKeys(t)
+	verifier, err := NewVerifier(cfg)
+	assert.NoError(t, err)
+
+	now := time.Now()
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"sub": "user-1", "aud": "receipts", "iss": "https://gateway.example.com",
+			"exp": now.Add(time.Hour).Unix(), "roles": []string{"submit", "read"},
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+
+		return c
+	}
+
+	hs256 := map[string]interface{}{"alg": "HS256", "typ": "JWT"}
+	rs256 := map[string]interface{}{"alg": "RS256", "typ": "JWT"}
+	rs256Kid := map[string]interface{}{"alg": "RS256", "typ": "JWT", "kid": "gateway-1"}
+
+	testCases := []struct {
+		id                int
+		useCase           string
+		token             string
+		expectedPrincipal *model.Principal
+		expectedError     string
+	}{
+		{
+			id: 1, useCase: "Positive case: HS256 token",
+			token:             signToken(t, hs256, claims(nil), []byte(testHMACSecret)),
+			expectedPrincipal: &model.Principal{ClientID: "user-1", UserID: "user-1", Scopes: []string{"submit", "read"}},
+		},
+		{
+			id: 2, useCase: "Positive case: RS256 token verified with the PEM key",
+			token:             signToken(t, rs256, claims(map[string]interface{}{"client_id": "partner-a"}), pemKey),
+			expectedPrincipal: &model.Principal{ClientID: "partner-a", UserID: "user-1", Scopes: []string{"submit", "read"}},
+		},
+		{
+			id: 3, useCase: "Positive case: RS256 token verified with the JWKS key, audience list and scope string",
+			token:             signToken(t, rs256Kid, claims(map[string]interface{}{"aud": []string{"other", "receipts"}, "roles": "read admin"}), jwksKey),
+			expectedPrincipal: &model.Principal{ClientID: "user-1", UserID: "user-1", Scopes: []string{"read", "admin"}},
+		},
+		{
+			id: 4, useCase: "Negative case: expired token",
+			token:         signToken(t, hs256, claims(map[string]interface{}{"exp": now.Add(-time.Minute).Unix()}), []byte(testHMACSecret)),
+			expectedError: "token has invalid claims: token is expired",
+		},
+		{
+			id: 5, useCase: "Positive case: expired within leeway",
+			token:             signToken(t, hs256, claims(map[string]interface{}{"exp": now.Add(-10 * time.Second).Unix()}), []byte(testHMACSecret)),
+			expectedPrincipal: &model.Principal{ClientID: "user-1", UserID: "user-1", Scopes: []string{"submit", "read"}},
+		},
+		{
+			id: 6, useCase: "Negative case: token not valid yet",
+			token:         signToken(t, hs256, claims(map[string]interface{}{"nbf": now.Add(time.Hour).Unix()}), []byte(testHMACSecret)),
+			expectedError: "token has invalid claims: token is not valid yet",
+		},
+		{
+			id: 7, useCase: "Negative case: wrong audience",
+			token:         signToken(t, hs256, claims(map[string]interface{}{"aud": "billing"}), []byte(testHMACSecret)),
+			expectedError: "token has invalid claims: token has invalid audience",
+		},
+		{
+			id: 8, useCase: "Negative case: wrong issuer",
+			token:         signToken(t, hs256, claims(map[string]interface{}{"iss": "https://evil.example.com"}), []byte(testHMACSecret)),
+			expectedError: "token has invalid claims: token has invalid issuer",
+		},
+		{
+			id: 9, useCase: "Negative case: missing exp",
+			token:         signToken(t, hs256, claims(map[string]interface{}{"exp": nil}), []byte(testHMACSecret)),
+			expectedError: "token has invalid claims: token is missing required claim: exp claim is required",
+		},
+		{
+			id: 10, useCase: "Negative case: signed with another secret",
+			token:         signToken(t, hs256, claims(nil), []byte("another-secret-another-secret-00")),
+			expectedError: "token signature is invalid: signature is invalid",
+		},
+		{
+			id: 11, useCase: "Negative case: RS256 token signed with the wrong key",
+			token:         signToken(t, rs256Kid, claims(nil), pemKey),
+			expectedError: "token signature is invalid: crypto/rsa: verification error",
+		},
+		{
+			id: 12, useCase: "Negative case: unknown kid",
+			token:         signToken(t, map[string]interface{}{"alg": "RS256", "kid": "gateway-2"}, claims(nil), jwksKey),
+			expectedError: "token is unverifiable: error while executing keyfunc: no key found for kid \"gateway-2\"",
+		},
+		{
+			id: 13, useCase: "Negative case: alg none",
+			token:         signToken(t, map[string]interface{}{"alg": "none"}, claims(nil), nil),
+			expectedError: "token signature is invalid: signing method none is invalid",
+		},
+		{
+			id: 14, useCase: "Negative case: malformed token",
+			token:         "not-a-token",
+			expectedError: "token is malformed: token contains an invalid number of segments",
+		},
+	}
+
+	for _, tc := range testCases {
+		c, err := verifier.Verify(tc.token)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			continue
+		}
+
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		if c != nil {
+			assert.Equal(t, tc.expectedPrincipal, c.Principal(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+	}
+}
+
+func TestNewVerifier(t *testing.T) {
+	cfg, pemKey, _ := setUpKeys(t)
+
+	verifier, err := NewVerifier(VerifierConfig{})
+	assert.Nil(t, verifier)
+	assert.NoError(t, err)
+
+	// Only RSA keys are configured, HS256 tokens must not be verified with any of them.
+	verifier, err = NewVerifier(VerifierConfig{RSAPublicKeyFile: cfg.RSAPublicKeyFile})
+	assert.NoError(t, err)
+
+	der, _ := x509.MarshalPKIXPublicKey(&pemKey.PublicKey)
+	token := signToken(t, map[string]interface{}{"alg": "HS256"}, map[string]interface{}{"sub": "user-1", "exp": time.Now().Add(time.Hour).Unix()}, der)
+	_, err = verifier.Verify(token)
+	assert.EqualError(t, err, "token signature is invalid: signing method HS256 is invalid")
+
+	short := filepath.Join(t.TempDir(), "short.secret")
+	assert.NoError(t, os.WriteFile(short, []byte("short"), 0600))
+	_, err = NewVerifier(VerifierConfig{HMACSecretFile: short})
+	assert.EqualError(t, err, "HMAC secret must be at least 32 bytes long")
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/responder"
+)
+
+// Authenticator authenticates requests and authorizes them against the scope required by a route.
+type Authenticator struct {
+	logger   *log.CustomLogger
+	keys     data.APIKeys
+	verifier *Verifier // Verifier of bearer tokens, nil when JWT authentication is not configured.
+	enabled  bool
+}
+
+// New creates and returns a new instance of Authenticator. When enabled is false every request is let through unauthenticated.
+func New(l *log.CustomLogger, keys data.APIKeys, verifier *Verifier, enabled bool) *Authenticator {
+	return &Authenticator{
+		logger:   l,
+		keys:     keys,
+		verifier: verifier,
+		enabled:  enabled,
+	}
+}
+
+// Require wraps next so that it is only served to callers granted scope.
+// The authenticated principal is stored in the request context, see FromContext.
+func (a *Authenticator) Require(scope string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.enabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		principal, err := a.Authenticate(r.Header.Get("Authorization"), r.Header.Get(APIKeyHeader))
+		if err != nil {
+			responder.SetErrorResponse(a.logger, err, w, r)
+			return
+		}
+
+		if !principal.HasScope(scope) {
+			responder.SetErrorResponse(a.logger, errors.NewForbidden(errors.Forbidden{Scope: scope}), w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), principal)))
+	})
+}
+
+// Enabled reports whether callers are authenticated, every caller is let through otherwise.
+func (a *Authenticator) Enabled() bool {
+	return a.enabled
+}
+
+// Authenticate resolves the principal from the bearer token of the authorization credentials or, when there are none, the API key.
+func (a *Authenticator) Authenticate(authorization, key string) (*model.Principal, error) {
+	if authorization != "" {
+		scheme, token, _ := strings.Cut(authorization, " ")
+		if !strings.EqualFold(scheme, "Bearer") || a.verifier == nil {
+			return nil, errors.NewUnauthorized(errors.Unauthorized{Reason: "unsupported authorization scheme"})
+		}
+
+		claims, err := a.verifier.Verify(strings.TrimSpace(token))
+		if err != nil {
+			return nil, errors.NewUnauthorized(errors.Unauthorized{Reason: fmt.Sprintf("invalid bearer token: %v", err)})
+		}
+
+		return claims.Principal(), nil
+	}
+
+	if key == "" {
+		return nil, errors.NewUnauthorized(errors.Unauthorized{Reason: "missing API key"})
+	}
+
+	apiKey, err := a.keys.Get(HashKey(key))
+	if err != nil {
+		return nil, errors.NewUnauthorized(errors.Unauthorized{Reason: "invalid API key"})
+	}
+
+	return &model.Principal{ClientID: apiKey.ClientID, Scopes: apiKey.Scopes}, nil
+}
@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestAuthenticatorRequire(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger, _ := log.NewCustomLogger("test.log")
+	keys := data.NewMockAPIKeys(ctrl)
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		enabled          bool
+		apiKey           string
+		statusCode       int
+		expectedResponse string
+		expectedClientID string
+		mockCall         *gomock.Call
+	}{
+		{
+			id: 1, useCase: "Positive case: authentication disabled",
+			enabled:          false,
+			statusCode:       200,
+			expectedResponse: "anonymous",
+		},
+		{
+			id: 2, useCase: "Negative case: missing API key",
+			enabled:          true,
+			statusCode:       401,
+			expectedResponse: "Authentication required: missing API key",
+		},
+		{
+			id: 3, useCase: "Negative case: unknown API key",
+			enabled:          true,
+			apiKey:           "wrong",
+			statusCode:       401,
+			expectedResponse: "Authentication required: invalid API key",
+			mockCall: keys.EXPECT().Get(HashKey("wrong")).
+				Return(nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "apiKeys", ID: HashKey("wrong")})),
+		},
+		{
+			id: 4, useCase: "Negative case: API key without the required scope",
+			enabled:          true,
+			apiKey:           "reader",
+			statusCode:       403,
+			expectedResponse: "Scope 'submit' is required for this request",
+			mockCall: keys.EXPECT().Get(HashKey("reader")).
+				Return(&model.APIKey{ClientID: "partner-a", KeyHash: HashKey("reader"), Scopes: []string{model.ScopeRead}}, nil),
+		},
+		{
+			id: 5, useCase: "Positive case: API key with the required scope",
+			enabled:          true,
+			apiKey:           "submitter",
+			statusCode:       200,
+			expectedResponse: "partner-b",
+			mockCall: keys.EXPECT().Get(HashKey("submitter")).
+				Return(&model.APIKey{ClientID: "partner-b", KeyHash: HashKey("submitter"), Scopes: []string{model.ScopeSubmit}}, nil),
+		},
+		{
+			id: 6, useCase: "Positive case: admin API key has every scope",
+			enabled:          true,
+			apiKey:           "admin",
+			statusCode:       200,
+			expectedResponse: "ops",
+			mockCall: keys.EXPECT().Get(HashKey("admin")).
+				Return(&model.APIKey{ClientID: "ops", KeyHash: HashKey("admin"), Scopes: []string{model.ScopeAdmin}}, nil),
+		},
+	}
+
+	for _, tc := range testCases {
+		authenticator := New(logger, keys, nil, tc.enabled)
+		h := authenticator.Require(model.ScopeSubmit, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientID := "anonymous"
+			if p := FromContext(r.Context()); p != nil {
+				clientID = p.ClientID
+			}
+
+			_, _ = w.Write([]byte(clientID))
+		}))
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/v1/receipts/process", nil)
+		if tc.apiKey != "" {
+			r.Header.Set(APIKeyHeader, tc.apiKey)
+		}
+
+		h.ServeHTTP(w, r)
+		result := w.Result()
+		resp, _ := io.ReadAll(result.Body)
+
+		assert.Equal(t, tc.statusCode, result.StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Regexp(t, regexp.MustCompile(tc.expectedResponse), string(resp), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestAuthenticatorRequire_Bearer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger, _ := log.NewCustomLogger("test.log")
+	keys := data.NewMockAPIKeys(ctrl)
+
+	cfg, _, _ := setUpKeys(t)
+	verifier, _ := NewVerifier(cfg)
+	authenticator := New(logger, keys, verifier, true)
+
+	token := func(roles []string) string {
+		return signToken(t, map[string]interface{}{"alg": "HS256"}, map[string]interface{}{
+			"sub": "user-1", "aud": "receipts", "iss": "https://gateway.example.com", "exp": time.Now().Add(time.Hour).Unix(), "roles": roles,
+		}, []byte(testHMACSecret))
+	}
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		authorization    string
+		statusCode       int
+		expectedResponse string
+	}{
+		{
+			id: 1, useCase: "Positive case: bearer token with the required role",
+			authorization:    "Bearer " + token([]string{model.ScopeSubmit}),
+			statusCode:       200,
+			expectedResponse: "user-1",
+		},
+		{
+			id: 2, useCase: "Negative case: bearer token without the required role",
+			authorization:    "Bearer " + token([]string{model.ScopeRead}),
+			statusCode:       403,
+			expectedResponse: "Scope 'submit' is required for this request",
+		},
+		{
+			id: 3, useCase: "Negative case: invalid bearer token",
+			authorization:    "Bearer " + token([]string{model.ScopeSubmit}) + "x",
+			statusCode:       401,
+			expectedResponse: "Authentication required: invalid bearer token: token signature is invalid",
+		},
+		{
+			id: 4, useCase: "Negative case: unsupported scheme",
+			authorization:    "Basic dXNlcjpwYXNz",
+			statusCode:       401,
+			expectedResponse: "Authentication required: unsupported authorization scheme",
+		},
+	}
+
+	for _, tc := range testCases {
+		h := authenticator.Require(model.ScopeSubmit, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(FromContext(r.Context()).UserID))
+		}))
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/v1/receipts/process", nil)
+		r.Header.Set("Authorization", tc.authorization)
+
+		h.ServeHTTP(w, r)
+		result := w.Result()
+		resp, _ := io.ReadAll(result.Body)
+
+		assert.Equal(t, tc.statusCode, result.StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Regexp(t, regexp.MustCompile(tc.expectedResponse), string(resp), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		if tc.statusCode == 401 {
+			assert.Contains(t, result.Header.Get("WWW-Authenticate"), "Bearer")
+		}
+	}
+}
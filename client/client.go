@@ -0,0 +1,261 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	er "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+)
+
+// idempotencyKeyHeader is the header of the idempotency key of a submission, see the idempotency package.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// RetryPolicy bounds the retries of requests failing in a way a retry may overcome: network errors, 429, 502, 503,
+// 504 and submissions still being processed under their idempotency key.
+type RetryPolicy struct {
+	MaxAttempts int           // Attempts of a request, including the first one, 1 disables retries.
+	Backoff     time.Duration // Delay before the first retry, doubled on every retry.
+	MaxBackoff  time.Duration // Bound of the delay between retries, longer Retry-After delays are not waited for.
+}
+
+// DefaultRetryPolicy is the retry policy of clients created without WithRetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, Backoff: 200 * time.Millisecond, MaxBackoff: 5 * time.Second}
+
+// Client is a typed client of the HTTP API of the receipts service, safe for concurrent use.
+// Errors of the API are returned as an *Error carrying the request ID and wrapping the error types of the errors
+// package, e.g. errors.EntityNotFound for a 404, found with errors.As. Submissions carry an Idempotency-Key header, so they are retried safely.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	header     http.Header // Headers sent with every request, like credentials.
+	retry      RetryPolicy
+	sleep      func(ctx context.Context, d time.Duration) error
+}
+
+// Option configures optional settings of Client.
+type Option func(*Client)
+
+// WithHTTPClient sends the requests with httpClient instead of http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithAPIKey authenticates the requests with an API key.
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.header.Set("X-API-Key", key)
+	}
+}
+
+// WithBearerToken authenticates the requests with a JWT bearer token.
+func WithBearerToken(token string) Option {
+	return func(c *Client) {
+		c.header.Set("Authorization", "Bearer "+token)
+	}
+}
+
+// WithRetryPolicy retries failed requests following policy instead of DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// New creates and returns a new Client of the API served at baseURL, e.g. http://localhost:8080.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("client: invalid base URL %q", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    strings.TrimSuffix(u.String(), "/"),
+		httpClient: http.DefaultClient,
+		header:     make(http.Header),
+		retry:      DefaultRetryPolicy,
+		sleep:      sleep,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.retry.MaxAttempts < 1 {
+		c.retry.MaxAttempts = 1
+	}
+
+	if c.retry.MaxBackoff < c.retry.Backoff {
+		c.retry.MaxBackoff = c.retry.Backoff
+	}
+
+	return c, nil
+}
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns a copy of ctx whose submissions send key as Idempotency-Key instead of a generated key,
+// so that a submission can be retried by the caller too, e.g. after a restart, without being processed twice.
+// Each receipt of a batch sends key suffixed with its index.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// newIdempotencyKey returns the idempotency key of ctx, or a generated key when ctx has none.
+func newIdempotencyKey(ctx context.Context) string {
+	if key, ok := ctx.Value(idempotencyKey{}).(string); ok && key != "" {
+		return key
+	}
+
+	return uuid.New().String()
+}
+
+// request describes a call to the API.
+type request struct {
+	method         string
+	path           string
+	query          url.Values
+	body           interface{}
+	idempotencyKey string // Sent with submissions, which are only retried with a key.
+}
+
+// do sends req, retrying it following the retry policy, and decodes the response body into out.
+func (c *Client) do(ctx context.Context, req request, out interface{}) error {
+	var body []byte
+	if req.body != nil {
+		b, err := json.Marshal(req.body)
+		if err != nil {
+			return fmt.Errorf("client: encoding request body: %w", err)
+		}
+
+		body = b
+	}
+
+	target := c.baseURL + req.path
+	if len(req.query) > 0 {
+		target += "?" + req.query.Encode()
+	}
+
+	retriable := req.method == http.MethodGet || req.idempotencyKey != ""
+	backoff := c.retry.Backoff
+
+	for attempt := 1; ; attempt++ {
+		delay, err := c.send(ctx, req, target, body, out)
+		if err == nil {
+			return nil
+		}
+
+		if !retriable || delay < 0 || attempt >= c.retry.MaxAttempts || ctx.Err() != nil {
+			return err
+		}
+
+		// The server may ask for a longer delay, retrying sooner would fail again.
+		if delay < backoff {
+			delay = backoff
+		}
+
+		if delay > c.retry.MaxBackoff {
+			return err
+		}
+
+		if err := c.sleep(ctx, delay); err != nil {
+			return err
+		}
+
+		backoff = min(2*backoff, c.retry.MaxBackoff)
+	}
+}
+
+// send sends a single attempt of req. When it fails it returns the delay the server asked to wait before a retry,
+// 0 if it did not ask for one, or -1 when the request must not be retried.
+func (c *Client) send(ctx context.Context, req request, target string, body []byte, out interface{}) (time.Duration, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, target, bytes.NewReader(body))
+	if err != nil {
+		return -1, fmt.Errorf("client: %w", err)
+	}
+
+	for name, values := range c.header {
+		httpReq.Header[name] = values
+	}
+
+	httpReq.Header.Set("Accept", "application/json, application/problem+json")
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	if req.idempotencyKey != "" {
+		httpReq.Header.Set(idempotencyKeyHeader, req.idempotencyKey)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		apiErr := decodeError(resp)
+		if !isRetriable(apiErr) {
+			return -1, apiErr
+		}
+
+		return retryAfter(resp), apiErr
+	}
+
+	if out == nil {
+		return 0, nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && !er.Is(err, io.EOF) {
+		return -1, fmt.Errorf("client: decoding response of %v %v: %w", req.method, req.path, err)
+	}
+
+	return 0, nil
+}
+
+// isRetriable reports whether a request that failed with err may succeed when sent again.
+func isRetriable(err errors.Error) bool {
+	switch err.Status() {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case http.StatusConflict:
+		return err.ErrorCode() == errors.CodeIdempotencyInProgress
+	default:
+		return false
+	}
+}
+
+// retryAfter returns the delay of the Retry-After header of resp in seconds, 0 when it is missing.
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// sleep waits for d, or returns the error of ctx if it is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
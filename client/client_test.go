@@ -0,0 +1,418 @@
+package client
+
+import (
+	"context"
+	er "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/config"
+	store "github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/health"
+	"github/shivasaicharanruthala/backend-engineer-takehome/idempotency"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/middleware"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/openapi"
+	"github/shivasaicharanruthala/backend-engineer-takehome/ratelimit"
+	"github/shivasaicharanruthala/backend-engineer-takehome/responder"
+	"github/shivasaicharanruthala/backend-engineer-takehome/routes"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+	"github/shivasaicharanruthala/backend-engineer-takehome/stream"
+)
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+
+// newTestServer serves the API over in-memory stores, with authentication and rate limiting disabled, through the
+// middlewares of the server. wrap, when not nil, wraps the API to alter the responses the client gets.
+func newTestServer(t *testing.T, wrap func(http.Handler) http.Handler) *httptest.Server {
+	logger, _ := log.NewCustomLogger("test.log")
+
+	cfg, err := config.Load([]string{})
+	assert.NoError(t, err)
+
+	spec, err := openapi.Load(openapi.Spec)
+	assert.NoError(t, err)
+
+	receipts, ledger := store.New(logger), store.NewLedger(logger)
+
+	router, err := routes.New(logger, cfg, routes.Dependencies{
+		Receipts:      service.New(logger, receipts, service.WithLedger(ledger)),
+		Users:         service.NewUsers(logger, ledger),
+		Rewards:       service.NewRewards(logger, store.NewRewards(logger), ledger),
+		Returns:       service.NewReturns(logger, receipts, store.NewAdjustments(logger), ledger, store.NewReviews(logger), nil),
+		Campaigns:     service.NewCampaigns(logger, store.NewCampaigns(logger)),
+		Retailers:     service.NewRetailers(logger, store.NewRetailers(logger)),
+		Reviews:       service.NewReviews(logger, store.NewReviews(logger), ledger, nil, cfg.PointsExpiryMonths, nil),
+		Webhooks:      service.NewWebhooks(logger, store.NewWebhooks(logger), store.NewDeliveries(logger), store.NewDeadLetters(logger), nil),
+		Stream:        stream.New(cfg.StreamBufferSize, cfg.StreamClientBuffer),
+		Checker:       health.New(),
+		Authenticator: auth.New(logger, store.NewAPIKeys(logger), nil, false),
+		Limits:        ratelimit.New(logger, nil, store.NewQuotas(logger), 0),
+		Idempotency:   idempotency.New(logger, store.NewIdempotency(logger, cfg.IdempotencyMaxKeys), cfg.IdempotencyTTL),
+		Spec:          spec,
+	})
+	assert.NoError(t, err)
+
+	var h http.Handler = router
+	if wrap != nil {
+		h = wrap(h)
+	}
+
+	server := httptest.NewServer(middleware.RequestID(h))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestClient(t *testing.T, server *httptest.Server) *Client {
+	c, err := New(server.URL, WithRetryPolicy(testRetryPolicy))
+	assert.NoError(t, err)
+
+	return c
+}
+
+func newTestReceipt(userID string) model.Receipt {
+	str := func(s string) *string { return &s }
+
+	return model.Receipt{
+		Retailer:     str("Target"),
+		PurchaseDate: str("2022-01-01"),
+		PurchaseTime: str("13:01"),
+		Items:        []model.Item{{ShortDescription: str("Mountain Dew 12PK"), Price: str("6.49")}},
+		Total:        str("6.49"),
+		UserID:       userID,
+	}
+}
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		id          int
+		useCase     string
+		baseURL     string
+		expectedErr bool
+	}{
+		{id: 1, useCase: "Positive case: base URL", baseURL: "http://localhost:8080"},
+		{id: 2, useCase: "Positive case: base URL with a trailing slash", baseURL: "https://receipts.example.com/"},
+		{id: 3, useCase: "Negative case: base URL without scheme", baseURL: "localhost:8080", expectedErr: true},
+		{id: 4, useCase: "Negative case: base URL without host", baseURL: "http://", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		_, err := New(tc.baseURL)
+		assert.Equal(t, tc.expectedErr, err != nil, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestClient(t *testing.T) {
+	c := newTestClient(t, newTestServer(t, nil))
+	ctx := context.Background()
+
+	receipt := newTestReceipt("user-1")
+	processed, err := c.ProcessReceipt(ctx, &receipt)
+	assert.NoError(t, err)
+	assert.True(t, model.IsValidUUID(processed.Id))
+
+	points, err := c.GetPoints(ctx, processed.Id)
+	assert.NoError(t, err)
+	assert.Positive(t, points.Points)
+
+	status, err := c.GetReceipt(ctx, processed.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, &model.ReceiptStatusResponse{Id: processed.Id, Status: model.JobProcessed, Points: &points.Points}, status)
+
+	adjustments, err := c.ListAdjustments(ctx, processed.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, points.Points, adjustments.Points)
+
+	balance, err := c.GetBalance(ctx, "user-1")
+	assert.NoError(t, err)
+	assert.Equal(t, &model.BalanceResponse{UserID: "user-1", Points: points.Points}, balance)
+
+	ledger, err := c.ListLedger(ctx, "user-1", 1, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ledger.Total)
+	assert.Equal(t, processed.Id, ledger.Entries[0].ReceiptID)
+}
+
+func TestClient_Errors(t *testing.T) {
+	c := newTestClient(t, newTestServer(t, nil))
+	ctx := context.Background()
+
+	invalid := newTestReceipt("user-1")
+	invalid.Items = nil
+
+	testCases := []struct {
+		id             int
+		useCase        string
+		call           func() error
+		expectedType   error
+		expectedCode   string
+		expectedStatus int
+	}{
+		{
+			id: 1, useCase: "Unknown receipt",
+			call:         func() error { _, err := c.GetPoints(ctx, "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f"); return err },
+			expectedType: errors.EntityNotFound{}, expectedCode: errors.CodeNotFound, expectedStatus: 404,
+		},
+		{
+			id: 2, useCase: "Invalid receipt ID",
+			call:         func() error { _, err := c.GetReceipt(ctx, "1234"); return err },
+			expectedType: errors.InvalidParam{}, expectedCode: errors.CodeInvalidField, expectedStatus: 400,
+		},
+		{
+			id: 3, useCase: "Receipt without items, sent as null",
+			call:         func() error { _, err := c.ProcessReceipt(ctx, &invalid); return err },
+			expectedType: errors.InvalidParam{}, expectedCode: errors.CodeInvalidField, expectedStatus: 400,
+		},
+		{
+			id: 4, useCase: "Page size above its maximum",
+			call:         func() error { _, err := c.ListLedger(ctx, "user-1", 1, 500); return err },
+			expectedType: errors.InvalidParam{}, expectedCode: errors.CodeInvalidField, expectedStatus: 400,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.call()
+
+		var apiErr *Error
+		assert.True(t, er.As(err, &apiErr), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.IsType(t, tc.expectedType, apiErr.Err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedCode, apiErr.ErrorCode(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedStatus, apiErr.Status(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.NotEmpty(t, apiErr.RequestID, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+// failing wraps the API to answer the first failures attempts with the error of status instead of the API.
+// When lost is set the API still processes the failed attempts, as when their response is lost.
+func failing(status, failures int, retryAfter string, lost bool, attempts *int32) func(http.Handler) http.Handler {
+	logger, _ := log.NewCustomLogger("test.log")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if int(atomic.AddInt32(attempts, 1)) > failures {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if lost {
+				next.ServeHTTP(httptest.NewRecorder(), r)
+			}
+
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+
+			responder.SetErrorResponse(logger, errors.NewCustomError(er.New(http.StatusText(status)), status), w, r)
+		})
+	}
+}
+
+func TestClient_Retries(t *testing.T) {
+	testCases := []struct {
+		id               int
+		useCase          string
+		status           int
+		failures         int
+		retryAfter       string
+		lost             bool
+		expectedAttempts int32
+		expectedCode     string
+	}{
+		{id: 1, useCase: "Positive case: retried after a lost response, the receipt is processed once", status: 503, failures: 1, lost: true, expectedAttempts: 2},
+		{id: 2, useCase: "Positive case: retried after a gateway timeout", status: 504, failures: 2, expectedAttempts: 3},
+		{id: 3, useCase: "Positive case: retried once the rate limit resets", status: 429, failures: 1, retryAfter: "0", expectedAttempts: 2},
+		{id: 4, useCase: "Negative case: attempts exhausted", status: 503, failures: 3, expectedAttempts: 3, expectedCode: errors.CodeUnavailable},
+		{id: 5, useCase: "Negative case: Retry-After above the maximum backoff", status: 429, failures: 1, retryAfter: "60", expectedAttempts: 1, expectedCode: errors.CodeRateLimited},
+		{id: 6, useCase: "Negative case: errors of the request are not retried", status: 400, failures: 1, expectedAttempts: 1, expectedCode: errors.CodeMalformedRequest},
+	}
+
+	for _, tc := range testCases {
+		var attempts int32
+		c := newTestClient(t, newTestServer(t, failing(tc.status, tc.failures, tc.retryAfter, tc.lost, &attempts)))
+
+		receipt := newTestReceipt("user-1")
+		processed, err := c.ProcessReceipt(context.Background(), &receipt)
+
+		assert.Equal(t, tc.expectedAttempts, atomic.LoadInt32(&attempts), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		if tc.expectedCode != "" {
+			var apiErr errors.Error
+			assert.True(t, er.As(err, &apiErr), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			assert.Equal(t, tc.expectedCode, apiErr.ErrorCode(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+
+			continue
+		}
+
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+
+		// Points are credited to the user once, whatever the number of attempts.
+		points, err := c.GetPoints(context.Background(), processed.Id)
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+
+		ledger, err := c.ListLedger(context.Background(), "user-1", 0, 0)
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, 1, ledger.Total, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, points.Points, ledger.Entries[0].Points, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestClient_ContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The caller gives up while the client waits to retry.
+	var attempts int32
+	server := newTestServer(t, func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			cancel()
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+	})
+
+	c, err := New(server.URL, WithRetryPolicy(RetryPolicy{MaxAttempts: 3, Backoff: time.Second, MaxBackoff: time.Second}))
+	assert.NoError(t, err)
+
+	_, err = c.GetPoints(ctx, "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestClient_ProcessReceipts(t *testing.T) {
+	c := newTestClient(t, newTestServer(t, nil))
+
+	invalid := newTestReceipt("user-1")
+	total := "6.4"
+	invalid.Total = &total
+	receipts := []model.Receipt{newTestReceipt("user-1"), invalid, newTestReceipt("user-2")}
+
+	ctx := WithIdempotencyKey(context.Background(), "batch-1")
+	results := c.ProcessReceipts(ctx, receipts)
+
+	assert.Len(t, results, 3)
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+	}
+
+	assert.NoError(t, results[0].Err)
+	assert.ErrorAs(t, results[1].Err, &errors.InvalidParam{})
+	assert.NoError(t, results[2].Err)
+
+	// Sending the batch again with the same key replays the responses of the receipts processed.
+	replayed := c.ProcessReceipts(ctx, receipts)
+	assert.Equal(t, results[0].Response, replayed[0].Response)
+	assert.Equal(t, results[2].Response, replayed[2].Response)
+
+	balance, err := c.GetBalance(context.Background(), "user-2")
+	assert.NoError(t, err)
+
+	points, err := c.GetPoints(context.Background(), results[2].Response.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, points.Points, balance.Points)
+
+	// Receipts left once the context is done are not sent.
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, result := range c.ProcessReceipts(canceled, receipts) {
+		assert.ErrorIs(t, result.Err, context.Canceled)
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	testCases := []struct {
+		id          int
+		useCase     string
+		status      int
+		header      map[string]string
+		body        string
+		expectedErr *Error
+	}{
+		{
+			id: 1, useCase: "Missing field of a receipt",
+			status: 400, body: `{"status":400,"detail":"Parameter items is required for this request","code":"receipt.missing_field","requestId":"req-1"}`,
+			expectedErr: &Error{Err: errors.MissingParam{Code: errors.CodeReceiptMissingField, Msg: "Parameter items is required for this request", StatusCode: 400}, RequestID: "req-1"},
+		},
+		{
+			id: 2, useCase: "Missing credentials",
+			status: 401, body: `{"status":401,"detail":"Missing credentials","code":"auth.unauthenticated"}`,
+			expectedErr: &Error{Err: errors.Unauthorized{Code: errors.CodeUnauthenticated, Msg: "Missing credentials", StatusCode: 401}},
+		},
+		{
+			id: 3, useCase: "Missing scope",
+			status: 403, body: `{"status":403,"detail":"Scope 'submit' is required for this request","code":"auth.forbidden"}`,
+			expectedErr: &Error{Err: errors.Forbidden{Code: errors.CodeForbidden, Msg: "Scope 'submit' is required for this request", StatusCode: 403}},
+		},
+		{
+			id: 4, useCase: "Submission still being processed",
+			status: 409, body: `{"status":409,"detail":"A request with this Idempotency-Key is still being processed","code":"idempotency.in_progress"}`,
+			expectedErr: &Error{Err: errors.Conflict{Code: errors.CodeIdempotencyInProgress, Msg: "A request with this Idempotency-Key is still being processed", StatusCode: 409}},
+		},
+		{
+			id: 5, useCase: "Rate limit exceeded",
+			status: 429, header: map[string]string{"Retry-After": "7"}, body: `{"status":429,"detail":"Rate limit exceeded, retry after 7 seconds","code":"rate_limit.exceeded"}`,
+			expectedErr: &Error{Err: errors.TooManyRequests{RetryAfter: 7, Code: errors.CodeRateLimited, Msg: "Rate limit exceeded, retry after 7 seconds", StatusCode: 429}},
+		},
+		{
+			id: 6, useCase: "Code unknown to the client",
+			status: 422, body: `{"status":422,"detail":"Idempotency-Key was already used for a different request","code":"idempotency.key_reused"}`,
+			expectedErr: &Error{Err: errors.CustomError{Err: er.New("Idempotency-Key was already used for a different request"), Code: errors.CodeIdempotencyMismatch, Msg: "Idempotency-Key was already used for a different request", StatusCode: 422}},
+		},
+		{
+			id: 7, useCase: "Response without problem details",
+			status: 502, body: "Bad Gateway\n",
+			expectedErr: &Error{Err: errors.CustomError{Err: er.New("Bad Gateway"), Code: errors.CodeInternal, Msg: "Bad Gateway", StatusCode: 502}},
+		},
+		{
+			id: 8, useCase: "Response without a body",
+			status:      503,
+			expectedErr: &Error{Err: errors.CustomError{Err: er.New("Service Unavailable"), Code: errors.CodeUnavailable, Msg: "Service Unavailable", StatusCode: 503}},
+		},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		for k, v := range tc.header {
+			w.Header().Set(k, v)
+		}
+
+		w.WriteHeader(tc.status)
+		_, _ = w.WriteString(tc.body)
+
+		err := decodeError(w.Result())
+
+		// Time stamps are those of the server, or of the client when the response has none.
+		switch e := err.Err.(type) {
+		case errors.MissingParam:
+			e.TimeStamp = time.Time{}
+			err.Err = e
+		case errors.Unauthorized:
+			e.TimeStamp = time.Time{}
+			err.Err = e
+		case errors.Forbidden:
+			e.TimeStamp = time.Time{}
+			err.Err = e
+		case errors.Conflict:
+			e.TimeStamp = time.Time{}
+			err.Err = e
+		case errors.TooManyRequests:
+			e.TimeStamp = time.Time{}
+			err.Err = e
+		case errors.CustomError:
+			e.TimeStamp = time.Time{}
+			err.Err = e
+		}
+
+		assert.Equal(t, tc.expectedErr, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedErr.ErrorCode(), err.ErrorCode(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.status, err.Status(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
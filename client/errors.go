@@ -0,0 +1,90 @@
+package client
+
+import (
+	"encoding/json"
+	er "errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/responder"
+)
+
+// maxErrorBody bounds the body of an error response read by the client.
+const maxErrorBody = 64 << 10
+
+// Error is an error of the API along with the ID of the request that raised it.
+type Error struct {
+	Err       errors.Error // Error of the errors package matching the problem details of the response.
+	RequestID string       // ID of the failed request, to look it up in the logs of the server.
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// ErrorCode returns the machine-readable code of the error.
+func (e *Error) ErrorCode() string {
+	return e.Err.ErrorCode()
+}
+
+// Status returns the HTTP status of the error.
+func (e *Error) Status() int {
+	return e.Err.Status()
+}
+
+// Unwrap returns the error of the errors package, so that errors.As finds it.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// decodeError returns the error of the errors package matching the problem details of resp, as the server raised it,
+// along with the ID of the request. Responses without problem details, like errors of a proxy, are returned as an
+// errors.CustomError of their status.
+func decodeError(resp *http.Response) *Error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+
+	var problem responder.Problem
+	if err := json.Unmarshal(body, &problem); err != nil || problem.Code == "" {
+		detail := strings.TrimSpace(string(body))
+		if detail == "" {
+			detail = http.StatusText(resp.StatusCode)
+		}
+
+		problem = responder.Problem{Status: resp.StatusCode, Detail: detail, Code: errors.CodeForStatus(resp.StatusCode)}
+	}
+
+	return &Error{Err: problemError(resp, problem), RequestID: problem.RequestID}
+}
+
+// problemError returns the error of the errors package matching problem.
+func problemError(resp *http.Response, problem responder.Problem) errors.Error {
+	if problem.Status == 0 {
+		problem.Status = resp.StatusCode
+	}
+
+	if problem.TimeStamp.IsZero() {
+		problem.TimeStamp = time.Now().UTC()
+	}
+
+	switch problem.Code {
+	case errors.CodeMissingField, errors.CodeReceiptMissingField:
+		return errors.MissingParam{Code: problem.Code, Msg: problem.Detail, StatusCode: problem.Status, TimeStamp: problem.TimeStamp}
+	case errors.CodeInvalidField, errors.CodeReceiptInvalidField:
+		return errors.InvalidParam{Code: problem.Code, Msg: problem.Detail, StatusCode: problem.Status, TimeStamp: problem.TimeStamp}
+	case errors.CodeNotFound:
+		return errors.EntityNotFound{Code: problem.Code, Msg: problem.Detail, StatusCode: problem.Status, TimeStamp: problem.TimeStamp}
+	case errors.CodeConflict, errors.CodeIdempotencyInProgress:
+		return errors.Conflict{Code: problem.Code, Msg: problem.Detail, StatusCode: problem.Status, TimeStamp: problem.TimeStamp}
+	case errors.CodeUnauthenticated:
+		return errors.Unauthorized{Code: problem.Code, Msg: problem.Detail, StatusCode: problem.Status, TimeStamp: problem.TimeStamp}
+	case errors.CodeForbidden:
+		return errors.Forbidden{Code: problem.Code, Msg: problem.Detail, StatusCode: problem.Status, TimeStamp: problem.TimeStamp}
+	case errors.CodeRateLimited:
+		return errors.TooManyRequests{RetryAfter: int(retryAfter(resp).Seconds()), Code: problem.Code, Msg: problem.Detail, StatusCode: problem.Status, TimeStamp: problem.TimeStamp}
+	default:
+		return errors.CustomError{Err: er.New(problem.Detail), Code: problem.Code, Msg: problem.Detail, StatusCode: problem.Status, TimeStamp: problem.TimeStamp}
+	}
+}
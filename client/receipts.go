@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// ProcessReceipt submits a receipt for processing and returns the ID assigned to it.
+// When the server processes receipts asynchronously the receipt is queued under that ID, GetReceipt reports its status.
+func (c *Client) ProcessReceipt(ctx context.Context, receipt *model.Receipt) (*model.ReceiptPostResponse, error) {
+	var resp model.ReceiptPostResponse
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/v1/receipts/process", body: receipt, idempotencyKey: newIdempotencyKey(ctx)}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// BatchResult is the outcome of a receipt of a batch, either the response to its submission or the error it failed with.
+type BatchResult struct {
+	Index    int                        // Index of the receipt in the batch.
+	Response *model.ReceiptPostResponse // Response to the submission, nil when it failed.
+	Err      error                      // Error of the submission, nil when it succeeded.
+}
+
+// ProcessReceipts submits the receipts of a batch one after the other and returns the outcome of each, in order.
+// A failed receipt does not stop the batch, the receipts left once ctx is done fail with the error of ctx.
+func (c *Client) ProcessReceipts(ctx context.Context, receipts []model.Receipt) []BatchResult {
+	key, hasKey := ctx.Value(idempotencyKey{}).(string)
+
+	results := make([]BatchResult, len(receipts))
+	for i := range receipts {
+		results[i].Index = i
+
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		receiptCtx := ctx
+		if hasKey && key != "" {
+			receiptCtx = WithIdempotencyKey(ctx, fmt.Sprintf("%v-%v", key, i))
+		}
+
+		results[i].Response, results[i].Err = c.ProcessReceipt(receiptCtx, &receipts[i])
+	}
+
+	return results
+}
+
+// GetReceipt returns the processing status of a receipt, along with its points once it is processed.
+func (c *Client) GetReceipt(ctx context.Context, receiptID string) (*model.ReceiptStatusResponse, error) {
+	var resp model.ReceiptStatusResponse
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/v1/receipts/" + url.PathEscape(receiptID)}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetPoints returns the points awarded to a processed receipt.
+func (c *Client) GetPoints(ctx context.Context, receiptID string) (*model.ReceiptGetResponse, error) {
+	var resp model.ReceiptGetResponse
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/v1/receipts/" + url.PathEscape(receiptID) + "/points"}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ListAdjustments returns the original and current points of a receipt along with its adjustments, oldest first.
+func (c *Client) ListAdjustments(ctx context.Context, receiptID string) (*model.AdjustmentHistory, error) {
+	var resp model.AdjustmentHistory
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/v1/receipts/" + url.PathEscape(receiptID) + "/adjustments"}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
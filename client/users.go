@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// GetBalance returns the points balance of a user.
+func (c *Client) GetBalance(ctx context.Context, userID string) (*model.BalanceResponse, error) {
+	var resp model.BalanceResponse
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/v1/users/" + url.PathEscape(userID) + "/balance"}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ListLedger returns a page of the ledger history of a user, newest entries first. Pages start at 1, a page or
+// pageSize of 0 lets the server use its default.
+func (c *Client) ListLedger(ctx context.Context, userID string, page, pageSize int) (*model.LedgerResponse, error) {
+	query := url.Values{}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+
+	if pageSize > 0 {
+		query.Set("pageSize", strconv.Itoa(pageSize))
+	}
+
+	var resp model.LedgerResponse
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/v1/users/" + url.PathEscape(userID) + "/ledger", query: query}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
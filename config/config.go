@@ -0,0 +1,479 @@
+package config
+
+import (
+	er "errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/ratelimit"
+)
+
+// DefaultFilePaths are the env files searched, in order, when no config file is given explicitly.
+// The first file that exists is loaded; missing files are not an error.
+var DefaultFilePaths = []string{".env", "/opt/receipts.dev.env", "/opt/receipts.prod.env"}
+
+// StoreTypes lists the supported values for Config.StoreType.
+var StoreTypes = []string{"memory"}
+
+// OutboxSinks lists the supported sinks of Config.OutboxSinks.
+var OutboxSinks = []string{"stdout", "file", "webhook", "broker"}
+
+const redacted = "******"
+
+// Config holds the typed configuration of the receipts server.
+// Every field is described by struct tags:
+//   - env:     name of the environment variable (also the key used in config files)
+//   - flag:    name of the command line flag
+//   - default: value used when no other source sets the field
+//   - secret:  when "true" the value is redacted when the configuration is printed
+type Config struct {
+	Port        int    `env:"PORT" flag:"port" default:"8080"`
+	LogFilePath string `env:"LOG_FILE_PATH" flag:"log-file" default:"receipts.log"`
+	StoreType   string `env:"STORE_TYPE" flag:"store" default:"memory"`
+
+	// GRPCEnabled serves the gRPC API of receipts on GRPCPort alongside the REST endpoints.
+	GRPCEnabled bool `env:"GRPC_ENABLED" flag:"grpc-enabled" default:"false"`
+	// GRPCPort is the port of the gRPC API.
+	GRPCPort int `env:"GRPC_PORT" flag:"grpc-port" default:"9090"`
+	// GRPCMaxBatchSize is the maximum number of receipts of a BatchProcess stream.
+	GRPCMaxBatchSize int `env:"GRPC_MAX_BATCH_SIZE" flag:"grpc-max-batch-size" default:"1000"`
+
+	// AccessLogSampleRate is the fraction of successful requests written to the access log, failed requests are always logged.
+	AccessLogSampleRate float64 `env:"ACCESS_LOG_SAMPLE_RATE" flag:"access-log-sample-rate" default:"1"`
+
+	// MaxRequestBodyBytes bounds the body of requests, larger bodies are rejected with a 413.
+	MaxRequestBodyBytes int64 `env:"MAX_REQUEST_BODY_BYTES" flag:"max-request-body-bytes" default:"1048576"`
+
+	// AuthEnabled requires callers of the receipts routes to authenticate.
+	AuthEnabled bool `env:"AUTH_ENABLED" flag:"auth-enabled" default:"false"`
+	// APIKeys is a comma separated list of clientID:scope1|scope2:key entries, see auth.ParseAPIKeys.
+	APIKeys string `env:"API_KEYS" flag:"api-keys" default:"" secret:"true"`
+
+	// JWT bearer tokens are verified with the keys of these files, see auth.VerifierConfig.
+	JWTHMACSecretFile   string        `env:"JWT_HMAC_SECRET_FILE" flag:"jwt-hmac-secret-file" default:""`
+	JWTRSAPublicKeyFile string        `env:"JWT_RSA_PUBLIC_KEY_FILE" flag:"jwt-rsa-public-key-file" default:""`
+	JWTJWKSFile         string        `env:"JWT_JWKS_FILE" flag:"jwt-jwks-file" default:""`
+	JWTAudience         string        `env:"JWT_AUDIENCE" flag:"jwt-audience" default:""`
+	JWTIssuer           string        `env:"JWT_ISSUER" flag:"jwt-issuer" default:""`
+	JWTRolesClaim       string        `env:"JWT_ROLES_CLAIM" flag:"jwt-roles-claim" default:"roles"`
+	JWTLeeway           time.Duration `env:"JWT_LEEWAY" flag:"jwt-leeway" default:"30s"`
+
+	// RateLimitEnabled enforces RateLimits on the receipts routes for each client.
+	RateLimitEnabled bool `env:"RATE_LIMIT_ENABLED" flag:"rate-limit-enabled" default:"true"`
+	// RateLimits is a comma separated list of route=rate:burst entries, see ratelimit.ParseLimits. The preauth entry
+	// limits every IP address before authentication, the * entry applies to routes without an entry of their own.
+	RateLimits string `env:"RATE_LIMITS" flag:"rate-limits" default:"preauth=100:200,*=50:100,/v1/receipts/process=10:20"`
+	// DailySubmissionQuota is the number of receipts a client may submit per UTC day, 0 means unlimited.
+	DailySubmissionQuota int `env:"DAILY_SUBMISSION_QUOTA" flag:"daily-submission-quota" default:"0"`
+	// IdempotencyTTL is how long the response to a request sent with an Idempotency-Key is replayed to its retries.
+	IdempotencyTTL time.Duration `env:"IDEMPOTENCY_TTL" flag:"idempotency-ttl" default:"24h"`
+	// IdempotencyMaxKeys is the number of Idempotency-Keys kept, the keys expiring first are discarded beyond it.
+	IdempotencyMaxKeys int `env:"IDEMPOTENCY_MAX_KEYS" flag:"idempotency-max-keys" default:"100000"`
+
+	// MaxPointsPerReceipt caps the points awarded to a single receipt, 0 means uncapped.
+	MaxPointsPerReceipt int `env:"MAX_POINTS_PER_RECEIPT" flag:"max-points-per-receipt" default:"0"`
+	// MaxUserPointsPerDay caps the points awarded to the receipts of a user per UTC day, 0 means uncapped.
+	MaxUserPointsPerDay int `env:"MAX_USER_POINTS_PER_DAY" flag:"max-user-points-per-day" default:"0"`
+
+	// FraudScoringEnabled assesses the fraud risk of every submitted receipt.
+	FraudScoringEnabled bool `env:"FRAUD_SCORING_ENABLED" flag:"fraud-scoring-enabled" default:"true"`
+	// FraudHistoryWindow is the number of latest receipts of the same submitter the fraud detectors look at.
+	FraudHistoryWindow int `env:"FRAUD_HISTORY_WINDOW" flag:"fraud-history-window" default:"20"`
+	// FraudReviewThreshold is the risk score, from 1 to 100, from which points are held for review, 0 never holds points.
+	FraudReviewThreshold int `env:"FRAUD_REVIEW_THRESHOLD" flag:"fraud-review-threshold" default:"70"`
+
+	// AsyncProcessing queues submitted receipts and scores them in a pool of workers, responding 202 before they are scored.
+	AsyncProcessing bool `env:"ASYNC_PROCESSING" flag:"async-processing" default:"false"`
+	// QueueWorkers is the number of workers scoring queued receipts.
+	QueueWorkers int `env:"QUEUE_WORKERS" flag:"queue-workers" default:"4"`
+	// QueueCapacity is the number of receipts that may wait for a worker, submissions beyond it are rejected with 503.
+	QueueCapacity int `env:"QUEUE_CAPACITY" flag:"queue-capacity" default:"1000"`
+	// QueueFile makes the queue durable by logging jobs to this file, queued receipts are then resumed on restart.
+	// Empty keeps the queue in memory only.
+	QueueFile string `env:"QUEUE_FILE" flag:"queue-file" default:""`
+	// QueueRetention is how long the status of a queued receipt is kept once it is processed or failed.
+	QueueRetention time.Duration `env:"QUEUE_RETENTION" flag:"queue-retention" default:"24h"`
+
+	// WebhooksEnabled delivers receipt events to the webhooks clients subscribe.
+	WebhooksEnabled bool `env:"WEBHOOKS_ENABLED" flag:"webhooks-enabled" default:"true"`
+	// WebhookWorkers is the number of concurrent webhook deliveries.
+	WebhookWorkers int `env:"WEBHOOK_WORKERS" flag:"webhook-workers" default:"4"`
+	// WebhookMaxAttempts is the number of attempts of a delivery before it goes to the dead-letter list.
+	WebhookMaxAttempts int `env:"WEBHOOK_MAX_ATTEMPTS" flag:"webhook-max-attempts" default:"5"`
+	// WebhookBackoff is the delay before the first retry of a failed delivery, doubled on every retry up to 10 minutes.
+	WebhookBackoff time.Duration `env:"WEBHOOK_BACKOFF" flag:"webhook-backoff" default:"1s"`
+	// WebhookTimeout bounds each delivery request.
+	WebhookTimeout time.Duration `env:"WEBHOOK_TIMEOUT" flag:"webhook-timeout" default:"5s"`
+
+	// StreamBufferSize is the number of latest scored receipts kept for clients resuming the receipts stream.
+	StreamBufferSize int `env:"STREAM_BUFFER_SIZE" flag:"stream-buffer-size" default:"1000"`
+	// StreamClientBuffer is the number of events buffered for each stream client, slower clients are disconnected.
+	StreamClientBuffer int `env:"STREAM_CLIENT_BUFFER" flag:"stream-client-buffer" default:"64"`
+	// StreamHeartbeat is the interval of the heartbeats keeping idle stream connections open.
+	StreamHeartbeat time.Duration `env:"STREAM_HEARTBEAT" flag:"stream-heartbeat" default:"15s"`
+
+	// GraphQLMaxDepth is the maximum depth of the nested selections of a GraphQL query.
+	GraphQLMaxDepth int `env:"GRAPHQL_MAX_DEPTH" flag:"graphql-max-depth" default:"8"`
+	// GraphQLMaxComplexity is the maximum cost of a GraphQL query, every field costs 1 and paginated lists the cost of each of their items.
+	GraphQLMaxComplexity int `env:"GRAPHQL_MAX_COMPLEXITY" flag:"graphql-max-complexity" default:"1000"`
+
+	// OpenAPIValidateRequests rejects requests not matching the OpenAPI document with a 400 before they reach the handlers.
+	OpenAPIValidateRequests bool `env:"OPENAPI_VALIDATE_REQUESTS" flag:"openapi-validate-requests" default:"true"`
+	// OpenAPIValidateResponses replaces responses not matching the OpenAPI document with a 500, meant for test environments.
+	OpenAPIValidateResponses bool `env:"OPENAPI_VALIDATE_RESPONSES" flag:"openapi-validate-responses" default:"false"`
+
+	// OutboxSinks is the comma separated list of sinks domain events are published to, empty disables the outbox.
+	OutboxSinks string `env:"OUTBOX_SINKS" flag:"outbox-sinks" default:""`
+	// OutboxFile is the file the file sink appends events to.
+	OutboxFile string `env:"OUTBOX_FILE" flag:"outbox-file" default:"outbox.log"`
+	// OutboxWebhookURL is the URL the webhook sink posts events to.
+	OutboxWebhookURL string `env:"OUTBOX_WEBHOOK_URL" flag:"outbox-webhook-url" default:""`
+	// OutboxInterval is how often the relay publishes the pending events of the outbox.
+	OutboxInterval time.Duration `env:"OUTBOX_INTERVAL" flag:"outbox-interval" default:"1s"`
+	// OutboxBatchSize is the maximum number of events published on each interval.
+	OutboxBatchSize int `env:"OUTBOX_BATCH_SIZE" flag:"outbox-batch-size" default:"100"`
+
+	// PointsExpiryMonths is the number of months after which earned points expire, 0 means points never expire.
+	PointsExpiryMonths int `env:"POINTS_EXPIRY_MONTHS" flag:"points-expiry-months" default:"12"`
+	// PointsExpiryInterval is how often the expiry job writes expiry entries for expired points.
+	PointsExpiryInterval time.Duration `env:"POINTS_EXPIRY_INTERVAL" flag:"points-expiry-interval" default:"1h"`
+
+	// ShutdownDrainDelay is how long readiness reports down before the server stops accepting connections.
+	ShutdownDrainDelay time.Duration `env:"SHUTDOWN_DRAIN_DELAY" flag:"shutdown-drain-delay" default:"5s"`
+	// ShutdownTimeout bounds how long in-flight requests may take to complete once the server stops.
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" flag:"shutdown-timeout" default:"15s"`
+}
+
+// Load builds the configuration from, in increasing order of precedence, defaults, a config file,
+// environment variables and command line flags, and validates the result.
+// The config file is the one named by the -config flag or CONFIG_FILE env variable, otherwise the first of DefaultFilePaths that exists.
+func Load(args []string) (*Config, error) {
+	cfg := &Config{}
+
+	// Register one string flag per field so that only flags explicitly passed override other sources.
+	fs := flag.NewFlagSet("receipts", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path to an env formatted config file")
+	flagValues := make(map[string]*string)
+	for _, f := range fields(cfg) {
+		flagValues[f.flag] = fs.String(f.flag, "", fmt.Sprintf("overrides %v (default %q)", f.env, f.def))
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	fileValues, err := readConfigFile(*configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	setFlags := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+
+	for _, f := range fields(cfg) {
+		value, source := f.def, "default"
+
+		if v, ok := fileValues[f.env]; ok {
+			value, source = v, "config file"
+		}
+
+		if v, ok := os.LookupEnv(f.env); ok {
+			value, source = v, "env"
+		}
+
+		if setFlags[f.flag] {
+			value, source = *flagValues[f.flag], "flag"
+		}
+
+		if err = setField(f.value, value); err != nil {
+			return nil, fmt.Errorf("invalid %v from %v: %w", f.env, source, err)
+		}
+	}
+
+	if err = cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate checks every configured value and returns all violations joined together.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Port < 1 || c.Port > 65535 {
+		errs = append(errs, fmt.Errorf("PORT must be between 1 and 65535, got %v", c.Port))
+	}
+
+	if c.GRPCEnabled && (c.GRPCPort < 1 || c.GRPCPort > 65535 || c.GRPCPort == c.Port) {
+		errs = append(errs, fmt.Errorf("GRPC_PORT must be between 1 and 65535 and differ from PORT, got %v", c.GRPCPort))
+	}
+
+	if c.GRPCMaxBatchSize <= 0 {
+		errs = append(errs, fmt.Errorf("GRPC_MAX_BATCH_SIZE must be positive, got %v", c.GRPCMaxBatchSize))
+	}
+
+	if c.LogFilePath == "" {
+		errs = append(errs, er.New("LOG_FILE_PATH must not be empty"))
+	} else if err := checkWritable(c.LogFilePath); err != nil {
+		errs = append(errs, fmt.Errorf("LOG_FILE_PATH %q is not writable: %w", c.LogFilePath, err))
+	}
+
+	if !contains(StoreTypes, c.StoreType) {
+		errs = append(errs, fmt.Errorf("STORE_TYPE must be one of %v, got %q", strings.Join(StoreTypes, ", "), c.StoreType))
+	}
+
+	if c.AccessLogSampleRate < 0 || c.AccessLogSampleRate > 1 {
+		errs = append(errs, fmt.Errorf("ACCESS_LOG_SAMPLE_RATE must be between 0 and 1, got %v", c.AccessLogSampleRate))
+	}
+
+	if c.MaxRequestBodyBytes <= 0 {
+		errs = append(errs, fmt.Errorf("MAX_REQUEST_BODY_BYTES must be positive, got %v", c.MaxRequestBodyBytes))
+	}
+
+	if keys, err := auth.ParseAPIKeys(c.APIKeys); err != nil {
+		errs = append(errs, fmt.Errorf("API_KEYS is invalid: %w", err))
+	} else if c.AuthEnabled && len(keys) == 0 && c.JWTHMACSecretFile == "" && c.JWTRSAPublicKeyFile == "" && c.JWTJWKSFile == "" {
+		errs = append(errs, er.New("AUTH_ENABLED requires at least one key in API_KEYS or a JWT key file"))
+	}
+
+	if c.JWTLeeway < 0 {
+		errs = append(errs, fmt.Errorf("JWT_LEEWAY must not be negative, got %v", c.JWTLeeway))
+	}
+
+	if _, err := ratelimit.ParseLimits(c.RateLimits); err != nil {
+		errs = append(errs, fmt.Errorf("RATE_LIMITS is invalid: %w", err))
+	}
+
+	if c.DailySubmissionQuota < 0 {
+		errs = append(errs, fmt.Errorf("DAILY_SUBMISSION_QUOTA must not be negative, got %v", c.DailySubmissionQuota))
+	}
+
+	if c.IdempotencyTTL <= 0 {
+		errs = append(errs, fmt.Errorf("IDEMPOTENCY_TTL must be positive, got %v", c.IdempotencyTTL))
+	}
+
+	if c.IdempotencyMaxKeys <= 0 {
+		errs = append(errs, fmt.Errorf("IDEMPOTENCY_MAX_KEYS must be positive, got %v", c.IdempotencyMaxKeys))
+	}
+
+	if c.MaxPointsPerReceipt < 0 || c.MaxUserPointsPerDay < 0 {
+		errs = append(errs, fmt.Errorf("MAX_POINTS_PER_RECEIPT and MAX_USER_POINTS_PER_DAY must not be negative, got %v and %v", c.MaxPointsPerReceipt, c.MaxUserPointsPerDay))
+	}
+
+	if c.FraudHistoryWindow < 0 || c.FraudReviewThreshold < 0 || c.FraudReviewThreshold > 100 {
+		errs = append(errs, fmt.Errorf("FRAUD_HISTORY_WINDOW must not be negative and FRAUD_REVIEW_THRESHOLD must be between 0 and 100, got %v and %v", c.FraudHistoryWindow, c.FraudReviewThreshold))
+	}
+
+	if c.QueueWorkers <= 0 || c.QueueCapacity <= 0 {
+		errs = append(errs, fmt.Errorf("QUEUE_WORKERS and QUEUE_CAPACITY must be positive, got %v and %v", c.QueueWorkers, c.QueueCapacity))
+	}
+
+	if c.QueueRetention <= 0 {
+		errs = append(errs, fmt.Errorf("QUEUE_RETENTION must be positive, got %v", c.QueueRetention))
+	}
+
+	if c.WebhookWorkers <= 0 || c.WebhookMaxAttempts <= 0 || c.WebhookBackoff <= 0 || c.WebhookTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("WEBHOOK_WORKERS, WEBHOOK_MAX_ATTEMPTS, WEBHOOK_BACKOFF and WEBHOOK_TIMEOUT must be positive, got %v, %v, %v and %v", c.WebhookWorkers, c.WebhookMaxAttempts, c.WebhookBackoff, c.WebhookTimeout))
+	}
+
+	if c.StreamBufferSize <= 0 || c.StreamClientBuffer <= 0 || c.StreamHeartbeat <= 0 {
+		errs = append(errs, fmt.Errorf("STREAM_BUFFER_SIZE, STREAM_CLIENT_BUFFER and STREAM_HEARTBEAT must be positive, got %v, %v and %v", c.StreamBufferSize, c.StreamClientBuffer, c.StreamHeartbeat))
+	}
+
+	if c.GraphQLMaxDepth <= 0 || c.GraphQLMaxComplexity <= 0 {
+		errs = append(errs, fmt.Errorf("GRAPHQL_MAX_DEPTH and GRAPHQL_MAX_COMPLEXITY must be positive, got %v and %v", c.GraphQLMaxDepth, c.GraphQLMaxComplexity))
+	}
+
+	for _, sink := range c.OutboxSinkNames() {
+		if !contains(OutboxSinks, sink) {
+			errs = append(errs, fmt.Errorf("OUTBOX_SINKS must only contain %v, got %q", strings.Join(OutboxSinks, ", "), sink))
+		}
+	}
+
+	if contains(c.OutboxSinkNames(), "file") && c.OutboxFile == "" {
+		errs = append(errs, er.New("OUTBOX_FILE must not be empty with the file sink"))
+	}
+
+	if contains(c.OutboxSinkNames(), "webhook") && c.OutboxWebhookURL == "" {
+		errs = append(errs, er.New("OUTBOX_WEBHOOK_URL must not be empty with the webhook sink"))
+	}
+
+	if c.OutboxInterval <= 0 || c.OutboxBatchSize <= 0 {
+		errs = append(errs, fmt.Errorf("OUTBOX_INTERVAL and OUTBOX_BATCH_SIZE must be positive, got %v and %v", c.OutboxInterval, c.OutboxBatchSize))
+	}
+
+	if c.PointsExpiryMonths < 0 || c.PointsExpiryInterval <= 0 {
+		errs = append(errs, fmt.Errorf("POINTS_EXPIRY_MONTHS must not be negative and POINTS_EXPIRY_INTERVAL must be positive, got %v and %v", c.PointsExpiryMonths, c.PointsExpiryInterval))
+	}
+
+	if c.ShutdownDrainDelay < 0 || c.ShutdownTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("SHUTDOWN_DRAIN_DELAY must not be negative and SHUTDOWN_TIMEOUT must be positive, got %v and %v", c.ShutdownDrainDelay, c.ShutdownTimeout))
+	}
+
+	return er.Join(errs...)
+}
+
+// OutboxSinkNames returns the names of the configured outbox sinks.
+func (c *Config) OutboxSinkNames() []string {
+	var names []string
+	for _, name := range strings.Split(c.OutboxSinks, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// String renders the effective configuration as space separated KEY=value pairs with secrets redacted.
+func (c *Config) String() string {
+	var pairs []string
+	for _, f := range fields(c) {
+		value := fmt.Sprint(f.value.Interface())
+		if f.secret && value != "" {
+			value = redacted
+		}
+
+		pairs = append(pairs, fmt.Sprintf("%v=%v", f.env, value))
+	}
+
+	return strings.Join(pairs, " ")
+}
+
+// field describes a single configurable field of Config.
+type field struct {
+	env    string
+	flag   string
+	def    string
+	secret bool
+	value  reflect.Value
+}
+
+// fields returns the configurable fields of cfg in declaration order.
+func fields(cfg *Config) []field {
+	v := reflect.ValueOf(cfg).Elem()
+	t := v.Type()
+
+	result := make([]field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag
+		result = append(result, field{
+			env:    tag.Get("env"),
+			flag:   tag.Get("flag"),
+			def:    tag.Get("default"),
+			secret: tag.Get("secret") == "true",
+			value:  v.Field(i),
+		})
+	}
+
+	return result
+}
+
+// setField parses raw according to the kind of the field and assigns it.
+func setField(v reflect.Value, raw string) error {
+	raw = strings.TrimSpace(raw)
+
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int, reflect.Int64:
+		if raw == "" {
+			return er.New("value must not be empty")
+		}
+
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(n)
+	case reflect.Float64:
+		if raw == "" {
+			return er.New("value must not be empty")
+		}
+
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field kind %v", v.Kind())
+	}
+
+	return nil
+}
+
+// readConfigFile reads an env formatted file. An explicitly named file must exist,
+// otherwise the first existing file of DefaultFilePaths is read.
+func readConfigFile(path string) (map[string]string, error) {
+	if path != "" {
+		values, err := godotenv.Read(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading config file %v: %w", path, err)
+		}
+
+		return values, nil
+	}
+
+	for _, p := range DefaultFilePaths {
+		if _, err := os.Stat(p); err != nil {
+			continue
+		}
+
+		values, err := godotenv.Read(p)
+		if err != nil {
+			return nil, fmt.Errorf("reading config file %v: %w", p, err)
+		}
+
+		return values, nil
+	}
+
+	return map[string]string{}, nil
+}
+
+// checkWritable verifies the file at path can be opened for appending, creating it if needed.
+func checkWritable(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0766)
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
+}
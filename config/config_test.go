@@ -0,0 +1,238 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// setUpEnv isolates a test from the env files and variables of the host.
+func setUpEnv(t *testing.T) string {
+	dir := t.TempDir()
+
+	defaultPaths := DefaultFilePaths
+	DefaultFilePaths = []string{filepath.Join(dir, ".env")}
+	t.Cleanup(func() { DefaultFilePaths = defaultPaths })
+
+	keys := []string{"CONFIG_FILE"}
+	for _, f := range fields(&Config{}) {
+		keys = append(keys, f.env)
+	}
+
+	for _, key := range keys {
+		if v, ok := os.LookupEnv(key); ok {
+			_ = os.Unsetenv(key)
+			t.Cleanup(func() { _ = os.Setenv(key, v) })
+		}
+	}
+
+	return dir
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	dir := setUpEnv(t)
+	logFile := filepath.Join(dir, "receipts.log")
+
+	configFile := filepath.Join(dir, "receipts.env")
+	err := os.WriteFile(configFile, []byte(fmt.Sprintf("PORT=9000\nLOG_FILE_PATH=%v\n", logFile)), 0644)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		id           int
+		useCase      string
+		args         []string
+		env          map[string]string
+		expectedPort int
+	}{
+		{
+			id: 1, useCase: "Positive case: defaults only",
+			args:         []string{"-log-file", logFile},
+			expectedPort: 8080,
+		},
+		{
+			id: 2, useCase: "Positive case: config file overrides defaults",
+			args:         []string{"-config", configFile},
+			expectedPort: 9000,
+		},
+		{
+			id: 3, useCase: "Positive case: env overrides config file",
+			args:         []string{"-config", configFile},
+			env:          map[string]string{"PORT": "9001"},
+			expectedPort: 9001,
+		},
+		{
+			id: 4, useCase: "Positive case: flag overrides env",
+			args:         []string{"-config", configFile, "-port", "9002"},
+			env:          map[string]string{"PORT": "9001"},
+			expectedPort: 9002,
+		},
+		{
+			id: 5, useCase: "Positive case: config file named by CONFIG_FILE",
+			env:          map[string]string{"CONFIG_FILE": configFile},
+			expectedPort: 9000,
+		},
+	}
+
+	for _, tc := range testCases {
+		for k, v := range tc.env {
+			t.Setenv(k, v)
+		}
+
+		cfg, err := Load(tc.args)
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedPort, cfg.Port, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+
+		for k := range tc.env {
+			_ = os.Unsetenv(k)
+		}
+	}
+}
+
+func TestLoad_Failure(t *testing.T) {
+	dir := setUpEnv(t)
+	logFile := filepath.Join(dir, "receipts.log")
+
+	testCases := []struct {
+		id            int
+		useCase       string
+		args          []string
+		env           map[string]string
+		expectedError string
+	}{
+		{
+			id: 1, useCase: "Negative case: empty PORT",
+			args:          []string{"-log-file", logFile},
+			env:           map[string]string{"PORT": ""},
+			expectedError: "invalid PORT from env: value must not be empty",
+		},
+		{
+			id: 2, useCase: "Negative case: non numeric PORT",
+			args:          []string{"-log-file", logFile, "-port", "http"},
+			expectedError: "invalid PORT from flag",
+		},
+		{
+			id: 3, useCase: "Negative case: PORT out of range",
+			args:          []string{"-log-file", logFile, "-port", "70000"},
+			expectedError: "PORT must be between 1 and 65535, got 70000",
+		},
+		{
+			id: 4, useCase: "Negative case: log file in a missing directory",
+			args:          []string{"-log-file", filepath.Join(dir, "missing", "receipts.log")},
+			expectedError: "is not writable",
+		},
+		{
+			id: 5, useCase: "Negative case: unknown store type",
+			args:          []string{"-log-file", logFile, "-store", "postgres"},
+			expectedError: "STORE_TYPE must be one of memory, got \"postgres\"",
+		},
+		{
+			id: 6, useCase: "Negative case: access log sample rate above 1",
+			args:          []string{"-log-file", logFile, "-access-log-sample-rate", "1.5"},
+			expectedError: "ACCESS_LOG_SAMPLE_RATE must be between 0 and 1, got 1.5",
+		},
+		{
+			id: 7, useCase: "Negative case: auth enabled without API keys",
+			args:          []string{"-log-file", logFile, "-auth-enabled", "true"},
+			expectedError: "AUTH_ENABLED requires at least one key in API_KEYS or a JWT key file",
+		},
+		{
+			id: 8, useCase: "Negative case: API key with unknown scope",
+			args:          []string{"-log-file", logFile, "-api-keys", "partner:write:s3cret"},
+			expectedError: "API_KEYS is invalid: API key entry for \"partner\" has unknown scope \"write\"",
+		},
+		{
+			id: 9, useCase: "Negative case: rate limit without burst",
+			args:          []string{"-log-file", logFile, "-rate-limits", "*=50"},
+			expectedError: "RATE_LIMITS is invalid: rate limit entry \"*=50\" must have the format route=rate:burst",
+		},
+		{
+			id: 10, useCase: "Negative case: negative points expiry",
+			args:          []string{"-log-file", logFile, "-points-expiry-months", "-1"},
+			expectedError: "POINTS_EXPIRY_MONTHS must not be negative and POINTS_EXPIRY_INTERVAL must be positive, got -1 and 1h0m0s",
+		},
+		{
+			id: 11, useCase: "Negative case: negative points cap",
+			args:          []string{"-log-file", logFile, "-max-user-points-per-day", "-5"},
+			expectedError: "MAX_POINTS_PER_RECEIPT and MAX_USER_POINTS_PER_DAY must not be negative, got 0 and -5",
+		},
+		{
+			id: 12, useCase: "Negative case: review threshold above 100",
+			args:          []string{"-log-file", logFile, "-fraud-review-threshold", "101"},
+			expectedError: "FRAUD_HISTORY_WINDOW must not be negative and FRAUD_REVIEW_THRESHOLD must be between 0 and 100, got 20 and 101",
+		},
+		{
+			id: 13, useCase: "Negative case: queue without workers",
+			args:          []string{"-log-file", logFile, "-queue-workers", "0"},
+			expectedError: "QUEUE_WORKERS and QUEUE_CAPACITY must be positive, got 0 and 1000",
+		},
+		{
+			id: 14, useCase: "Negative case: webhook deliveries never attempted",
+			args:          []string{"-log-file", logFile, "-webhook-max-attempts", "0"},
+			expectedError: "WEBHOOK_WORKERS, WEBHOOK_MAX_ATTEMPTS, WEBHOOK_BACKOFF and WEBHOOK_TIMEOUT must be positive, got 4, 0, 1s and 5s",
+		},
+		{
+			id: 15, useCase: "Negative case: gRPC on the REST port",
+			args:          []string{"-log-file", logFile, "-grpc-enabled", "true", "-grpc-port", "8080"},
+			expectedError: "GRPC_PORT must be between 1 and 65535 and differ from PORT, got 8080",
+		},
+		{
+			id: 16, useCase: "Negative case: stream without heartbeat",
+			args:          []string{"-log-file", logFile, "-stream-heartbeat", "0s"},
+			expectedError: "STREAM_BUFFER_SIZE, STREAM_CLIENT_BUFFER and STREAM_HEARTBEAT must be positive, got 1000, 64 and 0s",
+		},
+		{
+			id: 17, useCase: "Negative case: GraphQL without complexity limit",
+			args:          []string{"-log-file", logFile, "-graphql-max-complexity", "0"},
+			expectedError: "GRAPHQL_MAX_DEPTH and GRAPHQL_MAX_COMPLEXITY must be positive, got 8 and 0",
+		},
+		{
+			id: 18, useCase: "Negative case: unknown outbox sink",
+			args:          []string{"-log-file", logFile, "-outbox-sinks", "stdout,kafka"},
+			expectedError: "OUTBOX_SINKS must only contain stdout, file, webhook, broker, got \"kafka\"",
+		},
+		{
+			id: 19, useCase: "Negative case: webhook sink without URL",
+			args:          []string{"-log-file", logFile, "-outbox-sinks", "webhook"},
+			expectedError: "OUTBOX_WEBHOOK_URL must not be empty with the webhook sink",
+		},
+		{
+			id: 20, useCase: "Negative case: missing config file",
+			args:          []string{"-config", filepath.Join(dir, "missing.env")},
+			expectedError: "reading config file",
+		},
+		{
+			id: 21, useCase: "Negative case: unknown flag",
+			args:          []string{"-unknown"},
+			expectedError: "flag provided but not defined",
+		},
+	}
+
+	for _, tc := range testCases {
+		for k, v := range tc.env {
+			t.Setenv(k, v)
+		}
+
+		cfg, err := Load(tc.args)
+		assert.Nil(t, cfg, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.ErrorContains(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+
+		for k := range tc.env {
+			_ = os.Unsetenv(k)
+		}
+	}
+}
+
+func TestConfigString(t *testing.T) {
+	cfg := &Config{Port: 8080, LogFilePath: "receipts.log", StoreType: "memory", AuthEnabled: true, APIKeys: "partner:submit:s3cret", ShutdownDrainDelay: 5 * time.Second}
+
+	str := cfg.String()
+	assert.True(t, strings.HasPrefix(str, "PORT=8080 LOG_FILE_PATH=receipts.log STORE_TYPE=memory "))
+	assert.Contains(t, str, " AUTH_ENABLED=true API_KEYS=****** ")
+	assert.Contains(t, str, " SHUTDOWN_DRAIN_DELAY=5s ")
+	assert.NotContains(t, str, "s3cret")
+}
@@ -0,0 +1,39 @@
+package data
+
+import (
+	"sync"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// adjustmentStore is a thread-safe in-memory append-only history of the adjustments of receipts.
+type adjustmentStore struct {
+	logger      *log.CustomLogger
+	mu          sync.RWMutex
+	adjustments map[string][]model.Adjustment // Adjustments in insertion order with receipt IDs as keys.
+}
+
+// NewAdjustments creates and returns a new instance of adjustmentStore which implements methods of the interface Adjustments.
+func NewAdjustments(l *log.CustomLogger) Adjustments {
+	return &adjustmentStore{
+		logger:      l,
+		adjustments: make(map[string][]model.Adjustment),
+	}
+}
+
+// Insert appends an adjustment to the history of its receipt.
+func (as *adjustmentStore) Insert(adjustment *model.Adjustment) {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	as.adjustments[adjustment.ReceiptID] = append(as.adjustments[adjustment.ReceiptID], *adjustment)
+}
+
+// List returns the adjustments of a receipt, oldest first.
+func (as *adjustmentStore) List(receiptID string) []model.Adjustment {
+	as.mu.RLock()
+	defer as.mu.RUnlock()
+
+	return append(make([]model.Adjustment, 0), as.adjustments[receiptID]...)
+}
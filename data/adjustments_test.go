@@ -0,0 +1,25 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestAdjustmentStore(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewAdjustments(logger)
+
+	assert.Equal(t, []model.Adjustment{}, store.List("receipt-1"))
+
+	store.Insert(&model.Adjustment{ID: "1", ReceiptID: "receipt-1", Points: -3})
+	store.Insert(&model.Adjustment{ID: "2", ReceiptID: "receipt-2", Points: -5})
+	store.Insert(&model.Adjustment{ID: "3", ReceiptID: "receipt-1", Points: -8})
+
+	assert.Equal(t, []model.Adjustment{
+		{ID: "1", ReceiptID: "receipt-1", Points: -3},
+		{ID: "3", ReceiptID: "receipt-1", Points: -8},
+	}, store.List("receipt-1"))
+}
@@ -0,0 +1,45 @@
+package data
+
+import (
+	"sync"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// apiKeyStore is a thread-safe in-memory store of API keys indexed by the hash of the key.
+type apiKeyStore struct {
+	logger *log.CustomLogger
+	mu     sync.RWMutex
+	keys   map[string]model.APIKey // API keys with the SHA-256 hash of the key as keys.
+}
+
+// NewAPIKeys creates and returns a new instance of apiKeyStore which implements methods of the interface APIKeys.
+func NewAPIKeys(l *log.CustomLogger) APIKeys {
+	return &apiKeyStore{
+		logger: l,
+		keys:   make(map[string]model.APIKey),
+	}
+}
+
+// Get retrieves the API key with the given hash, it returns an error if no such key exists.
+func (as *apiKeyStore) Get(keyHash string) (*model.APIKey, error) {
+	as.mu.RLock()
+	defer as.mu.RUnlock()
+
+	key, exists := as.keys[keyHash]
+	if !exists {
+		return nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "apiKeys", ID: keyHash})
+	}
+
+	return &key, nil
+}
+
+// Insert adds an API key to the store, replacing any key with the same hash.
+func (as *apiKeyStore) Insert(key *model.APIKey) {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	as.keys[key.KeyHash] = *key
+}
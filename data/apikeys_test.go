@@ -0,0 +1,47 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestAPIKeyStore(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewAPIKeys(logger)
+
+	store.Insert(&model.APIKey{ClientID: "partner-a", KeyHash: "hash-a", Scopes: []string{model.ScopeRead}})
+
+	testCases := []struct {
+		id            int
+		useCase       string
+		keyHash       string
+		expectedKey   *model.APIKey
+		expectedError string
+	}{
+		{
+			id: 1, useCase: "Positive case: existing key",
+			keyHash:     "hash-a",
+			expectedKey: &model.APIKey{ClientID: "partner-a", KeyHash: "hash-a", Scopes: []string{model.ScopeRead}},
+		},
+		{
+			id: 2, useCase: "Negative case: unknown key",
+			keyHash:       "hash-b",
+			expectedError: "No 'apiKeys' found for Id: 'hash-b'",
+		},
+	}
+
+	for _, tc := range testCases {
+		key, err := store.Get(tc.keyHash)
+
+		assert.Equal(t, tc.expectedKey, key, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		} else {
+			assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package data
+
+import (
+	"sort"
+	"sync"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// campaignStore is a thread-safe in-memory store of promotional campaigns.
+type campaignStore struct {
+	logger    *log.CustomLogger
+	mu        sync.RWMutex
+	campaigns map[string]model.Campaign // Campaigns with their IDs as keys.
+}
+
+// NewCampaigns creates and returns a new instance of campaignStore which implements methods of the interface Campaigns.
+func NewCampaigns(l *log.CustomLogger) Campaigns {
+	return &campaignStore{
+		logger:    l,
+		campaigns: make(map[string]model.Campaign),
+	}
+}
+
+// Get retrieves a campaign by its ID, it returns an error if the campaign is not found.
+func (cs *campaignStore) Get(campaignID string) (*model.Campaign, error) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	campaign, exists := cs.campaigns[campaignID]
+	if !exists {
+		return nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "campaigns", ID: campaignID})
+	}
+
+	return &campaign, nil
+}
+
+// List returns every campaign ordered by start date, then ID, which is the order campaigns are evaluated in.
+func (cs *campaignStore) List() []model.Campaign {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	campaigns := make([]model.Campaign, 0, len(cs.campaigns))
+	for _, campaign := range cs.campaigns {
+		campaigns = append(campaigns, campaign)
+	}
+
+	sort.Slice(campaigns, func(i, j int) bool {
+		if campaigns[i].StartDate != campaigns[j].StartDate {
+			return campaigns[i].StartDate < campaigns[j].StartDate
+		}
+
+		return campaigns[i].ID < campaigns[j].ID
+	})
+
+	return campaigns
+}
+
+// Insert adds a campaign to the store.
+func (cs *campaignStore) Insert(campaign *model.Campaign) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	cs.campaigns[campaign.ID] = *campaign
+}
+
+// Update replaces a stored campaign, it returns an error if the campaign is not found.
+func (cs *campaignStore) Update(campaign *model.Campaign) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if _, exists := cs.campaigns[campaign.ID]; !exists {
+		return errors.NewEntityNotFound(errors.EntityNotFound{Entity: "campaigns", ID: campaign.ID})
+	}
+
+	cs.campaigns[campaign.ID] = *campaign
+
+	return nil
+}
+
+// Delete removes a campaign, it returns an error if the campaign is not found.
+// Receipts keep the campaigns that were applied to them.
+func (cs *campaignStore) Delete(campaignID string) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if _, exists := cs.campaigns[campaignID]; !exists {
+		return errors.NewEntityNotFound(errors.EntityNotFound{Entity: "campaigns", ID: campaignID})
+	}
+
+	delete(cs.campaigns, campaignID)
+
+	return nil
+}
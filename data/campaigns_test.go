@@ -0,0 +1,72 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestCampaignStore(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewCampaigns(logger)
+
+	store.Insert(&model.Campaign{ID: "b", Name: "Spring", StartDate: "2024-03-01", EndDate: "2024-05-31", Bonus: 10})
+	store.Insert(&model.Campaign{ID: "a", Name: "Spring 2x", StartDate: "2024-03-01", EndDate: "2024-03-31", Multiplier: 2})
+	store.Insert(&model.Campaign{ID: "c", Name: "Winter", StartDate: "2024-01-01", EndDate: "2024-02-28", Bonus: 5})
+
+	var order []string
+	for _, c := range store.List() {
+		order = append(order, c.ID)
+	}
+	assert.Equal(t, []string{"c", "a", "b"}, order)
+
+	testCases := []struct {
+		id            int
+		useCase       string
+		run           func() error
+		expectedError string
+	}{
+		{
+			id: 1, useCase: "Positive case: update existing campaign",
+			run: func() error { return store.Update(&model.Campaign{ID: "b", Name: "Spring", Bonus: 20}) },
+		},
+		{
+			id: 2, useCase: "Negative case: update unknown campaign",
+			run:           func() error { return store.Update(&model.Campaign{ID: "d"}) },
+			expectedError: "No 'campaigns' found for Id: 'd'",
+		},
+		{
+			id: 3, useCase: "Positive case: delete existing campaign",
+			run: func() error { return store.Delete("c") },
+		},
+		{
+			id: 4, useCase: "Negative case: delete deleted campaign",
+			run:           func() error { return store.Delete("c") },
+			expectedError: "No 'campaigns' found for Id: 'c'",
+		},
+		{
+			id: 5, useCase: "Negative case: get deleted campaign",
+			run: func() error {
+				_, err := store.Get("c")
+				return err
+			},
+			expectedError: "No 'campaigns' found for Id: 'c'",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.run()
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		} else {
+			assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+	}
+
+	campaign, err := store.Get("b")
+	assert.NoError(t, err)
+	assert.Equal(t, 20, campaign.Bonus)
+}
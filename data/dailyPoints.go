@@ -0,0 +1,58 @@
+package data
+
+import (
+	"sync"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+)
+
+// dailyPointsStore is a thread-safe in-memory store of the points reserved for each user per day.
+type dailyPointsStore struct {
+	logger  *log.CustomLogger
+	mu      sync.Mutex
+	awarded map[string]map[string]int // Points reserved with days in YYYY-MM-DD format and then user IDs as keys.
+}
+
+// NewDailyPoints creates and returns a new instance of dailyPointsStore which implements methods of the interface DailyPoints.
+func NewDailyPoints(l *log.CustomLogger) DailyPoints {
+	return &dailyPointsStore{
+		logger:  l,
+		awarded: make(map[string]map[string]int),
+	}
+}
+
+// Reserve counts up to points for a user on the given day without exceeding limit points on that day.
+// It returns the points counted, which are fewer than requested once the user nears the limit.
+// Points are kept for the given day and the day before, so a late reservation for the previous day around midnight
+// leaves the points of both days untouched.
+func (ds *dailyPointsStore) Reserve(userID string, day string, points, limit int) int {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	discardDays(ds.awarded, day)
+
+	reserved, ok := ds.awarded[day]
+	if !ok {
+		reserved = make(map[string]int)
+		ds.awarded[day] = reserved
+	}
+
+	awarded := max(min(points, limit-reserved[userID]), 0)
+	reserved[userID] += awarded
+
+	return awarded
+}
+
+// Release gives back points reserved for a user on the given day, e.g. the points of a receipt rejected on review.
+// Points of a day already discarded are not given back.
+func (ds *dailyPointsStore) Release(userID string, day string, points int) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	reserved, ok := ds.awarded[day]
+	if !ok {
+		return
+	}
+
+	reserved[userID] -= min(points, reserved[userID])
+}
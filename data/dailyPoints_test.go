@@ -0,0 +1,61 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+)
+
+func TestDailyPointsStoreReserve(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewDailyPoints(logger)
+
+	testCases := []struct {
+		id              int
+		useCase         string
+		userID          string
+		day             string
+		points          int
+		expectedAwarded int
+	}{
+		{id: 1, useCase: "Positive case: points within the limit", userID: "user-1", day: "2024-03-01", points: 60, expectedAwarded: 60},
+		{id: 2, useCase: "Positive case: points lowered to what is left", userID: "user-1", day: "2024-03-01", points: 60, expectedAwarded: 40},
+		{id: 3, useCase: "Negative case: limit reached", userID: "user-1", day: "2024-03-01", points: 10, expectedAwarded: 0},
+		{id: 4, useCase: "Positive case: limits are counted per user", userID: "user-2", day: "2024-03-01", points: 100, expectedAwarded: 100},
+		{id: 5, useCase: "Positive case: limit resets on the next day", userID: "user-1", day: "2024-03-02", points: 30, expectedAwarded: 30},
+		{id: 6, useCase: "Negative case: late receipt of the previous day", userID: "user-1", day: "2024-03-01", points: 10, expectedAwarded: 0},
+		{id: 7, useCase: "Positive case: points of the day kept after a late receipt", userID: "user-1", day: "2024-03-02", points: 80, expectedAwarded: 70},
+	}
+
+	for _, tc := range testCases {
+		awarded := store.Reserve(tc.userID, tc.day, tc.points, 100)
+		assert.Equal(t, tc.expectedAwarded, awarded, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestDailyPointsStoreRelease(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewDailyPoints(logger)
+
+	testCases := []struct {
+		id              int
+		useCase         string
+		releaseDay      string
+		day             string
+		expectedAwarded int
+	}{
+		{id: 1, useCase: "Positive case: released points can be reserved again", releaseDay: "2024-03-01", day: "2024-03-01", expectedAwarded: 60},
+		{id: 2, useCase: "Negative case: points released on the previous day leave the day untouched", releaseDay: "2024-03-01", day: "2024-03-02", expectedAwarded: 40},
+	}
+
+	for _, tc := range testCases {
+		store.Reserve("user-1", "2024-03-01", 60, 100)
+		store.Reserve("user-1", tc.day, 60, 100)
+		store.Release("user-1", tc.releaseDay, 60)
+
+		awarded := store.Reserve("user-1", tc.day, 60, 100)
+		assert.Equal(t, tc.expectedAwarded, awarded, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
@@ -0,0 +1,123 @@
+package data
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// idempotencyStore is a thread-safe in-memory store of the requests sent with an idempotency key.
+// Requests are also kept in a heap ordered by expiry, so that expired requests are discarded without scanning the store.
+type idempotencyStore struct {
+	logger   *log.CustomLogger
+	mu       sync.Mutex
+	maxKeys  int                           // Number of keys kept, the requests expiring first are discarded beyond it.
+	requests map[string]*idempotentRequest // Requests with their idempotency keys as keys.
+	expiries expiryHeap                    // Requests ordered by expiry.
+}
+
+// idempotentRequest is a stored request along with its index in the expiry heap.
+type idempotentRequest struct {
+	model.IdempotentRequest
+	index int
+}
+
+// NewIdempotency creates and returns a new instance of idempotencyStore which implements methods of the interface Idempotency.
+// It keeps up to maxKeys keys, 0 means unbounded.
+func NewIdempotency(l *log.CustomLogger, maxKeys int) Idempotency {
+	return &idempotencyStore{
+		logger:   l,
+		maxKeys:  maxKeys,
+		requests: make(map[string]*idempotentRequest),
+	}
+}
+
+// Reserve stores the request unless its key is already used by a request that did not expire at now.
+// It returns the request already using the key, or nil when the request was stored. Expired requests are discarded,
+// and so are the requests expiring first once the store holds maxKeys keys.
+func (is *idempotencyStore) Reserve(request *model.IdempotentRequest, now time.Time) *model.IdempotentRequest {
+	is.mu.Lock()
+	defer is.mu.Unlock()
+
+	for len(is.expiries) > 0 && !now.Before(is.expiries[0].ExpiresAt) {
+		is.remove(is.expiries[0])
+	}
+
+	if stored, ok := is.requests[request.Key]; ok {
+		r := stored.IdempotentRequest
+		return &r
+	}
+
+	for is.maxKeys > 0 && len(is.expiries) >= is.maxKeys {
+		is.remove(is.expiries[0])
+	}
+
+	stored := &idempotentRequest{IdempotentRequest: *request}
+	is.requests[request.Key] = stored
+	heap.Push(&is.expiries, stored)
+
+	return nil
+}
+
+// Complete records the response of a reserved request, it is replayed to the retries of the request until it expires.
+// The response of a request discarded while it was processed is not recorded.
+func (is *idempotencyStore) Complete(request *model.IdempotentRequest) {
+	is.mu.Lock()
+	defer is.mu.Unlock()
+
+	request.Completed = true
+
+	stored, ok := is.requests[request.Key]
+	if !ok {
+		return
+	}
+
+	stored.IdempotentRequest = *request
+	heap.Fix(&is.expiries, stored.index)
+}
+
+// Release discards a reserved request, so that its key may be used again.
+func (is *idempotencyStore) Release(key string) {
+	is.mu.Lock()
+	defer is.mu.Unlock()
+
+	if stored, ok := is.requests[key]; ok {
+		is.remove(stored)
+	}
+}
+
+// remove discards a stored request, the caller must hold the lock.
+func (is *idempotencyStore) remove(stored *idempotentRequest) {
+	heap.Remove(&is.expiries, stored.index)
+	delete(is.requests, stored.Key)
+}
+
+// expiryHeap implements heap.Interface, ordering requests by expiry.
+type expiryHeap []*idempotentRequest
+
+func (h expiryHeap) Len() int { return len(h) }
+
+func (h expiryHeap) Less(i, j int) bool { return h[i].ExpiresAt.Before(h[j].ExpiresAt) }
+
+func (h expiryHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+
+func (h *expiryHeap) Push(x any) {
+	stored := x.(*idempotentRequest)
+	stored.index = len(*h)
+	*h = append(*h, stored)
+}
+
+func (h *expiryHeap) Pop() any {
+	old := *h
+	stored := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+
+	return stored
+}
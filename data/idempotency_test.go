@@ -0,0 +1,67 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestIdempotencyStore(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewIdempotency(logger, 2)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		id              int
+		useCase         string
+		action          string
+		key             string
+		fingerprint     string
+		at              time.Time
+		expiresAt       time.Time // Expiry of the request, 24 hours after now when zero.
+		expectedStored  string    // Fingerprint of the request already using the key, empty when the request was stored.
+		expectCompleted bool
+	}{
+		{id: 1, useCase: "Positive case: first use of a key", action: "reserve", key: "a", fingerprint: "first", at: now},
+		{id: 2, useCase: "Negative case: key in use by a request being processed", action: "reserve", key: "a", fingerprint: "second", at: now, expectedStored: "first"},
+		{id: 3, useCase: "Positive case: keys are independent", action: "reserve", key: "b", fingerprint: "second", at: now},
+		{id: 4, useCase: "Positive case: completed request", action: "complete", key: "a", fingerprint: "first", at: now},
+		{id: 5, useCase: "Negative case: key in use by a completed request", action: "reserve", key: "a", fingerprint: "first", at: now.Add(time.Hour), expectedStored: "first", expectCompleted: true},
+		{id: 6, useCase: "Positive case: released request", action: "release", key: "b"},
+		{id: 7, useCase: "Positive case: key reused once released", action: "reserve", key: "b", fingerprint: "third", at: now},
+		{id: 8, useCase: "Positive case: key reused once expired", action: "reserve", key: "a", fingerprint: "fourth", at: now.Add(24 * time.Hour)},
+		{id: 9, useCase: "Positive case: key stored once the expired keys are discarded", action: "reserve", key: "c", fingerprint: "fifth", at: now.Add(24 * time.Hour), expiresAt: now.Add(30 * time.Hour)},
+		{id: 10, useCase: "Positive case: key stored up to the maximum of keys", action: "reserve", key: "d", fingerprint: "sixth", at: now.Add(24 * time.Hour), expiresAt: now.Add(36 * time.Hour)},
+		{id: 11, useCase: "Positive case: key expiring first discarded beyond the maximum of keys", action: "reserve", key: "e", fingerprint: "seventh", at: now.Add(24 * time.Hour), expiresAt: now.Add(48 * time.Hour)},
+		{id: 12, useCase: "Negative case: key expiring later kept", action: "reserve", key: "d", fingerprint: "eighth", at: now.Add(24 * time.Hour), expectedStored: "sixth"},
+		{id: 13, useCase: "Positive case: discarded key reused", action: "reserve", key: "c", fingerprint: "ninth", at: now.Add(24 * time.Hour), expiresAt: now.Add(48 * time.Hour)},
+	}
+
+	for _, tc := range testCases {
+		expiresAt := now.Add(24 * time.Hour)
+		if !tc.expiresAt.IsZero() {
+			expiresAt = tc.expiresAt
+		}
+
+		request := &model.IdempotentRequest{Key: tc.key, Fingerprint: tc.fingerprint, ExpiresAt: expiresAt}
+
+		switch tc.action {
+		case "complete":
+			store.Complete(request)
+		case "release":
+			store.Release(tc.key)
+		default:
+			stored := store.Reserve(request, tc.at)
+			if tc.expectedStored == "" {
+				assert.Nil(t, stored, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			} else {
+				assert.Equal(t, tc.expectedStored, stored.Fingerprint, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+				assert.Equal(t, tc.expectCompleted, stored.Completed, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			}
+		}
+	}
+}
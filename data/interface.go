@@ -1,10 +1,122 @@
 package data
 
 import (
+	"time"
+
 	"github/shivasaicharanruthala/backend-engineer-takehome/model"
 )
 
 type Receipts interface {
 	Get(receiptID string) (*model.ReceiptGetResponse, error)
-	Insert(receipt *model.Receipt) *model.ReceiptPostResponse
+	Find(receiptID string) (*model.Receipt, error)
+	Insert(receipt *model.Receipt, events ...model.DomainEvent) *model.ReceiptPostResponse
+	InsertHeld(receipt *model.Receipt, review *model.Review, reviews Reviews, events ...model.DomainEvent) *model.ReceiptPostResponse
+	Recent(submitter string, limit int) []model.Receipt
+	List(filter model.ReceiptFilter, offset, limit int) ([]model.Receipt, int)
+	Count() int
+	Ping() error
+}
+
+type APIKeys interface {
+	Get(keyHash string) (*model.APIKey, error)
+	Insert(key *model.APIKey)
+}
+
+type Quotas interface {
+	Increment(clientID string, day string, limit int) (int, bool)
+	Refund(clientID string, day string)
+}
+
+type Idempotency interface {
+	Reserve(request *model.IdempotentRequest, now time.Time) *model.IdempotentRequest
+	Complete(request *model.IdempotentRequest)
+	Release(key string)
+}
+
+type Ledger interface {
+	Bind(userID, clientID string) error
+	Client(userID string) string
+	Append(entry *model.LedgerEntry)
+	Debit(entry *model.LedgerEntry) (int, error)
+	Expire(now time.Time) []model.LedgerEntry
+	Expiring(userID string, from, until time.Time) ([]model.ExpiringPoints, error)
+	Balance(userID string) (int, error)
+	List(userID string, offset, limit int) ([]model.LedgerEntry, int, error)
+}
+
+type Rewards interface {
+	Get(rewardID string) (*model.Reward, error)
+	List() []model.Reward
+	Insert(reward *model.Reward)
+	Redeem(redemption *model.Redemption, debit func(cost int) (int, error)) error
+	Redemptions(userID string) []model.Redemption
+}
+
+type Adjustments interface {
+	Insert(adjustment *model.Adjustment)
+	List(receiptID string) []model.Adjustment
+}
+
+type Campaigns interface {
+	Get(campaignID string) (*model.Campaign, error)
+	List() []model.Campaign
+	Insert(campaign *model.Campaign)
+	Update(campaign *model.Campaign) error
+	Delete(campaignID string) error
+}
+
+type Retailers interface {
+	Get(retailerID string) (*model.Retailer, error)
+	List() []model.Retailer
+	Insert(retailer *model.Retailer) error
+	Update(retailer *model.Retailer) error
+	Delete(retailerID string) error
+	Resolve(raw string) (*model.Retailer, bool)
+}
+
+type DailyPoints interface {
+	Reserve(userID string, day string, points, limit int) int
+	Release(userID string, day string, points int)
+}
+
+type Reviews interface {
+	Insert(review *model.Review)
+	Get(receiptID string) (*model.Review, error)
+	List(status string) []model.Review
+	Decide(receiptID string, status, note string, decidedAt time.Time, events ...model.DomainEvent) (*model.Review, error)
+}
+
+type Jobs interface {
+	Insert(job *model.ReceiptJob) error
+	Get(jobID string) (*model.ReceiptJob, error)
+	Update(job *model.ReceiptJob) error
+	Pending() []model.ReceiptJob
+	Evict(before time.Time) int
+}
+
+type Webhooks interface {
+	Insert(webhook *model.Webhook)
+	Get(webhookID string) (*model.Webhook, error)
+	List(clientID string) []model.Webhook
+	Delete(webhookID string) error
+	Subscribed(clientID string, userID string, eventType string) []model.Webhook
+}
+
+type Deliveries interface {
+	Insert(delivery *model.Delivery)
+	List(webhookID string, status string) []model.Delivery
+}
+
+type DeadLetters interface {
+	Insert(delivery *model.Delivery)
+	List(webhookID string) []model.Delivery
+	Remove(webhookID string, deliveryID string) (*model.Delivery, error)
+}
+
+type Outbox interface {
+	Append(events ...model.DomainEvent)
+	Pending(limit int) []model.OutboxEntry
+	MarkPublished(eventID string)
+	MarkFailed(eventID string, errMsg string)
+	Count() int
 }
@@ -0,0 +1,246 @@
+package data
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// jobStore is a thread-safe store of the receipts queued for asynchronous processing.
+// When backed by a file every change is appended to it as a JSON line, so that queued receipts survive restarts.
+type jobStore struct {
+	logger *log.CustomLogger
+	mu     sync.Mutex
+	jobs   map[string]model.ReceiptJob // Jobs with their IDs as keys.
+	file   *os.File                    // Append-only log of the jobs, nil for an in-memory store.
+	path   string                      // Path of the job file, empty for an in-memory store.
+}
+
+// NewJobs creates and returns a new in-memory instance of jobStore which implements methods of the interface Jobs.
+// Queued receipts are lost on restart.
+func NewJobs(l *log.CustomLogger) Jobs {
+	return &jobStore{
+		logger: l,
+		jobs:   make(map[string]model.ReceiptJob),
+	}
+}
+
+// NewFileJobs creates and returns a new instance of jobStore backed by the file at path, it is created if missing.
+// The jobs of the file are loaded and the file is compacted to the latest state of each job.
+func NewFileJobs(l *log.CustomLogger, path string) (Jobs, error) {
+	js := &jobStore{
+		logger: l,
+		jobs:   make(map[string]model.ReceiptJob),
+		path:   path,
+	}
+
+	if err := js.load(path); err != nil {
+		return nil, err
+	}
+
+	if err := js.compact(path); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening job file: %w", err)
+	}
+
+	js.file = file
+
+	return js, nil
+}
+
+// Insert adds a job to the store.
+func (js *jobStore) Insert(job *model.ReceiptJob) error {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	return js.save(job)
+}
+
+// Get retrieves a job by its ID, it returns an error if the job is not found.
+func (js *jobStore) Get(jobID string) (*model.ReceiptJob, error) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	job, exists := js.jobs[jobID]
+	if !exists {
+		return nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "jobs", ID: jobID})
+	}
+
+	return &job, nil
+}
+
+// Update replaces a stored job, it returns an error if the job is not found.
+func (js *jobStore) Update(job *model.ReceiptJob) error {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	if _, exists := js.jobs[job.ID]; !exists {
+		return errors.NewEntityNotFound(errors.EntityNotFound{Entity: "jobs", ID: job.ID})
+	}
+
+	return js.save(job)
+}
+
+// Pending returns the jobs not processed yet, oldest first.
+func (js *jobStore) Pending() []model.ReceiptJob {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	jobs := make([]model.ReceiptJob, 0)
+	for _, job := range js.jobs {
+		if job.Status == model.JobPending {
+			jobs = append(jobs, job)
+		}
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		if !jobs[i].CreatedAt.Equal(jobs[j].CreatedAt) {
+			return jobs[i].CreatedAt.Before(jobs[j].CreatedAt)
+		}
+
+		return jobs[i].ID < jobs[j].ID
+	})
+
+	return jobs
+}
+
+// Evict discards the jobs done before the given time, processed or failed, and compacts the job file when there is one.
+// It returns the number of jobs discarded.
+func (js *jobStore) Evict(before time.Time) int {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	evicted := 0
+	for id, job := range js.jobs {
+		if job.Status != model.JobPending && job.UpdatedAt.Before(before) {
+			delete(js.jobs, id)
+			evicted++
+		}
+	}
+
+	if evicted == 0 || js.file == nil {
+		return evicted
+	}
+
+	// The evicted jobs stay in the job file when it cannot be compacted, they are loaded as done on restart.
+	err := js.compact(js.path)
+	if err == nil {
+		var file *os.File
+		if file, err = os.OpenFile(js.path, os.O_APPEND|os.O_WRONLY, 0o600); err == nil {
+			_ = js.file.Close()
+			js.file = file
+		}
+	}
+
+	if err != nil {
+		lm := log.Message{Level: "ERROR", ErrorMessage: fmt.Sprintf("Compacting the job file with error %v", err.Error())}
+		js.logger.Log(&lm)
+	}
+
+	return evicted
+}
+
+// save writes the job to the file, when there is one, then to memory, the caller must hold the lock.
+// The job is only kept in memory once it is durable.
+func (js *jobStore) save(job *model.ReceiptJob) error {
+	if js.file != nil {
+		line, err := json.Marshal(job)
+		if err != nil {
+			return errors.NewCustomError(err)
+		}
+
+		if _, err = js.file.Write(append(line, '\n')); err == nil {
+			err = js.file.Sync()
+		}
+
+		if err != nil {
+			lm := log.Message{Level: "ERROR", ErrorMessage: fmt.Sprintf("Writing job %v to the job file with error %v", job.ID, err.Error())}
+			js.logger.Log(&lm)
+
+			return errors.NewCustomError(err, 503)
+		}
+	}
+
+	js.jobs[job.ID] = *job
+
+	return nil
+}
+
+// load replays the job file at path, later lines replacing earlier ones. A missing file is an empty store.
+// A truncated last line, left by a crash during a write, is skipped.
+func (js *jobStore) load(path string) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("opening job file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		var job model.ReceiptJob
+		if err := json.Unmarshal(scanner.Bytes(), &job); err != nil {
+			lm := log.Message{Level: "ERROR", ErrorMessage: fmt.Sprintf("Skipping line %v of the job file with error %v", line, err.Error())}
+			js.logger.Log(&lm)
+
+			continue
+		}
+
+		js.jobs[job.ID] = job
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading job file: %w", err)
+	}
+
+	return nil
+}
+
+// compact rewrites the job file at path with one line per job, through a temporary file renamed over it.
+func (js *jobStore) compact(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("compacting job file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	for _, job := range js.jobs {
+		line, _ := json.Marshal(job)
+		_, _ = w.Write(append(line, '\n'))
+	}
+
+	if err = w.Flush(); err == nil {
+		err = tmp.Sync()
+	}
+
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("compacting job file: %w", err)
+	}
+
+	return nil
+}
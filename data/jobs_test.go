@@ -0,0 +1,124 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestFileJobStore(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	path := filepath.Join(t.TempDir(), "jobs.log")
+
+	now := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+	receipt := model.Receipt{Retailer: model.StringPointer("Target"), UserID: "user-1"}
+
+	store, err := NewFileJobs(logger, path)
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Insert(&model.ReceiptJob{ID: "b", Status: model.JobPending, Receipt: receipt, ClientID: "partner", CreatedAt: now.Add(time.Second)}))
+	assert.NoError(t, store.Insert(&model.ReceiptJob{ID: "a", Status: model.JobPending, Receipt: receipt, CreatedAt: now}))
+	assert.NoError(t, store.Insert(&model.ReceiptJob{ID: "c", Status: model.JobPending, Receipt: receipt, CreatedAt: now}))
+	assert.NoError(t, store.Update(&model.ReceiptJob{ID: "c", Status: model.JobFailed, Error: "invalid", CreatedAt: now}))
+	assert.EqualError(t, store.Update(&model.ReceiptJob{ID: "d"}), "No 'jobs' found for Id: 'd'")
+
+	// A crash during a write leaves a truncated last line.
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	_, _ = file.WriteString(`{"id":"e","status":"pend`)
+	_ = file.Close()
+
+	reopened, err := NewFileJobs(logger, path)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		id             int
+		useCase        string
+		jobID          string
+		expectedStatus string
+		expectedError  string
+	}{
+		{id: 1, useCase: "Positive case: pending job restored", jobID: "b", expectedStatus: model.JobPending},
+		{id: 2, useCase: "Positive case: latest state of an updated job restored", jobID: "c", expectedStatus: model.JobFailed},
+		{id: 3, useCase: "Negative case: truncated job skipped", jobID: "e", expectedError: "No 'jobs' found for Id: 'e'"},
+	}
+
+	for _, tc := range testCases {
+		job, err := reopened.Get(tc.jobID)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			continue
+		}
+
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedStatus, job.Status, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+
+	pending := reopened.Pending()
+	assert.Equal(t, []string{"a", "b"}, []string{pending[0].ID, pending[1].ID})
+	assert.Equal(t, "partner", pending[1].ClientID)
+	assert.Equal(t, "Target", *pending[1].Receipt.Retailer)
+	assert.Equal(t, "user-1", pending[1].Receipt.UserID)
+
+	// The file was compacted to one line per job.
+	content, _ := os.ReadFile(path)
+	lines := 0
+	for _, b := range content {
+		if b == '\n' {
+			lines++
+		}
+	}
+	assert.Equal(t, 3, lines)
+}
+
+func TestFileJobStoreEvict(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	path := filepath.Join(t.TempDir(), "jobs.log")
+
+	now := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	store, err := NewFileJobs(logger, path)
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Insert(&model.ReceiptJob{ID: "a", Status: model.JobPending, CreatedAt: now, UpdatedAt: now}))
+	assert.NoError(t, store.Insert(&model.ReceiptJob{ID: "b", Status: model.JobProcessed, CreatedAt: now, UpdatedAt: now}))
+	assert.NoError(t, store.Insert(&model.ReceiptJob{ID: "c", Status: model.JobFailed, CreatedAt: now, UpdatedAt: now}))
+	assert.NoError(t, store.Insert(&model.ReceiptJob{ID: "d", Status: model.JobProcessed, CreatedAt: now, UpdatedAt: now.Add(time.Hour)}))
+
+	assert.Equal(t, 2, store.Evict(now.Add(time.Minute)))
+
+	// Jobs written after the eviction are appended to the compacted file.
+	assert.NoError(t, store.Update(&model.ReceiptJob{ID: "a", Status: model.JobProcessed, CreatedAt: now, UpdatedAt: now.Add(time.Hour)}))
+
+	reopened, err := NewFileJobs(logger, path)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		id             int
+		useCase        string
+		jobID          string
+		expectedStatus string
+		expectedError  string
+	}{
+		{id: 1, useCase: "Positive case: job pending at the eviction kept", jobID: "a", expectedStatus: model.JobProcessed},
+		{id: 2, useCase: "Negative case: processed job evicted", jobID: "b", expectedError: "No 'jobs' found for Id: 'b'"},
+		{id: 3, useCase: "Negative case: failed job evicted", jobID: "c", expectedError: "No 'jobs' found for Id: 'c'"},
+		{id: 4, useCase: "Positive case: job done after the eviction time kept", jobID: "d", expectedStatus: model.JobProcessed},
+	}
+
+	for _, tc := range testCases {
+		job, err := reopened.Get(tc.jobID)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			continue
+		}
+
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedStatus, job.Status, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
@@ -0,0 +1,266 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// ledgerStore is a thread-safe in-memory append-only points ledger, entries are never updated nor removed.
+type ledgerStore struct {
+	logger   *log.CustomLogger
+	mu       sync.RWMutex
+	entries  map[string][]model.LedgerEntry // Entries in insertion order with user IDs as keys.
+	balances map[string]int                 // Sum of the points of the entries with user IDs as keys.
+	lots     map[string][]*lot              // Credits with points left, in the order they were earned, with user IDs as keys.
+	clients  map[string]string              // Client each user belongs to with user IDs as keys.
+	outbox   Outbox                         // Outbox the event of every appended entry is written to, nil drops them.
+}
+
+// lot tracks the points of a credit that were neither spent nor expired yet.
+type lot struct {
+	entry     model.LedgerEntry
+	remaining int
+}
+
+// NewLedger creates and returns a new instance of ledgerStore which implements methods of the interface Ledger.
+func NewLedger(l *log.CustomLogger) Ledger {
+	return &ledgerStore{
+		logger:   l,
+		entries:  make(map[string][]model.LedgerEntry),
+		balances: make(map[string]int),
+		lots:     make(map[string][]*lot),
+		clients:  make(map[string]string),
+	}
+}
+
+// NewLedgerWithOutbox creates and returns a new instance of ledgerStore writing the event of every entry, credits,
+// reversals, debits and expiries alike, to outbox in the same operation as the entry.
+func NewLedgerWithOutbox(l *log.CustomLogger, outbox Outbox) Ledger {
+	return &ledgerStore{
+		logger:   l,
+		entries:  make(map[string][]model.LedgerEntry),
+		balances: make(map[string]int),
+		lots:     make(map[string][]*lot),
+		clients:  make(map[string]string),
+		outbox:   outbox,
+	}
+}
+
+// Bind binds a user to the client submitting receipts on their behalf, the first client to do so.
+// It returns an error if the user is bound to another client, clients only earn points for their own users.
+func (ls *ledgerStore) Bind(userID, clientID string) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if bound, exists := ls.clients[userID]; exists && bound != clientID {
+		return errors.NewForbidden(fmt.Errorf("User '%v' belongs to another client", userID))
+	}
+
+	ls.clients[userID] = clientID
+
+	return nil
+}
+
+// Client returns the client a user is bound to, empty when no client submitted receipts on behalf of the user.
+func (ls *ledgerStore) Client(userID string) string {
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+
+	return ls.clients[userID]
+}
+
+// Append adds an entry to the ledger of its user and updates the balance of the user.
+func (ls *ledgerStore) Append(entry *model.LedgerEntry) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	ls.append(entry)
+}
+
+// Debit appends a debit entry, carrying negative points, unless it would take the balance of its user below zero.
+// Points are consumed first in first out: the oldest credits are spent first, as they are the first to expire.
+// Credits expired at the time of the debit are expired first so they are never spent.
+// The check and the append happen under the same lock, so concurrent debits can never spend the same points twice.
+// It returns the balance of the user after the debit.
+func (ls *ledgerStore) Debit(entry *model.LedgerEntry) (int, error) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	ls.expire(entry.UserID, entry.CreatedAt)
+
+	balance := ls.balances[entry.UserID]
+	if balance+entry.Points < 0 {
+		return balance, errors.NewConflict(fmt.Errorf("Insufficient points balance: %v available, %v required", balance, -entry.Points))
+	}
+
+	ls.append(entry)
+
+	return ls.balances[entry.UserID], nil
+}
+
+// Expire writes an expiry entry for the points left of every credit expired at now and returns the written entries.
+func (ls *ledgerStore) Expire(now time.Time) []model.LedgerEntry {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	var expired []model.LedgerEntry
+	for userID := range ls.lots {
+		expired = append(expired, ls.expire(userID, now)...)
+	}
+
+	return expired
+}
+
+// Expiring returns the points left of the credits of a user expiring after from and up to until, soonest first.
+// It returns an error if the user has no ledger entries.
+func (ls *ledgerStore) Expiring(userID string, from, until time.Time) ([]model.ExpiringPoints, error) {
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+
+	if _, exists := ls.entries[userID]; !exists {
+		return nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "users", ID: userID})
+	}
+
+	expiring := make([]model.ExpiringPoints, 0)
+	for _, l := range ls.lots[userID] {
+		if l.entry.ExpiresAt == nil || !l.entry.ExpiresAt.After(from) || l.entry.ExpiresAt.After(until) {
+			continue
+		}
+
+		expiring = append(expiring, model.ExpiringPoints{
+			EntryID:   l.entry.ID,
+			ReceiptID: l.entry.ReceiptID,
+			Points:    l.remaining,
+			ExpiresAt: *l.entry.ExpiresAt,
+		})
+	}
+
+	sort.SliceStable(expiring, func(i, j int) bool { return expiring[i].ExpiresAt.Before(expiring[j].ExpiresAt) })
+
+	return expiring, nil
+}
+
+// append adds an entry to the ledger, credits with points open a lot. The event of the entry is written to the
+// outbox. The caller must hold the lock.
+func (ls *ledgerStore) append(entry *model.LedgerEntry) {
+	ls.entries[entry.UserID] = append(ls.entries[entry.UserID], *entry)
+	ls.balances[entry.UserID] += entry.Points
+
+	if ls.outbox != nil {
+		ls.outbox.Append(model.NewLedgerEvent(entry, ls.clients[entry.UserID]))
+	}
+
+	switch {
+	case entry.Type == model.LedgerCredit && entry.Points > 0:
+		ls.lots[entry.UserID] = append(ls.lots[entry.UserID], &lot{entry: *entry, remaining: entry.Points})
+	case entry.Type == model.LedgerDebit || entry.Type == model.LedgerReversal:
+		ls.consume(entry.UserID, entry.ReceiptID, -entry.Points)
+	}
+}
+
+// consume takes points from the lots of a user in first in first out order. Reversals take back the points of
+// their own receipt first. The caller must hold the lock.
+func (ls *ledgerStore) consume(userID, receiptID string, points int) {
+	lots := ls.lots[userID]
+	if receiptID != "" {
+		for i, l := range lots {
+			if l.entry.ReceiptID == receiptID {
+				lots = append([]*lot{l}, append(lots[:i:i], lots[i+1:]...)...)
+				break
+			}
+		}
+	}
+
+	for _, l := range lots {
+		taken := min(l.remaining, points)
+		l.remaining -= taken
+		points -= taken
+	}
+
+	ls.compact(userID)
+}
+
+// expire writes expiry entries for the lots of a user expired at now. The caller must hold the lock.
+func (ls *ledgerStore) expire(userID string, now time.Time) []model.LedgerEntry {
+	var expired []model.LedgerEntry
+	for _, l := range ls.lots[userID] {
+		if l.entry.ExpiresAt == nil || l.entry.ExpiresAt.After(now) {
+			continue
+		}
+
+		entry := model.LedgerEntry{
+			ID:        uuid.New().String(),
+			UserID:    userID,
+			ReceiptID: l.entry.ReceiptID,
+			Type:      model.LedgerExpiry,
+			Points:    -l.remaining,
+			CreatedAt: now,
+		}
+
+		ls.append(&entry)
+		l.remaining = 0
+		expired = append(expired, entry)
+	}
+	ls.compact(userID)
+
+	return expired
+}
+
+// compact drops the lots of a user with no points left. The caller must hold the lock.
+func (ls *ledgerStore) compact(userID string) {
+	lots := ls.lots[userID][:0]
+	for _, l := range ls.lots[userID] {
+		if l.remaining > 0 {
+			lots = append(lots, l)
+		}
+	}
+
+	if len(lots) == 0 {
+		delete(ls.lots, userID)
+		return
+	}
+
+	ls.lots[userID] = lots
+}
+
+// Balance returns the points balance of a user, it returns an error if the user has no ledger entries.
+func (ls *ledgerStore) Balance(userID string) (int, error) {
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+
+	if _, exists := ls.entries[userID]; !exists {
+		return 0, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "users", ID: userID})
+	}
+
+	return ls.balances[userID], nil
+}
+
+// List returns at most limit entries of the ledger of a user, newest first, skipping the offset newest ones,
+// along with the total number of entries of the user. It returns an error if the user has no ledger entries.
+func (ls *ledgerStore) List(userID string, offset, limit int) ([]model.LedgerEntry, int, error) {
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+
+	entries, exists := ls.entries[userID]
+	if !exists {
+		return nil, 0, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "users", ID: userID})
+	}
+
+	if offset < 0 || offset >= len(entries) || limit <= 0 {
+		return []model.LedgerEntry{}, len(entries), nil
+	}
+
+	page := make([]model.LedgerEntry, 0, min(limit, len(entries)-offset))
+	for i := len(entries) - 1 - offset; i >= 0 && len(page) < limit; i-- {
+		page = append(page, entries[i])
+	}
+
+	return page, len(entries), nil
+}
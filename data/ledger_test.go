@@ -0,0 +1,275 @@
+package data
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestLedgerStore(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewLedger(logger)
+
+	for i, points := range []int{10, 25, 5} {
+		store.Append(&model.LedgerEntry{ID: fmt.Sprint(i + 1), UserID: "user-1", Type: model.LedgerCredit, Points: points})
+	}
+	store.Append(&model.LedgerEntry{ID: "4", UserID: "user-2", Type: model.LedgerCredit, Points: 7})
+
+	balance, err := store.Balance("user-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 40, balance)
+
+	_, err = store.Balance("user-3")
+	assert.EqualError(t, err, "No 'users' found for Id: 'user-3'")
+
+	testCases := []struct {
+		id            int
+		useCase       string
+		userID        string
+		offset        int
+		limit         int
+		expectedIDs   []string
+		expectedTotal int
+		expectedError string
+	}{
+		{
+			id: 1, useCase: "Positive case: first page, newest first",
+			userID: "user-1", offset: 0, limit: 2,
+			expectedIDs: []string{"3", "2"}, expectedTotal: 3,
+		},
+		{
+			id: 2, useCase: "Positive case: last partial page",
+			userID: "user-1", offset: 2, limit: 2,
+			expectedIDs: []string{"1"}, expectedTotal: 3,
+		},
+		{
+			id: 3, useCase: "Positive case: page past the end",
+			userID: "user-1", offset: 4, limit: 2,
+			expectedIDs: []string{}, expectedTotal: 3,
+		},
+		{
+			id: 4, useCase: "Positive case: negative offset of an overflowed page",
+			userID: "user-1", offset: -9223372036854775808, limit: 100,
+			expectedIDs: []string{}, expectedTotal: 3,
+		},
+		{
+			id: 5, useCase: "Positive case: offset at the total",
+			userID: "user-1", offset: 3, limit: 2,
+			expectedIDs: []string{}, expectedTotal: 3,
+		},
+		{
+			id: 6, useCase: "Negative case: unknown user",
+			userID: "user-3", offset: 0, limit: 2,
+			expectedError: "No 'users' found for Id: 'user-3'",
+		},
+	}
+
+	for _, tc := range testCases {
+		entries, total, err := store.List(tc.userID, tc.offset, tc.limit)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			continue
+		}
+
+		ids := make([]string, 0, len(entries))
+		for _, e := range entries {
+			ids = append(ids, e.ID)
+		}
+
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedIDs, ids, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedTotal, total, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestLedgerStoreBind(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewLedger(logger)
+
+	testCases := []struct {
+		id             int
+		useCase        string
+		userID         string
+		clientID       string
+		expectedError  string
+		expectedClient string
+	}{
+		{
+			id: 1, useCase: "Positive case: first client binds the user",
+			userID: "user-1", clientID: "partner-a",
+			expectedClient: "partner-a",
+		},
+		{
+			id: 2, useCase: "Positive case: same client again",
+			userID: "user-1", clientID: "partner-a",
+			expectedClient: "partner-a",
+		},
+		{
+			id: 3, useCase: "Negative case: user of another client",
+			userID: "user-1", clientID: "partner-b",
+			expectedError:  "User 'user-1' belongs to another client",
+			expectedClient: "partner-a",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := store.Bind(tc.userID, tc.clientID)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		} else {
+			assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+
+		assert.Equal(t, tc.expectedClient, store.Client(tc.userID), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+
+	assert.Empty(t, store.Client("user-2"))
+}
+
+func TestLedgerStoreDebit(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewLedger(logger)
+
+	store.Append(&model.LedgerEntry{ID: "1", UserID: "user-1", Type: model.LedgerCredit, Points: 50})
+
+	testCases := []struct {
+		id              int
+		useCase         string
+		userID          string
+		points          int
+		expectedBalance int
+		expectedError   string
+	}{
+		{
+			id: 1, useCase: "Positive case: balance covers the debit",
+			userID: "user-1", points: -30,
+			expectedBalance: 20,
+		},
+		{
+			id: 2, useCase: "Negative case: balance does not cover the debit",
+			userID: "user-1", points: -30,
+			expectedBalance: 20,
+			expectedError:   "Insufficient points balance: 20 available, 30 required",
+		},
+		{
+			id: 3, useCase: "Positive case: debit of the whole balance",
+			userID: "user-1", points: -20,
+			expectedBalance: 0,
+		},
+		{
+			id: 4, useCase: "Negative case: user without entries",
+			userID: "user-2", points: -1,
+			expectedBalance: 0,
+			expectedError:   "Insufficient points balance: 0 available, 1 required",
+		},
+	}
+
+	for _, tc := range testCases {
+		balance, err := store.Debit(&model.LedgerEntry{UserID: tc.userID, Type: model.LedgerDebit, Points: tc.points})
+
+		assert.Equal(t, tc.expectedBalance, balance, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		} else {
+			assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+	}
+
+	_, total, _ := store.List("user-1", 0, 10)
+	assert.Equal(t, 3, total)
+}
+
+func TestLedgerStoreDebit_Concurrent(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewLedger(logger)
+
+	store.Append(&model.LedgerEntry{ID: "1", UserID: "user-1", Type: model.LedgerCredit, Points: 100})
+
+	var wg sync.WaitGroup
+	var succeeded atomic.Int32
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if _, err := store.Debit(&model.LedgerEntry{UserID: "user-1", Type: model.LedgerDebit, Points: -30}); err == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	balance, _ := store.Balance("user-1")
+	assert.Equal(t, int32(3), succeeded.Load())
+	assert.Equal(t, 10, balance)
+}
+
+func TestLedgerStoreExpiry(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewLedger(logger)
+
+	earned := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	credit := func(id string, points int, expiresAt time.Time) *model.LedgerEntry {
+		return &model.LedgerEntry{ID: id, UserID: "user-1", ReceiptID: "r-" + id, Type: model.LedgerCredit, Points: points, CreatedAt: earned, ExpiresAt: &expiresAt}
+	}
+
+	store.Append(credit("1", 30, earned.AddDate(0, 1, 0)))
+	store.Append(credit("2", 50, earned.AddDate(0, 2, 0)))
+	store.Append(&model.LedgerEntry{ID: "3", UserID: "user-1", Type: model.LedgerCredit, Points: 20, CreatedAt: earned})
+
+	// The debit consumes the oldest credit first: 30 points of credit 1 and 10 of credit 2.
+	balance, err := store.Debit(&model.LedgerEntry{UserID: "user-1", Type: model.LedgerDebit, Points: -40, CreatedAt: earned.AddDate(0, 0, 10)})
+	assert.NoError(t, err)
+	assert.Equal(t, 60, balance)
+
+	expiring, err := store.Expiring("user-1", earned.AddDate(0, 1, 15), earned.AddDate(0, 3, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []model.ExpiringPoints{{EntryID: "2", ReceiptID: "r-2", Points: 40, ExpiresAt: earned.AddDate(0, 2, 0)}}, expiring)
+
+	// Credit 1 was spent entirely, only the 40 points left of credit 2 expire.
+	expired := store.Expire(earned.AddDate(0, 2, 0))
+	assert.Len(t, expired, 1)
+	assert.Equal(t, model.LedgerExpiry, expired[0].Type)
+	assert.Equal(t, -40, expired[0].Points)
+	assert.Equal(t, "r-2", expired[0].ReceiptID)
+
+	balance, _ = store.Balance("user-1")
+	assert.Equal(t, 20, balance)
+	assert.Empty(t, store.Expire(earned.AddDate(1, 0, 0)), "expired credits are expired once")
+
+	// Credits expired before a debit are never spent, even when the expiry job did not run yet.
+	store.Append(credit("4", 10, earned.AddDate(0, 3, 0)))
+	_, err = store.Debit(&model.LedgerEntry{UserID: "user-1", Type: model.LedgerDebit, Points: -25, CreatedAt: earned.AddDate(0, 4, 0)})
+	assert.EqualError(t, err, "Insufficient points balance: 20 available, 25 required")
+
+	_, err = store.Expiring("user-2", earned, earned.AddDate(1, 0, 0))
+	assert.EqualError(t, err, "No 'users' found for Id: 'user-2'")
+}
+
+func TestLedgerStoreReversal(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewLedger(logger)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	first, second := now.AddDate(0, 1, 0), now.AddDate(0, 2, 0)
+	store.Append(&model.LedgerEntry{ID: "1", UserID: "user-1", ReceiptID: "r-1", Type: model.LedgerCredit, Points: 30, ExpiresAt: &first})
+	store.Append(&model.LedgerEntry{ID: "2", UserID: "user-1", ReceiptID: "r-2", Type: model.LedgerCredit, Points: 50, ExpiresAt: &second})
+
+	// The reversal takes back the points of its own receipt, not the oldest credit.
+	store.Append(&model.LedgerEntry{ID: "3", UserID: "user-1", ReceiptID: "r-2", Type: model.LedgerReversal, Points: -20})
+
+	expiring, _ := store.Expiring("user-1", now, now.AddDate(1, 0, 0))
+	assert.Equal(t, []model.ExpiringPoints{
+		{EntryID: "1", ReceiptID: "r-1", Points: 30, ExpiresAt: first},
+		{EntryID: "2", ReceiptID: "r-2", Points: 30, ExpiresAt: second},
+	}, expiring)
+
+	balance, _ := store.Balance("user-1")
+	assert.Equal(t, 60, balance)
+}
@@ -7,6 +7,7 @@ package data
 import (
 	model "github/shivasaicharanruthala/backend-engineer-takehome/model"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -34,6 +35,35 @@ func (m *MockReceipts) EXPECT() *MockReceiptsMockRecorder {
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockReceipts) Count() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockReceiptsMockRecorder) Count() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockReceipts)(nil).Count))
+}
+
+// Find mocks base method.
+func (m *MockReceipts) Find(receiptID string) (*model.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", receiptID)
+	ret0, _ := ret[0].(*model.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockReceiptsMockRecorder) Find(receiptID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockReceipts)(nil).Find), receiptID)
+}
+
 // Get mocks base method.
 func (m *MockReceipts) Get(receiptID string) (*model.ReceiptGetResponse, error) {
 	m.ctrl.T.Helper()
@@ -50,15 +80,1246 @@ func (mr *MockReceiptsMockRecorder) Get(receiptID interface{}) *gomock.Call {
 }
 
 // Insert mocks base method.
-func (m *MockReceipts) Insert(receipt *model.Receipt) *model.ReceiptPostResponse {
+func (m *MockReceipts) Insert(receipt *model.Receipt, events ...model.DomainEvent) *model.ReceiptPostResponse {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{receipt}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Insert", varargs...)
+	ret0, _ := ret[0].(*model.ReceiptPostResponse)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockReceiptsMockRecorder) Insert(receipt interface{}, events ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{receipt}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockReceipts)(nil).Insert), varargs...)
+}
+
+// InsertHeld mocks base method.
+func (m *MockReceipts) InsertHeld(receipt *model.Receipt, review *model.Review, reviews Reviews, events ...model.DomainEvent) *model.ReceiptPostResponse {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Insert", receipt)
+	varargs := []interface{}{receipt, review, reviews}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InsertHeld", varargs...)
 	ret0, _ := ret[0].(*model.ReceiptPostResponse)
 	return ret0
 }
 
+// InsertHeld indicates an expected call of InsertHeld.
+func (mr *MockReceiptsMockRecorder) InsertHeld(receipt, review, reviews interface{}, events ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{receipt, review, reviews}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertHeld", reflect.TypeOf((*MockReceipts)(nil).InsertHeld), varargs...)
+}
+
+// List mocks base method.
+func (m *MockReceipts) List(filter model.ReceiptFilter, offset, limit int) ([]model.Receipt, int) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", filter, offset, limit)
+	ret0, _ := ret[0].([]model.Receipt)
+	ret1, _ := ret[1].(int)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockReceiptsMockRecorder) List(filter, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReceipts)(nil).List), filter, offset, limit)
+}
+
+// Ping mocks base method.
+func (m *MockReceipts) Ping() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockReceiptsMockRecorder) Ping() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockReceipts)(nil).Ping))
+}
+
+// Recent mocks base method.
+func (m *MockReceipts) Recent(submitter string, limit int) []model.Receipt {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recent", submitter, limit)
+	ret0, _ := ret[0].([]model.Receipt)
+	return ret0
+}
+
+// Recent indicates an expected call of Recent.
+func (mr *MockReceiptsMockRecorder) Recent(submitter, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recent", reflect.TypeOf((*MockReceipts)(nil).Recent), submitter, limit)
+}
+
+// MockAPIKeys is a mock of APIKeys interface.
+type MockAPIKeys struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeysMockRecorder
+}
+
+// MockAPIKeysMockRecorder is the mock recorder for MockAPIKeys.
+type MockAPIKeysMockRecorder struct {
+	mock *MockAPIKeys
+}
+
+// NewMockAPIKeys creates a new mock instance.
+func NewMockAPIKeys(ctrl *gomock.Controller) *MockAPIKeys {
+	mock := &MockAPIKeys{ctrl: ctrl}
+	mock.recorder = &MockAPIKeysMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeys) EXPECT() *MockAPIKeysMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockAPIKeys) Get(keyHash string) (*model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", keyHash)
+	ret0, _ := ret[0].(*model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAPIKeysMockRecorder) Get(keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAPIKeys)(nil).Get), keyHash)
+}
+
+// Insert mocks base method.
+func (m *MockAPIKeys) Insert(key *model.APIKey) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Insert", key)
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockAPIKeysMockRecorder) Insert(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockAPIKeys)(nil).Insert), key)
+}
+
+// MockQuotas is a mock of Quotas interface.
+type MockQuotas struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuotasMockRecorder
+}
+
+// MockQuotasMockRecorder is the mock recorder for MockQuotas.
+type MockQuotasMockRecorder struct {
+	mock *MockQuotas
+}
+
+// NewMockQuotas creates a new mock instance.
+func NewMockQuotas(ctrl *gomock.Controller) *MockQuotas {
+	mock := &MockQuotas{ctrl: ctrl}
+	mock.recorder = &MockQuotasMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuotas) EXPECT() *MockQuotasMockRecorder {
+	return m.recorder
+}
+
+// Increment mocks base method.
+func (m *MockQuotas) Increment(clientID, day string, limit int) (int, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Increment", clientID, day, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Increment indicates an expected call of Increment.
+func (mr *MockQuotasMockRecorder) Increment(clientID, day, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Increment", reflect.TypeOf((*MockQuotas)(nil).Increment), clientID, day, limit)
+}
+
+// Refund mocks base method.
+func (m *MockQuotas) Refund(clientID, day string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Refund", clientID, day)
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockQuotasMockRecorder) Refund(clientID, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockQuotas)(nil).Refund), clientID, day)
+}
+
+// MockIdempotency is a mock of Idempotency interface.
+type MockIdempotency struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyMockRecorder
+}
+
+// MockIdempotencyMockRecorder is the mock recorder for MockIdempotency.
+type MockIdempotencyMockRecorder struct {
+	mock *MockIdempotency
+}
+
+// NewMockIdempotency creates a new mock instance.
+func NewMockIdempotency(ctrl *gomock.Controller) *MockIdempotency {
+	mock := &MockIdempotency{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotency) EXPECT() *MockIdempotencyMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockIdempotency) Complete(request *model.IdempotentRequest) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Complete", request)
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIdempotencyMockRecorder) Complete(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIdempotency)(nil).Complete), request)
+}
+
+// Release mocks base method.
+func (m *MockIdempotency) Release(key string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Release", key)
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockIdempotencyMockRecorder) Release(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIdempotency)(nil).Release), key)
+}
+
+// Reserve mocks base method.
+func (m *MockIdempotency) Reserve(request *model.IdempotentRequest, now time.Time) *model.IdempotentRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", request, now)
+	ret0, _ := ret[0].(*model.IdempotentRequest)
+	return ret0
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockIdempotencyMockRecorder) Reserve(request, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockIdempotency)(nil).Reserve), request, now)
+}
+
+// MockLedger is a mock of Ledger interface.
+type MockLedger struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerMockRecorder
+}
+
+// MockLedgerMockRecorder is the mock recorder for MockLedger.
+type MockLedgerMockRecorder struct {
+	mock *MockLedger
+}
+
+// NewMockLedger creates a new mock instance.
+func NewMockLedger(ctrl *gomock.Controller) *MockLedger {
+	mock := &MockLedger{ctrl: ctrl}
+	mock.recorder = &MockLedgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedger) EXPECT() *MockLedgerMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockLedger) Append(entry *model.LedgerEntry) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Append", entry)
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockLedgerMockRecorder) Append(entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockLedger)(nil).Append), entry)
+}
+
+// Balance mocks base method.
+func (m *MockLedger) Balance(userID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Balance", userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Balance indicates an expected call of Balance.
+func (mr *MockLedgerMockRecorder) Balance(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balance", reflect.TypeOf((*MockLedger)(nil).Balance), userID)
+}
+
+// Bind mocks base method.
+func (m *MockLedger) Bind(userID, clientID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bind", userID, clientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Bind indicates an expected call of Bind.
+func (mr *MockLedgerMockRecorder) Bind(userID, clientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bind", reflect.TypeOf((*MockLedger)(nil).Bind), userID, clientID)
+}
+
+// Client mocks base method.
+func (m *MockLedger) Client(userID string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Client", userID)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Client indicates an expected call of Client.
+func (mr *MockLedgerMockRecorder) Client(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Client", reflect.TypeOf((*MockLedger)(nil).Client), userID)
+}
+
+// Debit mocks base method.
+func (m *MockLedger) Debit(entry *model.LedgerEntry) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Debit", entry)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Debit indicates an expected call of Debit.
+func (mr *MockLedgerMockRecorder) Debit(entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debit", reflect.TypeOf((*MockLedger)(nil).Debit), entry)
+}
+
+// Expire mocks base method.
+func (m *MockLedger) Expire(now time.Time) []model.LedgerEntry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expire", now)
+	ret0, _ := ret[0].([]model.LedgerEntry)
+	return ret0
+}
+
+// Expire indicates an expected call of Expire.
+func (mr *MockLedgerMockRecorder) Expire(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expire", reflect.TypeOf((*MockLedger)(nil).Expire), now)
+}
+
+// Expiring mocks base method.
+func (m *MockLedger) Expiring(userID string, from, until time.Time) ([]model.ExpiringPoints, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expiring", userID, from, until)
+	ret0, _ := ret[0].([]model.ExpiringPoints)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Expiring indicates an expected call of Expiring.
+func (mr *MockLedgerMockRecorder) Expiring(userID, from, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expiring", reflect.TypeOf((*MockLedger)(nil).Expiring), userID, from, until)
+}
+
+// List mocks base method.
+func (m *MockLedger) List(userID string, offset, limit int) ([]model.LedgerEntry, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", userID, offset, limit)
+	ret0, _ := ret[0].([]model.LedgerEntry)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockLedgerMockRecorder) List(userID, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockLedger)(nil).List), userID, offset, limit)
+}
+
+// MockRewards is a mock of Rewards interface.
+type MockRewards struct {
+	ctrl     *gomock.Controller
+	recorder *MockRewardsMockRecorder
+}
+
+// MockRewardsMockRecorder is the mock recorder for MockRewards.
+type MockRewardsMockRecorder struct {
+	mock *MockRewards
+}
+
+// NewMockRewards creates a new mock instance.
+func NewMockRewards(ctrl *gomock.Controller) *MockRewards {
+	mock := &MockRewards{ctrl: ctrl}
+	mock.recorder = &MockRewardsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRewards) EXPECT() *MockRewardsMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockRewards) Get(rewardID string) (*model.Reward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", rewardID)
+	ret0, _ := ret[0].(*model.Reward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRewardsMockRecorder) Get(rewardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRewards)(nil).Get), rewardID)
+}
+
+// Insert mocks base method.
+func (m *MockRewards) Insert(reward *model.Reward) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Insert", reward)
+}
+
 // Insert indicates an expected call of Insert.
-func (mr *MockReceiptsMockRecorder) Insert(receipt interface{}) *gomock.Call {
+func (mr *MockRewardsMockRecorder) Insert(reward interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockRewards)(nil).Insert), reward)
+}
+
+// List mocks base method.
+func (m *MockRewards) List() []model.Reward {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]model.Reward)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockRewardsMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRewards)(nil).List))
+}
+
+// Redeem mocks base method.
+func (m *MockRewards) Redeem(redemption *model.Redemption, debit func(int) (int, error)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeem", redemption, debit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redeem indicates an expected call of Redeem.
+func (mr *MockRewardsMockRecorder) Redeem(redemption, debit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockRewards)(nil).Redeem), redemption, debit)
+}
+
+// Redemptions mocks base method.
+func (m *MockRewards) Redemptions(userID string) []model.Redemption {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redemptions", userID)
+	ret0, _ := ret[0].([]model.Redemption)
+	return ret0
+}
+
+// Redemptions indicates an expected call of Redemptions.
+func (mr *MockRewardsMockRecorder) Redemptions(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redemptions", reflect.TypeOf((*MockRewards)(nil).Redemptions), userID)
+}
+
+// MockAdjustments is a mock of Adjustments interface.
+type MockAdjustments struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdjustmentsMockRecorder
+}
+
+// MockAdjustmentsMockRecorder is the mock recorder for MockAdjustments.
+type MockAdjustmentsMockRecorder struct {
+	mock *MockAdjustments
+}
+
+// NewMockAdjustments creates a new mock instance.
+func NewMockAdjustments(ctrl *gomock.Controller) *MockAdjustments {
+	mock := &MockAdjustments{ctrl: ctrl}
+	mock.recorder = &MockAdjustmentsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdjustments) EXPECT() *MockAdjustmentsMockRecorder {
+	return m.recorder
+}
+
+// Insert mocks base method.
+func (m *MockAdjustments) Insert(adjustment *model.Adjustment) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Insert", adjustment)
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockAdjustmentsMockRecorder) Insert(adjustment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockAdjustments)(nil).Insert), adjustment)
+}
+
+// List mocks base method.
+func (m *MockAdjustments) List(receiptID string) []model.Adjustment {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", receiptID)
+	ret0, _ := ret[0].([]model.Adjustment)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockAdjustmentsMockRecorder) List(receiptID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAdjustments)(nil).List), receiptID)
+}
+
+// MockCampaigns is a mock of Campaigns interface.
+type MockCampaigns struct {
+	ctrl     *gomock.Controller
+	recorder *MockCampaignsMockRecorder
+}
+
+// MockCampaignsMockRecorder is the mock recorder for MockCampaigns.
+type MockCampaignsMockRecorder struct {
+	mock *MockCampaigns
+}
+
+// NewMockCampaigns creates a new mock instance.
+func NewMockCampaigns(ctrl *gomock.Controller) *MockCampaigns {
+	mock := &MockCampaigns{ctrl: ctrl}
+	mock.recorder = &MockCampaignsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCampaigns) EXPECT() *MockCampaignsMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCampaigns) Delete(campaignID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", campaignID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCampaignsMockRecorder) Delete(campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCampaigns)(nil).Delete), campaignID)
+}
+
+// Get mocks base method.
+func (m *MockCampaigns) Get(campaignID string) (*model.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", campaignID)
+	ret0, _ := ret[0].(*model.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCampaignsMockRecorder) Get(campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCampaigns)(nil).Get), campaignID)
+}
+
+// Insert mocks base method.
+func (m *MockCampaigns) Insert(campaign *model.Campaign) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Insert", campaign)
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockCampaignsMockRecorder) Insert(campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockCampaigns)(nil).Insert), campaign)
+}
+
+// List mocks base method.
+func (m *MockCampaigns) List() []model.Campaign {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]model.Campaign)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockCampaignsMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCampaigns)(nil).List))
+}
+
+// Update mocks base method.
+func (m *MockCampaigns) Update(campaign *model.Campaign) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", campaign)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCampaignsMockRecorder) Update(campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCampaigns)(nil).Update), campaign)
+}
+
+// MockRetailers is a mock of Retailers interface.
+type MockRetailers struct {
+	ctrl     *gomock.Controller
+	recorder *MockRetailersMockRecorder
+}
+
+// MockRetailersMockRecorder is the mock recorder for MockRetailers.
+type MockRetailersMockRecorder struct {
+	mock *MockRetailers
+}
+
+// NewMockRetailers creates a new mock instance.
+func NewMockRetailers(ctrl *gomock.Controller) *MockRetailers {
+	mock := &MockRetailers{ctrl: ctrl}
+	mock.recorder = &MockRetailersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRetailers) EXPECT() *MockRetailersMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRetailers) Delete(retailerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", retailerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRetailersMockRecorder) Delete(retailerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRetailers)(nil).Delete), retailerID)
+}
+
+// Get mocks base method.
+func (m *MockRetailers) Get(retailerID string) (*model.Retailer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", retailerID)
+	ret0, _ := ret[0].(*model.Retailer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRetailersMockRecorder) Get(retailerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRetailers)(nil).Get), retailerID)
+}
+
+// Insert mocks base method.
+func (m *MockRetailers) Insert(retailer *model.Retailer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", retailer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockRetailersMockRecorder) Insert(retailer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockRetailers)(nil).Insert), retailer)
+}
+
+// List mocks base method.
+func (m *MockRetailers) List() []model.Retailer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]model.Retailer)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockRetailersMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRetailers)(nil).List))
+}
+
+// Resolve mocks base method.
+func (m *MockRetailers) Resolve(raw string) (*model.Retailer, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", raw)
+	ret0, _ := ret[0].(*model.Retailer)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockRetailersMockRecorder) Resolve(raw interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockRetailers)(nil).Resolve), raw)
+}
+
+// Update mocks base method.
+func (m *MockRetailers) Update(retailer *model.Retailer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", retailer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRetailersMockRecorder) Update(retailer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRetailers)(nil).Update), retailer)
+}
+
+// MockDailyPoints is a mock of DailyPoints interface.
+type MockDailyPoints struct {
+	ctrl     *gomock.Controller
+	recorder *MockDailyPointsMockRecorder
+}
+
+// MockDailyPointsMockRecorder is the mock recorder for MockDailyPoints.
+type MockDailyPointsMockRecorder struct {
+	mock *MockDailyPoints
+}
+
+// NewMockDailyPoints creates a new mock instance.
+func NewMockDailyPoints(ctrl *gomock.Controller) *MockDailyPoints {
+	mock := &MockDailyPoints{ctrl: ctrl}
+	mock.recorder = &MockDailyPointsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDailyPoints) EXPECT() *MockDailyPointsMockRecorder {
+	return m.recorder
+}
+
+// Release mocks base method.
+func (m *MockDailyPoints) Release(userID, day string, points int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Release", userID, day, points)
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockDailyPointsMockRecorder) Release(userID, day, points interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockDailyPoints)(nil).Release), userID, day, points)
+}
+
+// Reserve mocks base method.
+func (m *MockDailyPoints) Reserve(userID, day string, points, limit int) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", userID, day, points, limit)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockDailyPointsMockRecorder) Reserve(userID, day, points, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockDailyPoints)(nil).Reserve), userID, day, points, limit)
+}
+
+// MockReviews is a mock of Reviews interface.
+type MockReviews struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewsMockRecorder
+}
+
+// MockReviewsMockRecorder is the mock recorder for MockReviews.
+type MockReviewsMockRecorder struct {
+	mock *MockReviews
+}
+
+// NewMockReviews creates a new mock instance.
+func NewMockReviews(ctrl *gomock.Controller) *MockReviews {
+	mock := &MockReviews{ctrl: ctrl}
+	mock.recorder = &MockReviewsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviews) EXPECT() *MockReviewsMockRecorder {
+	return m.recorder
+}
+
+// Decide mocks base method.
+func (m *MockReviews) Decide(receiptID, status, note string, decidedAt time.Time, events ...model.DomainEvent) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{receiptID, status, note, decidedAt}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Decide", varargs...)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decide indicates an expected call of Decide.
+func (mr *MockReviewsMockRecorder) Decide(receiptID, status, note, decidedAt interface{}, events ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{receiptID, status, note, decidedAt}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decide", reflect.TypeOf((*MockReviews)(nil).Decide), varargs...)
+}
+
+// Get mocks base method.
+func (m *MockReviews) Get(receiptID string) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", receiptID)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReviewsMockRecorder) Get(receiptID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReviews)(nil).Get), receiptID)
+}
+
+// Insert mocks base method.
+func (m *MockReviews) Insert(review *model.Review) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Insert", review)
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockReviewsMockRecorder) Insert(review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockReviews)(nil).Insert), review)
+}
+
+// List mocks base method.
+func (m *MockReviews) List(status string) []model.Review {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", status)
+	ret0, _ := ret[0].([]model.Review)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockReviewsMockRecorder) List(status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReviews)(nil).List), status)
+}
+
+// MockJobs is a mock of Jobs interface.
+type MockJobs struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobsMockRecorder
+}
+
+// MockJobsMockRecorder is the mock recorder for MockJobs.
+type MockJobsMockRecorder struct {
+	mock *MockJobs
+}
+
+// NewMockJobs creates a new mock instance.
+func NewMockJobs(ctrl *gomock.Controller) *MockJobs {
+	mock := &MockJobs{ctrl: ctrl}
+	mock.recorder = &MockJobsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobs) EXPECT() *MockJobsMockRecorder {
+	return m.recorder
+}
+
+// Evict mocks base method.
+func (m *MockJobs) Evict(before time.Time) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evict", before)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Evict indicates an expected call of Evict.
+func (mr *MockJobsMockRecorder) Evict(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evict", reflect.TypeOf((*MockJobs)(nil).Evict), before)
+}
+
+// Get mocks base method.
+func (m *MockJobs) Get(jobID string) (*model.ReceiptJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", jobID)
+	ret0, _ := ret[0].(*model.ReceiptJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockJobsMockRecorder) Get(jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockJobs)(nil).Get), jobID)
+}
+
+// Insert mocks base method.
+func (m *MockJobs) Insert(job *model.ReceiptJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockJobsMockRecorder) Insert(job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockJobs)(nil).Insert), job)
+}
+
+// Pending mocks base method.
+func (m *MockJobs) Pending() []model.ReceiptJob {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pending")
+	ret0, _ := ret[0].([]model.ReceiptJob)
+	return ret0
+}
+
+// Pending indicates an expected call of Pending.
+func (mr *MockJobsMockRecorder) Pending() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockJobs)(nil).Pending))
+}
+
+// Update mocks base method.
+func (m *MockJobs) Update(job *model.ReceiptJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockJobsMockRecorder) Update(job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockJobs)(nil).Update), job)
+}
+
+// MockWebhooks is a mock of Webhooks interface.
+type MockWebhooks struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhooksMockRecorder
+}
+
+// MockWebhooksMockRecorder is the mock recorder for MockWebhooks.
+type MockWebhooksMockRecorder struct {
+	mock *MockWebhooks
+}
+
+// NewMockWebhooks creates a new mock instance.
+func NewMockWebhooks(ctrl *gomock.Controller) *MockWebhooks {
+	mock := &MockWebhooks{ctrl: ctrl}
+	mock.recorder = &MockWebhooksMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhooks) EXPECT() *MockWebhooksMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockWebhooks) Delete(webhookID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", webhookID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhooksMockRecorder) Delete(webhookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhooks)(nil).Delete), webhookID)
+}
+
+// Get mocks base method.
+func (m *MockWebhooks) Get(webhookID string) (*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", webhookID)
+	ret0, _ := ret[0].(*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockWebhooksMockRecorder) Get(webhookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWebhooks)(nil).Get), webhookID)
+}
+
+// Insert mocks base method.
+func (m *MockWebhooks) Insert(webhook *model.Webhook) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Insert", webhook)
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockWebhooksMockRecorder) Insert(webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockWebhooks)(nil).Insert), webhook)
+}
+
+// List mocks base method.
+func (m *MockWebhooks) List(clientID string) []model.Webhook {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", clientID)
+	ret0, _ := ret[0].([]model.Webhook)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockWebhooksMockRecorder) List(clientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWebhooks)(nil).List), clientID)
+}
+
+// Subscribed mocks base method.
+func (m *MockWebhooks) Subscribed(clientID, userID, eventType string) []model.Webhook {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribed", clientID, userID, eventType)
+	ret0, _ := ret[0].([]model.Webhook)
+	return ret0
+}
+
+// Subscribed indicates an expected call of Subscribed.
+func (mr *MockWebhooksMockRecorder) Subscribed(clientID, userID, eventType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribed", reflect.TypeOf((*MockWebhooks)(nil).Subscribed), clientID, userID, eventType)
+}
+
+// MockDeliveries is a mock of Deliveries interface.
+type MockDeliveries struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveriesMockRecorder
+}
+
+// MockDeliveriesMockRecorder is the mock recorder for MockDeliveries.
+type MockDeliveriesMockRecorder struct {
+	mock *MockDeliveries
+}
+
+// NewMockDeliveries creates a new mock instance.
+func NewMockDeliveries(ctrl *gomock.Controller) *MockDeliveries {
+	mock := &MockDeliveries{ctrl: ctrl}
+	mock.recorder = &MockDeliveriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveries) EXPECT() *MockDeliveriesMockRecorder {
+	return m.recorder
+}
+
+// Insert mocks base method.
+func (m *MockDeliveries) Insert(delivery *model.Delivery) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Insert", delivery)
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockDeliveriesMockRecorder) Insert(delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockDeliveries)(nil).Insert), delivery)
+}
+
+// List mocks base method.
+func (m *MockDeliveries) List(webhookID, status string) []model.Delivery {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", webhookID, status)
+	ret0, _ := ret[0].([]model.Delivery)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockDeliveriesMockRecorder) List(webhookID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDeliveries)(nil).List), webhookID, status)
+}
+
+// MockDeadLetters is a mock of DeadLetters interface.
+type MockDeadLetters struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeadLettersMockRecorder
+}
+
+// MockDeadLettersMockRecorder is the mock recorder for MockDeadLetters.
+type MockDeadLettersMockRecorder struct {
+	mock *MockDeadLetters
+}
+
+// NewMockDeadLetters creates a new mock instance.
+func NewMockDeadLetters(ctrl *gomock.Controller) *MockDeadLetters {
+	mock := &MockDeadLetters{ctrl: ctrl}
+	mock.recorder = &MockDeadLettersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeadLetters) EXPECT() *MockDeadLettersMockRecorder {
+	return m.recorder
+}
+
+// Insert mocks base method.
+func (m *MockDeadLetters) Insert(delivery *model.Delivery) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Insert", delivery)
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockDeadLettersMockRecorder) Insert(delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockDeadLetters)(nil).Insert), delivery)
+}
+
+// List mocks base method.
+func (m *MockDeadLetters) List(webhookID string) []model.Delivery {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", webhookID)
+	ret0, _ := ret[0].([]model.Delivery)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockDeadLettersMockRecorder) List(webhookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDeadLetters)(nil).List), webhookID)
+}
+
+// Remove mocks base method.
+func (m *MockDeadLetters) Remove(webhookID, deliveryID string) (*model.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", webhookID, deliveryID)
+	ret0, _ := ret[0].(*model.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockDeadLettersMockRecorder) Remove(webhookID, deliveryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockDeadLetters)(nil).Remove), webhookID, deliveryID)
+}
+
+// MockOutbox is a mock of Outbox interface.
+type MockOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMockRecorder
+}
+
+// MockOutboxMockRecorder is the mock recorder for MockOutbox.
+type MockOutboxMockRecorder struct {
+	mock *MockOutbox
+}
+
+// NewMockOutbox creates a new mock instance.
+func NewMockOutbox(ctrl *gomock.Controller) *MockOutbox {
+	mock := &MockOutbox{ctrl: ctrl}
+	mock.recorder = &MockOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutbox) EXPECT() *MockOutboxMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockOutbox) Append(events ...model.DomainEvent) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Append", varargs...)
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockOutboxMockRecorder) Append(events ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockOutbox)(nil).Append), events...)
+}
+
+// Count mocks base method.
+func (m *MockOutbox) Count() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockOutboxMockRecorder) Count() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockOutbox)(nil).Count))
+}
+
+// MarkFailed mocks base method.
+func (m *MockOutbox) MarkFailed(eventID, errMsg string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MarkFailed", eventID, errMsg)
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockOutboxMockRecorder) MarkFailed(eventID, errMsg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockOutbox)(nil).MarkFailed), eventID, errMsg)
+}
+
+// MarkPublished mocks base method.
+func (m *MockOutbox) MarkPublished(eventID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MarkPublished", eventID)
+}
+
+// MarkPublished indicates an expected call of MarkPublished.
+func (mr *MockOutboxMockRecorder) MarkPublished(eventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPublished", reflect.TypeOf((*MockOutbox)(nil).MarkPublished), eventID)
+}
+
+// Pending mocks base method.
+func (m *MockOutbox) Pending(limit int) []model.OutboxEntry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pending", limit)
+	ret0, _ := ret[0].([]model.OutboxEntry)
+	return ret0
+}
+
+// Pending indicates an expected call of Pending.
+func (mr *MockOutboxMockRecorder) Pending(limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockReceipts)(nil).Insert), receipt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockOutbox)(nil).Pending), limit)
 }
@@ -0,0 +1,85 @@
+package data
+
+import (
+	"sync"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// outboxStore is a thread-safe in-memory outbox of the domain events not yet published, oldest first.
+// Stores created with an outbox append the events of a mutation while holding their own lock, so that an event is
+// written if and only if its mutation is. Events leave the outbox once every sink received them.
+type outboxStore struct {
+	logger  *log.CustomLogger
+	mu      sync.Mutex
+	entries []model.OutboxEntry // Pending events in the order they were written.
+}
+
+// NewOutbox creates and returns a new instance of outboxStore which implements methods of the interface Outbox.
+func NewOutbox(l *log.CustomLogger) Outbox {
+	return &outboxStore{
+		logger: l,
+	}
+}
+
+// Append writes events to the outbox.
+func (ob *outboxStore) Append(events ...model.DomainEvent) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	for _, event := range events {
+		ob.entries = append(ob.entries, model.OutboxEntry{Event: event})
+	}
+}
+
+// Pending returns up to limit of the oldest events not yet published.
+func (ob *outboxStore) Pending(limit int) []model.OutboxEntry {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	n := min(limit, len(ob.entries))
+
+	return append([]model.OutboxEntry(nil), ob.entries[:n]...)
+}
+
+// MarkPublished removes a published event from the outbox, unknown events are ignored.
+func (ob *outboxStore) MarkPublished(eventID string) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	if i := ob.index(eventID); i >= 0 {
+		ob.entries = append(ob.entries[:i], ob.entries[i+1:]...)
+	}
+}
+
+// MarkFailed records a failed attempt to publish an event, which stays in the outbox to be retried.
+func (ob *outboxStore) MarkFailed(eventID string, errMsg string) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	if i := ob.index(eventID); i >= 0 {
+		ob.entries[i].Attempts++
+		ob.entries[i].LastError = errMsg
+	}
+}
+
+// Count returns the number of events not yet published.
+func (ob *outboxStore) Count() int {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	return len(ob.entries)
+}
+
+// index returns the position of an event in the outbox, -1 when it is not pending. Events are published oldest first,
+// so the event looked for is almost always the first one.
+func (ob *outboxStore) index(eventID string) int {
+	for i := range ob.entries {
+		if ob.entries[i].Event.ID == eventID {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,104 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestOutboxStore(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	outbox := NewOutbox(logger)
+	receipts, reviews, ledger := NewWithOutbox(logger, outbox), NewReviewsWithOutbox(logger, outbox), NewLedgerWithOutbox(logger, outbox)
+
+	now := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+	receipts.Insert(&model.Receipt{Id: "a", Points: 10}, model.DomainEvent{ID: "1", Type: model.DomainReceiptInserted, ReceiptID: "a"})
+	ledger.Append(&model.LedgerEntry{ID: "credit-a", UserID: "user-1", ReceiptID: "a", Type: model.LedgerCredit, Points: 10})
+
+	reviews.Insert(&model.Review{ReceiptID: "b", Status: model.ReviewPending})
+	_, err := reviews.Decide("b", model.ReviewRejected, "", now, model.DomainEvent{ID: "3", Type: model.DomainReceiptVoided, ReceiptID: "b"})
+	assert.NoError(t, err)
+
+	// A decision that fails writes no event.
+	_, err = reviews.Decide("b", model.ReviewApproved, "", now, model.DomainEvent{ID: "4", Type: model.DomainReceiptVoided, ReceiptID: "b"})
+	assert.Error(t, err)
+
+	// The ledger writes the event of the credit itself.
+	awarded := outbox.Pending(10)[1].Event
+	assert.Equal(t, model.DomainPointsAwarded, awarded.Type)
+	assert.Equal(t, "a", awarded.ReceiptID)
+
+	outbox.MarkFailed("1", "sink down")
+	outbox.MarkPublished(awarded.ID)
+	outbox.MarkPublished("unknown")
+
+	testCases := []struct {
+		id          int
+		useCase     string
+		limit       int
+		expectedIDs []string
+	}{
+		{id: 1, useCase: "Positive case: pending events oldest first", limit: 10, expectedIDs: []string{"1", "3"}},
+		{id: 2, useCase: "Positive case: pending events up to the limit", limit: 1, expectedIDs: []string{"1"}},
+	}
+
+	for _, tc := range testCases {
+		var ids []string
+		for _, entry := range outbox.Pending(tc.limit) {
+			ids = append(ids, entry.Event.ID)
+		}
+
+		assert.Equal(t, tc.expectedIDs, ids, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+
+	failed := outbox.Pending(1)[0]
+	assert.Equal(t, 1, failed.Attempts)
+	assert.Equal(t, "sink down", failed.LastError)
+	assert.Equal(t, 2, outbox.Count())
+}
+
+func TestLedgerOutboxEvents(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	outbox := NewOutbox(logger)
+	ledger := NewLedgerWithOutbox(logger, outbox)
+
+	now := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(time.Hour)
+
+	assert.NoError(t, ledger.Bind("user-1", "partner"))
+	ledger.Append(&model.LedgerEntry{ID: "credit-a", UserID: "user-1", ReceiptID: "a", Type: model.LedgerCredit, Points: 30, CreatedAt: now})
+	ledger.Append(&model.LedgerEntry{ID: "credit-b", UserID: "user-1", ReceiptID: "b", Type: model.LedgerCredit, Points: 20, CreatedAt: now, ExpiresAt: &expiresAt})
+	ledger.Append(&model.LedgerEntry{ID: "reversal-a", UserID: "user-1", ReceiptID: "a", Type: model.LedgerReversal, Points: -5, CreatedAt: now})
+	_, err := ledger.Debit(&model.LedgerEntry{ID: "debit", UserID: "user-1", RedemptionID: "r", Type: model.LedgerDebit, Points: -10, CreatedAt: now})
+	assert.NoError(t, err)
+	ledger.Expire(expiresAt)
+
+	testCases := []struct {
+		id       int
+		useCase  string
+		expected model.DomainEvent
+	}{
+		{id: 1, useCase: "Positive case: credit awards points", expected: model.DomainEvent{Type: model.DomainPointsAwarded, ReceiptID: "a", Points: 30}},
+		{id: 2, useCase: "Positive case: expiring credit awards points", expected: model.DomainEvent{Type: model.DomainPointsAwarded, ReceiptID: "b", Points: 20}},
+		{id: 3, useCase: "Positive case: reversal takes back points", expected: model.DomainEvent{Type: model.DomainPointsReversed, ReceiptID: "a", Points: -5}},
+		{id: 4, useCase: "Positive case: debit redeems points", expected: model.DomainEvent{Type: model.DomainPointsRedeemed, RedemptionID: "r", Points: -10}},
+		{id: 5, useCase: "Positive case: expiry expires the points left", expected: model.DomainEvent{Type: model.DomainPointsExpired, ReceiptID: "b", Points: -20}},
+	}
+
+	pending := outbox.Pending(10)
+	assert.Len(t, pending, len(testCases))
+
+	for i, tc := range testCases {
+		if i >= len(pending) {
+			break
+		}
+
+		event := pending[i].Event
+		tc.expected.ID, tc.expected.ClientID, tc.expected.UserID, tc.expected.OccurredAt = event.ID, "partner", "user-1", event.OccurredAt
+		assert.Equal(t, tc.expected, event, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
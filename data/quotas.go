@@ -0,0 +1,75 @@
+package data
+
+import (
+	"sync"
+	"time"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+)
+
+// quotaStore is a thread-safe in-memory store of the daily usage of each client.
+type quotaStore struct {
+	logger *log.CustomLogger
+	mu     sync.Mutex
+	usage  map[string]map[string]int // Usage with days in YYYY-MM-DD format and then client IDs as keys.
+}
+
+// NewQuotas creates and returns a new instance of quotaStore which implements methods of the interface Quotas.
+func NewQuotas(l *log.CustomLogger) Quotas {
+	return &quotaStore{
+		logger: l,
+		usage:  make(map[string]map[string]int),
+	}
+}
+
+// Increment counts one use of the quota of a client for the given day, unless the client already used limit on that day.
+// It returns the usage of the day and whether the use was counted. Usage is kept for the given day and the day before,
+// so a late use counted for the previous day around midnight leaves the usage of both days untouched.
+func (qs *quotaStore) Increment(clientID string, day string, limit int) (int, bool) {
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+
+	discardDays(qs.usage, day)
+
+	usage, ok := qs.usage[day]
+	if !ok {
+		usage = make(map[string]int)
+		qs.usage[day] = usage
+	}
+
+	if usage[clientID] >= limit {
+		return usage[clientID], false
+	}
+
+	usage[clientID]++
+
+	return usage[clientID], true
+}
+
+// Refund gives back one use of the quota of a client counted by Increment on the given day.
+// Uses of a day whose usage was already discarded are not refunded.
+func (qs *quotaStore) Refund(clientID string, day string) {
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+
+	usage := qs.usage[day]
+	if usage[clientID] == 0 {
+		return
+	}
+
+	usage[clientID]--
+}
+
+// discardDays deletes the entries of days keyed in YYYY-MM-DD format before the day preceding the given day.
+func discardDays[V any](days map[string]V, day string) {
+	oldest := day
+	if t, err := time.Parse("2006-01-02", day); err == nil {
+		oldest = t.AddDate(0, 0, -1).Format("2006-01-02")
+	}
+
+	for d := range days {
+		if d < oldest {
+			delete(days, d)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+)
+
+func TestQuotaStoreIncrement(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewQuotas(logger)
+
+	testCases := []struct {
+		id              int
+		useCase         string
+		clientID        string
+		day             string
+		expectedUsed    int
+		expectedAllowed bool
+	}{
+		{id: 1, useCase: "Positive case: first submission of the day", clientID: "partner-a", day: "2024-03-01", expectedUsed: 1, expectedAllowed: true},
+		{id: 2, useCase: "Positive case: last submission within the quota", clientID: "partner-a", day: "2024-03-01", expectedUsed: 2, expectedAllowed: true},
+		{id: 3, useCase: "Negative case: quota used up", clientID: "partner-a", day: "2024-03-01", expectedUsed: 2, expectedAllowed: false},
+		{id: 4, useCase: "Positive case: quotas are counted per client", clientID: "partner-b", day: "2024-03-01", expectedUsed: 1, expectedAllowed: true},
+		{id: 5, useCase: "Positive case: quota resets on the next day", clientID: "partner-a", day: "2024-03-02", expectedUsed: 1, expectedAllowed: true},
+		{id: 6, useCase: "Negative case: late submission of the previous day", clientID: "partner-a", day: "2024-03-01", expectedUsed: 2, expectedAllowed: false},
+		{id: 7, useCase: "Positive case: usage of the day kept after a late submission", clientID: "partner-a", day: "2024-03-02", expectedUsed: 2, expectedAllowed: true},
+	}
+
+	for _, tc := range testCases {
+		used, allowed := store.Increment(tc.clientID, tc.day, 2)
+
+		assert.Equal(t, tc.expectedUsed, used, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedAllowed, allowed, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestQuotaStoreRefund(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewQuotas(logger)
+
+	testCases := []struct {
+		id              int
+		useCase         string
+		refundDay       string
+		day             string
+		expectedUsed    int
+		expectedAllowed bool
+	}{
+		{id: 1, useCase: "Positive case: a refunded use can be used again", refundDay: "2024-03-01", day: "2024-03-01", expectedUsed: 1, expectedAllowed: true},
+		{id: 2, useCase: "Negative case: refunds of the previous day leave the day untouched", refundDay: "2024-03-01", day: "2024-03-02", expectedUsed: 1, expectedAllowed: false},
+	}
+
+	for _, tc := range testCases {
+		store.Increment("partner-a", "2024-03-01", 1)
+		store.Increment("partner-a", tc.day, 1)
+		store.Refund("partner-a", tc.refundDay)
+
+		used, allowed := store.Increment("partner-a", tc.day, 1)
+
+		assert.Equal(t, tc.expectedUsed, used, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedAllowed, allowed, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
@@ -1,6 +1,7 @@
 package data
 
 import (
+	er "errors"
 	"sync"
 
 	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
@@ -13,6 +14,9 @@ type receiptStore struct {
 	logger             *log.CustomLogger
 	mu                 sync.Mutex               // Mutex to ensure thread-safe access to the in-memory receipt map.
 	inMemoryReceiptMap map[string]model.Receipt // In-memory map to store receipts with their IDs as keys.
+	bySubmitter        map[string][]string      // IDs of the receipts of each submitter in insertion order.
+	order              []string                 // IDs of all receipts in insertion order.
+	outbox             Outbox                   // Outbox the events of inserted receipts are written to, nil drops them.
 }
 
 // New creates and returns a new instance of receiptStore which implements methods of the interface Receipts.
@@ -20,6 +24,17 @@ func New(l *log.CustomLogger) Receipts {
 	return &receiptStore{
 		logger:             l,
 		inMemoryReceiptMap: make(map[string]model.Receipt),
+		bySubmitter:        make(map[string][]string),
+	}
+}
+
+// NewWithOutbox creates and returns a new instance of receiptStore writing the events of every inserted receipt to outbox.
+func NewWithOutbox(l *log.CustomLogger, outbox Outbox) Receipts {
+	return &receiptStore{
+		logger:             l,
+		inMemoryReceiptMap: make(map[string]model.Receipt),
+		bySubmitter:        make(map[string][]string),
+		outbox:             outbox,
 	}
 }
 
@@ -37,19 +52,133 @@ func (rs *receiptStore) Get(receiptID string) (*model.ReceiptGetResponse, error)
 	}
 
 	return &model.ReceiptGetResponse{
-		Points: receipt.Points,
+		Points:       receipt.Points,
+		RetailerID:   receipt.RetailerID,
+		RetailerName: receipt.RetailerName,
+		Campaigns:    receipt.Campaigns,
+		Cap:          receipt.Cap,
+		ClientID:     receipt.ClientID,
+		UserID:       receipt.UserID,
 	}, nil
 }
 
-// Insert adds a new receipt to the in-memory store.
+// Find retrieves the full receipt stored under an ID, it returns an error if the receipt is not found.
+// The returned receipt is a copy, stored receipts are never modified.
+func (rs *receiptStore) Find(receiptID string) (*model.Receipt, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	receipt, exists := rs.inMemoryReceiptMap[receiptID]
+	if !exists {
+		return nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "receipts", ID: receiptID})
+	}
+
+	receipt.Items = append([]model.Item(nil), receipt.Items...)
+
+	return &receipt, nil
+}
+
+// Insert adds a new receipt to the in-memory store, and its events to the outbox in the same operation.
 // It returns a ReceiptPostResponse containing the ID of the newly inserted receipt.
-func (rs *receiptStore) Insert(receipt *model.Receipt) *model.ReceiptPostResponse {
+func (rs *receiptStore) Insert(receipt *model.Receipt, events ...model.DomainEvent) *model.ReceiptPostResponse {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	return rs.insert(receipt, events...)
+}
+
+// InsertHeld adds a receipt whose points are held for a fraud review, and its review to reviews in the same operation,
+// so that the receipt is never found without its review. Its events are written to the outbox in the same operation too.
+func (rs *receiptStore) InsertHeld(receipt *model.Receipt, review *model.Review, reviews Reviews, events ...model.DomainEvent) *model.ReceiptPostResponse {
 	rs.mu.Lock()
 	defer rs.mu.Unlock()
 
+	reviews.Insert(review)
+
+	return rs.insert(receipt, events...)
+}
+
+// insert adds a receipt to the store and its events to the outbox. The caller must hold the lock.
+func (rs *receiptStore) insert(receipt *model.Receipt, events ...model.DomainEvent) *model.ReceiptPostResponse {
+	if _, exists := rs.inMemoryReceiptMap[receipt.Id]; !exists {
+		rs.order = append(rs.order, receipt.Id)
+	}
+
 	rs.inMemoryReceiptMap[receipt.Id] = *receipt
 
+	if rs.outbox != nil {
+		rs.outbox.Append(events...)
+	}
+
+	if submitter := receipt.Submitter(); submitter != "" {
+		rs.bySubmitter[submitter] = append(rs.bySubmitter[submitter], receipt.Id)
+	}
+
 	return &model.ReceiptPostResponse{
 		Id: receipt.Id,
 	}
 }
+
+// Recent returns up to limit of the latest receipts of a submitter, oldest first.
+// Anonymous receipts have no submitter and are never returned.
+func (rs *receiptStore) Recent(submitter string, limit int) []model.Receipt {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	ids := rs.bySubmitter[submitter]
+	if len(ids) > limit {
+		ids = ids[len(ids)-limit:]
+	}
+
+	receipts := make([]model.Receipt, 0, len(ids))
+	for _, id := range ids {
+		receipts = append(receipts, rs.inMemoryReceiptMap[id])
+	}
+
+	return receipts
+}
+
+// List returns the receipts selected by filter, newest first, skipping offset receipts and returning at most limit.
+// It also returns the number of receipts selected by filter.
+func (rs *receiptStore) List(filter model.ReceiptFilter, offset, limit int) ([]model.Receipt, int) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	receipts := make([]model.Receipt, 0)
+	total := 0
+	for i := len(rs.order) - 1; i >= 0; i-- {
+		receipt := rs.inMemoryReceiptMap[rs.order[i]]
+		if !filter.Matches(&receipt) {
+			continue
+		}
+
+		if total >= offset && len(receipts) < limit {
+			receipt.Items = append([]model.Item(nil), receipt.Items...)
+			receipts = append(receipts, receipt)
+		}
+
+		total++
+	}
+
+	return receipts, total
+}
+
+// Count returns the number of receipts in the in-memory store.
+func (rs *receiptStore) Count() int {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	return len(rs.inMemoryReceiptMap)
+}
+
+// Ping verifies the store can be accessed, it blocks while another operation holds the store.
+func (rs *receiptStore) Ping() error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if rs.inMemoryReceiptMap == nil {
+		return errors.NewCustomError(er.New("receipt store is not initialized"), 503)
+	}
+
+	return nil
+}
@@ -16,6 +16,7 @@ func NewTest() *receiptStore {
 	return &receiptStore{
 		logger:             logger,
 		inMemoryReceiptMap: make(map[string]model.Receipt),
+		bySubmitter:        make(map[string][]string),
 	}
 }
 
@@ -88,6 +89,31 @@ func TestDataStoreInsert(t *testing.T) {
 	}
 }
 
+// TestDataStoreInsertHeld checks that a held receipt is never found without its review, as returns rely on it.
+func TestDataStoreInsertHeld(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store, reviews := NewTest(), NewReviews(logger)
+
+	const receiptID = "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f"
+
+	found := make(chan error)
+	go func() {
+		for {
+			if _, err := store.Find(receiptID); err == nil {
+				_, err = reviews.Get(receiptID)
+				found <- err
+
+				return
+			}
+		}
+	}()
+
+	resp := store.InsertHeld(&model.Receipt{Id: receiptID}, &model.Review{ReceiptID: receiptID, Status: model.ReviewPending}, reviews)
+
+	assert.Equal(t, &model.ReceiptPostResponse{Id: receiptID}, resp)
+	assert.NoError(t, <-found)
+}
+
 // TestConcurrencyInsert tests the concurrent insertion of receipts into the store.
 func TestConcurrencyInsert(t *testing.T) {
 	// Create a new test store
@@ -138,3 +164,118 @@ func TestConcurrencyInsert(t *testing.T) {
 		assert.Equal(t, receipt, &storedReceipt)
 	}
 }
+
+func TestDataStoreCount(t *testing.T) {
+	store := NewTest()
+	assert.Equal(t, 0, store.Count())
+
+	_ = store.Insert(&model.Receipt{Id: "1a77ec9d-5334-43d0-a9e1-4fca8807bf8f"})
+	_ = store.Insert(&model.Receipt{Id: "2a77ec9d-5334-43d0-a9e1-4fca8807bf8f"})
+	_ = store.Insert(&model.Receipt{Id: "2a77ec9d-5334-43d0-a9e1-4fca8807bf8f"})
+
+	assert.Equal(t, 2, store.Count())
+}
+
+func TestDataStorePing(t *testing.T) {
+	assert.NoError(t, NewTest().Ping())
+	assert.Error(t, (&receiptStore{}).Ping())
+}
+
+func TestDataStoreFind(t *testing.T) {
+	store := NewTest()
+
+	receiptID := "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f"
+	store.Insert(&model.Receipt{Id: receiptID, Points: 10, Items: []model.Item{{ShortDescription: model.StringPointer("Gatorade")}}})
+
+	receipt, err := store.Find(receiptID)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, receipt.Points)
+
+	// Changing the returned receipt leaves the stored receipt untouched.
+	receipt.Items[0] = model.Item{ShortDescription: model.StringPointer("Doritos")}
+	stored, _ := store.Find(receiptID)
+	assert.Equal(t, "Gatorade", *stored.Items[0].ShortDescription)
+
+	_, err = store.Find("5a77ec9d-5334-43d0-a9e1-4fca8807bf8f")
+	assert.EqualError(t, err, "No 'receipts' found for Id: '5a77ec9d-5334-43d0-a9e1-4fca8807bf8f'")
+}
+
+func TestDataStoreRecent(t *testing.T) {
+	store := NewTest()
+
+	for i, r := range []model.Receipt{
+		{Id: "1", UserID: "user-1"},
+		{Id: "2", ClientID: "partner"},
+		{Id: "3", UserID: "user-1", ClientID: "partner"},
+		{Id: "4"},
+		{Id: "5", UserID: "user-1"},
+	} {
+		r.Points = i
+		store.Insert(&r)
+	}
+
+	testCases := []struct {
+		id          int
+		useCase     string
+		submitter   string
+		limit       int
+		expectedIDs []string
+	}{
+		{id: 1, useCase: "Positive case: receipts of a user oldest first", submitter: "user:user-1", limit: 10, expectedIDs: []string{"1", "3", "5"}},
+		{id: 2, useCase: "Positive case: latest receipts within the limit", submitter: "user:user-1", limit: 2, expectedIDs: []string{"3", "5"}},
+		{id: 3, useCase: "Positive case: receipts of a client without user", submitter: "client:partner", limit: 10, expectedIDs: []string{"2"}},
+		{id: 4, useCase: "Negative case: anonymous receipts are not indexed", submitter: "", limit: 10, expectedIDs: []string{}},
+	}
+
+	for _, tc := range testCases {
+		ids := []string{}
+		for _, r := range store.Recent(tc.submitter, tc.limit) {
+			ids = append(ids, r.Id)
+		}
+
+		assert.Equal(t, tc.expectedIDs, ids, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestDataStoreList(t *testing.T) {
+	store := NewTest()
+
+	for i, r := range []model.Receipt{
+		{Id: "1", UserID: "user-1", ClientID: "partner", Retailer: model.StringPointer("Target")},
+		{Id: "2", ClientID: "partner", Retailer: model.StringPointer("Walgreens")},
+		{Id: "3", UserID: "user-1", ClientID: "partner", Retailer: model.StringPointer(" target "), RetailerName: "Target"},
+		{Id: "4", ClientID: "other", Retailer: model.StringPointer("M&M Corner Market")},
+		{Id: "5", UserID: "user-1", ClientID: "partner", Retailer: model.StringPointer("TGT"), RetailerName: "Target"},
+	} {
+		r.Points = 10 * i
+		store.Insert(&r)
+	}
+
+	testCases := []struct {
+		id            int
+		useCase       string
+		filter        model.ReceiptFilter
+		offset, limit int
+		expectedIDs   []string
+		expectedTotal int
+	}{
+		{id: 1, useCase: "Positive case: every receipt newest first", limit: 10, expectedIDs: []string{"5", "4", "3", "2", "1"}, expectedTotal: 5},
+		{id: 2, useCase: "Positive case: page of the receipts", offset: 1, limit: 2, expectedIDs: []string{"4", "3"}, expectedTotal: 5},
+		{id: 3, useCase: "Positive case: receipts of a client", filter: model.ReceiptFilter{ClientID: "partner"}, limit: 10, expectedIDs: []string{"5", "3", "2", "1"}, expectedTotal: 4},
+		{id: 4, useCase: "Positive case: receipts of a user with a minimum of points", filter: model.ReceiptFilter{UserID: "user-1", MinPoints: 20}, limit: 10, expectedIDs: []string{"5", "3"}, expectedTotal: 2},
+		{id: 5, useCase: "Positive case: raw or canonical retailer name", filter: model.ReceiptFilter{Retailer: "TARGET"}, limit: 10, expectedIDs: []string{"5", "3", "1"}, expectedTotal: 3},
+		{id: 6, useCase: "Negative case: offset beyond the receipts", filter: model.ReceiptFilter{ClientID: "other"}, offset: 1, limit: 10, expectedIDs: []string{}, expectedTotal: 1},
+	}
+
+	for _, tc := range testCases {
+		receipts, total := store.List(tc.filter, tc.offset, tc.limit)
+
+		ids := []string{}
+		for _, r := range receipts {
+			ids = append(ids, r.Id)
+		}
+
+		assert.Equal(t, tc.expectedIDs, ids, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedTotal, total, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
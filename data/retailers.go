@@ -0,0 +1,178 @@
+package data
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// retailerStore is a thread-safe in-memory catalog of canonical retailers.
+type retailerStore struct {
+	logger    *log.CustomLogger
+	mu        sync.RWMutex
+	retailers map[string]model.Retailer   // Retailers with their IDs as keys.
+	patterns  map[string][]*regexp.Regexp // Compiled aliases with the retailer IDs as keys.
+	sorted    []model.Retailer            // Retailers ordered by name, then ID, replaced on every change of the catalog.
+}
+
+// NewRetailers creates and returns a new instance of retailerStore which implements methods of the interface Retailers.
+func NewRetailers(l *log.CustomLogger) Retailers {
+	return &retailerStore{
+		logger:    l,
+		retailers: make(map[string]model.Retailer),
+		patterns:  make(map[string][]*regexp.Regexp),
+	}
+}
+
+// Get retrieves a retailer by its ID, it returns an error if the retailer is not found.
+func (rs *retailerStore) Get(retailerID string) (*model.Retailer, error) {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	retailer, exists := rs.retailers[retailerID]
+	if !exists {
+		return nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "retailers", ID: retailerID})
+	}
+
+	return &retailer, nil
+}
+
+// List returns every retailer ordered by name, then ID, which is the order raw names are resolved in.
+func (rs *retailerStore) List() []model.Retailer {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	return append([]model.Retailer{}, rs.sorted...)
+}
+
+// Insert adds a retailer to the catalog, it returns an error if another retailer has the same name.
+// The aliases of the retailer must have been validated.
+func (rs *retailerStore) Insert(retailer *model.Retailer) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if err := rs.checkName(retailer); err != nil {
+		return err
+	}
+
+	rs.store(retailer)
+
+	return nil
+}
+
+// Update replaces a retailer of the catalog, it returns an error if the retailer is not found or
+// if another retailer has the same name. Receipts keep the retailer they were resolved to when submitted.
+func (rs *retailerStore) Update(retailer *model.Retailer) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if _, exists := rs.retailers[retailer.ID]; !exists {
+		return errors.NewEntityNotFound(errors.EntityNotFound{Entity: "retailers", ID: retailer.ID})
+	}
+
+	if err := rs.checkName(retailer); err != nil {
+		return err
+	}
+
+	rs.store(retailer)
+
+	return nil
+}
+
+// Delete removes a retailer from the catalog, it returns an error if the retailer is not found.
+func (rs *retailerStore) Delete(retailerID string) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if _, exists := rs.retailers[retailerID]; !exists {
+		return errors.NewEntityNotFound(errors.EntityNotFound{Entity: "retailers", ID: retailerID})
+	}
+
+	delete(rs.retailers, retailerID)
+	delete(rs.patterns, retailerID)
+	rs.refresh()
+
+	return nil
+}
+
+// Resolve returns the canonical retailer a raw retailer name resolves to, it returns false when no retailer matches.
+// Names are compared before aliases so that an alias of one retailer never shadows the name of another,
+// ties are broken by the order of List.
+func (rs *retailerStore) Resolve(raw string) (*model.Retailer, bool) {
+	name := model.NormalizeRetailerName(raw)
+	if name == "" {
+		return nil, false
+	}
+
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	for _, retailer := range rs.sorted {
+		if strings.EqualFold(model.NormalizeRetailerName(retailer.Name), name) {
+			return &retailer, true
+		}
+	}
+
+	for _, retailer := range rs.sorted {
+		for _, pattern := range rs.patterns[retailer.ID] {
+			if pattern.MatchString(name) {
+				return &retailer, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// refresh replaces the sorted snapshot of the catalog, ordered by name, then ID, the caller must hold the lock.
+// Readers holding the previous snapshot keep it unchanged.
+func (rs *retailerStore) refresh() {
+	retailers := make([]model.Retailer, 0, len(rs.retailers))
+	for _, retailer := range rs.retailers {
+		retailers = append(retailers, retailer)
+	}
+
+	sort.Slice(retailers, func(i, j int) bool {
+		if retailers[i].Name != retailers[j].Name {
+			return retailers[i].Name < retailers[j].Name
+		}
+
+		return retailers[i].ID < retailers[j].ID
+	})
+
+	rs.sorted = retailers
+}
+
+// checkName returns an error if a retailer other than the given one has the same name, the caller must hold the lock.
+func (rs *retailerStore) checkName(retailer *model.Retailer) error {
+	name := model.NormalizeRetailerName(retailer.Name)
+	for id, existing := range rs.retailers {
+		if id != retailer.ID && strings.EqualFold(model.NormalizeRetailerName(existing.Name), name) {
+			return errors.NewConflict(fmt.Errorf("Retailer '%v' already exists with Id: '%v'", existing.Name, id))
+		}
+	}
+
+	return nil
+}
+
+// store saves a retailer along with its compiled aliases, the caller must hold the lock.
+func (rs *retailerStore) store(retailer *model.Retailer) {
+	patterns, err := retailer.Patterns()
+	if err != nil {
+		lm := log.Message{Level: "ERROR", ErrorMessage: fmt.Sprintf("Compiling aliases of retailer %v with error %v", retailer.ID, err.Error())}
+		rs.logger.Log(&lm)
+	}
+
+	stored := *retailer
+	stored.Aliases = append([]string(nil), retailer.Aliases...)
+
+	rs.retailers[retailer.ID] = stored
+	rs.patterns[retailer.ID] = patterns
+	rs.refresh()
+}
@@ -0,0 +1,115 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestRetailerStore_Resolve(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewRetailers(logger)
+
+	assert.NoError(t, store.Insert(&model.Retailer{ID: "target", Name: "Target", Aliases: []string{`target( store| #\d+)?`}}))
+	assert.NoError(t, store.Insert(&model.Retailer{ID: "target-foods", Name: "Target Foods"}))
+	assert.NoError(t, store.Insert(&model.Retailer{ID: "walmart", Name: "Walmart", Aliases: []string{`wal-?mart( supercenter)?( #\d+)?`}}))
+
+	testCases := []struct {
+		id         int
+		useCase    string
+		raw        string
+		retailerID string
+	}{
+		{id: 1, useCase: "Positive case: canonical name ignoring case", raw: "TARGET", retailerID: "target"},
+		{id: 2, useCase: "Positive case: store number alias", raw: "TARGET #1234", retailerID: "target"},
+		{id: 3, useCase: "Positive case: alias with extra whitespace", raw: "  Target   Store ", retailerID: "target"},
+		{id: 4, useCase: "Positive case: name of another retailer wins over a prefix", raw: "target foods", retailerID: "target-foods"},
+		{id: 5, useCase: "Positive case: hyphenated alias", raw: "Wal-Mart Supercenter #42", retailerID: "walmart"},
+		{id: 6, useCase: "Negative case: alias must match the whole name", raw: "Target Store Outlet"},
+		{id: 7, useCase: "Negative case: unknown retailer", raw: "M&M Corner Market"},
+		{id: 8, useCase: "Negative case: blank name", raw: "   "},
+	}
+
+	for _, tc := range testCases {
+		retailer, ok := store.Resolve(tc.raw)
+		if tc.retailerID == "" {
+			assert.False(t, ok, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			continue
+		}
+
+		assert.True(t, ok, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.retailerID, retailer.ID, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestRetailerStore(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewRetailers(logger)
+
+	assert.NoError(t, store.Insert(&model.Retailer{ID: "b", Name: "Walmart"}))
+	assert.NoError(t, store.Insert(&model.Retailer{ID: "a", Name: "Target", Aliases: []string{"target store"}}))
+
+	testCases := []struct {
+		id            int
+		useCase       string
+		run           func() error
+		expectedError string
+	}{
+		{
+			id: 1, useCase: "Negative case: insert duplicate name",
+			run:           func() error { return store.Insert(&model.Retailer{ID: "c", Name: "WALMART"}) },
+			expectedError: "Retailer 'Walmart' already exists with Id: 'b'",
+		},
+		{
+			id: 2, useCase: "Positive case: update keeps its own name",
+			run: func() error {
+				return store.Update(&model.Retailer{ID: "a", Name: "Target", Aliases: []string{"tgt"}})
+			},
+		},
+		{
+			id: 3, useCase: "Negative case: update to the name of another retailer",
+			run:           func() error { return store.Update(&model.Retailer{ID: "a", Name: "Walmart"}) },
+			expectedError: "Retailer 'Walmart' already exists with Id: 'b'",
+		},
+		{
+			id: 4, useCase: "Negative case: update unknown retailer",
+			run:           func() error { return store.Update(&model.Retailer{ID: "c", Name: "Costco"}) },
+			expectedError: "No 'retailers' found for Id: 'c'",
+		},
+		{
+			id: 5, useCase: "Positive case: delete existing retailer",
+			run: func() error { return store.Delete("b") },
+		},
+		{
+			id: 6, useCase: "Negative case: delete deleted retailer",
+			run:           func() error { return store.Delete("b") },
+			expectedError: "No 'retailers' found for Id: 'b'",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.run()
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		} else {
+			assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+	}
+
+	// Updated aliases replace the previous ones.
+	_, ok := store.Resolve("target store")
+	assert.False(t, ok)
+
+	retailer, ok := store.Resolve("TGT")
+	assert.True(t, ok)
+	assert.Equal(t, "a", retailer.ID)
+
+	// Deleted retailers are no longer resolved.
+	_, ok = store.Resolve("Walmart")
+	assert.False(t, ok)
+
+	assert.Equal(t, []model.Retailer{{ID: "a", Name: "Target", Aliases: []string{"tgt"}}}, store.List())
+}
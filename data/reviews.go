@@ -0,0 +1,107 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// reviewStore is a thread-safe in-memory store of the receipts held for a fraud review.
+type reviewStore struct {
+	logger  *log.CustomLogger
+	mu      sync.RWMutex
+	reviews map[string]model.Review // Reviews with the receipt IDs as keys.
+	outbox  Outbox                  // Outbox the events of decisions are written to, nil drops them.
+}
+
+// NewReviews creates and returns a new instance of reviewStore which implements methods of the interface Reviews.
+func NewReviews(l *log.CustomLogger) Reviews {
+	return &reviewStore{
+		logger:  l,
+		reviews: make(map[string]model.Review),
+	}
+}
+
+// NewReviewsWithOutbox creates and returns a new instance of reviewStore writing the events of every decision to outbox.
+func NewReviewsWithOutbox(l *log.CustomLogger, outbox Outbox) Reviews {
+	return &reviewStore{
+		logger:  l,
+		reviews: make(map[string]model.Review),
+		outbox:  outbox,
+	}
+}
+
+// Insert adds a review to the store.
+func (rs *reviewStore) Insert(review *model.Review) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.reviews[review.ReceiptID] = *review
+}
+
+// Get retrieves the review of a receipt, it returns an error if the receipt was never held for review.
+func (rs *reviewStore) Get(receiptID string) (*model.Review, error) {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	review, exists := rs.reviews[receiptID]
+	if !exists {
+		return nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "reviews", ID: receiptID})
+	}
+
+	return &review, nil
+}
+
+// List returns the reviews with the given status, every review when status is empty, oldest first.
+func (rs *reviewStore) List(status string) []model.Review {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	reviews := make([]model.Review, 0)
+	for _, review := range rs.reviews {
+		if status == "" || review.Status == status {
+			reviews = append(reviews, review)
+		}
+	}
+
+	sort.Slice(reviews, func(i, j int) bool {
+		if !reviews[i].CreatedAt.Equal(reviews[j].CreatedAt) {
+			return reviews[i].CreatedAt.Before(reviews[j].CreatedAt)
+		}
+
+		return reviews[i].ReceiptID < reviews[j].ReceiptID
+	})
+
+	return reviews
+}
+
+// Decide records the decision on a pending review. It returns an error if the review is not found,
+// or a conflict if it was already decided, so that concurrent decisions never credit points twice.
+// The events of the decision are written to the outbox in the same operation.
+func (rs *reviewStore) Decide(receiptID string, status, note string, decidedAt time.Time, events ...model.DomainEvent) (*model.Review, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	review, exists := rs.reviews[receiptID]
+	if !exists {
+		return nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "reviews", ID: receiptID})
+	}
+
+	if review.Status != model.ReviewPending {
+		return nil, errors.NewConflict(fmt.Errorf("Review of receipt '%v' was already decided: %v", receiptID, review.Status))
+	}
+
+	review.Status, review.Note, review.DecidedAt = status, note, &decidedAt
+	rs.reviews[receiptID] = review
+
+	if rs.outbox != nil {
+		rs.outbox.Append(events...)
+	}
+
+	return &review, nil
+}
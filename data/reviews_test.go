@@ -0,0 +1,52 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestReviewStore(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewReviews(logger)
+
+	now := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+	store.Insert(&model.Review{ReceiptID: "b", Points: 20, Status: model.ReviewPending, CreatedAt: now.Add(time.Minute)})
+	store.Insert(&model.Review{ReceiptID: "a", Points: 10, Status: model.ReviewPending, CreatedAt: now})
+
+	testCases := []struct {
+		id             int
+		useCase        string
+		receiptID      string
+		status         string
+		expectedStatus string
+		expectedError  string
+	}{
+		{id: 1, useCase: "Positive case: approve pending review", receiptID: "a", status: model.ReviewApproved, expectedStatus: model.ReviewApproved},
+		{id: 2, useCase: "Negative case: decide decided review", receiptID: "a", status: model.ReviewRejected, expectedError: "Review of receipt 'a' was already decided: approved"},
+		{id: 3, useCase: "Negative case: receipt never held", receiptID: "c", status: model.ReviewApproved, expectedError: "No 'reviews' found for Id: 'c'"},
+	}
+
+	for _, tc := range testCases {
+		review, err := store.Decide(tc.receiptID, tc.status, "checked", now.Add(time.Hour))
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			continue
+		}
+
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedStatus, review.Status, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, now.Add(time.Hour), *review.DecidedAt, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+
+	pending := store.List(model.ReviewPending)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, "b", pending[0].ReceiptID)
+
+	all := store.List("")
+	assert.Equal(t, []string{"a", "b"}, []string{all[0].ReceiptID, all[1].ReceiptID})
+}
@@ -0,0 +1,110 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// rewardStore is a thread-safe in-memory reward catalog, along with the redemptions of its rewards.
+type rewardStore struct {
+	logger      *log.CustomLogger
+	mu          sync.Mutex
+	rewards     map[string]model.Reward       // Rewards with their IDs as keys.
+	redemptions map[string][]model.Redemption // Redemptions with user IDs as keys, oldest first.
+}
+
+// NewRewards creates and returns a new instance of rewardStore which implements methods of the interface Rewards.
+func NewRewards(l *log.CustomLogger) Rewards {
+	return &rewardStore{
+		logger:      l,
+		rewards:     make(map[string]model.Reward),
+		redemptions: make(map[string][]model.Redemption),
+	}
+}
+
+// Get retrieves a reward by its ID, it returns an error if the reward is not in the catalog.
+func (rs *rewardStore) Get(rewardID string) (*model.Reward, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	reward, exists := rs.rewards[rewardID]
+	if !exists {
+		return nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "rewards", ID: rewardID})
+	}
+
+	return &reward, nil
+}
+
+// List returns every reward of the catalog ordered by cost, then name.
+func (rs *rewardStore) List() []model.Reward {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rewards := make([]model.Reward, 0, len(rs.rewards))
+	for _, reward := range rs.rewards {
+		rewards = append(rewards, reward)
+	}
+
+	sort.Slice(rewards, func(i, j int) bool {
+		if rewards[i].Cost != rewards[j].Cost {
+			return rewards[i].Cost < rewards[j].Cost
+		}
+
+		return rewards[i].Name < rewards[j].Name
+	})
+
+	return rewards
+}
+
+// Insert adds a reward to the catalog, replacing any reward with the same ID.
+func (rs *rewardStore) Insert(reward *model.Reward) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.rewards[reward.ID] = *reward
+}
+
+// Redeem redeems the reward of a redemption in a single operation: it checks the reward is in stock, debits its cost
+// with debit, which returns the balance left, then takes one unit of its inventory and saves the redemption.
+// It returns an error if the reward is not in the catalog, out of stock, or the error of debit, the inventory is
+// left untouched and nothing is saved then. Redemptions of the same reward are serialized, so that parallel
+// redemptions can never oversell it.
+func (rs *rewardStore) Redeem(redemption *model.Redemption, debit func(cost int) (int, error)) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	reward, exists := rs.rewards[redemption.RewardID]
+	if !exists {
+		return errors.NewEntityNotFound(errors.EntityNotFound{Entity: "rewards", ID: redemption.RewardID})
+	}
+
+	if reward.Inventory <= 0 {
+		return errors.NewConflict(fmt.Errorf("Reward '%v' is out of stock", redemption.RewardID))
+	}
+
+	balance, err := debit(reward.Cost)
+	if err != nil {
+		return err
+	}
+
+	reward.Inventory--
+	rs.rewards[reward.ID] = reward
+
+	redemption.Points, redemption.Balance = reward.Cost, balance
+	rs.redemptions[redemption.UserID] = append(rs.redemptions[redemption.UserID], *redemption)
+
+	return nil
+}
+
+// Redemptions returns the redemptions of a user, oldest first.
+func (rs *rewardStore) Redemptions(userID string) []model.Redemption {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	return append([]model.Redemption{}, rs.redemptions[userID]...)
+}
@@ -0,0 +1,115 @@
+package data
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestRewardStore(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewRewards(logger)
+
+	store.Insert(&model.Reward{ID: "mug", Name: "Mug", Cost: 500, Inventory: 1})
+	store.Insert(&model.Reward{ID: "sticker", Name: "Sticker", Cost: 50, Inventory: 10})
+
+	assert.Equal(t, []model.Reward{
+		{ID: "sticker", Name: "Sticker", Cost: 50, Inventory: 10},
+		{ID: "mug", Name: "Mug", Cost: 500, Inventory: 1},
+	}, store.List())
+
+	// debit covers a balance of 600 points.
+	balance := 600
+	debit := func(cost int) (int, error) {
+		if cost > balance {
+			return balance, errors.NewConflict(fmt.Errorf("Insufficient points balance: %v available, %v required", balance, cost))
+		}
+
+		balance -= cost
+
+		return balance, nil
+	}
+
+	testCases := []struct {
+		id                int
+		useCase           string
+		rewardID          string
+		expectedInventory int
+		expectedBalance   int
+		expectedError     string
+	}{
+		{
+			id: 1, useCase: "Positive case: reward in stock",
+			rewardID: "mug", expectedInventory: 0, expectedBalance: 100,
+		},
+		{
+			id: 2, useCase: "Negative case: reward out of stock",
+			rewardID: "mug", expectedInventory: 0, expectedError: "Reward 'mug' is out of stock",
+		},
+		{
+			id: 3, useCase: "Negative case: unknown reward",
+			rewardID: "hat", expectedError: "No 'rewards' found for Id: 'hat'",
+		},
+		{
+			id: 4, useCase: "Negative case: failed debit keeps the inventory",
+			rewardID: "poster", expectedInventory: 3, expectedError: "Insufficient points balance: 100 available, 200 required",
+		},
+	}
+
+	store.Insert(&model.Reward{ID: "poster", Name: "Poster", Cost: 200, Inventory: 3})
+
+	for _, tc := range testCases {
+		redemption := &model.Redemption{ID: fmt.Sprint(tc.id), UserID: "user-1", RewardID: tc.rewardID}
+
+		err := store.Redeem(redemption, debit)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		} else {
+			assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			assert.Equal(t, 500, redemption.Points, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			assert.Equal(t, tc.expectedBalance, redemption.Balance, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+
+		if reward, err := store.Get(tc.rewardID); err == nil {
+			assert.Equal(t, tc.expectedInventory, reward.Inventory, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+	}
+
+	// Only the successful redemption is saved.
+	redemptions := store.Redemptions("user-1")
+	assert.Len(t, redemptions, 1)
+	assert.Equal(t, "1", redemptions[0].ID)
+	assert.Empty(t, store.Redemptions("user-2"))
+}
+
+func TestRewardStoreRedeem_Concurrent(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewRewards(logger)
+
+	store.Insert(&model.Reward{ID: "mug", Name: "Mug", Cost: 500, Inventory: 5})
+
+	var wg sync.WaitGroup
+	var succeeded atomic.Int32
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := store.Redeem(&model.Redemption{UserID: "user-1", RewardID: "mug"}, func(int) (int, error) { return 0, nil }); err == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	reward, _ := store.Get("mug")
+	assert.Equal(t, int32(5), succeeded.Load())
+	assert.Equal(t, 0, reward.Inventory)
+	assert.Len(t, store.Redemptions("user-1"), 5)
+}
@@ -0,0 +1,210 @@
+package data
+
+import (
+	"sort"
+	"sync"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// deliveryLogSize is the number of latest delivery attempts kept per webhook.
+const deliveryLogSize = 500
+
+// webhookStore is a thread-safe in-memory store of webhook subscriptions.
+type webhookStore struct {
+	logger   *log.CustomLogger
+	mu       sync.RWMutex
+	webhooks map[string]model.Webhook // Webhooks with their IDs as keys.
+}
+
+// NewWebhooks creates and returns a new instance of webhookStore which implements methods of the interface Webhooks.
+func NewWebhooks(l *log.CustomLogger) Webhooks {
+	return &webhookStore{
+		logger:   l,
+		webhooks: make(map[string]model.Webhook),
+	}
+}
+
+// Insert adds a webhook to the store.
+func (ws *webhookStore) Insert(webhook *model.Webhook) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	stored := *webhook
+	stored.Events = append([]string(nil), webhook.Events...)
+
+	ws.webhooks[webhook.ID] = stored
+}
+
+// Get retrieves a webhook by its ID, it returns an error if the webhook is not found.
+func (ws *webhookStore) Get(webhookID string) (*model.Webhook, error) {
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+
+	webhook, exists := ws.webhooks[webhookID]
+	if !exists {
+		return nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "webhooks", ID: webhookID})
+	}
+
+	return &webhook, nil
+}
+
+// List returns the webhooks of a client, oldest first.
+func (ws *webhookStore) List(clientID string) []model.Webhook {
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+
+	webhooks := make([]model.Webhook, 0)
+	for _, webhook := range ws.webhooks {
+		if webhook.ClientID == clientID {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		if !webhooks[i].CreatedAt.Equal(webhooks[j].CreatedAt) {
+			return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+		}
+
+		return webhooks[i].ID < webhooks[j].ID
+	})
+
+	return webhooks
+}
+
+// Delete removes a webhook, it returns an error if the webhook is not found.
+func (ws *webhookStore) Delete(webhookID string) error {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	if _, exists := ws.webhooks[webhookID]; !exists {
+		return errors.NewEntityNotFound(errors.EntityNotFound{Entity: "webhooks", ID: webhookID})
+	}
+
+	delete(ws.webhooks, webhookID)
+
+	return nil
+}
+
+// Subscribed returns the webhooks of a client subscribed to events of the given type about a receipt of userID.
+// Webhooks restricted to a user only receive the events of that user.
+func (ws *webhookStore) Subscribed(clientID string, userID string, eventType string) []model.Webhook {
+	var webhooks []model.Webhook
+	for _, webhook := range ws.List(clientID) {
+		if webhook.Subscribes(eventType) && (webhook.UserID == "" || webhook.UserID == userID) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	return webhooks
+}
+
+// deliveryStore is a thread-safe in-memory log of webhook delivery attempts, bounded per webhook.
+type deliveryStore struct {
+	logger     *log.CustomLogger
+	mu         sync.RWMutex
+	deliveries map[string][]model.Delivery // Delivery attempts with the webhook IDs as keys, oldest first.
+}
+
+// NewDeliveries creates and returns a new instance of deliveryStore which implements methods of the interface Deliveries.
+func NewDeliveries(l *log.CustomLogger) Deliveries {
+	return &deliveryStore{
+		logger:     l,
+		deliveries: make(map[string][]model.Delivery),
+	}
+}
+
+// Insert appends a delivery attempt to the log of its webhook, dropping the oldest attempts beyond the size of the log.
+func (ds *deliveryStore) Insert(delivery *model.Delivery) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	deliveries := append(ds.deliveries[delivery.WebhookID], *delivery)
+	if len(deliveries) > deliveryLogSize {
+		deliveries = deliveries[len(deliveries)-deliveryLogSize:]
+	}
+
+	ds.deliveries[delivery.WebhookID] = deliveries
+}
+
+// List returns the delivery attempts of a webhook with the given status, every attempt when status is empty, newest first.
+func (ds *deliveryStore) List(webhookID string, status string) []model.Delivery {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	deliveries := ds.deliveries[webhookID]
+
+	result := make([]model.Delivery, 0)
+	for i := len(deliveries) - 1; i >= 0; i-- {
+		if status == "" || deliveries[i].Status == status {
+			result = append(result, deliveries[i])
+		}
+	}
+
+	return result
+}
+
+// deadLetterStore is a thread-safe in-memory store of the deliveries that failed their last attempt. Unlike the
+// delivery log it is not bounded, dead letters are only removed once acknowledged or replayed.
+type deadLetterStore struct {
+	logger      *log.CustomLogger
+	mu          sync.RWMutex
+	deadLetters map[string][]model.Delivery // Dead deliveries with the webhook IDs as keys, oldest first.
+}
+
+// NewDeadLetters creates and returns a new instance of deadLetterStore which implements methods of the interface DeadLetters.
+func NewDeadLetters(l *log.CustomLogger) DeadLetters {
+	return &deadLetterStore{
+		logger:      l,
+		deadLetters: make(map[string][]model.Delivery),
+	}
+}
+
+// Insert adds a dead delivery to the dead letters of its webhook.
+func (ds *deadLetterStore) Insert(delivery *model.Delivery) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	ds.deadLetters[delivery.WebhookID] = append(ds.deadLetters[delivery.WebhookID], *delivery)
+}
+
+// List returns the dead letters of a webhook, newest first.
+func (ds *deadLetterStore) List(webhookID string) []model.Delivery {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	deadLetters := ds.deadLetters[webhookID]
+
+	result := make([]model.Delivery, 0, len(deadLetters))
+	for i := len(deadLetters) - 1; i >= 0; i-- {
+		result = append(result, deadLetters[i])
+	}
+
+	return result
+}
+
+// Remove removes a dead letter of a webhook and returns it, it returns an error if the dead letter is not found.
+func (ds *deadLetterStore) Remove(webhookID string, deliveryID string) (*model.Delivery, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	deadLetters := ds.deadLetters[webhookID]
+	for i := range deadLetters {
+		if deadLetters[i].ID != deliveryID {
+			continue
+		}
+
+		delivery := deadLetters[i]
+		if len(deadLetters) == 1 {
+			delete(ds.deadLetters, webhookID)
+		} else {
+			ds.deadLetters[webhookID] = append(deadLetters[:i:i], deadLetters[i+1:]...)
+		}
+
+		return &delivery, nil
+	}
+
+	return nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "dead-letters", ID: deliveryID})
+}
@@ -0,0 +1,114 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestWebhookStore(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewWebhooks(logger)
+
+	now := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+	store.Insert(&model.Webhook{ID: "b", ClientID: "partner", Events: []string{model.EventReceiptScored, model.EventReceiptVoided}, CreatedAt: now.Add(time.Minute)})
+	store.Insert(&model.Webhook{ID: "a", ClientID: "partner", Events: []string{model.EventReceiptScored}, CreatedAt: now})
+	store.Insert(&model.Webhook{ID: "c", ClientID: "other", Events: []string{model.EventReceiptVoided}, CreatedAt: now})
+	store.Insert(&model.Webhook{ID: "d", ClientID: "partner", UserID: "user-1", Events: []string{model.EventReceiptScored}, CreatedAt: now.Add(2 * time.Minute)})
+
+	testCases := []struct {
+		id          int
+		useCase     string
+		clientID    string
+		userID      string
+		eventType   string
+		expectedIDs []string
+	}{
+		{id: 1, useCase: "Positive case: every webhook of the client subscribed, oldest first", clientID: "partner", eventType: model.EventReceiptScored, expectedIDs: []string{"a", "b"}},
+		{id: 2, useCase: "Positive case: only webhooks subscribed to the event", clientID: "partner", eventType: model.EventReceiptVoided, expectedIDs: []string{"b"}},
+		{id: 3, useCase: "Negative case: no webhook of the client subscribed", clientID: "other", eventType: model.EventReceiptAdjusted, expectedIDs: nil},
+		{id: 4, useCase: "Positive case: webhook of a user receives the events of that user", clientID: "partner", userID: "user-1", eventType: model.EventReceiptScored, expectedIDs: []string{"a", "b", "d"}},
+		{id: 5, useCase: "Negative case: webhook of a user does not receive the events of other users", clientID: "partner", userID: "user-2", eventType: model.EventReceiptScored, expectedIDs: []string{"a", "b"}},
+	}
+
+	for _, tc := range testCases {
+		var ids []string
+		for _, webhook := range store.Subscribed(tc.clientID, tc.userID, tc.eventType) {
+			ids = append(ids, webhook.ID)
+		}
+
+		assert.Equal(t, tc.expectedIDs, ids, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+
+	assert.NoError(t, store.Delete("a"))
+	assert.EqualError(t, store.Delete("a"), "No 'webhooks' found for Id: 'a'")
+	assert.Len(t, store.List("partner"), 2)
+}
+
+func TestDeliveryStore(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewDeliveries(logger)
+
+	for i := 1; i <= deliveryLogSize+2; i++ {
+		status := model.DeliverySucceeded
+		if i%2 == 0 {
+			status = model.DeliveryDead
+		}
+
+		store.Insert(&model.Delivery{ID: fmt.Sprint(i), WebhookID: "a", Status: status})
+	}
+
+	all := store.List("a", "")
+	assert.Len(t, all, deliveryLogSize)
+	assert.Equal(t, fmt.Sprint(deliveryLogSize+2), all[0].ID)
+	assert.Equal(t, "3", all[len(all)-1].ID)
+
+	dead := store.List("a", model.DeliveryDead)
+	assert.Len(t, dead, deliveryLogSize/2)
+	assert.Empty(t, store.List("b", ""))
+}
+
+func TestDeadLetterStore(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	store := NewDeadLetters(logger)
+
+	for i := 1; i <= deliveryLogSize+2; i++ {
+		store.Insert(&model.Delivery{ID: fmt.Sprint(i), WebhookID: "a", Status: model.DeliveryDead})
+	}
+
+	// Dead letters are not trimmed like the delivery log.
+	deadLetters := store.List("a")
+	assert.Len(t, deadLetters, deliveryLogSize+2)
+	assert.Equal(t, fmt.Sprint(deliveryLogSize+2), deadLetters[0].ID)
+	assert.Equal(t, "1", deadLetters[len(deadLetters)-1].ID)
+
+	testCases := []struct {
+		id            int
+		useCase       string
+		webhookID     string
+		deliveryID    string
+		expectedError error
+	}{
+		{id: 1, useCase: "Positive case: dead letter of the webhook", webhookID: "a", deliveryID: "1"},
+		{id: 2, useCase: "Negative case: dead letter already removed", webhookID: "a", deliveryID: "1", expectedError: errors.EntityNotFound{Entity: "dead-letters", ID: "1"}},
+		{id: 3, useCase: "Negative case: dead letter of another webhook", webhookID: "b", deliveryID: "2", expectedError: errors.EntityNotFound{Entity: "dead-letters", ID: "2"}},
+	}
+
+	for _, tc := range testCases {
+		delivery, err := store.Remove(tc.webhookID, tc.deliveryID)
+		if tc.expectedError != nil {
+			assert.EqualError(t, err, tc.expectedError.Error(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		} else {
+			assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			assert.Equal(t, tc.deliveryID, delivery.ID, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+	}
+
+	assert.Len(t, store.List("a"), deliveryLogSize+1)
+	assert.Empty(t, store.List("b"))
+}
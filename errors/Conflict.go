@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Conflict struct {
+	Reason     string    `json:"-"`
+	Code       string    `json:"-"` // Overrides the default code of the error.
+	Msg        string    `json:"-"`
+	StatusCode int       `json:"-" default:"409"`
+	TimeStamp  time.Time `json:"-"`
+}
+
+func NewConflict(err error) Conflict {
+	return Conflict{
+		Msg:        err.Error(),
+		StatusCode: http.StatusConflict,
+		TimeStamp:  time.Now().UTC(),
+	}
+}
+
+func (e Conflict) Error() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+
+	return fmt.Sprintf("Request conflicts with the current state: %v", e.Reason)
+}
+
+// ErrorCode returns the machine-readable code of the error.
+func (e Conflict) ErrorCode() string {
+	return codeOrDefault(e.Code, CodeConflict)
+}
+
+// Status returns the HTTP status of the error.
+func (e Conflict) Status() int {
+	return status(e.StatusCode, http.StatusConflict)
+}
@@ -1,12 +1,17 @@
 package errors
 
-import "time"
+import (
+	er "errors"
+	"net/http"
+	"time"
+)
 
 type CustomError struct {
 	Err        error     `json:"-"`
-	Msg        string    `json:"msg"`
+	Code       string    `json:"-"` // Overrides the code derived from the status of the error.
+	Msg        string    `json:"-"`
 	StatusCode int       `json:"-" default:"500"`
-	TimeStamp  time.Time `json:"timestamp"`
+	TimeStamp  time.Time `json:"-"`
 }
 
 func NewCustomError(err error, statusCode ...int) CustomError {
@@ -21,6 +26,12 @@ func NewCustomError(err error, statusCode ...int) CustomError {
 		code = 503
 	}
 
+	// Bodies cut by http.MaxBytesReader are too large rather than malformed.
+	var tooLarge *http.MaxBytesError
+	if er.As(err, &tooLarge) {
+		code = http.StatusRequestEntityTooLarge
+	}
+
 	return CustomError{
 		Err:        err,
 		Msg:        err.Error(),
@@ -36,3 +47,13 @@ func (ce CustomError) Error() string {
 
 	return ce.Err.Error()
 }
+
+// ErrorCode returns the machine-readable code of the error.
+func (ce CustomError) ErrorCode() string {
+	return codeOrDefault(ce.Code, CodeForStatus(ce.Status()))
+}
+
+// Status returns the HTTP status of the error.
+func (ce CustomError) Status() int {
+	return status(ce.StatusCode, http.StatusInternalServerError)
+}
@@ -9,9 +9,10 @@ import (
 type EntityNotFound struct {
 	Entity     string    `json:"-"`
 	ID         string    `json:"-"`
-	Msg        string    `json:"msg"`
+	Code       string    `json:"-"` // Overrides the default code of the error.
+	Msg        string    `json:"-"`
 	StatusCode int       `json:"-" default:"400"`
-	TimeStamp  time.Time `json:"timestamp"`
+	TimeStamp  time.Time `json:"-"`
 }
 
 func NewEntityNotFound(err error) EntityNotFound {
@@ -30,3 +31,13 @@ func (e EntityNotFound) Error() string {
 
 	return fmt.Sprintf("No '%v' found for Id: '%v'", e.Entity, e.ID)
 }
+
+// ErrorCode returns the machine-readable code of the error.
+func (e EntityNotFound) ErrorCode() string {
+	return codeOrDefault(e.Code, CodeNotFound)
+}
+
+// Status returns the HTTP status of the error.
+func (e EntityNotFound) Status() int {
+	return status(e.StatusCode, http.StatusNotFound)
+}
@@ -0,0 +1,71 @@
+package errors
+
+import "net/http"
+
+// Machine-readable codes of the errors, stable across releases so that clients may branch on them rather than on messages.
+const (
+	CodeMissingField          = "request.missing_field"
+	CodeInvalidField          = "request.invalid_field"
+	CodeMalformedRequest      = "request.malformed"
+	CodeRequestTooLarge       = "request.too_large"
+	CodeReceiptMissingField   = "receipt.missing_field"
+	CodeReceiptInvalidField   = "receipt.invalid_field"
+	CodeNotFound              = "resource.not_found"
+	CodeConflict              = "resource.conflict"
+	CodeUnauthenticated       = "auth.unauthenticated"
+	CodeForbidden             = "auth.forbidden"
+	CodeRateLimited           = "rate_limit.exceeded"
+	CodeIdempotencyInProgress = "idempotency.in_progress"
+	CodeIdempotencyMismatch   = "idempotency.key_reused"
+	CodeUnavailable           = "server.unavailable"
+	CodeInternal              = "server.internal"
+)
+
+// Error is implemented by the errors of the API, which responders serialize as problem details.
+type Error interface {
+	error
+	ErrorCode() string // Machine-readable code of the error.
+	Status() int       // HTTP status of the error.
+}
+
+// status returns the status when it is set, otherwise the default status of the error type.
+func status(statusCode, defaultStatus int) int {
+	if statusCode == 0 {
+		return defaultStatus
+	}
+
+	return statusCode
+}
+
+// codeOrDefault returns the code when it is set, otherwise the default code of the error type.
+func codeOrDefault(code, defaultCode string) string {
+	if code == "" {
+		return defaultCode
+	}
+
+	return code
+}
+
+// CodeForStatus returns the code of errors without a more specific code, derived from their HTTP status.
+func CodeForStatus(statusCode int) string {
+	switch {
+	case statusCode == http.StatusNotFound:
+		return CodeNotFound
+	case statusCode == http.StatusConflict:
+		return CodeConflict
+	case statusCode == http.StatusUnauthorized:
+		return CodeUnauthenticated
+	case statusCode == http.StatusForbidden:
+		return CodeForbidden
+	case statusCode == http.StatusRequestEntityTooLarge:
+		return CodeRequestTooLarge
+	case statusCode == http.StatusTooManyRequests:
+		return CodeRateLimited
+	case statusCode == http.StatusServiceUnavailable:
+		return CodeUnavailable
+	case statusCode >= 400 && statusCode < 500:
+		return CodeMalformedRequest
+	default:
+		return CodeInternal
+	}
+}
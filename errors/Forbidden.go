@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Forbidden struct {
+	Scope      string    `json:"-"`
+	Code       string    `json:"-"` // Overrides the default code of the error.
+	Msg        string    `json:"-"`
+	StatusCode int       `json:"-" default:"403"`
+	TimeStamp  time.Time `json:"-"`
+}
+
+func NewForbidden(err error) Forbidden {
+	return Forbidden{
+		Msg:        err.Error(),
+		StatusCode: http.StatusForbidden,
+		TimeStamp:  time.Now().UTC(),
+	}
+}
+
+func (e Forbidden) Error() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+
+	return fmt.Sprintf("Scope '%v' is required for this request", e.Scope)
+}
+
+// ErrorCode returns the machine-readable code of the error.
+func (e Forbidden) ErrorCode() string {
+	return codeOrDefault(e.Code, CodeForbidden)
+}
+
+// Status returns the HTTP status of the error.
+func (e Forbidden) Status() int {
+	return status(e.StatusCode, http.StatusForbidden)
+}
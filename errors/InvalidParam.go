@@ -8,17 +8,25 @@ import (
 
 type InvalidParam struct {
 	Param      string    `json:"-"`
-	Msg        string    `json:"msg"`
+	Code       string    `json:"-"` // Overrides the default code of the error.
+	Msg        string    `json:"-"`
 	StatusCode int       `json:"-" default:"400"`
-	TimeStamp  time.Time `json:"timestamp"`
+	TimeStamp  time.Time `json:"-"`
 }
 
 func NewInvalidParam(err error) InvalidParam {
-	return InvalidParam{
+	e := InvalidParam{
 		Msg:        err.Error(),
 		StatusCode: http.StatusBadRequest,
 		TimeStamp:  time.Now().UTC(),
 	}
+
+	// Parameters of a resource, like the fields of a receipt, keep their resource specific code.
+	if param, ok := err.(InvalidParam); ok {
+		e.Code = param.Code
+	}
+
+	return e
 }
 func (e InvalidParam) Error() string {
 	if e.Msg != "" {
@@ -27,3 +35,13 @@ func (e InvalidParam) Error() string {
 
 	return fmt.Sprintf("Incorrect value for parameter: " + e.Param)
 }
+
+// ErrorCode returns the machine-readable code of the error.
+func (e InvalidParam) ErrorCode() string {
+	return codeOrDefault(e.Code, CodeInvalidField)
+}
+
+// Status returns the HTTP status of the error.
+func (e InvalidParam) Status() int {
+	return status(e.StatusCode, http.StatusBadRequest)
+}
@@ -8,17 +8,25 @@ import (
 
 type MissingParam struct {
 	Param      string    `json:"-"`
-	Msg        string    `json:"msg"`
+	Code       string    `json:"-"` // Overrides the default code of the error.
+	Msg        string    `json:"-"`
 	StatusCode int       `json:"-" default:"400"`
-	TimeStamp  time.Time `json:"timestamp"`
+	TimeStamp  time.Time `json:"-"`
 }
 
 func NewMissingParam(err error) MissingParam {
-	return MissingParam{
+	e := MissingParam{
 		Msg:        err.Error(),
 		StatusCode: http.StatusBadRequest,
 		TimeStamp:  time.Now().UTC(),
 	}
+
+	// Parameters of a resource, like the fields of a receipt, keep their resource specific code.
+	if param, ok := err.(MissingParam); ok {
+		e.Code = param.Code
+	}
+
+	return e
 }
 
 func (e MissingParam) Error() string {
@@ -28,3 +36,13 @@ func (e MissingParam) Error() string {
 
 	return fmt.Sprintf("Parameter " + e.Param + " is required for this request")
 }
+
+// ErrorCode returns the machine-readable code of the error.
+func (e MissingParam) ErrorCode() string {
+	return codeOrDefault(e.Code, CodeMissingField)
+}
+
+// Status returns the HTTP status of the error.
+func (e MissingParam) Status() int {
+	return status(e.StatusCode, http.StatusBadRequest)
+}
@@ -0,0 +1,42 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type TooManyRequests struct {
+	RetryAfter int       `json:"-"` // Seconds until the request may be retried.
+	Code       string    `json:"-"` // Overrides the default code of the error.
+	Msg        string    `json:"-"`
+	StatusCode int       `json:"-" default:"429"`
+	TimeStamp  time.Time `json:"-"`
+}
+
+func NewTooManyRequests(err error, retryAfter int) TooManyRequests {
+	return TooManyRequests{
+		RetryAfter: retryAfter,
+		Msg:        err.Error(),
+		StatusCode: http.StatusTooManyRequests,
+		TimeStamp:  time.Now().UTC(),
+	}
+}
+
+func (e TooManyRequests) Error() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+
+	return fmt.Sprintf("Too many requests, retry after %v seconds", e.RetryAfter)
+}
+
+// ErrorCode returns the machine-readable code of the error.
+func (e TooManyRequests) ErrorCode() string {
+	return codeOrDefault(e.Code, CodeRateLimited)
+}
+
+// Status returns the HTTP status of the error.
+func (e TooManyRequests) Status() int {
+	return status(e.StatusCode, http.StatusTooManyRequests)
+}
@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Unauthorized struct {
+	Reason     string    `json:"-"`
+	Code       string    `json:"-"` // Overrides the default code of the error.
+	Msg        string    `json:"-"`
+	StatusCode int       `json:"-" default:"401"`
+	TimeStamp  time.Time `json:"-"`
+}
+
+func NewUnauthorized(err error) Unauthorized {
+	return Unauthorized{
+		Msg:        err.Error(),
+		StatusCode: http.StatusUnauthorized,
+		TimeStamp:  time.Now().UTC(),
+	}
+}
+
+func (e Unauthorized) Error() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+
+	return fmt.Sprintf("Authentication required: %v", e.Reason)
+}
+
+// ErrorCode returns the machine-readable code of the error.
+func (e Unauthorized) ErrorCode() string {
+	return codeOrDefault(e.Code, CodeUnauthenticated)
+}
+
+// Status returns the HTTP status of the error.
+func (e Unauthorized) Status() int {
+	return status(e.StatusCode, http.StatusUnauthorized)
+}
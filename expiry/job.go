@@ -0,0 +1,64 @@
+package expiry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/metrics"
+)
+
+// Job periodically writes expiry entries to the points ledger for credits that expired before they were spent.
+type Job struct {
+	logger   *log.CustomLogger
+	ledger   data.Ledger
+	interval time.Duration
+	now      func() time.Time
+}
+
+// New creates and returns a Job expiring points of the ledger every interval.
+func New(l *log.CustomLogger, ledger data.Ledger, interval time.Duration) *Job {
+	return &Job{
+		logger:   l,
+		ledger:   ledger,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Run expires points once immediately and then every interval until ctx is done.
+func (j *Job) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce expires the points of every credit expired by now and returns the number of points expired.
+func (j *Job) RunOnce() int {
+	expired := j.ledger.Expire(j.now().UTC())
+
+	points := 0
+	for _, e := range expired {
+		points -= e.Points
+	}
+
+	if len(expired) > 0 {
+		metrics.PointsExpired.WithLabelValues().Add(float64(points))
+
+		lm := log.Message{Level: "INFO", Msg: fmt.Sprintf("Expired %v points of %v credits", points, len(expired))}
+		j.logger.Log(&lm)
+	}
+
+	return points
+}
@@ -0,0 +1,36 @@
+package expiry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/metrics"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestJobRunOnce(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	ledger := data.NewLedger(logger)
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	expired, valid := now.Add(-time.Hour), now.Add(time.Hour)
+	ledger.Append(&model.LedgerEntry{ID: "1", UserID: "user-1", Type: model.LedgerCredit, Points: 30, ExpiresAt: &expired})
+	ledger.Append(&model.LedgerEntry{ID: "2", UserID: "user-1", Type: model.LedgerCredit, Points: 50, ExpiresAt: &valid})
+	ledger.Append(&model.LedgerEntry{ID: "3", UserID: "user-2", Type: model.LedgerCredit, Points: 5, ExpiresAt: &expired})
+
+	job := New(logger, ledger, time.Hour)
+	job.now = func() time.Time { return now }
+
+	counter := metrics.PointsExpired.WithLabelValues()
+	before := counter.Value()
+
+	assert.Equal(t, 35, job.RunOnce())
+	assert.Equal(t, 0, job.RunOnce())
+	assert.Equal(t, before+35, counter.Value())
+
+	balance, _ := ledger.Balance("user-1")
+	assert.Equal(t, 50, balance)
+}
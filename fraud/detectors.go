@@ -0,0 +1,108 @@
+package fraud
+
+import (
+	"fmt"
+	"strings"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// Default returns the built-in detectors, any two of them finding their pattern reach a risk score of 80.
+func Default() []Detector {
+	return []Detector{
+		RuleFarming{Rule: "round_dollar_total", MinReceipts: 5, Ratio: 0.6, Score: 40},
+		RuleFarming{Rule: "afternoon_purchase_time", MinReceipts: 5, Ratio: 0.6, Score: 40},
+		PaddedDescriptions{MinItems: 10, Ratio: 0.9, Score: 40},
+	}
+}
+
+// RuleFarming detects submitters farming a scoring rule: the receipt earned points from Rule and so did at least
+// Ratio of the latest receipts of the submitter, counting the receipt, once there are at least MinReceipts of them.
+// It uses the breakdown of the receipts, e.g. round_dollar_total finds totals ending in .00 far more often than chance.
+type RuleFarming struct {
+	Rule        string
+	MinReceipts int
+	Ratio       float64
+	Score       int
+}
+
+// Name identifies the detector by the rule it watches.
+func (d RuleFarming) Name() string {
+	return d.Rule + "_farming"
+}
+
+// Detect returns Score when the submitter farms the rule, 0 otherwise.
+func (d RuleFarming) Detect(receipt *model.Receipt, recent []model.Receipt) (int, string) {
+	if !earned(receipt, d.Rule) || len(recent)+1 < d.MinReceipts {
+		return 0, ""
+	}
+
+	hits := 1
+	for i := range recent {
+		if earned(&recent[i], d.Rule) {
+			hits++
+		}
+	}
+
+	if float64(hits) < d.Ratio*float64(len(recent)+1) {
+		return 0, ""
+	}
+
+	return d.Score, fmt.Sprintf("%v of the last %v receipts earned points from %v", hits, len(recent)+1, d.Rule)
+}
+
+// PaddedDescriptions detects item descriptions padded to a trimmed length multiple of 3, which earns points from the
+// item_description_length rule: at least Ratio of the items of the latest receipts of the submitter, counting the receipt,
+// have such descriptions, once there are at least MinItems of them. About a third of genuine descriptions do.
+type PaddedDescriptions struct {
+	MinItems int
+	Ratio    float64
+	Score    int
+}
+
+// Name identifies the detector.
+func (d PaddedDescriptions) Name() string {
+	return "padded_descriptions"
+}
+
+// Detect returns Score when the descriptions of the submitter look padded, 0 otherwise.
+func (d PaddedDescriptions) Detect(receipt *model.Receipt, recent []model.Receipt) (int, string) {
+	padded, items := countPadded(receipt)
+	if padded == 0 {
+		return 0, ""
+	}
+
+	for i := range recent {
+		p, n := countPadded(&recent[i])
+		padded, items = padded+p, items+n
+	}
+
+	if items < d.MinItems || float64(padded) < d.Ratio*float64(items) {
+		return 0, ""
+	}
+
+	return d.Score, fmt.Sprintf("%v of the last %v item descriptions have a trimmed length multiple of 3", padded, items)
+}
+
+// earned reports whether the rule awarded points to the receipt.
+func earned(receipt *model.Receipt, rule string) bool {
+	for _, rp := range receipt.Breakdown {
+		if rp.Rule == rule {
+			return true
+		}
+	}
+
+	return false
+}
+
+// countPadded returns the number of items of the receipt with a trimmed description length multiple of 3, and the number of items.
+func countPadded(receipt *model.Receipt) (int, int) {
+	padded := 0
+	for _, item := range receipt.Items {
+		if item.ShortDescription != nil && len(strings.Trim(*item.ShortDescription, " "))%3 == 0 {
+			padded++
+		}
+	}
+
+	return padded, len(receipt.Items)
+}
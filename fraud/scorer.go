@@ -0,0 +1,51 @@
+package fraud
+
+import (
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/metrics"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// Detector finds a fraud pattern in a receipt, given the latest receipts of the same submitter oldest first.
+type Detector interface {
+	// Name identifies the detector in the signals of risk assessments.
+	Name() string
+	// Detect returns the risk score of the receipt along with the reason for it, the score is 0 when the pattern is not found.
+	Detect(receipt *model.Receipt, recent []model.Receipt) (int, string)
+}
+
+// Scorer assesses the fraud risk of receipts with a set of detectors.
+type Scorer struct {
+	logger    *log.CustomLogger
+	detectors []Detector
+}
+
+// New creates and returns a Scorer running the given detectors, Default returns the built-in ones.
+func New(l *log.CustomLogger, detectors ...Detector) *Scorer {
+	return &Scorer{
+		logger:    l,
+		detectors: detectors,
+	}
+}
+
+// Score runs every detector on the receipt and returns its risk assessment.
+// The risk score is the sum of the scores of the detectors capped at 100.
+func (s *Scorer) Score(receipt *model.Receipt, recent []model.Receipt) model.RiskAssessment {
+	var risk model.RiskAssessment
+
+	for _, d := range s.detectors {
+		score, reason := d.Detect(receipt, recent)
+		if score <= 0 {
+			continue
+		}
+
+		risk.Score += score
+		risk.Signals = append(risk.Signals, model.RiskSignal{Detector: d.Name(), Score: score, Reason: reason})
+
+		metrics.FraudSignals.WithLabelValues(d.Name()).Inc()
+	}
+
+	risk.Score = min(risk.Score, 100)
+
+	return risk
+}
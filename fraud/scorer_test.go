@@ -0,0 +1,111 @@
+package fraud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// scored returns a receipt with the given items, scored with the scoring rules.
+func scored(total, purchaseTime string, descriptions ...string) model.Receipt {
+	receipt := model.Receipt{
+		Retailer:     model.StringPointer("Target"),
+		PurchaseDate: model.StringPointer("2024-03-02"),
+		PurchaseTime: model.StringPointer(purchaseTime),
+		Total:        model.StringPointer(total),
+	}
+
+	for _, d := range descriptions {
+		receipt.Items = append(receipt.Items, model.Item{ShortDescription: model.StringPointer(d), Price: model.StringPointer("1.00")})
+	}
+
+	_ = receipt.CalculateTotalReceiptPoints()
+
+	return receipt
+}
+
+// repeat returns n copies of the receipt.
+func repeat(receipt model.Receipt, n int) []model.Receipt {
+	receipts := make([]model.Receipt, n)
+	for i := range receipts {
+		receipts[i] = receipt
+	}
+
+	return receipts
+}
+
+func TestScorer(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	scorer := New(logger, Default()...)
+
+	genuine := scored("12.37", "09:12", "Pepsi")
+	roundTotal := scored("20.00", "09:12", "Pepsi")
+	farmer := scored("20.00", "14:30", "Doritos", "Pepsi")
+	padded := scored("12.37", "09:12", "Gatorade Cool Blue", "Mountain Dew", "Cola Zero")
+
+	testCases := []struct {
+		id              int
+		useCase         string
+		receipt         model.Receipt
+		recent          []model.Receipt
+		expectedScore   int
+		expectedSignals []string
+	}{
+		{
+			id: 1, useCase: "Positive case: genuine history",
+			receipt: roundTotal, recent: repeat(genuine, 10),
+		},
+		{
+			id: 2, useCase: "Positive case: round totals without enough history",
+			receipt: roundTotal, recent: repeat(roundTotal, 3),
+		},
+		{
+			id: 3, useCase: "Positive case: round totals farmed",
+			receipt: roundTotal, recent: append(repeat(genuine, 1), repeat(roundTotal, 4)...),
+			expectedScore: 40, expectedSignals: []string{"round_dollar_total_farming"},
+		},
+		{
+			id: 4, useCase: "Negative case: receipt not exploiting the farmed rule",
+			receipt: genuine, recent: repeat(roundTotal, 10),
+		},
+		{
+			id: 5, useCase: "Positive case: round totals and afternoon times farmed",
+			receipt: farmer, recent: repeat(farmer, 5),
+			expectedScore: 80, expectedSignals: []string{"round_dollar_total_farming", "afternoon_purchase_time_farming"},
+		},
+		{
+			id: 6, useCase: "Positive case: padded descriptions",
+			receipt: padded, recent: repeat(padded, 3),
+			expectedScore: 40, expectedSignals: []string{"padded_descriptions"},
+		},
+		{
+			id: 7, useCase: "Positive case: score capped at 100",
+			receipt: scored("20.00", "14:30", "Gatorade Cool Blue", "Mountain Dew"), recent: repeat(scored("20.00", "14:30", "Gatorade Cool Blue", "Mountain Dew"), 5),
+			expectedScore: 100, expectedSignals: []string{"round_dollar_total_farming", "afternoon_purchase_time_farming", "padded_descriptions"},
+		},
+	}
+
+	for _, tc := range testCases {
+		risk := scorer.Score(&tc.receipt, tc.recent)
+
+		var signals []string
+		for _, s := range risk.Signals {
+			signals = append(signals, s.Detector)
+		}
+
+		assert.Equal(t, tc.expectedScore, risk.Score, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedSignals, signals, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestRuleFarming_Reason(t *testing.T) {
+	detector := RuleFarming{Rule: "round_dollar_total", MinReceipts: 3, Ratio: 0.5, Score: 25}
+	roundTotal := scored("20.00", "09:12", "Pepsi")
+
+	score, reason := detector.Detect(&roundTotal, []model.Receipt{scored("12.37", "09:12", "Pepsi"), roundTotal})
+	assert.Equal(t, 25, score)
+	assert.Equal(t, "2 of the last 3 receipts earned points from round_dollar_total", reason)
+}
@@ -0,0 +1,49 @@
+package graphql
+
+import (
+	"net/http"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+)
+
+// Codes of the errors of GraphQL responses, set in the extensions of each error.
+const (
+	CodeParseFailed      = "GRAPHQL_PARSE_FAILED"
+	CodeValidationFailed = "GRAPHQL_VALIDATION_FAILED"
+	CodeQueryTooComplex  = "QUERY_TOO_COMPLEX"
+	CodeBadUserInput     = "BAD_USER_INPUT"
+	CodeNotFound         = "NOT_FOUND"
+	CodeUnauthenticated  = "UNAUTHENTICATED"
+	CodeForbidden        = "FORBIDDEN"
+	CodeConflict         = "CONFLICT"
+	CodeTooManyRequests  = "TOO_MANY_REQUESTS"
+	CodeUnavailable      = "UNAVAILABLE"
+	CodeInternal         = "INTERNAL_SERVER_ERROR"
+)
+
+// errorCode returns the code of an error returned by a resolver, mirroring the status codes of the REST API.
+func errorCode(err error) string {
+	switch val := err.(type) {
+	case errors.MissingParam, errors.InvalidParam:
+		return CodeBadUserInput
+	case errors.EntityNotFound:
+		return CodeNotFound
+	case errors.Unauthorized:
+		return CodeUnauthenticated
+	case errors.Forbidden:
+		return CodeForbidden
+	case errors.Conflict:
+		return CodeConflict
+	case errors.TooManyRequests:
+		return CodeTooManyRequests
+	case errors.CustomError:
+		switch {
+		case val.StatusCode == http.StatusServiceUnavailable:
+			return CodeUnavailable
+		case val.StatusCode >= 400 && val.StatusCode < 500:
+			return CodeBadUserInput
+		}
+	}
+
+	return CodeInternal
+}
@@ -0,0 +1,591 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	er "errors"
+	"fmt"
+	"reflect"
+)
+
+// Request is a GraphQL request, OperationName selects the operation to execute when the query holds several.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Options bound the execution of requests, a limit of 0 is no limit.
+type Options struct {
+	MaxDepth      int  // Maximum depth of the nested selection sets of an operation.
+	MaxComplexity int  // Maximum cost of an operation, the sum of the complexities of its fields.
+	MaxFragments  int  // Maximum number of fragments defined by a document.
+	QueryOnly     bool // Mutations are rejected, set for requests that must not change the state of the server.
+}
+
+// Error is an error of a GraphQL response, Path is the path of the field it occurred at.
+type Error struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// Result is the response to a GraphQL request.
+// Data is nil when the request failed before execution, or when the null of a non-nullable field propagated to the root.
+type Result struct {
+	Data     *OrderedMap
+	Errors   []*Error
+	executed bool // Execution started, the response then holds data even when it is null.
+}
+
+// MarshalJSON encodes the result, data is null when execution started but a null propagated to the root,
+// and absent when the request failed before execution.
+func (r *Result) MarshalJSON() ([]byte, error) {
+	resp := struct {
+		Data   *json.RawMessage `json:"data,omitempty"`
+		Errors []*Error         `json:"errors,omitempty"`
+	}{Errors: r.Errors}
+
+	if r.executed {
+		data, err := json.Marshal(r.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		raw := json.RawMessage(data)
+		resp.Data = &raw
+	}
+
+	return json.Marshal(resp)
+}
+
+// OrderedMap is a JSON object whose keys are encoded in the order of the fields of the selection set.
+// A nil OrderedMap is encoded as null.
+type OrderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedMap() *OrderedMap {
+	return &OrderedMap{values: make(map[string]interface{})}
+}
+
+// Get returns the value of key.
+func (m *OrderedMap) Get(key string) interface{} {
+	return m.values[key]
+}
+
+func (m *OrderedMap) set(key string, v interface{}) {
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+
+	m.values[key] = v
+}
+
+// MarshalJSON encodes the map as a JSON object with its keys in order.
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		k, _ := json.Marshal(key)
+		buf.Write(k)
+		buf.WriteByte(':')
+
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(v)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// Execute parses, validates and executes a request.
+// Errors of the request are returned without data, errors of fields are returned along the data, with null for the fields that failed.
+func (s *Schema) Execute(ctx context.Context, req Request, opts Options) *Result {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return requestError(err, CodeParseFailed)
+	}
+
+	if opts.MaxFragments > 0 && len(doc.fragments) > opts.MaxFragments {
+		return requestError(fmt.Errorf("Query defines %v fragments, more than the maximum of %v", len(doc.fragments), opts.MaxFragments), CodeQueryTooComplex)
+	}
+
+	op, err := doc.operation(req.OperationName)
+	if err != nil {
+		return requestError(err, CodeValidationFailed)
+	}
+
+	root := s.Query
+	if op.kind == operationMutation {
+		if s.Mutation == nil {
+			return requestError(fmt.Errorf("Schema is not configured for mutations"), CodeValidationFailed)
+		}
+
+		if opts.QueryOnly {
+			return requestError(fmt.Errorf("Mutations are only accepted in POST requests"), CodeValidationFailed)
+		}
+
+		root = s.Mutation
+	}
+
+	vars, err := s.coerceVariables(op, req.Variables)
+	if err != nil {
+		return requestError(err, CodeValidationFailed)
+	}
+
+	v := &validator{doc: doc, vars: vars, declared: make(map[string]bool), costs: make(map[string]cost), maxComplexity: opts.MaxComplexity}
+	for _, def := range op.variables {
+		v.declared[def.name] = true
+	}
+
+	// The validation stops as soon as the complexity exceeds the maximum.
+	_, depth, err := v.selectionSet(root, op.selectionSet, 1)
+	if er.As(err, &tooComplex{}) {
+		return requestError(err, CodeQueryTooComplex)
+	}
+
+	if err != nil {
+		return requestError(err, CodeValidationFailed)
+	}
+
+	if opts.MaxDepth > 0 && depth > opts.MaxDepth {
+		return requestError(fmt.Errorf("Query depth %v exceeds the maximum depth of %v", depth, opts.MaxDepth), CodeQueryTooComplex)
+	}
+
+	e := &executor{ctx: ctx, doc: doc, vars: vars}
+
+	data, _ := e.selectionSet(root, nil, op.selectionSet, []interface{}{})
+
+	return &Result{Data: data, Errors: e.errors, executed: true}
+}
+
+// operation returns the operation of the document named name, the only operation of the document when name is empty.
+func (doc *document) operation(name string) (*operation, error) {
+	if name == "" {
+		if len(doc.operations) > 1 {
+			return nil, fmt.Errorf("Must provide operation name if query contains multiple operations")
+		}
+
+		return doc.operations[0], nil
+	}
+
+	for _, op := range doc.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Unknown operation named %q", name)
+}
+
+func requestError(err error, code string) *Result {
+	return &Result{Errors: []*Error{{Message: err.Error(), Extensions: map[string]interface{}{"code": code}}}}
+}
+
+// validator checks the selection sets of an operation against the schema and computes their complexity and depth.
+type validator struct {
+	doc       *document
+	vars      map[string]interface{}
+	declared  map[string]bool // Variables declared by the operation.
+	fragments []string        // Fragments being spread, to detect cycles.
+	costs     map[string]cost // Costs of the fragments already validated, so that fragments spread many times are walked once.
+
+	maxComplexity int // Complexity the validation stops at, 0 for no limit.
+}
+
+// cost is the complexity of a fragment and the depth of its deepest field relative to the spread.
+type cost struct {
+	complexity int
+	depth      int
+}
+
+// tooComplex stops the validation once the complexity of the selections validated so far exceeds the maximum.
+type tooComplex struct {
+	complexity, max int
+}
+
+func (e tooComplex) Error() string {
+	return fmt.Sprintf("Query complexity of at least %v exceeds the maximum complexity of %v", e.complexity, e.max)
+}
+
+// selectionSet validates the selections of an object at depth, it returns their complexity and the depth of their deepest field.
+func (v *validator) selectionSet(obj *Object, selections []selection, depth int) (int, int, error) {
+	complexity, maxDepth := 0, depth
+
+	for _, sel := range selections {
+		var (
+			c, d int
+			err  error
+		)
+
+		switch sel := sel.(type) {
+		case *field:
+			c, d, err = v.field(obj, sel, depth)
+		case *inlineFragment:
+			if err = v.directives(sel.directives); err != nil {
+				return 0, 0, err
+			}
+
+			if err = v.typeCondition(obj, sel.typeCondition); err != nil {
+				return 0, 0, err
+			}
+
+			c, d, err = v.selectionSet(obj, sel.selectionSet, depth)
+		case *fragmentSpread:
+			c, d, err = v.fragmentSpread(obj, sel, depth)
+		}
+
+		if err != nil {
+			return 0, 0, err
+		}
+
+		complexity += c
+		maxDepth = max(maxDepth, d)
+
+		if v.maxComplexity > 0 && complexity > v.maxComplexity {
+			return 0, 0, tooComplex{complexity: complexity, max: v.maxComplexity}
+		}
+	}
+
+	return complexity, maxDepth, nil
+}
+
+func (v *validator) field(obj *Object, f *field, depth int) (int, int, error) {
+	if err := v.directives(f.directives); err != nil {
+		return 0, 0, err
+	}
+
+	if f.name == "__typename" {
+		if len(f.arguments) > 0 || f.selectionSet != nil {
+			return 0, 0, fmt.Errorf("Field \"__typename\" takes no arguments and no selections")
+		}
+
+		return 0, depth, nil
+	}
+
+	def, exists := obj.Fields[f.name]
+	if !exists {
+		return 0, 0, fmt.Errorf("Cannot query field %q on type %q", f.name, obj)
+	}
+
+	for _, arg := range f.arguments {
+		if err := v.variables(arg.value); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	args, err := coerceArgs(def.Args, f.arguments, v.vars)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Field %q: %v", f.name, err)
+	}
+
+	childComplexity, childDepth := 0, depth
+	switch t := namedType(def.Type).(type) {
+	case *Object:
+		if f.selectionSet == nil {
+			return 0, 0, fmt.Errorf("Field %q of type %q must have a selection of subfields", f.name, def.Type)
+		}
+
+		if childComplexity, childDepth, err = v.selectionSet(t, f.selectionSet, depth+1); err != nil {
+			return 0, 0, err
+		}
+	default:
+		if f.selectionSet != nil {
+			return 0, 0, fmt.Errorf("Field %q must not have a selection since type %q has no subfields", f.name, def.Type)
+		}
+	}
+
+	if def.Complexity != nil {
+		return def.Complexity(args, childComplexity), childDepth, nil
+	}
+
+	return 1 + childComplexity, childDepth, nil
+}
+
+func (v *validator) fragmentSpread(obj *Object, spread *fragmentSpread, depth int) (int, int, error) {
+	if err := v.directives(spread.directives); err != nil {
+		return 0, 0, err
+	}
+
+	f, exists := v.doc.fragments[spread.name]
+	if !exists {
+		return 0, 0, fmt.Errorf("Unknown fragment %q", spread.name)
+	}
+
+	for _, name := range v.fragments {
+		if name == spread.name {
+			return 0, 0, fmt.Errorf("Cannot spread fragment %q within itself", spread.name)
+		}
+	}
+
+	if err := v.typeCondition(obj, f.typeCondition); err != nil {
+		return 0, 0, err
+	}
+
+	if c, ok := v.costs[spread.name]; ok {
+		return c.complexity, depth + c.depth, nil
+	}
+
+	v.fragments = append(v.fragments, spread.name)
+	defer func() { v.fragments = v.fragments[:len(v.fragments)-1] }()
+
+	complexity, d, err := v.selectionSet(obj, f.selectionSet, depth)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	v.costs[spread.name] = cost{complexity: complexity, depth: d - depth}
+
+	return complexity, d, nil
+}
+
+// typeCondition checks that a fragment applies to obj, the schema has no interfaces nor unions.
+func (v *validator) typeCondition(obj *Object, condition string) error {
+	if condition != "" && condition != obj.Name {
+		return fmt.Errorf("Fragment on %q cannot be spread on type %q", condition, obj)
+	}
+
+	return nil
+}
+
+// directives checks that only the @include and @skip directives are used, with a valid if argument.
+func (v *validator) directives(directives []*directive) error {
+	for _, d := range directives {
+		if d.name != "include" && d.name != "skip" {
+			return fmt.Errorf("Unknown directive \"@%v\"", d.name)
+		}
+
+		for _, arg := range d.arguments {
+			if err := v.variables(arg.value); err != nil {
+				return err
+			}
+		}
+
+		if _, err := coerceArgs(Args{"if": {Type: &NonNull{OfType: Boolean}}}, d.arguments, v.vars); err != nil {
+			return fmt.Errorf("Directive \"@%v\": %v", d.name, err)
+		}
+	}
+
+	return nil
+}
+
+// variables checks that the variables a value refers to are declared by the operation.
+func (v *validator) variables(val *value) error {
+	switch val.kind {
+	case valueVariable:
+		if !v.declared[val.raw] {
+			return fmt.Errorf("Variable \"$%v\" is not defined", val.raw)
+		}
+	case valueList:
+		for _, item := range val.list {
+			if err := v.variables(item); err != nil {
+				return err
+			}
+		}
+	case valueObject:
+		for _, f := range val.fields {
+			if err := v.variables(f.value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// executor executes a validated operation, collecting the errors of the fields.
+type executor struct {
+	ctx    context.Context
+	doc    *document
+	vars   map[string]interface{}
+	errors []*Error
+}
+
+// selectionSet executes the selections of an object with the value source.
+// It reports false when a non-nullable field is null, the object is then null as well.
+func (e *executor) selectionSet(obj *Object, source interface{}, selections []selection, path []interface{}) (*OrderedMap, bool) {
+	keys, fields := e.collectFields(obj, selections)
+
+	result := newOrderedMap()
+	for _, key := range keys {
+		v, ok := e.field(obj, source, fields[key], append(path[:len(path):len(path)], key))
+		if !ok {
+			return nil, false
+		}
+
+		result.set(key, v)
+	}
+
+	return result, true
+}
+
+// collectFields groups the fields selected on an object by their response key, following fragments and directives.
+func (e *executor) collectFields(obj *Object, selections []selection) ([]string, map[string][]*field) {
+	var keys []string
+	fields := make(map[string][]*field)
+	e.collect(obj, selections, &keys, fields, make(map[string]bool))
+
+	return keys, fields
+}
+
+func (e *executor) collect(obj *Object, selections []selection, keys *[]string, fields map[string][]*field, visited map[string]bool) {
+	for _, sel := range selections {
+		switch sel := sel.(type) {
+		case *field:
+			if !e.included(sel.directives) {
+				continue
+			}
+
+			key := sel.responseKey()
+			if _, exists := fields[key]; !exists {
+				*keys = append(*keys, key)
+			}
+
+			fields[key] = append(fields[key], sel)
+		case *inlineFragment:
+			if e.included(sel.directives) {
+				e.collect(obj, sel.selectionSet, keys, fields, visited)
+			}
+		case *fragmentSpread:
+			if visited[sel.name] || !e.included(sel.directives) {
+				continue
+			}
+
+			visited[sel.name] = true
+			e.collect(obj, e.doc.fragments[sel.name].selectionSet, keys, fields, visited)
+		}
+	}
+}
+
+// included evaluates the @include and @skip directives of a selection.
+func (e *executor) included(directives []*directive) bool {
+	for _, d := range directives {
+		args, _ := coerceArgs(Args{"if": {Type: &NonNull{OfType: Boolean}}}, d.arguments, e.vars)
+		if cond, _ := args["if"].(bool); cond == (d.name == "skip") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// field resolves and completes a field, fields sharing a response key are merged.
+func (e *executor) field(obj *Object, source interface{}, fields []*field, path []interface{}) (interface{}, bool) {
+	f := fields[0]
+	if f.name == "__typename" {
+		return obj.Name, true
+	}
+
+	def := obj.Fields[f.name]
+	_, nonNull := def.Type.(*NonNull)
+
+	args, _ := coerceArgs(def.Args, f.arguments, e.vars)
+
+	resolved, err := def.Resolve(ResolveParams{Context: e.ctx, Source: source, Args: args})
+	if err != nil {
+		e.errors = append(e.errors, &Error{Message: err.Error(), Path: path, Extensions: map[string]interface{}{"code": errorCode(err)}})
+
+		return nil, !nonNull
+	}
+
+	var selections []selection
+	for _, f := range fields {
+		selections = append(selections, f.selectionSet...)
+	}
+
+	return e.complete(def.Type, resolved, selections, path)
+}
+
+// complete converts a resolved value to its type, it reports false when a non-nullable value is null.
+// A null in a nullable position stops the propagation.
+func (e *executor) complete(t Type, resolved interface{}, selections []selection, path []interface{}) (interface{}, bool) {
+	if nn, ok := t.(*NonNull); ok {
+		v, ok := e.completeNullable(nn.OfType, resolved, selections, path)
+		if !ok {
+			return nil, false
+		}
+
+		if v == nil {
+			e.errors = append(e.errors, &Error{Message: fmt.Sprintf("Cannot return null for non-nullable field of type %q", t), Path: path, Extensions: map[string]interface{}{"code": CodeInternal}})
+
+			return nil, false
+		}
+
+		return v, true
+	}
+
+	v, ok := e.completeNullable(t, resolved, selections, path)
+	if !ok {
+		return nil, true
+	}
+
+	return v, true
+}
+
+func (e *executor) completeNullable(t Type, resolved interface{}, selections []selection, path []interface{}) (interface{}, bool) {
+	if isNil(resolved) {
+		return nil, true
+	}
+
+	switch t := t.(type) {
+	case *List:
+		rv := reflect.ValueOf(resolved)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			e.errors = append(e.errors, &Error{Message: fmt.Sprintf("Expected a list for field of type %q", t), Path: path, Extensions: map[string]interface{}{"code": CodeInternal}})
+
+			return nil, true
+		}
+
+		items := make([]interface{}, rv.Len())
+		for i := range items {
+			item, ok := e.complete(t.OfType, rv.Index(i).Interface(), selections, append(path[:len(path):len(path)], i))
+			if !ok {
+				return nil, false
+			}
+
+			items[i] = item
+		}
+
+		return items, true
+	case *Object:
+		m, ok := e.selectionSet(t, resolved, selections, path)
+		if !ok {
+			return nil, false
+		}
+
+		return m, true
+	default:
+		return resolved, true
+	}
+}
+
+// isNil reports whether v is nil or a nil pointer or map, nil slices are empty lists.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Ptr, reflect.Map:
+		return rv.IsNil()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,286 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	er "errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+)
+
+// newTestSchema returns a schema of books with their authors, authors list their books so that queries may nest indefinitely.
+func newTestSchema(t *testing.T) *Schema {
+	type book struct {
+		title  string
+		author string
+	}
+
+	books := []book{{title: "Dune", author: "Herbert"}, {title: "Emma", author: "Austen"}, {title: "Persuasion", author: "Austen"}}
+
+	author := &Object{Name: "Author"}
+	bookType := &Object{Name: "Book", Fields: Fields{
+		"title": {Type: &NonNull{OfType: String}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(book).title, nil
+		}},
+		"author": {Type: &NonNull{OfType: author}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(book).author, nil
+		}},
+		"isbn": {Type: &NonNull{OfType: String}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return nil, nil
+		}},
+	}}
+
+	author.Fields = Fields{
+		"name": {Type: &NonNull{OfType: String}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(string), nil
+		}},
+		"books": {
+			Type: &NonNull{OfType: &List{OfType: &NonNull{OfType: bookType}}},
+			Args: Args{"first": {Type: Int, Default: 10}},
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				var result []book
+				for _, b := range books {
+					if b.author == p.Source.(string) && len(result) < p.Args["first"].(int) {
+						result = append(result, b)
+					}
+				}
+
+				return result, nil
+			},
+			Complexity: func(args map[string]interface{}, childComplexity int) int {
+				return 1 + args["first"].(int)*childComplexity
+			},
+		},
+	}
+
+	query := &Object{Name: "Query", Fields: Fields{
+		"book": {
+			Type: bookType,
+			Args: Args{"title": {Type: &NonNull{OfType: String}}},
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				for _, b := range books {
+					if b.title == p.Args["title"] {
+						return b, nil
+					}
+				}
+
+				return nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "books", ID: p.Args["title"].(string)})
+			},
+		},
+		"author": {
+			Type: author,
+			Args: Args{"name": {Type: &NonNull{OfType: String}}},
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return p.Args["name"], nil
+			},
+		},
+	}}
+
+	mutation := &Object{Name: "Mutation", Fields: Fields{
+		"addBook": {
+			Type: &NonNull{OfType: bookType},
+			Args: Args{"book": {Type: &NonNull{OfType: &InputObject{Name: "BookInput", Fields: Args{
+				"title":  {Type: &NonNull{OfType: String}},
+				"author": {Type: String, Default: "Anonymous"},
+			}}}}},
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				input := p.Args["book"].(map[string]interface{})
+				if input["title"] == "" {
+					return nil, er.New("title is empty")
+				}
+
+				return book{title: input["title"].(string), author: input["author"].(string)}, nil
+			},
+		},
+	}}
+
+	schema, err := NewSchema(query, mutation)
+	assert.NoError(t, err)
+
+	return schema
+}
+
+func TestSchemaExecute(t *testing.T) {
+	schema := newTestSchema(t)
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		request          Request
+		opts             Options
+		expectedResponse string
+	}{
+		{
+			id: 1, useCase: "Positive case: nested fields in the order of the selection set",
+			request:          Request{Query: `{ book(title: "Dune") { title author { name } } }`},
+			expectedResponse: `{"data":{"book":{"title":"Dune","author":{"name":"Herbert"}}}}`,
+		},
+		{
+			id: 2, useCase: "Positive case: aliases, variables and fragments",
+			request: Request{
+				Query:     `query Books($name: String!) { writer: author(name: $name) { ...AuthorBooks } } fragment AuthorBooks on Author { name books(first: 1) { title __typename } }`,
+				Variables: map[string]interface{}{"name": "Austen"},
+			},
+			expectedResponse: `{"data":{"writer":{"name":"Austen","books":[{"title":"Emma","__typename":"Book"}]}}}`,
+		},
+		{
+			id: 3, useCase: "Positive case: skip and include directives",
+			request: Request{
+				Query:     `query ($short: Boolean!) { author(name: "Austen") { name @include(if: $short) books @skip(if: $short) { title } } }`,
+				Variables: map[string]interface{}{"short": true},
+			},
+			expectedResponse: `{"data":{"author":{"name":"Austen"}}}`,
+		},
+		{
+			id: 4, useCase: "Positive case: operation selected by name",
+			request:          Request{Query: `query A { author(name: "A") { name } } query B { author(name: "B") { name } }`, OperationName: "B"},
+			expectedResponse: `{"data":{"author":{"name":"B"}}}`,
+		},
+		{
+			id: 5, useCase: "Positive case: mutation with the defaults of an input object",
+			request:          Request{Query: `mutation { addBook(book: {title: "Ulysses"}) { title author { name } } }`},
+			expectedResponse: `{"data":{"addBook":{"title":"Ulysses","author":{"name":"Anonymous"}}}}`,
+		},
+		{
+			id: 6, useCase: "Negative case: error of a nullable field",
+			request:          Request{Query: `{ book(title: "Ulysses") { title } dune: book(title: "Dune") { title } }`},
+			expectedResponse: `{"data":{"book":null,"dune":{"title":"Dune"}},"errors":[{"message":"No 'books' found for Id: 'Ulysses'","path":["book"],"extensions":{"code":"NOT_FOUND"}}]}`,
+		},
+		{
+			id: 7, useCase: "Negative case: null of a non-nullable field propagates to the nearest nullable field",
+			request:          Request{Query: `{ book(title: "Dune") { title isbn } }`},
+			expectedResponse: `{"data":{"book":null},"errors":[{"message":"Cannot return null for non-nullable field of type \"String!\"","path":["book","isbn"],"extensions":{"code":"INTERNAL_SERVER_ERROR"}}]}`,
+		},
+		{
+			id: 8, useCase: "Negative case: error of a non-nullable root field nulls the data",
+			request:          Request{Query: `mutation { addBook(book: {title: ""}) { title } }`},
+			expectedResponse: `{"data":null,"errors":[{"message":"title is empty","path":["addBook"],"extensions":{"code":"INTERNAL_SERVER_ERROR"}}]}`,
+		},
+		{
+			id: 9, useCase: "Negative case: syntax error",
+			request:          Request{Query: `{ book(title: "Dune") { title }`},
+			expectedResponse: `{"errors":[{"message":"Syntax Error: Unexpected end of document at line 1, column 32","extensions":{"code":"GRAPHQL_PARSE_FAILED"}}]}`,
+		},
+		{
+			id: 10, useCase: "Negative case: unknown field",
+			request:          Request{Query: `{ book(title: "Dune") { pages } }`},
+			expectedResponse: `{"errors":[{"message":"Cannot query field \"pages\" on type \"Book\"","extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}}]}`,
+		},
+		{
+			id: 11, useCase: "Negative case: missing required argument",
+			request:          Request{Query: `{ book { title } }`},
+			expectedResponse: `{"errors":[{"message":"Field \"book\": Argument \"title\" of required type \"String!\" was not provided","extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}}]}`,
+		},
+		{
+			id: 12, useCase: "Negative case: argument of the wrong type",
+			request:          Request{Query: `{ author(name: "Austen") { books(first: "two") { title } } }`},
+			expectedResponse: `{"errors":[{"message":"Field \"books\": Argument \"first\" has an invalid value: Expected type \"Int\", found \"two\"","extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}}]}`,
+		},
+		{
+			id: 13, useCase: "Negative case: object field without selection",
+			request:          Request{Query: `{ book(title: "Dune") }`},
+			expectedResponse: `{"errors":[{"message":"Field \"book\" of type \"Book\" must have a selection of subfields","extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}}]}`,
+		},
+		{
+			id: 14, useCase: "Negative case: required variable not provided",
+			request:          Request{Query: `query ($name: String!) { author(name: $name) { name } }`},
+			expectedResponse: `{"errors":[{"message":"Variable \"$name\" of required type \"String!\" was not provided","extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}}]}`,
+		},
+		{
+			id: 15, useCase: "Negative case: undeclared variable",
+			request:          Request{Query: `{ author(name: $name) { name } }`},
+			expectedResponse: `{"errors":[{"message":"Variable \"$name\" is not defined","extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}}]}`,
+		},
+		{
+			id: 16, useCase: "Negative case: fragment spread within itself",
+			request:          Request{Query: `{ author(name: "Austen") { ...A } } fragment A on Author { books { author { ...A } } }`},
+			expectedResponse: `{"errors":[{"message":"Cannot spread fragment \"A\" within itself","extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}}]}`,
+		},
+		{
+			id: 17, useCase: "Negative case: mutation in a query only request",
+			request:          Request{Query: `mutation { addBook(book: {title: "Ulysses"}) { title } }`},
+			opts:             Options{QueryOnly: true},
+			expectedResponse: `{"errors":[{"message":"Mutations are only accepted in POST requests","extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}}]}`,
+		},
+		{
+			id: 18, useCase: "Negative case: query deeper than the maximum depth",
+			request:          Request{Query: `{ author(name: "Austen") { books { author { books { title } } } } }`},
+			opts:             Options{MaxDepth: 3},
+			expectedResponse: `{"errors":[{"message":"Query depth 5 exceeds the maximum depth of 3","extensions":{"code":"QUERY_TOO_COMPLEX"}}]}`,
+		},
+		{
+			id: 19, useCase: "Negative case: query more complex than the maximum complexity",
+			request:          Request{Query: `{ author(name: "Austen") { books(first: 10) { author { books(first: 10) { title } } } } }`},
+			opts:             Options{MaxComplexity: 100},
+			expectedResponse: `{"errors":[{"message":"Query complexity of at least 121 exceeds the maximum complexity of 100","extensions":{"code":"QUERY_TOO_COMPLEX"}}]}`,
+		},
+		{
+			id: 20, useCase: "Positive case: query within the limits",
+			request:          Request{Query: `{ author(name: "Austen") { books(first: 2) { title } } }`},
+			opts:             Options{MaxDepth: 3, MaxComplexity: 4},
+			expectedResponse: `{"data":{"author":{"books":[{"title":"Emma"},{"title":"Persuasion"}]}}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		resp, err := json.Marshal(schema.Execute(context.Background(), tc.request, tc.opts))
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.JSONEq(t, tc.expectedResponse, string(resp), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+// TestSchemaExecute_NestedFragments checks that fragments spread many times are validated once, a document of chained
+// fragments each spreading the next twice has a complexity exponential in its length.
+func TestSchemaExecute_NestedFragments(t *testing.T) {
+	schema := newTestSchema(t)
+
+	var query strings.Builder
+	query.WriteString(`{ author(name: "Austen") { ...F0 } }`)
+	for i := 0; i < 40; i++ {
+		fmt.Fprintf(&query, " fragment F%v on Author { ...F%v ...F%v }", i, i+1, i+1)
+	}
+
+	query.WriteString(" fragment F40 on Author { name }")
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		opts             Options
+		expectedResponse string
+	}{
+		{
+			id: 1, useCase: "Negative case: complexity of the fragments exceeds the maximum",
+			opts:             Options{MaxComplexity: 1000},
+			expectedResponse: `{"errors":[{"message":"Query complexity of at least 1024 exceeds the maximum complexity of 1000","extensions":{"code":"QUERY_TOO_COMPLEX"}}]}`,
+		},
+		{
+			id: 2, useCase: "Negative case: more fragments than the maximum",
+			opts:             Options{MaxFragments: 32},
+			expectedResponse: `{"errors":[{"message":"Query defines 41 fragments, more than the maximum of 32","extensions":{"code":"QUERY_TOO_COMPLEX"}}]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		start := time.Now()
+		resp, err := json.Marshal(schema.Execute(context.Background(), Request{Query: query.String()}, tc.opts))
+
+		assert.Less(t, time.Since(start), time.Second, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.JSONEq(t, tc.expectedResponse, string(resp), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestNewSchema_DuplicateType(t *testing.T) {
+	query := &Object{Name: "Query", Fields: Fields{
+		"a": {Type: &Object{Name: "Thing", Fields: Fields{}}},
+		"b": {Type: &Object{Name: "Thing", Fields: Fields{}}},
+	}}
+
+	_, err := NewSchema(query, nil)
+	assert.EqualError(t, err, "graphql: type Thing is defined twice")
+}
@@ -0,0 +1,222 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Kinds of lexical tokens of a GraphQL document.
+const (
+	tokenEOF = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+// token is a lexical token of a GraphQL document, value holds the unescaped value of strings.
+type token struct {
+	kind  int
+	value string
+	line  int
+	col   int
+}
+
+// lexer splits a GraphQL document into tokens, skipping whitespace, commas and comments.
+type lexer struct {
+	src  string
+	pos  int
+	line int
+	col  int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: strings.TrimPrefix(src, "\uFEFF"), line: 1, col: 1}
+}
+
+// next returns the next token of the document, a token of kind tokenEOF at its end.
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+
+	tok := token{line: l.line, col: l.col}
+	if l.pos >= len(l.src) {
+		tok.kind = tokenEOF
+		return tok, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.advance(3)
+		tok.kind, tok.value = tokenPunctuator, "..."
+	case strings.IndexByte("!$&()=:@[]{}|", c) >= 0:
+		l.advance(1)
+		tok.kind, tok.value = tokenPunctuator, string(c)
+	case c == '_' || isLetter(c):
+		start := l.pos
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.advance(1)
+		}
+		tok.kind, tok.value = tokenName, l.src[start:l.pos]
+	case c == '-' || isDigit(c):
+		return l.number(tok)
+	case c == '"':
+		return l.string(tok)
+	default:
+		return tok, l.errorf(tok, "Unexpected character %q", c)
+	}
+
+	return tok, nil
+}
+
+// number lexes an integer or a float.
+func (l *lexer) number(tok token) (token, error) {
+	start := l.pos
+	tok.kind = tokenInt
+
+	if l.src[l.pos] == '-' {
+		l.advance(1)
+	}
+
+	if !l.digits() {
+		return tok, l.errorf(tok, "Invalid number")
+	}
+
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		tok.kind = tokenFloat
+		l.advance(1)
+		if !l.digits() {
+			return tok, l.errorf(tok, "Invalid number")
+		}
+	}
+
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		tok.kind = tokenFloat
+		l.advance(1)
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.advance(1)
+		}
+		if !l.digits() {
+			return tok, l.errorf(tok, "Invalid number")
+		}
+	}
+
+	tok.value = l.src[start:l.pos]
+
+	return tok, nil
+}
+
+// digits consumes a sequence of digits, it reports whether there was at least one.
+func (l *lexer) digits() bool {
+	start := l.pos
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.advance(1)
+	}
+
+	return l.pos > start
+}
+
+// string lexes a quoted string or a block string.
+func (l *lexer) string(tok token) (token, error) {
+	tok.kind = tokenString
+
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		l.advance(3)
+		end := strings.Index(l.src[l.pos:], `"""`)
+		if end < 0 {
+			return tok, l.errorf(tok, "Unterminated string")
+		}
+
+		tok.value = strings.TrimSpace(l.src[l.pos : l.pos+end])
+		l.advance(end + 3)
+
+		return tok, nil
+	}
+
+	l.advance(1)
+
+	var sb strings.Builder
+	for {
+		if l.pos >= len(l.src) || l.src[l.pos] == '\n' {
+			return tok, l.errorf(tok, "Unterminated string")
+		}
+
+		c := l.src[l.pos]
+		switch {
+		case c == '"':
+			l.advance(1)
+			tok.value = sb.String()
+			return tok, nil
+		case c == '\\' && l.pos+1 < len(l.src):
+			escaped := l.src[l.pos+1]
+			if escaped == 'u' && l.pos+6 <= len(l.src) {
+				r, err := strconv.ParseUint(l.src[l.pos+2:l.pos+6], 16, 32)
+				if err != nil {
+					return tok, l.errorf(tok, "Invalid unicode escape")
+				}
+				sb.WriteRune(rune(r))
+				l.advance(6)
+				continue
+			}
+
+			unescaped, ok := map[byte]byte{'"': '"', '\\': '\\', '/': '/', 'b': '\b', 'f': '\f', 'n': '\n', 'r': '\r', 't': '\t'}[escaped]
+			if !ok {
+				return tok, l.errorf(tok, "Invalid escape sequence \\%c", escaped)
+			}
+			sb.WriteByte(unescaped)
+			l.advance(2)
+		default:
+			_, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			sb.WriteString(l.src[l.pos : l.pos+size])
+			l.advance(size)
+		}
+	}
+}
+
+// skipIgnored skips whitespace, line terminators, commas and comments.
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == ' ' || c == '\t' || c == ',' || c == '\r':
+			l.advance(1)
+		case c == '\n':
+			l.pos++
+			l.line++
+			l.col = 1
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// advance moves n bytes forward, keeping track of the line and column.
+func (l *lexer) advance(n int) {
+	for i := 0; i < n && l.pos < len(l.src); i++ {
+		if l.src[l.pos] == '\n' {
+			l.line++
+			l.col = 1
+		} else {
+			l.col++
+		}
+		l.pos++
+	}
+}
+
+func (l *lexer) errorf(tok token, format string, args ...interface{}) error {
+	return fmt.Errorf("Syntax Error: %v at line %v, column %v", fmt.Sprintf(format, args...), tok.line, tok.col)
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
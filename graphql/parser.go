@@ -0,0 +1,612 @@
+package graphql
+
+import (
+	"fmt"
+)
+
+// Kinds of operations.
+const (
+	operationQuery    = "query"
+	operationMutation = "mutation"
+)
+
+// document is a parsed executable GraphQL document.
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	kind         string
+	name         string
+	variables    []*variableDefinition
+	selectionSet []selection
+}
+
+type variableDefinition struct {
+	name         string
+	typ          *typeRef
+	defaultValue *value
+}
+
+// typeRef is a type written in a document, a named type when elem is nil, otherwise a list of elem.
+type typeRef struct {
+	name    string
+	elem    *typeRef
+	nonNull bool
+}
+
+type fragment struct {
+	name          string
+	typeCondition string
+	directives    []*directive
+	selectionSet  []selection
+}
+
+// selection is a field, a fragment spread or an inline fragment of a selection set.
+type selection interface{}
+
+type field struct {
+	alias        string
+	name         string
+	arguments    []*argument
+	directives   []*directive
+	selectionSet []selection
+}
+
+// responseKey is the key of the field in the response, its alias when it has one.
+func (f *field) responseKey() string {
+	if f.alias != "" {
+		return f.alias
+	}
+
+	return f.name
+}
+
+type fragmentSpread struct {
+	name       string
+	directives []*directive
+}
+
+type inlineFragment struct {
+	typeCondition string
+	directives    []*directive
+	selectionSet  []selection
+}
+
+type argument struct {
+	name  string
+	value *value
+}
+
+type directive struct {
+	name      string
+	arguments []*argument
+}
+
+// Kinds of values written in a document.
+const (
+	valueVariable = iota
+	valueInt
+	valueFloat
+	valueString
+	valueBoolean
+	valueNull
+	valueEnum
+	valueList
+	valueObject
+)
+
+// value is a value written in a document, raw holds the literal of scalars and the name of variables and enums.
+type value struct {
+	kind   int
+	raw    string
+	list   []*value
+	fields []*argument
+}
+
+// parser is a recursive descent parser of executable GraphQL documents.
+type parser struct {
+	lexer *lexer
+	tok   token
+}
+
+// parse parses an executable GraphQL document, type system definitions are not accepted.
+func parse(src string) (*document, error) {
+	p := &parser{lexer: newLexer(src)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &document{fragments: make(map[string]*fragment)}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek("{"), p.peekName(operationQuery), p.peekName(operationMutation):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+
+			doc.operations = append(doc.operations, op)
+		case p.peekName("fragment"):
+			f, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+
+			if _, exists := doc.fragments[f.name]; exists {
+				return nil, fmt.Errorf("There can be only one fragment named %q", f.name)
+			}
+
+			doc.fragments[f.name] = f
+		default:
+			return nil, p.unexpected()
+		}
+	}
+
+	if len(doc.operations) == 0 {
+		return nil, fmt.Errorf("Document does not contain any operation")
+	}
+
+	return doc, nil
+}
+
+func (p *parser) operation() (*operation, error) {
+	op := &operation{kind: operationQuery}
+
+	if p.tok.kind == tokenName {
+		op.kind = p.tok.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		if p.tok.kind == tokenName {
+			op.name = p.tok.value
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+
+		if p.peek("(") {
+			variables, err := p.variableDefinitions()
+			if err != nil {
+				return nil, err
+			}
+
+			op.variables = variables
+		}
+
+		if _, err := p.directives(); err != nil {
+			return nil, err
+		}
+	}
+
+	selectionSet, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+
+	op.selectionSet = selectionSet
+
+	return op, nil
+}
+
+func (p *parser) variableDefinitions() ([]*variableDefinition, error) {
+	var definitions []*variableDefinition
+
+	err := p.many("(", ")", func() error {
+		if err := p.expect("$"); err != nil {
+			return err
+		}
+
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+
+		if err = p.expect(":"); err != nil {
+			return err
+		}
+
+		typ, err := p.typeRef()
+		if err != nil {
+			return err
+		}
+
+		definition := &variableDefinition{name: name, typ: typ}
+		if p.peek("=") {
+			if err = p.advance(); err != nil {
+				return err
+			}
+
+			if definition.defaultValue, err = p.value(true); err != nil {
+				return err
+			}
+		}
+
+		definitions = append(definitions, definition)
+
+		return nil
+	})
+
+	return definitions, err
+}
+
+func (p *parser) typeRef() (*typeRef, error) {
+	var typ *typeRef
+
+	if p.peek("[") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		elem, err := p.typeRef()
+		if err != nil {
+			return nil, err
+		}
+
+		if err = p.expect("]"); err != nil {
+			return nil, err
+		}
+
+		typ = &typeRef{elem: elem}
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+
+		typ = &typeRef{name: name}
+	}
+
+	if p.peek("!") {
+		typ.nonNull = true
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	return typ, nil
+}
+
+func (p *parser) fragment() (*fragment, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.peekName("on") {
+		return nil, p.unexpected()
+	}
+
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.peekName("on") {
+		return nil, p.unexpected()
+	}
+
+	if err = p.advance(); err != nil {
+		return nil, err
+	}
+
+	f := &fragment{name: name}
+	if f.typeCondition, err = p.name(); err != nil {
+		return nil, err
+	}
+
+	if f.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+
+	if f.selectionSet, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	var selections []selection
+
+	err := p.many("{", "}", func() error {
+		s, err := p.selection()
+		if err != nil {
+			return err
+		}
+
+		selections = append(selections, s)
+
+		return nil
+	})
+
+	return selections, err
+}
+
+func (p *parser) selection() (selection, error) {
+	if !p.peek("...") {
+		return p.field()
+	}
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind == tokenName && p.tok.value != "on" {
+		spread := &fragmentSpread{name: p.tok.value}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		var err error
+		spread.directives, err = p.directives()
+
+		return spread, err
+	}
+
+	inline := &inlineFragment{}
+	if p.peekName("on") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		var err error
+		if inline.typeCondition, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	if inline.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+
+	if inline.selectionSet, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+
+	return inline, nil
+}
+
+func (p *parser) field() (*field, error) {
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+
+	f := &field{name: name}
+	if p.peek(":") {
+		if err = p.advance(); err != nil {
+			return nil, err
+		}
+
+		f.alias = name
+		if f.name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+
+	if f.arguments, err = p.arguments(false); err != nil {
+		return nil, err
+	}
+
+	if f.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+
+	if p.peek("{") {
+		if f.selectionSet, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
+
+func (p *parser) arguments(constant bool) ([]*argument, error) {
+	if !p.peek("(") {
+		return nil, nil
+	}
+
+	var arguments []*argument
+
+	err := p.many("(", ")", func() error {
+		arg, err := p.argument(constant)
+		if err != nil {
+			return err
+		}
+
+		arguments = append(arguments, arg)
+
+		return nil
+	})
+
+	return arguments, err
+}
+
+func (p *parser) argument(constant bool) (*argument, error) {
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = p.expect(":"); err != nil {
+		return nil, err
+	}
+
+	v, err := p.value(constant)
+	if err != nil {
+		return nil, err
+	}
+
+	return &argument{name: name, value: v}, nil
+}
+
+func (p *parser) directives() ([]*directive, error) {
+	var directives []*directive
+
+	for p.peek("@") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+
+		arguments, err := p.arguments(false)
+		if err != nil {
+			return nil, err
+		}
+
+		directives = append(directives, &directive{name: name, arguments: arguments})
+	}
+
+	return directives, nil
+}
+
+// value parses a value, variables are not accepted in constant values.
+func (p *parser) value(constant bool) (*value, error) {
+	tok := p.tok
+
+	switch {
+	case p.peek("$") && !constant:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+
+		return &value{kind: valueVariable, raw: name}, nil
+	case p.peek("["):
+		v := &value{kind: valueList, list: []*value{}}
+		err := p.any("[", "]", func() error {
+			item, err := p.value(constant)
+			if err != nil {
+				return err
+			}
+
+			v.list = append(v.list, item)
+
+			return nil
+		})
+
+		return v, err
+	case p.peek("{"):
+		v := &value{kind: valueObject, fields: []*argument{}}
+		err := p.any("{", "}", func() error {
+			f, err := p.argument(constant)
+			if err != nil {
+				return err
+			}
+
+			v.fields = append(v.fields, f)
+
+			return nil
+		})
+
+		return v, err
+	case tok.kind == tokenInt, tok.kind == tokenFloat, tok.kind == tokenString, tok.kind == tokenName:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		kind := map[int]int{tokenInt: valueInt, tokenFloat: valueFloat, tokenString: valueString}[tok.kind]
+		if tok.kind == tokenName {
+			switch tok.value {
+			case "true", "false":
+				kind = valueBoolean
+			case "null":
+				kind = valueNull
+			default:
+				kind = valueEnum
+			}
+		}
+
+		return &value{kind: kind, raw: tok.value}, nil
+	default:
+		return nil, p.unexpected()
+	}
+}
+
+// many parses the items between the open and close punctuators, there must be at least one.
+func (p *parser) many(open, close string, item func() error) error {
+	if err := p.expect(open); err != nil {
+		return err
+	}
+
+	for {
+		if err := item(); err != nil {
+			return err
+		}
+
+		if p.peek(close) {
+			return p.advance()
+		}
+	}
+}
+
+// any parses the items between the open and close punctuators, there may be none.
+func (p *parser) any(open, close string, item func() error) error {
+	if err := p.expect(open); err != nil {
+		return err
+	}
+
+	for !p.peek(close) {
+		if err := item(); err != nil {
+			return err
+		}
+	}
+
+	return p.advance()
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected()
+	}
+
+	name := p.tok.value
+
+	return name, p.advance()
+}
+
+func (p *parser) expect(punctuator string) error {
+	if !p.peek(punctuator) {
+		return p.unexpected()
+	}
+
+	return p.advance()
+}
+
+func (p *parser) peek(punctuator string) bool {
+	return p.tok.kind == tokenPunctuator && p.tok.value == punctuator
+}
+
+func (p *parser) peekName(name string) bool {
+	return p.tok.kind == tokenName && p.tok.value == name
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+
+	p.tok = tok
+
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return p.lexer.errorf(p.tok, "Unexpected end of document")
+	}
+
+	return p.lexer.errorf(p.tok, "Unexpected %q", p.tok.value)
+}
@@ -0,0 +1,389 @@
+package graphql
+
+import (
+	"context"
+	er "errors"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/ratelimit"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+// Pagination of the receipts query.
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// receiptsResolver resolves the fields of the receipts schema with the same service layer as the REST API.
+type receiptsResolver struct {
+	receipts service.Receipts
+	users    service.Users
+}
+
+// NewReceiptsSchema creates the GraphQL schema over receipts, their items and the points of users:
+//
+//	type Query {
+//	  receipt(id: ID!): Receipt
+//	  receipts(filter: ReceiptFilter, first: Int = 20, offset: Int = 0): ReceiptPage!
+//	  user(id: ID!): User
+//	}
+//
+//	type Mutation {
+//	  processReceipt(receipt: ReceiptInput!): Receipt!
+//	}
+//
+// Receipts and users are scoped to the principal of the request context like their REST endpoints,
+// the mutation requires the submit scope.
+func NewReceiptsSchema(receipts service.Receipts, users service.Users) (*Schema, error) {
+	r := &receiptsResolver{receipts: receipts, users: users}
+
+	item := &Object{Name: "Item", Fields: Fields{
+		"shortDescription": {Type: &NonNull{OfType: String}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(model.Item).ShortDescription, nil
+		}},
+		"price": {Type: &NonNull{OfType: String}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(model.Item).Price, nil
+		}},
+	}}
+
+	rulePoints := &Object{Name: "RulePoints", Fields: Fields{
+		"rule": {Type: &NonNull{OfType: String}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(model.RulePoints).Rule, nil
+		}},
+		"points": {Type: &NonNull{OfType: Int}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(model.RulePoints).Points, nil
+		}},
+	}}
+
+	appliedCampaign := &Object{Name: "AppliedCampaign", Fields: Fields{
+		"campaignId": {Type: &NonNull{OfType: ID}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(model.AppliedCampaign).CampaignID, nil
+		}},
+		"name": {Type: &NonNull{OfType: String}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(model.AppliedCampaign).Name, nil
+		}},
+		"points": {Type: &NonNull{OfType: Int}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(model.AppliedCampaign).Points, nil
+		}},
+	}}
+
+	pointsCap := &Object{Name: "PointsCap", Fields: Fields{
+		"type": {Type: &NonNull{OfType: String}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*model.PointsCap).Type, nil
+		}},
+		"limit": {Type: &NonNull{OfType: Int}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*model.PointsCap).Limit, nil
+		}},
+		"computedPoints": {Type: &NonNull{OfType: Int}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*model.PointsCap).ComputedPoints, nil
+		}},
+		"awardedPoints": {Type: &NonNull{OfType: Int}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*model.PointsCap).AwardedPoints, nil
+		}},
+	}}
+
+	user := &Object{Name: "User", Fields: Fields{
+		"id": {Type: &NonNull{OfType: ID}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(string), nil
+		}},
+		"balance": {Type: &NonNull{OfType: Int}, Resolve: r.balance},
+	}}
+
+	receipt := &Object{Name: "Receipt", Fields: Fields{
+		"id": {Type: &NonNull{OfType: ID}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*model.Receipt).Id, nil
+		}},
+		"retailer": {Type: &NonNull{OfType: String}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*model.Receipt).Retailer, nil
+		}},
+		"purchaseDate": {Type: &NonNull{OfType: String}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*model.Receipt).PurchaseDate, nil
+		}},
+		"purchaseTime": {Type: &NonNull{OfType: String}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*model.Receipt).PurchaseTime, nil
+		}},
+		"total": {Type: &NonNull{OfType: String}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*model.Receipt).Total, nil
+		}},
+		"points": {Type: &NonNull{OfType: Int}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*model.Receipt).Points, nil
+		}},
+		"status": {Type: String, Resolve: r.status},
+		"retailerId": {Type: ID, Resolve: func(p ResolveParams) (interface{}, error) {
+			return optional(p.Source.(*model.Receipt).RetailerID), nil
+		}},
+		"retailerName": {Type: String, Resolve: func(p ResolveParams) (interface{}, error) {
+			return optional(p.Source.(*model.Receipt).RetailerName), nil
+		}},
+		"items": {Type: &NonNull{OfType: &List{OfType: &NonNull{OfType: item}}}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*model.Receipt).Items, nil
+		}},
+		"breakdown": {Type: &NonNull{OfType: &List{OfType: &NonNull{OfType: rulePoints}}}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*model.Receipt).Breakdown, nil
+		}},
+		"campaigns": {Type: &NonNull{OfType: &List{OfType: &NonNull{OfType: appliedCampaign}}}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*model.Receipt).Campaigns, nil
+		}},
+		"cap": {Type: pointsCap, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*model.Receipt).Cap, nil
+		}},
+		"user": {Type: user, Resolve: func(p ResolveParams) (interface{}, error) {
+			return r.user(p.Context, p.Source.(*model.Receipt).UserID)
+		}},
+	}}
+
+	receiptPage := &Object{Name: "ReceiptPage", Fields: Fields{
+		"totalCount": {Type: &NonNull{OfType: Int}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*receiptPage).totalCount, nil
+		}},
+		"hasNextPage": {Type: &NonNull{OfType: Boolean}, Resolve: func(p ResolveParams) (interface{}, error) {
+			page := p.Source.(*receiptPage)
+			return page.offset+len(page.receipts) < page.totalCount, nil
+		}},
+		"receipts": {Type: &NonNull{OfType: &List{OfType: &NonNull{OfType: receipt}}}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*receiptPage).receipts, nil
+		}},
+	}}
+
+	receiptFilter := &InputObject{Name: "ReceiptFilter", Fields: Args{
+		"userId":    {Type: String},
+		"retailer":  {Type: String},
+		"minPoints": {Type: Int},
+	}}
+
+	itemInput := &InputObject{Name: "ItemInput", Fields: Args{
+		"shortDescription": {Type: String},
+		"price":            {Type: String},
+	}}
+
+	receiptInput := &InputObject{Name: "ReceiptInput", Fields: Args{
+		"retailer":     {Type: String},
+		"purchaseDate": {Type: String},
+		"purchaseTime": {Type: String},
+		"total":        {Type: String},
+		"userId":       {Type: String},
+		"items":        {Type: &List{OfType: &NonNull{OfType: itemInput}}},
+	}}
+
+	query := &Object{Name: "Query", Fields: Fields{
+		"receipt": {
+			Type:    receipt,
+			Args:    Args{"id": {Type: &NonNull{OfType: ID}}},
+			Resolve: r.receipt,
+		},
+		"receipts": {
+			Type: &NonNull{OfType: receiptPage},
+			Args: Args{
+				"filter": {Type: receiptFilter},
+				"first":  {Type: Int, Default: defaultPageSize},
+				"offset": {Type: Int, Default: 0},
+			},
+			Resolve: r.list,
+			// Every receipt of the page costs its selection set.
+			Complexity: func(args map[string]interface{}, childComplexity int) int {
+				first, _ := args["first"].(int)
+				return 1 + max(first, 1)*childComplexity
+			},
+		},
+		"user": {
+			Type: user,
+			Args: Args{"id": {Type: &NonNull{OfType: ID}}},
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return r.user(p.Context, p.Args["id"].(string))
+			},
+		},
+	}}
+
+	mutation := &Object{Name: "Mutation", Fields: Fields{
+		"processReceipt": {
+			Type:    &NonNull{OfType: receipt},
+			Args:    Args{"receipt": {Type: &NonNull{OfType: receiptInput}}},
+			Resolve: r.process,
+		},
+	}}
+
+	return NewSchema(query, mutation)
+}
+
+// receiptPage is a page of the receipts selected by a filter.
+type receiptPage struct {
+	receipts   []*model.Receipt
+	offset     int
+	totalCount int
+}
+
+// receipt retrieves a receipt readable by the principal, receipts of other clients are reported as not found.
+func (r *receiptsResolver) receipt(p ResolveParams) (interface{}, error) {
+	receiptID := p.Args["id"].(string)
+	if !model.IsValidUUID(receiptID) {
+		return nil, errors.NewInvalidParam(errors.InvalidParam{Param: "id"})
+	}
+
+	receipt, err := r.receipts.Find(receiptID)
+	if err != nil {
+		return nil, err
+	}
+
+	if principal := auth.FromContext(p.Context); principal != nil && !principal.CanAccess(receipt.ClientID, receipt.UserID) {
+		return nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "receipts", ID: receiptID})
+	}
+
+	return receipt, nil
+}
+
+// list retrieves a page of the receipts selected by the filter, newest first.
+// Clients only list their own receipts and users authenticated themselves only their own receipts.
+func (r *receiptsResolver) list(p ResolveParams) (interface{}, error) {
+	first, offset := p.Args["first"].(int), p.Args["offset"].(int)
+	if first < 1 || first > maxPageSize {
+		return nil, errors.NewInvalidParam(errors.InvalidParam{Param: "first"})
+	}
+
+	if offset < 0 {
+		return nil, errors.NewInvalidParam(errors.InvalidParam{Param: "offset"})
+	}
+
+	var filter model.ReceiptFilter
+	if args, ok := p.Args["filter"].(map[string]interface{}); ok {
+		filter.UserID, _ = args["userId"].(string)
+		filter.Retailer, _ = args["retailer"].(string)
+		filter.MinPoints, _ = args["minPoints"].(int)
+	}
+
+	if principal := auth.FromContext(p.Context); principal != nil && !principal.HasScope(model.ScopeAdmin) {
+		filter.ClientID = principal.ClientID
+
+		if principal.UserID != "" {
+			if filter.UserID != "" && filter.UserID != principal.UserID {
+				return nil, errors.NewForbidden(er.New("Receipts of other users cannot be listed"))
+			}
+
+			filter.UserID = principal.UserID
+		}
+	}
+
+	receipts, total := r.receipts.List(filter, offset, first)
+
+	page := &receiptPage{receipts: make([]*model.Receipt, 0, len(receipts)), offset: offset, totalCount: total}
+	for i := range receipts {
+		page.receipts = append(page.receipts, &receipts[i])
+	}
+
+	return page, nil
+}
+
+// status retrieves the status of the fraud review of a receipt, null when its points were never held.
+func (r *receiptsResolver) status(p ResolveParams) (interface{}, error) {
+	resp, err := r.receipts.Get(p.Source.(*model.Receipt).Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return optional(resp.Status), nil
+}
+
+// user returns the user with the given ID when the principal may read their points, null for receipts without user.
+func (r *receiptsResolver) user(ctx context.Context, userID string) (interface{}, error) {
+	if userID == "" {
+		return nil, nil
+	}
+
+	if !model.IsValidUserID(userID) {
+		return nil, errors.NewInvalidParam(errors.InvalidParam{Param: "id"})
+	}
+
+	if principal := auth.FromContext(ctx); principal != nil && !principal.CanAccessUser(userID, r.users.Client(userID)) {
+		return nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "users", ID: userID})
+	}
+
+	return userID, nil
+}
+
+// balance retrieves the points balance of a user, users who never earned points have a balance of 0.
+func (r *receiptsResolver) balance(p ResolveParams) (interface{}, error) {
+	balance, err := r.users.Balance(p.Source.(string))
+	if err != nil {
+		var notFound errors.EntityNotFound
+		if er.As(err, &notFound) {
+			return 0, nil
+		}
+
+		return nil, err
+	}
+
+	return balance.Points, nil
+}
+
+// process scores a receipt submitted by the principal, with the same rules as receiptsHandler.Insert, and returns it.
+// Every receipt counts against the daily quota of the caller, receipts failing to be processed are refunded.
+func (r *receiptsResolver) process(p ResolveParams) (interface{}, error) {
+	principal := auth.FromContext(p.Context)
+	if principal != nil && !principal.HasScope(model.ScopeSubmit) {
+		return nil, errors.NewForbidden(er.New("Submitting receipts requires the submit scope"))
+	}
+
+	receipt := toReceipt(p.Args["receipt"].(map[string]interface{}))
+
+	// Records the submitting client on the receipt, users authenticated themselves only submit receipts on their own behalf.
+	if err := principal.BindReceipt(receipt); err != nil {
+		return nil, err
+	}
+
+	refund, err := ratelimit.ChargeContext(p.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.receipts.Insert(p.Context, receipt)
+	if err != nil {
+		refund()
+		return nil, err
+	}
+
+	return r.receipts.Find(resp.Id)
+}
+
+// toReceipt converts a receipt input to the model, absent fields stay nil so that validation reports them missing.
+func toReceipt(input map[string]interface{}) *model.Receipt {
+	receipt := &model.Receipt{
+		Retailer:     stringArg(input, "retailer"),
+		PurchaseDate: stringArg(input, "purchaseDate"),
+		PurchaseTime: stringArg(input, "purchaseTime"),
+		Total:        stringArg(input, "total"),
+	}
+
+	if userID := stringArg(input, "userId"); userID != nil {
+		receipt.UserID = *userID
+	}
+
+	if items, ok := input["items"].([]interface{}); ok {
+		receipt.Items = make([]model.Item, 0, len(items))
+		for _, item := range items {
+			fields := item.(map[string]interface{})
+			receipt.Items = append(receipt.Items, model.Item{ShortDescription: stringArg(fields, "shortDescription"), Price: stringArg(fields, "price")})
+		}
+	}
+
+	return receipt
+}
+
+// stringArg returns the string field name of an input object, nil when it is absent or null.
+func stringArg(input map[string]interface{}, name string) *string {
+	if s, ok := input[name].(string); ok {
+		return &s
+	}
+
+	return nil
+}
+
+// optional returns s, or nil for null when s is empty.
+func optional(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
@@ -0,0 +1,174 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	store "github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+// processQuery submits a receipt scoring 31 points: 6 for the retailer and 25 for the total multiple of 0.25.
+const processQuery = `mutation ($userId: String) {
+	processReceipt(receipt: {
+		retailer: "Target", purchaseDate: "2022-01-02", purchaseTime: "13:13", total: "1.25", userId: $userId,
+		items: [{shortDescription: "Pepsi - 12-oz", price: "1.25"}]
+	}) { id }
+}`
+
+func TestReceiptsSchema(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	ledger := store.NewLedger(logger)
+	receipts := service.New(logger, store.New(logger), service.WithLedger(ledger))
+
+	schema, err := NewReceiptsSchema(receipts, service.NewUsers(logger, ledger))
+	assert.NoError(t, err)
+
+	partner := &model.Principal{ClientID: "partner", Scopes: []string{model.ScopeSubmit, model.ScopeRead}}
+	user := &model.Principal{ClientID: "partner", UserID: "user-1", Scopes: []string{model.ScopeSubmit, model.ScopeRead}}
+	other := &model.Principal{ClientID: "other", Scopes: []string{model.ScopeSubmit, model.ScopeRead}}
+	reader := &model.Principal{ClientID: "partner", Scopes: []string{model.ScopeRead}}
+
+	// Receipts of user-1 and user-2 submitted by partner, and an anonymous receipt submitted by other.
+	submit := func(principal *model.Principal, userID string) string {
+		result := schema.Execute(auth.NewContext(context.Background(), principal), Request{Query: processQuery, Variables: map[string]interface{}{"userId": userID}}, Options{})
+		assert.Empty(t, result.Errors)
+
+		return result.Data.Get("processReceipt").(*OrderedMap).Get("id").(string)
+	}
+
+	first, second, _, anonymous := submit(user, ""), submit(partner, "user-2"), submit(partner, "user-1"), submit(other, "")
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		principal        *model.Principal
+		request          Request
+		expectedResponse string
+	}{
+		{
+			id: 1, useCase: "Positive case: receipt with its items, breakdown and the balance of its user in one request",
+			principal: partner,
+			request: Request{
+				Query:     `query ($id: ID!) { receipt(id: $id) { id retailer points status items { shortDescription price } breakdown { rule points } cap { limit } user { id balance } } }`,
+				Variables: map[string]interface{}{"id": first},
+			},
+			expectedResponse: fmt.Sprintf(`{"data":{"receipt":{"id":%q,"retailer":"Target","points":31,"status":null,
+				"items":[{"shortDescription":"Pepsi - 12-oz","price":"1.25"}],
+				"breakdown":[{"rule":"retailer_name","points":6},{"rule":"quarter_multiple_total","points":25}],
+				"cap":null,"user":{"id":"user-1","balance":62}}}}`, first),
+		},
+		{
+			id: 2, useCase: "Positive case: page of the receipts of a client, newest first",
+			principal: partner,
+			request:   Request{Query: `{ receipts(first: 1, offset: 1) { totalCount hasNextPage receipts { id user { id } } } }`},
+			expectedResponse: fmt.Sprintf(`{"data":{"receipts":{"totalCount":3,"hasNextPage":true,
+				"receipts":[{"id":%q,"user":{"id":"user-2"}}]}}}`, second),
+		},
+		{
+			id: 3, useCase: "Positive case: receipts filtered by user and retailer",
+			principal:        partner,
+			request:          Request{Query: `{ receipts(filter: {userId: "user-2", retailer: "target", minPoints: 31}) { totalCount hasNextPage receipts { id } } }`},
+			expectedResponse: fmt.Sprintf(`{"data":{"receipts":{"totalCount":1,"hasNextPage":false,"receipts":[{"id":%q}]}}}`, second),
+		},
+		{
+			id: 4, useCase: "Positive case: users authenticated themselves only list their own receipts",
+			principal:        user,
+			request:          Request{Query: `{ receipts { totalCount } }`},
+			expectedResponse: `{"data":{"receipts":{"totalCount":2}}}`,
+		},
+		{
+			id: 5, useCase: "Positive case: balance of a user without points",
+			principal:        &model.Principal{ClientID: "partner", UserID: "user-3", Scopes: []string{model.ScopeRead}},
+			request:          Request{Query: `{ user(id: "user-3") { id balance } }`},
+			expectedResponse: `{"data":{"user":{"id":"user-3","balance":0}}}`,
+		},
+		{
+			id: 6, useCase: "Negative case: receipt of another client",
+			principal:        partner,
+			request:          Request{Query: fmt.Sprintf(`{ receipt(id: %q) { id } }`, anonymous)},
+			expectedResponse: fmt.Sprintf(`{"data":{"receipt":null},"errors":[{"message":"No 'receipts' found for Id: '%v'","path":["receipt"],"extensions":{"code":"NOT_FOUND"}}]}`, anonymous),
+		},
+		{
+			id: 7, useCase: "Negative case: invalid receipt ID",
+			principal:        partner,
+			request:          Request{Query: `{ receipt(id: "1") { id } }`},
+			expectedResponse: `{"data":{"receipt":null},"errors":[{"message":"Incorrect value for parameter: id","path":["receipt"],"extensions":{"code":"BAD_USER_INPUT"}}]}`,
+		},
+		{
+			id: 8, useCase: "Negative case: page size beyond the maximum",
+			principal:        partner,
+			request:          Request{Query: `{ receipts(first: 101) { totalCount } }`},
+			expectedResponse: `{"data":null,"errors":[{"message":"Incorrect value for parameter: first","path":["receipts"],"extensions":{"code":"BAD_USER_INPUT"}}]}`,
+		},
+		{
+			id: 9, useCase: "Negative case: users listing the receipts of other users",
+			principal:        user,
+			request:          Request{Query: `{ receipts(filter: {userId: "user-2"}) { totalCount } }`},
+			expectedResponse: `{"data":null,"errors":[{"message":"Receipts of other users cannot be listed","path":["receipts"],"extensions":{"code":"FORBIDDEN"}}]}`,
+		},
+		{
+			id: 10, useCase: "Negative case: points of another user",
+			principal:        user,
+			request:          Request{Query: `{ user(id: "user-2") { balance } }`},
+			expectedResponse: `{"data":{"user":null},"errors":[{"message":"No 'users' found for Id: 'user-2'","path":["user"],"extensions":{"code":"NOT_FOUND"}}]}`,
+		},
+		{
+			id: 11, useCase: "Negative case: invalid receipt submitted",
+			principal:        partner,
+			request:          Request{Query: `mutation { processReceipt(receipt: {retailer: "Target"}) { id } }`},
+			expectedResponse: `{"data":null,"errors":[{"message":"Parameter purchaseDate is required for this request","path":["processReceipt"],"extensions":{"code":"BAD_USER_INPUT"}}]}`,
+		},
+		{
+			id: 12, useCase: "Negative case: receipt submitted without the submit scope",
+			principal:        reader,
+			request:          Request{Query: processQuery},
+			expectedResponse: `{"data":null,"errors":[{"message":"Submitting receipts requires the submit scope","path":["processReceipt"],"extensions":{"code":"FORBIDDEN"}}]}`,
+		},
+		{
+			id: 13, useCase: "Negative case: receipt submitted on behalf of another user",
+			principal:        user,
+			request:          Request{Query: processQuery, Variables: map[string]interface{}{"userId": "user-2"}},
+			expectedResponse: `{"data":null,"errors":[{"message":"Receipts can only be submitted on behalf of the authenticated user","path":["processReceipt"],"extensions":{"code":"FORBIDDEN"}}]}`,
+		},
+		{
+			id: 14, useCase: "Negative case: points of a user of another client",
+			principal:        other,
+			request:          Request{Query: `{ user(id: "user-2") { balance } }`},
+			expectedResponse: `{"data":{"user":null},"errors":[{"message":"No 'users' found for Id: 'user-2'","path":["user"],"extensions":{"code":"NOT_FOUND"}}]}`,
+		},
+		{
+			id: 15, useCase: "Negative case: receipt submitted on behalf of a user of another client",
+			principal:        other,
+			request:          Request{Query: processQuery, Variables: map[string]interface{}{"userId": "user-2"}},
+			expectedResponse: `{"data":null,"errors":[{"message":"User 'user-2' belongs to another client","path":["processReceipt"],"extensions":{"code":"FORBIDDEN"}}]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		result := schema.Execute(auth.NewContext(context.Background(), tc.principal), tc.request, Options{})
+
+		resp, err := json.Marshal(result)
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.JSONEq(t, tc.expectedResponse, string(resp), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestReceiptsSchema_Complexity(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	schema, err := NewReceiptsSchema(service.New(logger, store.New(logger)), service.NewUsers(logger, store.NewLedger(logger)))
+	assert.NoError(t, err)
+
+	// Every receipt of the page costs its selection set: 1 + 100 * (1 + 1 + 2), for the list, the ID and the items with their price.
+	result := schema.Execute(context.Background(), Request{Query: `{ receipts(first: 100) { receipts { id items { price } } } }`}, Options{MaxComplexity: 400})
+
+	resp, err := json.Marshal(result)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"errors":[{"message":"Query complexity of at least 401 exceeds the maximum complexity of 400","extensions":{"code":"QUERY_TOO_COMPLEX"}}]}`, string(resp))
+}
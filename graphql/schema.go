@@ -0,0 +1,254 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Type is a GraphQL type: a *Scalar, an *Object, an *InputObject, a *List or a *NonNull.
+type Type interface {
+	String() string
+}
+
+// Scalar is a leaf type, Parse coerces input values decoded from JSON or written in the document.
+type Scalar struct {
+	Name  string
+	Parse func(v interface{}) (interface{}, bool)
+}
+
+// Object is an output type with a set of fields.
+type Object struct {
+	Name   string
+	Fields Fields
+}
+
+// Fields are the fields of an object with their names as keys.
+type Fields map[string]*Field
+
+// Field is a field of an object, Resolve computes its value from the value of the object.
+// Complexity computes the cost of the field from its arguments and the cost of its selection set,
+// the cost of a field is 1 plus the cost of its selection set when it is nil.
+type Field struct {
+	Type       Type
+	Args       Args
+	Resolve    ResolveFunc
+	Complexity func(args map[string]interface{}, childComplexity int) int
+}
+
+// ResolveFunc computes the value of a field.
+type ResolveFunc func(p ResolveParams) (interface{}, error)
+
+// ResolveParams are the inputs of a resolver: the value of the object the field belongs to and the coerced arguments of the field.
+type ResolveParams struct {
+	Context context.Context
+	Source  interface{}
+	Args    map[string]interface{}
+}
+
+// InputObject is an input type with a set of fields, it is coerced to a map[string]interface{}.
+type InputObject struct {
+	Name   string
+	Fields Args
+}
+
+// Args are the arguments of a field or the fields of an input object with their names as keys.
+type Args map[string]*Argument
+
+// Argument is an argument of a field or a field of an input object, Default is used when it is not given.
+type Argument struct {
+	Type    Type
+	Default interface{}
+}
+
+// List is a list of values of OfType.
+type List struct {
+	OfType Type
+}
+
+// NonNull is a value of OfType that is never null.
+type NonNull struct {
+	OfType Type
+}
+
+func (s *Scalar) String() string       { return s.Name }
+func (o *Object) String() string       { return o.Name }
+func (io *InputObject) String() string { return io.Name }
+func (l *List) String() string         { return "[" + l.OfType.String() + "]" }
+func (nn *NonNull) String() string     { return nn.OfType.String() + "!" }
+
+// Built-in scalars.
+var (
+	Int = &Scalar{Name: "Int", Parse: func(v interface{}) (interface{}, bool) {
+		var n float64
+		switch v := v.(type) {
+		case int:
+			n = float64(v)
+		case float64:
+			n = v
+		default:
+			return nil, false
+		}
+
+		if n != math.Trunc(n) || n < math.MinInt32 || n > math.MaxInt32 {
+			return nil, false
+		}
+
+		return int(n), true
+	}}
+
+	Float = &Scalar{Name: "Float", Parse: func(v interface{}) (interface{}, bool) {
+		switch v := v.(type) {
+		case int:
+			return float64(v), true
+		case float64:
+			return v, true
+		default:
+			return nil, false
+		}
+	}}
+
+	String = &Scalar{Name: "String", Parse: func(v interface{}) (interface{}, bool) {
+		s, ok := v.(string)
+		return s, ok
+	}}
+
+	Boolean = &Scalar{Name: "Boolean", Parse: func(v interface{}) (interface{}, bool) {
+		b, ok := v.(bool)
+		return b, ok
+	}}
+
+	// ID accepts strings and integers, it is coerced to a string.
+	ID = &Scalar{Name: "ID", Parse: func(v interface{}) (interface{}, bool) {
+		switch v := v.(type) {
+		case string:
+			return v, true
+		case int:
+			return strconv.Itoa(v), true
+		case float64:
+			if v != math.Trunc(v) {
+				return nil, false
+			}
+
+			return strconv.FormatFloat(v, 'f', 0, 64), true
+		default:
+			return nil, false
+		}
+	}}
+)
+
+// Schema is an executable GraphQL schema, Mutation is nil when the schema has no mutations.
+type Schema struct {
+	Query    *Object
+	Mutation *Object
+	types    map[string]Type // Named types reachable from the root types, variables are declared with them.
+}
+
+// NewSchema creates a schema with the given root types, it returns an error if two different types share a name.
+func NewSchema(query, mutation *Object) (*Schema, error) {
+	s := &Schema{Query: query, Mutation: mutation, types: make(map[string]Type)}
+
+	for _, t := range []Type{Int, Float, String, Boolean, ID} {
+		s.types[t.String()] = t
+	}
+
+	for _, root := range []*Object{query, mutation} {
+		if root == nil {
+			continue
+		}
+
+		if err := s.register(root); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// register adds t and the types of its fields and arguments to the named types of the schema.
+func (s *Schema) register(t Type) error {
+	switch t := t.(type) {
+	case *List:
+		return s.register(t.OfType)
+	case *NonNull:
+		return s.register(t.OfType)
+	}
+
+	if registered, exists := s.types[t.String()]; exists {
+		if registered != t {
+			return fmt.Errorf("graphql: type %v is defined twice", t)
+		}
+
+		return nil
+	}
+
+	s.types[t.String()] = t
+
+	switch t := t.(type) {
+	case *Object:
+		for _, f := range t.Fields {
+			if err := s.register(f.Type); err != nil {
+				return err
+			}
+
+			for _, arg := range f.Args {
+				if err := s.register(arg.Type); err != nil {
+					return err
+				}
+			}
+		}
+	case *InputObject:
+		for _, f := range t.Fields {
+			if err := s.register(f.Type); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveTypeRef returns the type a variable is declared with.
+func (s *Schema) resolveTypeRef(ref *typeRef) (Type, error) {
+	var t Type
+	if ref.elem != nil {
+		elem, err := s.resolveTypeRef(ref.elem)
+		if err != nil {
+			return nil, err
+		}
+
+		t = &List{OfType: elem}
+	} else {
+		named, exists := s.types[ref.name]
+		if !exists {
+			return nil, fmt.Errorf("Unknown type %q", ref.name)
+		}
+
+		if _, ok := named.(*Object); ok {
+			return nil, fmt.Errorf("Type %q is not an input type", ref.name)
+		}
+
+		t = named
+	}
+
+	if ref.nonNull {
+		t = &NonNull{OfType: t}
+	}
+
+	return t, nil
+}
+
+// namedType returns the type t wraps in lists and non-null types.
+func namedType(t Type) Type {
+	for {
+		switch wrapped := t.(type) {
+		case *List:
+			t = wrapped.OfType
+		case *NonNull:
+			t = wrapped.OfType
+		default:
+			return t
+		}
+	}
+}
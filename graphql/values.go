@@ -0,0 +1,232 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// enumLiteral is an enum value written in a document, no scalar accepts it.
+type enumLiteral string
+
+// coerceValue coerces an input value decoded from JSON, or converted from a literal, to t.
+// Input objects are coerced to a map[string]interface{} holding the given fields and the defaults of the others.
+func coerceValue(t Type, v interface{}) (interface{}, error) {
+	if nn, ok := t.(*NonNull); ok {
+		if v == nil {
+			return nil, fmt.Errorf("Expected non-nullable type %q not to be null", t)
+		}
+
+		return coerceValue(nn.OfType, v)
+	}
+
+	if v == nil {
+		return nil, nil
+	}
+
+	switch t := t.(type) {
+	case *List:
+		items, ok := v.([]interface{})
+		if !ok {
+			// A single value is coerced to a list of one value.
+			item, err := coerceValue(t.OfType, v)
+			if err != nil {
+				return nil, err
+			}
+
+			return []interface{}{item}, nil
+		}
+
+		coerced := make([]interface{}, len(items))
+		for i, item := range items {
+			c, err := coerceValue(t.OfType, item)
+			if err != nil {
+				return nil, fmt.Errorf("In element #%v: %v", i, err)
+			}
+
+			coerced[i] = c
+		}
+
+		return coerced, nil
+	case *Scalar:
+		coerced, ok := t.Parse(v)
+		if !ok {
+			return nil, fmt.Errorf("Expected type %q, found %v", t, display(v))
+		}
+
+		return coerced, nil
+	case *InputObject:
+		fields, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Expected type %q to be an object", t)
+		}
+
+		for name := range fields {
+			if _, exists := t.Fields[name]; !exists {
+				return nil, fmt.Errorf("Field %q is not defined by type %q", name, t)
+			}
+		}
+
+		coerced := make(map[string]interface{})
+		for name, def := range t.Fields {
+			fv, given := fields[name]
+			if !given {
+				if def.Default != nil {
+					coerced[name] = def.Default
+				} else if _, required := def.Type.(*NonNull); required {
+					return nil, fmt.Errorf("Field %q of required type %q was not provided", name, def.Type)
+				}
+
+				continue
+			}
+
+			c, err := coerceValue(def.Type, fv)
+			if err != nil {
+				return nil, fmt.Errorf("In field %q: %v", name, err)
+			}
+
+			coerced[name] = c
+		}
+
+		return coerced, nil
+	default:
+		return nil, fmt.Errorf("Type %q is not an input type", t)
+	}
+}
+
+// literal converts a value written in a document to the Go value it stands for, substituting variables with their value.
+// It reports false when the value is a variable that was not provided.
+func literal(v *value, vars map[string]interface{}) (interface{}, bool) {
+	switch v.kind {
+	case valueVariable:
+		val, provided := vars[v.raw]
+		return val, provided
+	case valueInt:
+		if n, err := strconv.Atoi(v.raw); err == nil {
+			return n, true
+		}
+
+		// Integers beyond the range of int are left to the scalars to reject.
+		f, _ := strconv.ParseFloat(v.raw, 64)
+		return f, true
+	case valueFloat:
+		f, _ := strconv.ParseFloat(v.raw, 64)
+		return f, true
+	case valueString:
+		return v.raw, true
+	case valueBoolean:
+		return v.raw == "true", true
+	case valueEnum:
+		return enumLiteral(v.raw), true
+	case valueList:
+		items := make([]interface{}, 0, len(v.list))
+		for _, item := range v.list {
+			val, _ := literal(item, vars)
+			items = append(items, val)
+		}
+
+		return items, true
+	case valueObject:
+		fields := make(map[string]interface{})
+		for _, f := range v.fields {
+			if val, provided := literal(f.value, vars); provided {
+				fields[f.name] = val
+			}
+		}
+
+		return fields, true
+	default:
+		return nil, true
+	}
+}
+
+// coerceArgs coerces the arguments given to a field to their definitions, adding the defaults of the arguments not given.
+func coerceArgs(defs Args, args []*argument, vars map[string]interface{}) (map[string]interface{}, error) {
+	given := make(map[string]*argument, len(args))
+	for _, arg := range args {
+		if _, exists := defs[arg.name]; !exists {
+			return nil, fmt.Errorf("Unknown argument %q", arg.name)
+		}
+
+		if _, exists := given[arg.name]; exists {
+			return nil, fmt.Errorf("There can be only one argument named %q", arg.name)
+		}
+
+		given[arg.name] = arg
+	}
+
+	coerced := make(map[string]interface{}, len(defs))
+	for name, def := range defs {
+		var (
+			v        interface{}
+			provided bool
+		)
+
+		if arg, exists := given[name]; exists {
+			v, provided = literal(arg.value, vars)
+		}
+
+		if !provided {
+			if def.Default != nil {
+				coerced[name] = def.Default
+			} else if _, required := def.Type.(*NonNull); required {
+				return nil, fmt.Errorf("Argument %q of required type %q was not provided", name, def.Type)
+			}
+
+			continue
+		}
+
+		c, err := coerceValue(def.Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("Argument %q has an invalid value: %v", name, err)
+		}
+
+		coerced[name] = c
+	}
+
+	return coerced, nil
+}
+
+// coerceVariables coerces the variables given to an operation to their declared types, adding their defaults.
+func (s *Schema) coerceVariables(op *operation, given map[string]interface{}) (map[string]interface{}, error) {
+	coerced := make(map[string]interface{}, len(op.variables))
+	for _, def := range op.variables {
+		t, err := s.resolveTypeRef(def.typ)
+		if err != nil {
+			return nil, fmt.Errorf("Variable \"$%v\": %v", def.name, err)
+		}
+
+		v, provided := given[def.name]
+		if !provided && def.defaultValue != nil {
+			v, provided = literal(def.defaultValue, nil)
+		}
+
+		if !provided {
+			if _, required := t.(*NonNull); required {
+				return nil, fmt.Errorf("Variable \"$%v\" of required type %q was not provided", def.name, t)
+			}
+
+			continue
+		}
+
+		if coerced[def.name], err = coerceValue(t, v); err != nil {
+			return nil, fmt.Errorf("Variable \"$%v\" got an invalid value: %v", def.name, err)
+		}
+	}
+
+	return coerced, nil
+}
+
+// display formats an input value for error messages.
+func display(v interface{}) string {
+	if e, ok := v.(enumLiteral); ok {
+		return string(e)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(b)
+}
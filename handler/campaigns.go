@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/responder"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+// campaignsHandler is a HTTP handler for the campaign endpoints.
+type campaignsHandler struct {
+	logger *log.CustomLogger
+	svc    service.Campaigns
+}
+
+// NewCampaigns creates and returns a new instance of campaignsHandler.
+func NewCampaigns(l *log.CustomLogger, svc service.Campaigns) *campaignsHandler {
+	return &campaignsHandler{
+		logger: l,
+		svc:    svc,
+	}
+}
+
+// List handles HTTP GET requests to retrieve every campaign.
+func (ch *campaignsHandler) List(w http.ResponseWriter, r *http.Request) {
+	responder.SetResponse(ch.svc.List(), 200, w)
+}
+
+// Get handles HTTP GET requests to retrieve a campaign by its ID.
+func (ch *campaignsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	campaignID, ok := ch.campaignID(w, r)
+	if !ok {
+		return
+	}
+
+	campaign, err := ch.svc.Get(campaignID)
+	if err != nil {
+		responder.SetErrorResponse(ch.logger, err, w, r)
+
+		return
+	}
+
+	responder.SetResponse(campaign, 200, w)
+}
+
+// Insert handles HTTP POST requests to create a campaign.
+func (ch *campaignsHandler) Insert(w http.ResponseWriter, r *http.Request) {
+	var campaign model.Campaign
+	if err := decodeBody(r, &campaign); err != nil {
+		responder.SetErrorResponse(ch.logger, err, w, r)
+
+		return
+	}
+
+	resp, err := ch.svc.Insert(&campaign)
+	if err != nil {
+		responder.SetErrorResponse(ch.logger, err, w, r)
+
+		return
+	}
+
+	responder.SetResponse(resp, 201, w)
+}
+
+// Update handles HTTP PUT requests to replace a campaign.
+func (ch *campaignsHandler) Update(w http.ResponseWriter, r *http.Request) {
+	campaignID, ok := ch.campaignID(w, r)
+	if !ok {
+		return
+	}
+
+	var campaign model.Campaign
+	if err := decodeBody(r, &campaign); err != nil {
+		responder.SetErrorResponse(ch.logger, err, w, r)
+
+		return
+	}
+
+	resp, err := ch.svc.Update(campaignID, &campaign)
+	if err != nil {
+		responder.SetErrorResponse(ch.logger, err, w, r)
+
+		return
+	}
+
+	responder.SetResponse(resp, 200, w)
+}
+
+// Delete handles HTTP DELETE requests to remove a campaign.
+func (ch *campaignsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	campaignID, ok := ch.campaignID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := ch.svc.Delete(campaignID); err != nil {
+		responder.SetErrorResponse(ch.logger, err, w, r)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// campaignID validates the campaign ID path parameter.
+func (ch *campaignsHandler) campaignID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	campaignID := mux.Vars(r)["id"]
+	if !model.IsValidUUID(campaignID) {
+		responder.SetErrorResponse(ch.logger, errors.NewInvalidParam(errors.InvalidParam{Param: "id"}), w, r)
+
+		return "", false
+	}
+
+	return campaignID, true
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+func TestHandlerCampaigns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	campaignsService := service.NewMockCampaigns(ctrl)
+	logger, _ := log.NewCustomLogger("test.log")
+	handler := NewCampaigns(logger, campaignsService)
+
+	campaignID := "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f"
+	campaign := &model.Campaign{ID: campaignID, Name: "Double", StartDate: "2024-03-02", EndDate: "2024-03-03", Retailer: "Target", Multiplier: 2}
+	body := `{"name": "Double", "startDate": "2024-03-02", "endDate": "2024-03-03", "retailer": "Target", "multiplier": 2}`
+	notFound := errors.NewEntityNotFound(errors.EntityNotFound{Entity: "campaigns", ID: campaignID})
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		method           string
+		campaignID       string
+		body             string
+		expectedResponse string
+		statusCode       int
+		mockCall         *gomock.Call
+	}{
+		{
+			id: 1, useCase: "Positive case: create campaign",
+			method: "POST", body: body,
+			expectedResponse: `{"id":"4a77ec9d-5334-43d0-a9e1-4fca8807bf8f","name":"Double","startDate":"2024-03-02","endDate":"2024-03-03","retailer":"Target","multiplier":2}`,
+			statusCode:       201,
+			mockCall:         campaignsService.EXPECT().Insert(&model.Campaign{Name: "Double", StartDate: "2024-03-02", EndDate: "2024-03-03", Retailer: "Target", Multiplier: 2}).Return(campaign, nil),
+		},
+		{
+			id: 2, useCase: "Negative case: get with invalid id",
+			method: "GET", campaignID: "c-1",
+			expectedResponse: "Incorrect value for parameter: id",
+			statusCode:       400,
+		},
+		{
+			id: 3, useCase: "Positive case: get campaign",
+			method: "GET", campaignID: campaignID,
+			expectedResponse: `"name":"Double"`,
+			statusCode:       200,
+			mockCall:         campaignsService.EXPECT().Get(campaignID).Return(campaign, nil),
+		},
+		{
+			id: 4, useCase: "Positive case: update campaign",
+			method: "PUT", campaignID: campaignID, body: body,
+			expectedResponse: `"multiplier":2`,
+			statusCode:       200,
+			mockCall:         campaignsService.EXPECT().Update(campaignID, gomock.Any()).Return(campaign, nil),
+		},
+		{
+			id: 5, useCase: "Positive case: delete campaign",
+			method: "DELETE", campaignID: campaignID,
+			statusCode: 204,
+			mockCall:   campaignsService.EXPECT().Delete(campaignID).Return(nil),
+		},
+		{
+			id: 6, useCase: "Negative case: delete unknown campaign",
+			method: "DELETE", campaignID: campaignID,
+			expectedResponse: "No 'campaigns' found for Id: '4a77ec9d-5334-43d0-a9e1-4fca8807bf8f'",
+			statusCode:       404,
+			mockCall:         campaignsService.EXPECT().Delete(campaignID).Return(notFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(tc.method, "/v1/campaigns/"+tc.campaignID, bytes.NewBufferString(tc.body))
+		r = mux.SetURLVars(r, map[string]string{"id": tc.campaignID})
+
+		switch tc.method {
+		case "POST":
+			handler.Insert(w, r)
+		case "GET":
+			handler.Get(w, r)
+		case "PUT":
+			handler.Update(w, r)
+		case "DELETE":
+			handler.Delete(w, r)
+		}
+
+		resp, _ := io.ReadAll(w.Result().Body)
+		assert.Equal(t, tc.statusCode, w.Result().StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Contains(t, string(resp), tc.expectedResponse, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/graphql"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/responder"
+)
+
+const (
+	maxGraphQLQueryLength = 16 << 10 // Maximum length of a query in bytes.
+	maxGraphQLFragments   = 32       // Maximum number of fragments of a query.
+)
+
+// graphqlHandler is a HTTP handler for the GraphQL endpoint.
+type graphqlHandler struct {
+	logger *log.CustomLogger
+	schema *graphql.Schema
+	opts   graphql.Options // Depth and complexity limits of the queries.
+}
+
+// NewGraphQL creates and returns a new instance of graphqlHandler executing requests against schema within the limits of opts.
+func NewGraphQL(l *log.CustomLogger, schema *graphql.Schema, opts graphql.Options) *graphqlHandler {
+	return &graphqlHandler{
+		logger: l,
+		schema: schema,
+		opts:   opts,
+	}
+}
+
+// Query handles GraphQL requests, sent as a JSON body of POST requests or as the query parameters of GET requests.
+// GET requests only execute queries. Errors of the GraphQL request are part of the response body, which is always sent with a 200 status.
+func (gh *graphqlHandler) Query(w http.ResponseWriter, r *http.Request) {
+	var req graphql.Request
+
+	opts := gh.opts
+	opts.MaxFragments = maxGraphQLFragments
+
+	if r.Method == http.MethodGet {
+		query := r.URL.Query()
+		req.Query, req.OperationName = query.Get("query"), query.Get("operationName")
+
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				responder.SetErrorResponse(gh.logger, errors.NewInvalidParam(errors.InvalidParam{Param: "variables"}), w, r)
+
+				return
+			}
+		}
+
+		opts.QueryOnly = true
+	} else {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			responder.SetErrorResponse(gh.logger, errors.NewCustomError(err, 400), w, r)
+
+			return
+		}
+
+		if err = json.Unmarshal(body, &req); err != nil {
+			responder.SetErrorResponse(gh.logger, errors.NewCustomError(err, 400), w, r)
+
+			return
+		}
+	}
+
+	if req.Query == "" {
+		responder.SetErrorResponse(gh.logger, errors.NewMissingParam(errors.MissingParam{Param: "query"}), w, r)
+
+		return
+	}
+
+	if len(req.Query) > maxGraphQLQueryLength {
+		responder.SetErrorResponse(gh.logger, errors.NewInvalidParam(fmt.Errorf("Query is longer than the maximum length of %v bytes", maxGraphQLQueryLength)), w, r)
+
+		return
+	}
+
+	responder.SetResponse(gh.schema.Execute(r.Context(), req, opts), 200, w)
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/graphql"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+func TestHandlerGraphQL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	receiptService := service.NewMockReceipts(ctrl)
+	logger, _ := log.NewCustomLogger("test.log")
+
+	schema, err := graphql.NewReceiptsSchema(receiptService, service.NewMockUsers(ctrl))
+	assert.NoError(t, err)
+
+	handler := NewGraphQL(logger, schema, graphql.Options{MaxDepth: 3})
+
+	receiptID := "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f"
+	receipt := &model.Receipt{Id: receiptID, Points: 28, Items: []model.Item{{ShortDescription: model.StringPointer("Gum"), Price: model.StringPointer("1.00")}}}
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		method           string
+		target           string
+		body             string
+		expectedResponse string
+		statusCode       int
+		mockCall         *gomock.Call
+	}{
+		{
+			id: 1, useCase: "Positive case: query in a POST request",
+			method:           "POST",
+			body:             `{"query":"query ($id: ID!) { receipt(id: $id) { points items { price } } }","variables":{"id":"` + receiptID + `"}}`,
+			expectedResponse: `{"data":{"receipt":{"points":28,"items":[{"price":"1.00"}]}}}`,
+			statusCode:       200,
+			mockCall:         receiptService.EXPECT().Find(receiptID).Return(receipt, nil),
+		},
+		{
+			id: 2, useCase: "Positive case: query in a GET request",
+			method:           "GET",
+			target:           "?query=" + url.QueryEscape(`{ receipt(id: "`+receiptID+`") { points } }`),
+			expectedResponse: `{"data":{"receipt":{"points":28}}}`,
+			statusCode:       200,
+			mockCall:         receiptService.EXPECT().Find(receiptID).Return(receipt, nil),
+		},
+		{
+			id: 3, useCase: "Negative case: mutation in a GET request",
+			method:           "GET",
+			target:           "?query=" + url.QueryEscape(`mutation { processReceipt(receipt: {}) { id } }`),
+			expectedResponse: `{"errors":[{"message":"Mutations are only accepted in POST requests","extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}}]}`,
+			statusCode:       200,
+		},
+		{
+			id: 4, useCase: "Negative case: query deeper than the maximum depth",
+			method:           "POST",
+			body:             `{"query":"{ receipts { receipts { items { price } } } }"}`,
+			expectedResponse: `{"errors":[{"message":"Query depth 4 exceeds the maximum depth of 3","extensions":{"code":"QUERY_TOO_COMPLEX"}}]}`,
+			statusCode:       200,
+		},
+		{
+			id: 5, useCase: "Negative case: invalid variables in a GET request",
+			method:           "GET",
+			target:           "?query=" + url.QueryEscape(`{ receipts { totalCount } }`) + "&variables=%7B",
+			expectedResponse: `Incorrect value for parameter: variables`,
+			statusCode:       400,
+		},
+		{
+			id: 6, useCase: "Negative case: malformed body",
+			method:           "POST",
+			body:             `{"query":`,
+			expectedResponse: `unexpected end of JSON input`,
+			statusCode:       400,
+		},
+		{
+			id: 7, useCase: "Negative case: missing query",
+			method:           "POST",
+			body:             `{"variables":{}}`,
+			expectedResponse: `Parameter query is required for this request`,
+			statusCode:       400,
+		},
+		{
+			id: 8, useCase: "Negative case: query longer than the maximum length",
+			method:           "POST",
+			body:             `{"query":"{ receipts { totalCount } }` + strings.Repeat(" ", maxGraphQLQueryLength) + `"}`,
+			expectedResponse: `Query is longer than the maximum length of 16384 bytes`,
+			statusCode:       400,
+		},
+		{
+			id: 9, useCase: "Negative case: more fragments than the maximum",
+			method:           "POST",
+			body:             `{"query":"{ receipts { totalCount } }` + fragments(maxGraphQLFragments+1) + `"}`,
+			expectedResponse: `Query defines 33 fragments, more than the maximum of 32`,
+			statusCode:       200,
+		},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(tc.method, "/graphql"+tc.target, strings.NewReader(tc.body))
+
+		handler.Query(w, r)
+
+		resp, _ := io.ReadAll(w.Result().Body)
+		assert.Equal(t, tc.statusCode, w.Result().StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Contains(t, string(resp), tc.expectedResponse, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+// fragments returns the definitions of n fragments on the receipts query.
+func fragments(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, " fragment F%v on Query { receipts { totalCount } }", i)
+	}
+
+	return b.String()
+}
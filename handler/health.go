@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/health"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/responder"
+)
+
+// healthHandler is a HTTP handler for the liveness and readiness endpoints.
+type healthHandler struct {
+	logger  *log.CustomLogger
+	checker *health.Checker
+}
+
+// NewHealth creates and returns a new instance of healthHandler.
+func NewHealth(l *log.CustomLogger, checker *health.Checker) *healthHandler {
+	return &healthHandler{
+		logger:  l,
+		checker: checker,
+	}
+}
+
+// Live handles HTTP GET requests to check whether the service is up.
+// It responds with 200 as long as the process is able to serve requests.
+func (hh *healthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	responder.SetResponse(hh.checker.Live(), http.StatusOK, w)
+}
+
+// Ready handles HTTP GET requests to check whether the service can take traffic.
+// It responds with 503 when a dependency check fails or the server is draining for shutdown.
+func (hh *healthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	report := hh.checker.Ready()
+	if report.Status != health.StatusUp {
+		lm := log.Message{Level: "WARN", Method: r.Method, URI: r.RequestURI, StatusCode: http.StatusServiceUnavailable, Msg: "readiness check failed"}
+		hh.logger.LogContext(r.Context(), &lm)
+
+		responder.SetResponse(report, http.StatusServiceUnavailable, w)
+		return
+	}
+
+	responder.SetResponse(report, http.StatusOK, w)
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	er "errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/health"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+)
+
+func TestHealthHandler(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		checkErr         error
+		draining         bool
+		ready            bool
+		statusCode       int
+		expectedResponse string
+	}{
+		{
+			id: 1, useCase: "Positive case: live",
+			statusCode:       200,
+			expectedResponse: `^{"status":"up","timestamp":".*"}$`,
+		},
+		{
+			id: 2, useCase: "Positive case: ready",
+			ready:            true,
+			statusCode:       200,
+			expectedResponse: `^{"status":"up","checks":{"shutdown":{"status":"up"},"store":{"status":"up"}},"timestamp":".*"}$`,
+		},
+		{
+			id: 3, useCase: "Negative case: not ready, store unreachable",
+			checkErr:         er.New("store unreachable"),
+			ready:            true,
+			statusCode:       503,
+			expectedResponse: `"store":{"status":"down","error":"store unreachable"}`,
+		},
+		{
+			id: 4, useCase: "Negative case: not ready, draining",
+			draining:         true,
+			ready:            true,
+			statusCode:       503,
+			expectedResponse: `"shutdown":{"status":"down","error":"shutdown in progress"}`,
+		},
+		{
+			id: 5, useCase: "Positive case: live while draining",
+			draining:         true,
+			statusCode:       200,
+			expectedResponse: `^{"status":"up","timestamp":".*"}$`,
+		},
+	}
+
+	for _, tc := range testCases {
+		checkErr := tc.checkErr
+		checker := health.New(health.Check{Name: "store", Fn: func() error { return checkErr }})
+		if tc.draining {
+			checker.SetDraining()
+		}
+
+		handler := NewHealth(logger, checker)
+
+		w := httptest.NewRecorder()
+		if tc.ready {
+			handler.Ready(w, httptest.NewRequest("GET", "/v1/health/ready", nil))
+		} else {
+			handler.Live(w, httptest.NewRequest("GET", "/v1/health/live", nil))
+		}
+
+		result := w.Result()
+		resp, _ := io.ReadAll(result.Body)
+
+		assert.Equal(t, tc.statusCode, result.StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Regexp(t, tc.expectedResponse, string(resp), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
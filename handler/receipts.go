@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
 	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
 	"github/shivasaicharanruthala/backend-engineer-takehome/log"
 	"github/shivasaicharanruthala/backend-engineer-takehome/model"
@@ -18,14 +19,31 @@ import (
 type receiptsHandler struct {
 	logger *log.CustomLogger
 	svc    service.Receipts
+	jobs   service.Jobs // Queue of receipts processed asynchronously, nil when receipts are processed synchronously.
+}
+
+// Option configures optional dependencies of receiptsHandler.
+type Option func(*receiptsHandler)
+
+// WithJobs queues submitted receipts for asynchronous processing instead of scoring them within the request.
+func WithJobs(jobs service.Jobs) Option {
+	return func(rh *receiptsHandler) {
+		rh.jobs = jobs
+	}
 }
 
 // New creates and returns a new instance of receiptsHandler.
-func New(l *log.CustomLogger, svc service.Receipts) *receiptsHandler {
-	return &receiptsHandler{
+func New(l *log.CustomLogger, svc service.Receipts, opts ...Option) *receiptsHandler {
+	rh := &receiptsHandler{
 		logger: l,
 		svc:    svc,
 	}
+
+	for _, opt := range opts {
+		opt(rh)
+	}
+
+	return rh
 }
 
 // Get handles HTTP GET requests to retrieve a receipt by its ID.
@@ -55,6 +73,13 @@ func (rh *receiptsHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Clients can only read their own receipts, others are reported as not found to not disclose they exist.
+	if principal := auth.FromContext(r.Context()); principal != nil && !principal.CanAccess(receiptPoints.ClientID, receiptPoints.UserID) {
+		responder.SetErrorResponse(rh.logger, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "receipts", ID: receiptID}), w, r)
+
+		return
+	}
+
 	// Responds with the receipt points if successfully retrieved.
 	responder.SetResponse(receiptPoints, 200, w)
 	return
@@ -83,8 +108,31 @@ func (rh *receiptsHandler) Insert(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Records the submitting client on the receipt, users authenticated themselves only submit receipts on their own behalf.
+	if err = auth.FromContext(r.Context()).BindReceipt(&receipt); err != nil {
+		responder.SetErrorResponse(rh.logger, err, w, r)
+
+		return
+	}
+
+	// Queues the receipt when processing is asynchronous, its status is reported under the ID of the job.
+	if rh.jobs != nil {
+		job, err := rh.jobs.Enqueue(&receipt)
+		if err != nil {
+			responder.SetErrorResponse(rh.logger, err, w, r)
+
+			return
+		}
+
+		statusURL := "/v1/receipts/" + job.ID
+		w.Header().Set("Location", statusURL)
+		responder.SetResponse(model.ReceiptQueuedResponse{Id: job.ID, Status: job.Status, StatusURL: statusURL}, 202, w)
+
+		return
+	}
+
 	// service call to insert receipt
-	receiptResponse, err := rh.svc.Insert(&receipt)
+	receiptResponse, err := rh.svc.Insert(r.Context(), &receipt)
 	if err != nil {
 		responder.SetErrorResponse(rh.logger, err, w, r)
 
@@ -95,9 +143,57 @@ func (rh *receiptsHandler) Insert(w http.ResponseWriter, r *http.Request) {
 	return
 }
 
-// Health handles HTTP GET requests to check the health status of the service.
-// It responds with a simple health check message.
-func (rh *receiptsHandler) Health(w http.ResponseWriter, r *http.Request) {
-	resp := "{'health': 'ok'}"
-	responder.SetResponse(resp, 200, w)
+// Status handles HTTP GET requests to retrieve the processing status of a receipt by its ID, along with its points once processed.
+// Receipts processed synchronously are reported as processed.
+func (rh *receiptsHandler) Status(w http.ResponseWriter, r *http.Request) {
+	receiptID := mux.Vars(r)["id"]
+	if !model.IsValidUUID(receiptID) {
+		responder.SetErrorResponse(rh.logger, errors.NewInvalidParam(errors.InvalidParam{Param: "id"}), w, r)
+
+		return
+	}
+
+	status, err := rh.status(receiptID)
+	if err != nil {
+		responder.SetErrorResponse(rh.logger, err, w, r)
+
+		return
+	}
+
+	// Clients can only read their own receipts, others are reported as not found to not disclose they exist.
+	if principal := auth.FromContext(r.Context()); principal != nil && !principal.CanAccess(status.ClientID, status.UserID) {
+		responder.SetErrorResponse(rh.logger, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "receipts", ID: receiptID}), w, r)
+
+		return
+	}
+
+	responder.SetResponse(status, 200, w)
+}
+
+// status returns the processing status of a receipt from its job, or from the stored receipt when it was never queued.
+func (rh *receiptsHandler) status(receiptID string) (*model.ReceiptStatusResponse, error) {
+	if rh.jobs != nil {
+		if job, err := rh.jobs.Get(receiptID); err == nil {
+			status := &model.ReceiptStatusResponse{Id: job.ID, Status: job.Status, Error: job.Error, ClientID: job.ClientID, UserID: job.UserID}
+			if job.Status != model.JobProcessed {
+				return status, nil
+			}
+
+			receipt, err := rh.svc.Get(receiptID)
+			if err != nil {
+				return nil, err
+			}
+
+			status.Points = &receipt.Points
+
+			return status, nil
+		}
+	}
+
+	receipt, err := rh.svc.Get(receiptID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ReceiptStatusResponse{Id: receiptID, Status: model.JobProcessed, Points: &receipt.Points, ClientID: receipt.ClientID, UserID: receipt.UserID}, nil
 }
@@ -2,9 +2,12 @@ package handler
 
 import (
 	"bytes"
+	er "errors"
+	"fmt"
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
 	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
 	"github/shivasaicharanruthala/backend-engineer-takehome/log"
 	"github/shivasaicharanruthala/backend-engineer-takehome/model"
@@ -127,7 +130,7 @@ func TestHandlerInsert(t *testing.T) {
 			reqBody:          `{}`,
 			statusCode:       400,
 			expectedResponse: "Parameter retailer is required for this request",
-			mockCall: receiptService.EXPECT().Insert(&model.Receipt{}).
+			mockCall: receiptService.EXPECT().Insert(gomock.Any(), &model.Receipt{}).
 				Return(nil, errors.NewMissingParam(errors.MissingParam{Param: "retailer"}))},
 		{
 			id: 4, useCase: "Positive case: valid body",
@@ -135,7 +138,7 @@ func TestHandlerInsert(t *testing.T) {
 			reqBody:          `{"retailer": "Target", "purchaseDate": "2022-01-01", "purchaseTime": "13:01", "items": [{"shortDescription": "Mountain Dew 12PK", "price": "5.00"}], "total": "5.00"}`,
 			statusCode:       201,
 			expectedResponse: "",
-			mockCall: receiptService.EXPECT().Insert(&model.Receipt{
+			mockCall: receiptService.EXPECT().Insert(gomock.Any(), &model.Receipt{
 				Retailer:     model.StringPointer("Target"),
 				PurchaseDate: model.StringPointer("2022-01-01"),
 				PurchaseTime: model.StringPointer("13:01"),
@@ -163,3 +166,227 @@ func TestHandlerInsert(t *testing.T) {
 		}
 	}
 }
+
+func TestHandlerGet_Ownership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	receiptService := service.NewMockReceipts(ctrl)
+	logger, _ := log.NewCustomLogger("test.log")
+	handler := New(logger, receiptService)
+
+	receiptID := "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f"
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		principal        *model.Principal
+		expectedResponse string
+		statusCode       int
+	}{
+		{
+			id: 1, useCase: "Positive case: owner reads its receipt",
+			principal:        &model.Principal{ClientID: "partner-a", Scopes: []string{model.ScopeRead}},
+			expectedResponse: `{"points":10}`,
+			statusCode:       200,
+		},
+		{
+			id: 2, useCase: "Negative case: other client cannot read the receipt",
+			principal:        &model.Principal{ClientID: "partner-b", Scopes: []string{model.ScopeRead}},
+			expectedResponse: "No 'receipts' found for Id: '4a77ec9d-5334-43d0-a9e1-4fca8807bf8f'",
+			statusCode:       404,
+		},
+		{
+			id: 3, useCase: "Positive case: admin reads receipts of every client",
+			principal:        &model.Principal{ClientID: "ops", Scopes: []string{model.ScopeAdmin}},
+			expectedResponse: `{"points":10}`,
+			statusCode:       200,
+		},
+	}
+
+	for _, tc := range testCases {
+		receiptService.EXPECT().Get(receiptID).Return(&model.ReceiptGetResponse{Points: 10, ClientID: "partner-a"}, nil)
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/v1/receipts/"+receiptID+"/points", nil)
+		r = mux.SetURLVars(r, map[string]string{"id": receiptID})
+		r = r.WithContext(auth.NewContext(r.Context(), tc.principal))
+
+		handler.Get(w, r)
+		result := w.Result()
+		resp, _ := io.ReadAll(result.Body)
+
+		assert.Equal(t, tc.statusCode, result.StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Regexp(t, regexp.MustCompile(tc.expectedResponse), string(resp), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestHandlerInsert_RecordsClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	receiptService := service.NewMockReceipts(ctrl)
+	logger, _ := log.NewCustomLogger("test.log")
+	handler := New(logger, receiptService)
+
+	receiptService.EXPECT().Insert(gomock.Any(), &model.Receipt{Retailer: model.StringPointer("Target"), ClientID: "partner-a"}).
+		Return(&model.ReceiptPostResponse{Id: "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f"}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/v1/receipts/process", bytes.NewBuffer([]byte(`{"retailer": "Target"}`)))
+	r = r.WithContext(auth.NewContext(r.Context(), &model.Principal{ClientID: "partner-a", Scopes: []string{model.ScopeSubmit}}))
+
+	handler.Insert(w, r)
+
+	assert.Equal(t, 201, w.Result().StatusCode)
+}
+
+func TestHandlerInsert_OnBehalfOfUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	receiptService := service.NewMockReceipts(ctrl)
+	logger, _ := log.NewCustomLogger("test.log")
+	handler := New(logger, receiptService)
+
+	user := &model.Principal{ClientID: "app", UserID: "user-1", Scopes: []string{model.ScopeSubmit}}
+
+	testCases := []struct {
+		id         int
+		useCase    string
+		body       string
+		principal  *model.Principal
+		statusCode int
+		mockCall   *gomock.Call
+	}{
+		{
+			id: 1, useCase: "Positive case: API client submits on behalf of a user",
+			body:       `{"retailer": "Target", "userId": "user-2"}`,
+			principal:  &model.Principal{ClientID: "partner-a", Scopes: []string{model.ScopeSubmit}},
+			statusCode: 201,
+			mockCall: receiptService.EXPECT().Insert(gomock.Any(), &model.Receipt{Retailer: model.StringPointer("Target"), UserID: "user-2", ClientID: "partner-a"}).
+				Return(&model.ReceiptPostResponse{Id: "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f"}, nil),
+		},
+		{
+			id: 2, useCase: "Positive case: authenticated user submits without a user id",
+			body:       `{"retailer": "Walmart"}`,
+			principal:  user,
+			statusCode: 201,
+			mockCall: receiptService.EXPECT().Insert(gomock.Any(), &model.Receipt{Retailer: model.StringPointer("Walmart"), UserID: "user-1", ClientID: "app"}).
+				Return(&model.ReceiptPostResponse{Id: "5a77ec9d-5334-43d0-a9e1-4fca8807bf8f"}, nil),
+		},
+		{
+			id: 3, useCase: "Negative case: authenticated user submits on behalf of another user",
+			body:       `{"retailer": "Target", "userId": "user-2"}`,
+			principal:  user,
+			statusCode: 403,
+		},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/v1/receipts/process", bytes.NewBuffer([]byte(tc.body)))
+		r = r.WithContext(auth.NewContext(r.Context(), tc.principal))
+
+		handler.Insert(w, r)
+
+		assert.Equal(t, tc.statusCode, w.Result().StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestHandlerInsert_Async(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	receiptService := service.NewMockReceipts(ctrl)
+	jobs := service.NewMockJobs(ctrl)
+	logger, _ := log.NewCustomLogger("test.log")
+	handler := New(logger, receiptService, WithJobs(jobs))
+
+	jobs.EXPECT().Enqueue(&model.Receipt{Retailer: model.StringPointer("Target"), ClientID: "partner-a"}).
+		Return(&model.ReceiptJob{ID: "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f", Status: model.JobPending}, nil)
+	jobs.EXPECT().Enqueue(gomock.Any()).Return(nil, errors.NewCustomError(er.New("receipt queue is full"), 503))
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		statusCode       int
+		expectedLocation string
+		expectedResponse string
+	}{
+		{
+			id: 1, useCase: "Positive case: receipt queued",
+			statusCode:       202,
+			expectedLocation: "/v1/receipts/4a77ec9d-5334-43d0-a9e1-4fca8807bf8f",
+			expectedResponse: `{"id":"4a77ec9d-5334-43d0-a9e1-4fca8807bf8f","status":"pending","statusUrl":"/v1/receipts/4a77ec9d-5334-43d0-a9e1-4fca8807bf8f"}`,
+		},
+		{
+			id: 2, useCase: "Negative case: queue full",
+			statusCode:       503,
+			expectedResponse: `"detail":"Service Unavailable"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/v1/receipts/process", bytes.NewBuffer([]byte(`{"retailer": "Target"}`)))
+		r = r.WithContext(auth.NewContext(r.Context(), &model.Principal{ClientID: "partner-a", Scopes: []string{model.ScopeSubmit}}))
+
+		handler.Insert(w, r)
+		resp, _ := io.ReadAll(w.Result().Body)
+
+		assert.Equal(t, tc.statusCode, w.Result().StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedLocation, w.Result().Header.Get("Location"), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Contains(t, string(resp), tc.expectedResponse, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestHandlerStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	receiptService := service.NewMockReceipts(ctrl)
+	jobs := service.NewMockJobs(ctrl)
+	logger, _ := log.NewCustomLogger("test.log")
+
+	pendingID, processedID, failedID, syncID := "1a77ec9d-5334-43d0-a9e1-4fca8807bf8f", "2a77ec9d-5334-43d0-a9e1-4fca8807bf8f", "3a77ec9d-5334-43d0-a9e1-4fca8807bf8f", "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f"
+	notFound := func(id string) error {
+		return errors.NewEntityNotFound(errors.EntityNotFound{Entity: "jobs", ID: id})
+	}
+
+	jobs.EXPECT().Get(pendingID).Return(&model.ReceiptJob{ID: pendingID, Status: model.JobPending, ClientID: "partner-a"}, nil).AnyTimes()
+	jobs.EXPECT().Get(processedID).Return(&model.ReceiptJob{ID: processedID, Status: model.JobProcessed, ClientID: "partner-a"}, nil).AnyTimes()
+	jobs.EXPECT().Get(failedID).Return(&model.ReceiptJob{ID: failedID, Status: model.JobFailed, Error: "Incorrect value for parameter: purchaseDate", ClientID: "partner-a"}, nil).AnyTimes()
+	jobs.EXPECT().Get(syncID).Return(nil, notFound(syncID)).AnyTimes()
+	receiptService.EXPECT().Get(processedID).Return(&model.ReceiptGetResponse{Points: 18, ClientID: "partner-a"}, nil).AnyTimes()
+	receiptService.EXPECT().Get(syncID).Return(&model.ReceiptGetResponse{Points: 32, ClientID: "partner-a"}, nil).AnyTimes()
+
+	partnerA := &model.Principal{ClientID: "partner-a", Scopes: []string{model.ScopeRead}}
+	partnerB := &model.Principal{ClientID: "partner-b", Scopes: []string{model.ScopeRead}}
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		receiptID        string
+		principal        *model.Principal
+		async            bool
+		statusCode       int
+		expectedResponse string
+	}{
+		{id: 1, useCase: "Positive case: pending receipt", receiptID: pendingID, principal: partnerA, async: true, statusCode: 200, expectedResponse: `{"id":"` + pendingID + `","status":"pending"}`},
+		{id: 2, useCase: "Positive case: processed receipt with its points", receiptID: processedID, principal: partnerA, async: true, statusCode: 200, expectedResponse: `{"id":"` + processedID + `","status":"processed","points":18}`},
+		{id: 3, useCase: "Positive case: failed receipt with the error", receiptID: failedID, principal: partnerA, async: true, statusCode: 200, expectedResponse: `{"id":"` + failedID + `","status":"failed","error":"Incorrect value for parameter: purchaseDate"}`},
+		{id: 4, useCase: "Positive case: receipt processed before the queue", receiptID: syncID, principal: partnerA, async: true, statusCode: 200, expectedResponse: `{"id":"` + syncID + `","status":"processed","points":32}`},
+		{id: 5, useCase: "Positive case: synchronous processing", receiptID: syncID, principal: partnerA, statusCode: 200, expectedResponse: `{"id":"` + syncID + `","status":"processed","points":32}`},
+		{id: 6, useCase: "Negative case: receipt of another client", receiptID: pendingID, principal: partnerB, async: true, statusCode: 404, expectedResponse: "No 'receipts' found for Id"},
+		{id: 7, useCase: "Negative case: invalid id", receiptID: "1234", principal: partnerA, async: true, statusCode: 400, expectedResponse: "Incorrect value for parameter: id"},
+	}
+
+	for _, tc := range testCases {
+		handler := New(logger, receiptService)
+		if tc.async {
+			handler = New(logger, receiptService, WithJobs(jobs))
+		}
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/v1/receipts/"+tc.receiptID, nil)
+		r = mux.SetURLVars(r, map[string]string{"id": tc.receiptID})
+		r = r.WithContext(auth.NewContext(r.Context(), tc.principal))
+
+		handler.Status(w, r)
+		resp, _ := io.ReadAll(w.Result().Body)
+
+		assert.Equal(t, tc.statusCode, w.Result().StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Contains(t, string(resp), tc.expectedResponse, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/responder"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+// retailersHandler is a HTTP handler for the retailer catalog endpoints.
+type retailersHandler struct {
+	logger *log.CustomLogger
+	svc    service.Retailers
+}
+
+// NewRetailers creates and returns a new instance of retailersHandler.
+func NewRetailers(l *log.CustomLogger, svc service.Retailers) *retailersHandler {
+	return &retailersHandler{
+		logger: l,
+		svc:    svc,
+	}
+}
+
+// List handles HTTP GET requests to retrieve every retailer of the catalog.
+func (rh *retailersHandler) List(w http.ResponseWriter, r *http.Request) {
+	responder.SetResponse(rh.svc.List(), 200, w)
+}
+
+// Get handles HTTP GET requests to retrieve a retailer by its ID.
+func (rh *retailersHandler) Get(w http.ResponseWriter, r *http.Request) {
+	retailerID, ok := rh.retailerID(w, r)
+	if !ok {
+		return
+	}
+
+	retailer, err := rh.svc.Get(retailerID)
+	if err != nil {
+		responder.SetErrorResponse(rh.logger, err, w, r)
+
+		return
+	}
+
+	responder.SetResponse(retailer, 200, w)
+}
+
+// Insert handles HTTP POST requests to add a retailer to the catalog.
+func (rh *retailersHandler) Insert(w http.ResponseWriter, r *http.Request) {
+	var retailer model.Retailer
+	if err := decodeBody(r, &retailer); err != nil {
+		responder.SetErrorResponse(rh.logger, err, w, r)
+
+		return
+	}
+
+	resp, err := rh.svc.Insert(r.Context(), &retailer)
+	if err != nil {
+		responder.SetErrorResponse(rh.logger, err, w, r)
+
+		return
+	}
+
+	responder.SetResponse(resp, 201, w)
+}
+
+// Update handles HTTP PUT requests to replace a retailer.
+func (rh *retailersHandler) Update(w http.ResponseWriter, r *http.Request) {
+	retailerID, ok := rh.retailerID(w, r)
+	if !ok {
+		return
+	}
+
+	var retailer model.Retailer
+	if err := decodeBody(r, &retailer); err != nil {
+		responder.SetErrorResponse(rh.logger, err, w, r)
+
+		return
+	}
+
+	resp, err := rh.svc.Update(r.Context(), retailerID, &retailer)
+	if err != nil {
+		responder.SetErrorResponse(rh.logger, err, w, r)
+
+		return
+	}
+
+	responder.SetResponse(resp, 200, w)
+}
+
+// Delete handles HTTP DELETE requests to remove a retailer from the catalog.
+func (rh *retailersHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	retailerID, ok := rh.retailerID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := rh.svc.Delete(retailerID); err != nil {
+		responder.SetErrorResponse(rh.logger, err, w, r)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// retailerID validates the retailer ID path parameter.
+func (rh *retailersHandler) retailerID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	retailerID := mux.Vars(r)["id"]
+	if !model.IsValidUUID(retailerID) {
+		responder.SetErrorResponse(rh.logger, errors.NewInvalidParam(errors.InvalidParam{Param: "id"}), w, r)
+
+		return "", false
+	}
+
+	return retailerID, true
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+func TestHandlerRetailers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	retailersService := service.NewMockRetailers(ctrl)
+	logger, _ := log.NewCustomLogger("test.log")
+	handler := NewRetailers(logger, retailersService)
+
+	retailerID := "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f"
+	retailer := &model.Retailer{ID: retailerID, Name: "Target", Aliases: []string{"target store"}}
+	body := `{"name": "Target", "aliases": ["target store"]}`
+	notFound := errors.NewEntityNotFound(errors.EntityNotFound{Entity: "retailers", ID: retailerID})
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		method           string
+		retailerID       string
+		body             string
+		expectedResponse string
+		statusCode       int
+		mockCall         *gomock.Call
+	}{
+		{
+			id: 1, useCase: "Positive case: create retailer",
+			method: "POST", body: body,
+			expectedResponse: `{"id":"4a77ec9d-5334-43d0-a9e1-4fca8807bf8f","name":"Target","aliases":["target store"]}`,
+			statusCode:       201,
+			mockCall:         retailersService.EXPECT().Insert(gomock.Any(), &model.Retailer{Name: "Target", Aliases: []string{"target store"}}).Return(retailer, nil),
+		},
+		{
+			id: 2, useCase: "Negative case: get with invalid id",
+			method: "GET", retailerID: "c-1",
+			expectedResponse: "Incorrect value for parameter: id",
+			statusCode:       400,
+		},
+		{
+			id: 3, useCase: "Positive case: get retailer",
+			method: "GET", retailerID: retailerID,
+			expectedResponse: `"name":"Target"`,
+			statusCode:       200,
+			mockCall:         retailersService.EXPECT().Get(retailerID).Return(retailer, nil),
+		},
+		{
+			id: 4, useCase: "Positive case: update retailer",
+			method: "PUT", retailerID: retailerID, body: body,
+			expectedResponse: `"aliases":["target store"]`,
+			statusCode:       200,
+			mockCall:         retailersService.EXPECT().Update(gomock.Any(), retailerID, gomock.Any()).Return(retailer, nil),
+		},
+		{
+			id: 5, useCase: "Positive case: delete retailer",
+			method: "DELETE", retailerID: retailerID,
+			statusCode: 204,
+			mockCall:   retailersService.EXPECT().Delete(retailerID).Return(nil),
+		},
+		{
+			id: 6, useCase: "Negative case: delete unknown retailer",
+			method: "DELETE", retailerID: retailerID,
+			expectedResponse: "No 'retailers' found for Id: '4a77ec9d-5334-43d0-a9e1-4fca8807bf8f'",
+			statusCode:       404,
+			mockCall:         retailersService.EXPECT().Delete(retailerID).Return(notFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(tc.method, "/v1/retailers/"+tc.retailerID, bytes.NewBufferString(tc.body))
+		r = mux.SetURLVars(r, map[string]string{"id": tc.retailerID})
+
+		switch tc.method {
+		case "POST":
+			handler.Insert(w, r)
+		case "GET":
+			handler.Get(w, r)
+		case "PUT":
+			handler.Update(w, r)
+		case "DELETE":
+			handler.Delete(w, r)
+		}
+
+		resp, _ := io.ReadAll(w.Result().Body)
+		assert.Equal(t, tc.statusCode, w.Result().StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Contains(t, string(resp), tc.expectedResponse, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/responder"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+// returnsHandler is a HTTP handler for the returns and adjustment history endpoints of receipts.
+type returnsHandler struct {
+	logger *log.CustomLogger
+	svc    service.Returns
+}
+
+// NewReturns creates and returns a new instance of returnsHandler.
+func NewReturns(l *log.CustomLogger, svc service.Returns) *returnsHandler {
+	return &returnsHandler{
+		logger: l,
+		svc:    svc,
+	}
+}
+
+// Insert handles HTTP POST requests submitting a return of items of a receipt.
+func (rh *returnsHandler) Insert(w http.ResponseWriter, r *http.Request) {
+	receiptID := mux.Vars(r)["id"]
+	if !model.IsValidUUID(receiptID) {
+		responder.SetErrorResponse(rh.logger, errors.NewInvalidParam(errors.InvalidParam{Param: "id"}), w, r)
+
+		return
+	}
+
+	var req model.ReturnRequest
+	if err := decodeBody(r, &req); err != nil {
+		responder.SetErrorResponse(rh.logger, err, w, r)
+
+		return
+	}
+
+	adjustment, err := rh.svc.Return(receiptID, &req, auth.FromContext(r.Context()))
+	if err != nil {
+		responder.SetErrorResponse(rh.logger, err, w, r)
+
+		return
+	}
+
+	responder.SetResponse(adjustment, 201, w)
+}
+
+// History handles HTTP GET requests to retrieve the adjustment history of a receipt.
+func (rh *returnsHandler) History(w http.ResponseWriter, r *http.Request) {
+	receiptID := mux.Vars(r)["id"]
+	if !model.IsValidUUID(receiptID) {
+		responder.SetErrorResponse(rh.logger, errors.NewInvalidParam(errors.InvalidParam{Param: "id"}), w, r)
+
+		return
+	}
+
+	history, err := rh.svc.History(receiptID, auth.FromContext(r.Context()))
+	if err != nil {
+		responder.SetErrorResponse(rh.logger, err, w, r)
+
+		return
+	}
+
+	responder.SetResponse(history, 200, w)
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+func TestHandlerReturnInsert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	returnsService := service.NewMockReturns(ctrl)
+	logger, _ := log.NewCustomLogger("test.log")
+	handler := NewReturns(logger, returnsService)
+
+	receiptID := "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f"
+	req := &model.ReturnRequest{Items: []model.Item{{ShortDescription: model.StringPointer("Gum"), Price: model.StringPointer("0.35")}}}
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		receiptID        string
+		body             string
+		expectedResponse string
+		statusCode       int
+		mockCall         *gomock.Call
+	}{
+		{
+			id: 1, useCase: "Negative case: invalid receipt id",
+			receiptID: "4a77ec9d", body: `{}`,
+			expectedResponse: "Incorrect value for parameter: id",
+			statusCode:       400,
+		},
+		{
+			id: 2, useCase: "Negative case: item not on the receipt",
+			receiptID: receiptID, body: `{"items": [{"shortDescription": "Gum", "price": "0.35"}]}`,
+			expectedResponse: "Item 'Gum' priced 0.35 is not on the receipt or was already returned",
+			statusCode:       400,
+			mockCall: returnsService.EXPECT().Return(receiptID, req, nil).
+				Return(nil, errors.NewInvalidParam(fmt.Errorf("Item 'Gum' priced 0.35 is not on the receipt or was already returned"))),
+		},
+		{
+			id: 3, useCase: "Positive case: return recorded",
+			receiptID: receiptID, body: `{"items": [{"shortDescription": "Gum", "price": "0.35"}]}`,
+			expectedResponse: `"pointsBefore":28,"pointsAfter":25,"points":-3`,
+			statusCode:       201,
+			mockCall: returnsService.EXPECT().Return(receiptID, req, nil).
+				Return(&model.Adjustment{ID: "a-1", ReceiptID: receiptID, Type: model.AdjustmentReturn, PointsBefore: 28, PointsAfter: 25, Points: -3}, nil),
+		},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/v1/receipts/"+tc.receiptID+"/returns", bytes.NewBufferString(tc.body))
+		r = mux.SetURLVars(r, map[string]string{"id": tc.receiptID})
+
+		handler.Insert(w, r)
+
+		resp, _ := io.ReadAll(w.Result().Body)
+		assert.Equal(t, tc.statusCode, w.Result().StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Contains(t, string(resp), tc.expectedResponse, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestHandlerReturnHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	returnsService := service.NewMockReturns(ctrl)
+	logger, _ := log.NewCustomLogger("test.log")
+	handler := NewReturns(logger, returnsService)
+
+	receiptID := "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f"
+	returnsService.EXPECT().History(receiptID, nil).
+		Return(&model.AdjustmentHistory{ReceiptID: receiptID, OriginalPoints: 28, Points: 25, Adjustments: []model.Adjustment{}}, nil)
+
+	w := httptest.NewRecorder()
+	r := mux.SetURLVars(httptest.NewRequest("GET", "/v1/receipts/"+receiptID+"/adjustments", nil), map[string]string{"id": receiptID})
+	handler.History(w, r)
+
+	assert.Equal(t, 200, w.Result().StatusCode)
+	assert.Equal(t, `{"receiptId":"4a77ec9d-5334-43d0-a9e1-4fca8807bf8f","originalPoints":28,"points":25,"adjustments":[]}`, w.Body.String())
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/responder"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+// reviewsHandler is a HTTP handler for the fraud review queue endpoints.
+type reviewsHandler struct {
+	logger *log.CustomLogger
+	svc    service.Reviews
+}
+
+// NewReviews creates and returns a new instance of reviewsHandler.
+func NewReviews(l *log.CustomLogger, svc service.Reviews) *reviewsHandler {
+	return &reviewsHandler{
+		logger: l,
+		svc:    svc,
+	}
+}
+
+// List handles HTTP GET requests to retrieve the review queue, the status query parameter defaults to pending reviews.
+func (rh *reviewsHandler) List(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = model.ReviewPending
+	}
+
+	if !model.IsValidReviewStatus(status) {
+		responder.SetErrorResponse(rh.logger, errors.NewInvalidParam(errors.InvalidParam{Param: "status"}), w, r)
+
+		return
+	}
+
+	responder.SetResponse(rh.svc.List(status), 200, w)
+}
+
+// Decide handles HTTP POST requests approving or rejecting a receipt pending review.
+func (rh *reviewsHandler) Decide(w http.ResponseWriter, r *http.Request) {
+	receiptID := mux.Vars(r)["id"]
+	if !model.IsValidUUID(receiptID) {
+		responder.SetErrorResponse(rh.logger, errors.NewInvalidParam(errors.InvalidParam{Param: "id"}), w, r)
+
+		return
+	}
+
+	var decision model.ReviewDecision
+	if err := decodeBody(r, &decision); err != nil {
+		responder.SetErrorResponse(rh.logger, err, w, r)
+
+		return
+	}
+
+	review, err := rh.svc.Decide(r.Context(), receiptID, &decision)
+	if err != nil {
+		responder.SetErrorResponse(rh.logger, err, w, r)
+
+		return
+	}
+
+	responder.SetResponse(review, 200, w)
+}
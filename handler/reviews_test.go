@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+func TestHandlerReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	reviewsService := service.NewMockReviews(ctrl)
+	logger, _ := log.NewCustomLogger("test.log")
+	handler := NewReviews(logger, reviewsService)
+
+	receiptID := "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f"
+	review := model.Review{ReceiptID: receiptID, Points: 15, Status: model.ReviewPending, Risk: model.RiskAssessment{Score: 80}}
+	approved := review
+	approved.Status = model.ReviewApproved
+	conflict := errors.NewConflict(fmt.Errorf("Review of receipt '%v' was already decided: approved", receiptID))
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		method           string
+		target           string
+		receiptID        string
+		body             string
+		expectedResponse string
+		statusCode       int
+		mockCall         *gomock.Call
+	}{
+		{
+			id: 1, useCase: "Positive case: pending reviews by default",
+			method: "GET", target: "/v1/reviews",
+			expectedResponse: `"status":"pending_review"`,
+			statusCode:       200,
+			mockCall:         reviewsService.EXPECT().List(model.ReviewPending).Return([]model.Review{review}),
+		},
+		{
+			id: 2, useCase: "Negative case: unknown status",
+			method: "GET", target: "/v1/reviews?status=done",
+			expectedResponse: "Incorrect value for parameter: status",
+			statusCode:       400,
+		},
+		{
+			id: 3, useCase: "Negative case: invalid receipt id",
+			method: "POST", target: "/v1/reviews/1234/decision", receiptID: "1234", body: `{"decision": "approve"}`,
+			expectedResponse: "Incorrect value for parameter: id",
+			statusCode:       400,
+		},
+		{
+			id: 4, useCase: "Positive case: review approved",
+			method: "POST", target: "/v1/reviews/" + receiptID + "/decision", receiptID: receiptID, body: `{"decision": "approve"}`,
+			expectedResponse: `"status":"approved"`,
+			statusCode:       200,
+			mockCall:         reviewsService.EXPECT().Decide(gomock.Any(), receiptID, &model.ReviewDecision{Decision: model.DecisionApprove}).Return(&approved, nil),
+		},
+		{
+			id: 5, useCase: "Negative case: review already decided",
+			method: "POST", target: "/v1/reviews/" + receiptID + "/decision", receiptID: receiptID, body: `{"decision": "reject"}`,
+			expectedResponse: "was already decided",
+			statusCode:       409,
+			mockCall:         reviewsService.EXPECT().Decide(gomock.Any(), receiptID, gomock.Any()).Return(nil, conflict),
+		},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(tc.method, tc.target, bytes.NewBufferString(tc.body))
+		r = mux.SetURLVars(r, map[string]string{"id": tc.receiptID})
+
+		if tc.method == "GET" {
+			handler.List(w, r)
+		} else {
+			handler.Decide(w, r)
+		}
+
+		resp, _ := io.ReadAll(w.Result().Body)
+		assert.Equal(t, tc.statusCode, w.Result().StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Contains(t, string(resp), tc.expectedResponse, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/responder"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+// rewardsHandler is a HTTP handler for the reward catalog and redemption endpoints.
+type rewardsHandler struct {
+	logger *log.CustomLogger
+	svc    service.Rewards
+	users  service.Users // Users the points are redeemed of, checked to be accessible by the caller.
+}
+
+// NewRewards creates and returns a new instance of rewardsHandler.
+func NewRewards(l *log.CustomLogger, svc service.Rewards, users service.Users) *rewardsHandler {
+	return &rewardsHandler{
+		logger: l,
+		svc:    svc,
+		users:  users,
+	}
+}
+
+// List handles HTTP GET requests to retrieve the reward catalog.
+func (rh *rewardsHandler) List(w http.ResponseWriter, r *http.Request) {
+	responder.SetResponse(rh.svc.List(), 200, w)
+}
+
+// Insert handles HTTP POST requests to add a reward to the catalog.
+func (rh *rewardsHandler) Insert(w http.ResponseWriter, r *http.Request) {
+	var reward model.Reward
+	if err := decodeBody(r, &reward); err != nil {
+		responder.SetErrorResponse(rh.logger, err, w, r)
+
+		return
+	}
+
+	resp, err := rh.svc.Insert(&reward)
+	if err != nil {
+		responder.SetErrorResponse(rh.logger, err, w, r)
+
+		return
+	}
+
+	responder.SetResponse(resp, 201, w)
+}
+
+// Redeem handles HTTP POST requests of a user redeeming points for a reward.
+// It responds with 409 when the reward is out of stock or the balance of the user does not cover its cost.
+func (rh *rewardsHandler) Redeem(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pathUserID(rh.logger, rh.users, w, r)
+	if !ok {
+		return
+	}
+
+	var req model.RedemptionRequest
+	if err := decodeBody(r, &req); err != nil {
+		responder.SetErrorResponse(rh.logger, err, w, r)
+
+		return
+	}
+
+	redemption, err := rh.svc.Redeem(userID, &req)
+	if err != nil {
+		responder.SetErrorResponse(rh.logger, err, w, r)
+
+		return
+	}
+
+	responder.SetResponse(redemption, 201, w)
+}
+
+// decodeBody reads and unmarshals the JSON body of a request into v.
+func decodeBody(r *http.Request, v interface{}) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errors.NewCustomError(err, 400)
+	}
+
+	if err = json.Unmarshal(body, v); err != nil {
+		return errors.NewCustomError(err, 400)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+func TestHandlerRedeem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rewardsService := service.NewMockRewards(ctrl)
+	usersService := service.NewMockUsers(ctrl)
+	logger, _ := log.NewCustomLogger("test.log")
+	handler := NewRewards(logger, rewardsService, usersService)
+
+	usersService.EXPECT().Client("user-1").Return("app").AnyTimes()
+	usersService.EXPECT().Client("user-2").Return("partner-b").AnyTimes()
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		userID           string
+		body             string
+		principal        *model.Principal
+		expectedResponse string
+		statusCode       int
+		mockCall         *gomock.Call
+	}{
+		{
+			id: 1, useCase: "Negative case: user redeems points of another user",
+			userID: "user-2", body: `{"rewardId": "mug"}`,
+			principal:        &model.Principal{ClientID: "app", UserID: "user-1", Scopes: []string{model.ScopeSubmit}},
+			expectedResponse: "No 'users' found for Id: 'user-2'",
+			statusCode:       404,
+		},
+		{
+			id: 2, useCase: "Negative case: API client redeems points of a user of another client",
+			userID: "user-2", body: `{"rewardId": "mug"}`,
+			principal:        &model.Principal{ClientID: "partner-a", Scopes: []string{model.ScopeSubmit}},
+			expectedResponse: "No 'users' found for Id: 'user-2'",
+			statusCode:       404,
+		},
+		{
+			id: 3, useCase: "Negative case: malformed body",
+			userID: "user-1", body: `{"rewardId":`,
+			expectedResponse: "unexpected end of JSON input",
+			statusCode:       400,
+		},
+		{
+			id: 4, useCase: "Negative case: insufficient balance",
+			userID: "user-1", body: `{"rewardId": "mug"}`,
+			expectedResponse: "Insufficient points balance: 10 available, 30 required",
+			statusCode:       409,
+			mockCall: rewardsService.EXPECT().Redeem("user-1", &model.RedemptionRequest{RewardID: "mug"}).
+				Return(nil, errors.NewConflict(fmt.Errorf("Insufficient points balance: 10 available, 30 required"))),
+		},
+		{
+			id: 5, useCase: "Positive case: redemption",
+			userID: "user-1", body: `{"rewardId": "mug"}`,
+			expectedResponse: `"rewardId":"mug","points":30,"balance":70`,
+			statusCode:       201,
+			mockCall: rewardsService.EXPECT().Redeem("user-1", &model.RedemptionRequest{RewardID: "mug"}).
+				Return(&model.Redemption{ID: "r-1", UserID: "user-1", RewardID: "mug", Points: 30, Balance: 70}, nil),
+		},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/v1/users/"+tc.userID+"/redemptions", bytes.NewBufferString(tc.body))
+		r = mux.SetURLVars(r, map[string]string{"userId": tc.userID})
+		if tc.principal != nil {
+			r = r.WithContext(auth.NewContext(r.Context(), tc.principal))
+		}
+
+		handler.Redeem(w, r)
+
+		resp, _ := io.ReadAll(w.Result().Body)
+		assert.Equal(t, tc.statusCode, w.Result().StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Contains(t, string(resp), tc.expectedResponse, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestHandlerRewards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rewardsService := service.NewMockRewards(ctrl)
+	logger, _ := log.NewCustomLogger("test.log")
+	handler := NewRewards(logger, rewardsService, service.NewMockUsers(ctrl))
+
+	rewardsService.EXPECT().List().Return([]model.Reward{{ID: "mug", Name: "Mug", Cost: 30, Inventory: 4}})
+	w := httptest.NewRecorder()
+	handler.List(w, httptest.NewRequest("GET", "/v1/rewards", nil))
+	assert.Equal(t, 200, w.Result().StatusCode)
+	assert.Equal(t, `[{"id":"mug","name":"Mug","cost":30,"inventory":4}]`, w.Body.String())
+
+	rewardsService.EXPECT().Insert(&model.Reward{Name: "Mug", Cost: 30, Inventory: 4}).
+		Return(&model.Reward{ID: "mug", Name: "Mug", Cost: 30, Inventory: 4}, nil)
+	w = httptest.NewRecorder()
+	handler.Insert(w, httptest.NewRequest("POST", "/v1/rewards", bytes.NewBufferString(`{"name": "Mug", "cost": 30, "inventory": 4}`)))
+	assert.Equal(t, 201, w.Result().StatusCode)
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	er "errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/responder"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+// streamHandler is a HTTP handler for the Server-Sent Events stream of scored receipts.
+type streamHandler struct {
+	logger    *log.CustomLogger
+	svc       service.Stream
+	heartbeat time.Duration // Interval of the comments keeping idle connections open.
+}
+
+// NewStream creates and returns a new instance of streamHandler sending a heartbeat every heartbeat interval.
+func NewStream(l *log.CustomLogger, svc service.Stream, heartbeat time.Duration) *streamHandler {
+	return &streamHandler{
+		logger:    l,
+		svc:       svc,
+		heartbeat: heartbeat,
+	}
+}
+
+// Stream handles HTTP GET requests opening a stream of the receipts scored from now on, filtered by the retailer and
+// clientId query parameters. Principals that are not admins only stream the receipts of their own client, and users
+// authenticated themselves only their own receipts.
+// Clients resume after the event of the Last-Event-ID header, as long as it is still buffered.
+func (sh *streamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	filter := model.StreamFilter{Retailer: r.URL.Query().Get("retailer"), ClientID: r.URL.Query().Get("clientId")}
+
+	if principal := auth.FromContext(r.Context()); principal != nil && !principal.HasScope(model.ScopeAdmin) {
+		if filter.ClientID != "" && filter.ClientID != principal.ClientID {
+			responder.SetErrorResponse(sh.logger, errors.NewForbidden(fmt.Errorf("Client '%v' may not stream the receipts of client '%v'", principal.ClientID, filter.ClientID)), w, r)
+
+			return
+		}
+
+		filter.ClientID = principal.ClientID
+		filter.UserID = principal.UserID
+	}
+
+	var lastEventID uint64
+	if header := r.Header.Get("Last-Event-ID"); header != "" {
+		id, err := strconv.ParseUint(header, 10, 64)
+		if err != nil {
+			responder.SetErrorResponse(sh.logger, errors.NewInvalidParam(errors.InvalidParam{Param: "Last-Event-ID"}), w, r)
+
+			return
+		}
+
+		lastEventID = id
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		responder.SetErrorResponse(sh.logger, errors.NewCustomError(er.New("streaming is not supported"), 500), w, r)
+
+		return
+	}
+
+	events, replay, cancel := sh.svc.Subscribe(filter, lastEventID)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	for i := range replay {
+		if err := writeEvent(w, &replay[i]); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(sh.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return // too slow to keep up, the client reconnects with the Last-Event-ID header
+			}
+
+			if err := writeEvent(w, &event); err != nil {
+				return
+			}
+		}
+
+		flusher.Flush()
+	}
+}
+
+// writeEvent writes a scored receipt in the Server-Sent Events format.
+func writeEvent(w http.ResponseWriter, event *model.StreamEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %v\nevent: %v\ndata: %s\n\n", event.ID, model.EventReceiptScored, data)
+
+	return err
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+func TestHandlerStream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	streamService := service.NewMockStream(ctrl)
+	logger, _ := log.NewCustomLogger("test.log")
+	handler := NewStream(logger, streamService, 10*time.Millisecond)
+
+	partner := &model.Principal{ClientID: "partner", Scopes: []string{model.ScopeRead}}
+	admin := &model.Principal{ClientID: "ops", Scopes: []string{model.ScopeAdmin}}
+	replay := []model.StreamEvent{{ID: 6, ReceiptID: "a", Retailer: "Target", Points: 28, ClientID: "partner"}}
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		target           string
+		lastEventID      string
+		principal        *model.Principal
+		expectedFilter   model.StreamFilter
+		expectedLastID   uint64
+		expectedResponse string
+		statusCode       int
+	}{
+		{
+			id: 1, useCase: "Positive case: resume own client stream filtered by retailer",
+			target: "/v1/receipts/stream?retailer=Target", lastEventID: "5", principal: partner,
+			expectedFilter: model.StreamFilter{Retailer: "Target", ClientID: "partner"}, expectedLastID: 5,
+			expectedResponse: "id: 6\nevent: receipt.scored\ndata: {\"receiptId\":\"a\",\"retailer\":\"Target\",\"points\":28,\"clientId\":\"partner\",\"scoredAt\":\"0001-01-01T00:00:00Z\"}\n\n",
+			statusCode:       200,
+		},
+		{
+			id: 2, useCase: "Positive case: admin streams every client",
+			target: "/v1/receipts/stream", principal: admin,
+			expectedFilter: model.StreamFilter{}, statusCode: 200,
+		},
+		{
+			id: 3, useCase: "Negative case: stream of another client",
+			target: "/v1/receipts/stream?clientId=other", principal: partner,
+			expectedResponse: "Client 'partner' may not stream the receipts of client 'other'", statusCode: 403,
+		},
+		{
+			id: 4, useCase: "Negative case: invalid Last-Event-ID",
+			target: "/v1/receipts/stream", lastEventID: "abc", principal: partner,
+			expectedResponse: "Incorrect value for parameter: Last-Event-ID", statusCode: 400,
+		},
+	}
+
+	for _, tc := range testCases {
+		if tc.statusCode == 200 {
+			streamService.EXPECT().Subscribe(tc.expectedFilter, tc.expectedLastID).Return(make(chan model.StreamEvent), replay, func() {})
+		}
+
+		ctx, cancel := context.WithTimeout(auth.NewContext(context.Background(), tc.principal), 35*time.Millisecond)
+		r := httptest.NewRequest("GET", tc.target, nil).WithContext(ctx)
+		if tc.lastEventID != "" {
+			r.Header.Set("Last-Event-ID", tc.lastEventID)
+		}
+
+		w := httptest.NewRecorder()
+		handler.Stream(w, r)
+		cancel()
+
+		assert.Equal(t, tc.statusCode, w.Code, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		if tc.statusCode != 200 {
+			assert.Contains(t, w.Body.String(), tc.expectedResponse, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			continue
+		}
+
+		assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.True(t, strings.HasPrefix(w.Body.String(), tc.expectedResponse), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Contains(t, w.Body.String(), ": heartbeat\n\n", fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestHandlerStream_Live(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	streamService := service.NewMockStream(ctrl)
+	logger, _ := log.NewCustomLogger("test.log")
+
+	events := make(chan model.StreamEvent, 1)
+	streamService.EXPECT().Subscribe(model.StreamFilter{}, uint64(0)).Return(events, nil, func() {})
+
+	server := httptest.NewServer(http.HandlerFunc(NewStream(logger, streamService, time.Minute).Stream))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	events <- model.StreamEvent{ID: 1, ReceiptID: "a", Retailer: "Target", Points: 28}
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "id: 1\n", line)
+
+	// A client too slow to keep up is disconnected.
+	close(events)
+	_, _ = reader.ReadString('\n')
+	_, _ = reader.ReadString('\n')
+	_, _ = reader.ReadString('\n')
+	_, err = reader.ReadString('\n')
+	assert.Error(t, err)
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/responder"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+// Pagination of the ledger history, maxPage keeps the offset of a page from overflowing.
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	maxPage         = 100000
+)
+
+// Window of the expiring points, in days.
+const (
+	defaultExpiringDays = 30
+	maxExpiringDays     = 366
+)
+
+// usersHandler is a HTTP handler for the points balance and ledger endpoints of users.
+type usersHandler struct {
+	logger *log.CustomLogger
+	svc    service.Users
+}
+
+// NewUsers creates and returns a new instance of usersHandler.
+func NewUsers(l *log.CustomLogger, svc service.Users) *usersHandler {
+	return &usersHandler{
+		logger: l,
+		svc:    svc,
+	}
+}
+
+// Balance handles HTTP GET requests to retrieve the points balance of a user.
+func (uh *usersHandler) Balance(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pathUserID(uh.logger, uh.svc, w, r)
+	if !ok {
+		return
+	}
+
+	balance, err := uh.svc.Balance(userID)
+	if err != nil {
+		responder.SetErrorResponse(uh.logger, err, w, r)
+
+		return
+	}
+
+	responder.SetResponse(balance, 200, w)
+}
+
+// Ledger handles HTTP GET requests to retrieve the ledger history of a user, paginated by the page and pageSize query parameters.
+func (uh *usersHandler) Ledger(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pathUserID(uh.logger, uh.svc, w, r)
+	if !ok {
+		return
+	}
+
+	page, err := queryInt(r, "page", 1, 1, maxPage)
+	if err != nil {
+		responder.SetErrorResponse(uh.logger, err, w, r)
+
+		return
+	}
+
+	pageSize, err := queryInt(r, "pageSize", defaultPageSize, 1, maxPageSize)
+	if err != nil {
+		responder.SetErrorResponse(uh.logger, err, w, r)
+
+		return
+	}
+
+	ledger, err := uh.svc.Ledger(userID, page, pageSize)
+	if err != nil {
+		responder.SetErrorResponse(uh.logger, err, w, r)
+
+		return
+	}
+
+	responder.SetResponse(ledger, 200, w)
+}
+
+// Expiring handles HTTP GET requests to retrieve the points of a user expiring within the next days, set by the days query parameter.
+func (uh *usersHandler) Expiring(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pathUserID(uh.logger, uh.svc, w, r)
+	if !ok {
+		return
+	}
+
+	days, err := queryInt(r, "days", defaultExpiringDays, 1, maxExpiringDays)
+	if err != nil {
+		responder.SetErrorResponse(uh.logger, err, w, r)
+
+		return
+	}
+
+	expiring, err := uh.svc.Expiring(userID, days)
+	if err != nil {
+		responder.SetErrorResponse(uh.logger, err, w, r)
+
+		return
+	}
+
+	responder.SetResponse(expiring, 200, w)
+}
+
+// pathUserID validates the user ID path parameter and that the caller may access the points of the user, API clients
+// only access the users bound to them. Users the caller may not access are reported as not found to not disclose they exist.
+func pathUserID(logger *log.CustomLogger, users service.Users, w http.ResponseWriter, r *http.Request) (string, bool) {
+	userID := mux.Vars(r)["userId"]
+	if !model.IsValidUserID(userID) {
+		responder.SetErrorResponse(logger, errors.NewInvalidParam(errors.InvalidParam{Param: "userId"}), w, r)
+
+		return "", false
+	}
+
+	if principal := auth.FromContext(r.Context()); principal != nil && !principal.CanAccessUser(userID, users.Client(userID)) {
+		responder.SetErrorResponse(logger, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "users", ID: userID}), w, r)
+
+		return "", false
+	}
+
+	return userID, true
+}
+
+// queryInt parses the integer query parameter name, it returns def when the parameter is absent.
+// A max of 0 leaves the value unbounded.
+func queryInt(r *http.Request, name string, def, min, max int) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < min || (max > 0 && n > max) {
+		return 0, errors.NewInvalidParam(errors.InvalidParam{Param: name})
+	}
+
+	return n, nil
+}
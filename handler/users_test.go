@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+func TestHandlerBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	usersService := service.NewMockUsers(ctrl)
+	logger, _ := log.NewCustomLogger("test.log")
+	handler := NewUsers(logger, usersService)
+
+	usersService.EXPECT().Client(gomock.Any()).Return("app").AnyTimes()
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		userID           string
+		principal        *model.Principal
+		expectedResponse string
+		statusCode       int
+		mockCall         *gomock.Call
+	}{
+		{
+			id: 1, useCase: "Negative case: invalid user id",
+			userID:           "user!1",
+			expectedResponse: "Incorrect value for parameter: userId",
+			statusCode:       400,
+		},
+		{
+			id: 2, useCase: "Negative case: user reads the balance of another user",
+			userID:           "user-2",
+			principal:        &model.Principal{ClientID: "app", UserID: "user-1", Scopes: []string{model.ScopeRead}},
+			expectedResponse: "No 'users' found for Id: 'user-2'",
+			statusCode:       404,
+		},
+		{
+			id: 3, useCase: "Negative case: unknown user",
+			userID:           "user-3",
+			expectedResponse: "No 'users' found for Id: 'user-3'",
+			statusCode:       404,
+			mockCall: usersService.EXPECT().Balance("user-3").
+				Return(nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "users", ID: "user-3"})),
+		},
+		{
+			id: 4, useCase: "Positive case: user reads own balance",
+			userID:           "user-1",
+			principal:        &model.Principal{ClientID: "app", UserID: "user-1", Scopes: []string{model.ScopeRead}},
+			expectedResponse: `{"userId":"user-1","points":40}`,
+			statusCode:       200,
+			mockCall:         usersService.EXPECT().Balance("user-1").Return(&model.BalanceResponse{UserID: "user-1", Points: 40}, nil),
+		},
+		{
+			id: 5, useCase: "Negative case: API client reads the balance of a user of another client",
+			userID:           "user-1",
+			principal:        &model.Principal{ClientID: "partner-a", Scopes: []string{model.ScopeRead}},
+			expectedResponse: "No 'users' found for Id: 'user-1'",
+			statusCode:       404,
+		},
+		{
+			id: 6, useCase: "Positive case: API client reads the balance of its user",
+			userID:           "user-1",
+			principal:        &model.Principal{ClientID: "app", Scopes: []string{model.ScopeRead}},
+			expectedResponse: `{"userId":"user-1","points":40}`,
+			statusCode:       200,
+			mockCall:         usersService.EXPECT().Balance("user-1").Return(&model.BalanceResponse{UserID: "user-1", Points: 40}, nil),
+		},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/v1/users/"+tc.userID+"/balance", nil)
+		r = mux.SetURLVars(r, map[string]string{"userId": tc.userID})
+		if tc.principal != nil {
+			r = r.WithContext(auth.NewContext(r.Context(), tc.principal))
+		}
+
+		handler.Balance(w, r)
+
+		resp, _ := io.ReadAll(w.Result().Body)
+		assert.Equal(t, tc.statusCode, w.Result().StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Contains(t, string(resp), tc.expectedResponse, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestHandlerLedger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	usersService := service.NewMockUsers(ctrl)
+	logger, _ := log.NewCustomLogger("test.log")
+	handler := NewUsers(logger, usersService)
+
+	usersService.EXPECT().Client(gomock.Any()).Return("app").AnyTimes()
+
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	ledger := &model.LedgerResponse{
+		UserID:   "user-1",
+		Entries:  []model.LedgerEntry{{ID: "e-1", UserID: "user-1", ReceiptID: "r-1", Type: model.LedgerCredit, Points: 40, CreatedAt: createdAt}},
+		Page:     2,
+		PageSize: 1,
+		Total:    2,
+	}
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		query            string
+		expectedResponse string
+		statusCode       int
+		mockCall         *gomock.Call
+	}{
+		{
+			id: 1, useCase: "Negative case: page below 1",
+			query:            "?page=0",
+			expectedResponse: "Incorrect value for parameter: page",
+			statusCode:       400,
+		},
+		{
+			id: 2, useCase: "Negative case: page size above the maximum",
+			query:            "?pageSize=101",
+			expectedResponse: "Incorrect value for parameter: pageSize",
+			statusCode:       400,
+		},
+		{
+			id: 3, useCase: "Negative case: page above the maximum",
+			query:            "?page=9223372036854775807",
+			expectedResponse: "Incorrect value for parameter: page",
+			statusCode:       400,
+		},
+		{
+			id: 4, useCase: "Positive case: default pagination",
+			expectedResponse: `"page":1,"pageSize":20`,
+			statusCode:       200,
+			mockCall: usersService.EXPECT().Ledger("user-1", 1, 20).
+				Return(&model.LedgerResponse{UserID: "user-1", Entries: []model.LedgerEntry{}, Page: 1, PageSize: 20}, nil),
+		},
+		{
+			id: 5, useCase: "Positive case: requested page",
+			query:            "?page=2&pageSize=1",
+			expectedResponse: `{"userId":"user-1","entries":[{"id":"e-1","userId":"user-1","receiptId":"r-1","type":"credit","points":40,"createdAt":"2024-03-01T12:00:00Z"}],"page":2,"pageSize":1,"total":2}`,
+			statusCode:       200,
+			mockCall:         usersService.EXPECT().Ledger("user-1", 2, 1).Return(ledger, nil),
+		},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/v1/users/user-1/ledger"+tc.query, nil)
+		r = mux.SetURLVars(r, map[string]string{"userId": "user-1"})
+
+		handler.Ledger(w, r)
+
+		resp, _ := io.ReadAll(w.Result().Body)
+		assert.Equal(t, tc.statusCode, w.Result().StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Contains(t, string(resp), tc.expectedResponse, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestHandlerExpiring(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	usersService := service.NewMockUsers(ctrl)
+	logger, _ := log.NewCustomLogger("test.log")
+	handler := NewUsers(logger, usersService)
+
+	usersService.EXPECT().Client(gomock.Any()).Return("app").AnyTimes()
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		query            string
+		expectedResponse string
+		statusCode       int
+		mockCall         *gomock.Call
+	}{
+		{
+			id: 1, useCase: "Negative case: days above the maximum",
+			query:            "?days=400",
+			expectedResponse: "Incorrect value for parameter: days",
+			statusCode:       400,
+		},
+		{
+			id: 2, useCase: "Positive case: default window",
+			expectedResponse: `{"userId":"user-1","days":30,"points":0,"entries":[]}`,
+			statusCode:       200,
+			mockCall: usersService.EXPECT().Expiring("user-1", 30).
+				Return(&model.ExpiringResponse{UserID: "user-1", Days: 30, Entries: []model.ExpiringPoints{}}, nil),
+		},
+		{
+			id: 3, useCase: "Positive case: requested window",
+			query:            "?days=7",
+			expectedResponse: `"days":7,"points":30`,
+			statusCode:       200,
+			mockCall: usersService.EXPECT().Expiring("user-1", 7).
+				Return(&model.ExpiringResponse{UserID: "user-1", Days: 7, Points: 30, Entries: []model.ExpiringPoints{{EntryID: "e-1", Points: 30}}}, nil),
+		},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/v1/users/user-1/expiring"+tc.query, nil)
+		r = mux.SetURLVars(r, map[string]string{"userId": "user-1"})
+
+		handler.Expiring(w, r)
+
+		resp, _ := io.ReadAll(w.Result().Body)
+		assert.Equal(t, tc.statusCode, w.Result().StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Contains(t, string(resp), tc.expectedResponse, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/responder"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+// webhooksHandler is a HTTP handler for the webhook subscription endpoints.
+type webhooksHandler struct {
+	logger *log.CustomLogger
+	svc    service.Webhooks
+}
+
+// NewWebhooks creates and returns a new instance of webhooksHandler.
+func NewWebhooks(l *log.CustomLogger, svc service.Webhooks) *webhooksHandler {
+	return &webhooksHandler{
+		logger: l,
+		svc:    svc,
+	}
+}
+
+// List handles HTTP GET requests to retrieve the webhooks of the client.
+func (wh *webhooksHandler) List(w http.ResponseWriter, r *http.Request) {
+	responder.SetResponse(wh.svc.List(auth.FromContext(r.Context())), 200, w)
+}
+
+// Insert handles HTTP POST requests to subscribe a webhook to receipt events.
+func (wh *webhooksHandler) Insert(w http.ResponseWriter, r *http.Request) {
+	var webhook model.Webhook
+	if err := decodeBody(r, &webhook); err != nil {
+		responder.SetErrorResponse(wh.logger, err, w, r)
+
+		return
+	}
+
+	resp, err := wh.svc.Insert(&webhook, auth.FromContext(r.Context()))
+	if err != nil {
+		responder.SetErrorResponse(wh.logger, err, w, r)
+
+		return
+	}
+
+	responder.SetResponse(resp, 201, w)
+}
+
+// Delete handles HTTP DELETE requests to remove a webhook.
+func (wh *webhooksHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	webhookID, ok := wh.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := wh.svc.Delete(webhookID, auth.FromContext(r.Context())); err != nil {
+		responder.SetErrorResponse(wh.logger, err, w, r)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Deliveries handles HTTP GET requests to retrieve the delivery log of a webhook, optionally filtered by the status query parameter.
+func (wh *webhooksHandler) Deliveries(w http.ResponseWriter, r *http.Request) {
+	wh.deliveries(w, r, r.URL.Query().Get("status"))
+}
+
+// DeadLetters handles HTTP GET requests to retrieve the deliveries of a webhook that failed their last attempt and
+// were neither acknowledged nor replayed.
+func (wh *webhooksHandler) DeadLetters(w http.ResponseWriter, r *http.Request) {
+	webhookID, ok := wh.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	deadLetters, err := wh.svc.DeadLetters(webhookID, auth.FromContext(r.Context()))
+	if err != nil {
+		responder.SetErrorResponse(wh.logger, err, w, r)
+
+		return
+	}
+
+	responder.SetResponse(deadLetters, 200, w)
+}
+
+// Acknowledge handles HTTP DELETE requests to remove a dead letter of a webhook without delivering its event again.
+func (wh *webhooksHandler) Acknowledge(w http.ResponseWriter, r *http.Request) {
+	webhookID, deliveryID, ok := wh.deadLetterID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := wh.svc.Acknowledge(webhookID, deliveryID, auth.FromContext(r.Context())); err != nil {
+		responder.SetErrorResponse(wh.logger, err, w, r)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Replay handles HTTP POST requests to deliver the event of a dead letter of a webhook again.
+func (wh *webhooksHandler) Replay(w http.ResponseWriter, r *http.Request) {
+	webhookID, deliveryID, ok := wh.deadLetterID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := wh.svc.Replay(webhookID, deliveryID, auth.FromContext(r.Context())); err != nil {
+		responder.SetErrorResponse(wh.logger, err, w, r)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// deliveries responds with the deliveries of the webhook of the request with the given status.
+func (wh *webhooksHandler) deliveries(w http.ResponseWriter, r *http.Request, status string) {
+	webhookID, ok := wh.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	deliveries, err := wh.svc.Deliveries(webhookID, status, auth.FromContext(r.Context()))
+	if err != nil {
+		responder.SetErrorResponse(wh.logger, err, w, r)
+
+		return
+	}
+
+	responder.SetResponse(deliveries, 200, w)
+}
+
+// webhookID validates the webhook ID path parameter.
+func (wh *webhooksHandler) webhookID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	webhookID := mux.Vars(r)["id"]
+	if !model.IsValidUUID(webhookID) {
+		responder.SetErrorResponse(wh.logger, errors.NewInvalidParam(errors.InvalidParam{Param: "id"}), w, r)
+
+		return "", false
+	}
+
+	return webhookID, true
+}
+
+// deadLetterID validates the webhook ID and delivery ID path parameters.
+func (wh *webhooksHandler) deadLetterID(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	webhookID, ok := wh.webhookID(w, r)
+	if !ok {
+		return "", "", false
+	}
+
+	deliveryID := mux.Vars(r)["deliveryId"]
+	if !model.IsValidUUID(deliveryID) {
+		responder.SetErrorResponse(wh.logger, errors.NewInvalidParam(errors.InvalidParam{Param: "deliveryId"}), w, r)
+
+		return "", "", false
+	}
+
+	return webhookID, deliveryID, true
+}
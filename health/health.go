@@ -0,0 +1,126 @@
+package health
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// CheckTimeout bounds how long a single dependency check may take before it is reported down.
+var CheckTimeout = 2 * time.Second
+
+// Check is a named dependency check, Fn returns an error when the dependency is unusable.
+type Check struct {
+	Name string
+	Fn   func() error
+}
+
+// CheckResult is the outcome of a single Check.
+type CheckResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report is the health document returned by the liveness and readiness endpoints.
+type Report struct {
+	Status    string                 `json:"status"`
+	Checks    map[string]CheckResult `json:"checks,omitempty"`
+	TimeStamp time.Time              `json:"timestamp"`
+}
+
+// Checker runs dependency checks and tracks whether the server is shutting down.
+type Checker struct {
+	checks   []Check
+	draining atomic.Bool
+}
+
+// New creates and returns a Checker for the given dependency checks.
+func New(checks ...Check) *Checker {
+	return &Checker{checks: checks}
+}
+
+// SetDraining marks the server as shutting down, after which readiness reports down.
+func (c *Checker) SetDraining() {
+	c.draining.Store(true)
+}
+
+// Draining reports whether the server is shutting down.
+func (c *Checker) Draining() bool {
+	return c.draining.Load()
+}
+
+// Live reports whether the process is up. It does not run dependency checks,
+// a failing dependency must not get a healthy process restarted.
+func (c *Checker) Live() Report {
+	return Report{Status: StatusUp, TimeStamp: time.Now().UTC()}
+}
+
+// Ready runs every dependency check concurrently and reports down if any of them fails or the server is draining.
+func (c *Checker) Ready() Report {
+	report := Report{Status: StatusUp, Checks: make(map[string]CheckResult, len(c.checks)+1), TimeStamp: time.Now().UTC()}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	for _, check := range c.checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+
+			result := run(check)
+
+			mu.Lock()
+			report.Checks[check.Name] = result
+			mu.Unlock()
+		}(check)
+	}
+
+	wg.Wait()
+
+	shutdown := CheckResult{Status: StatusUp}
+	if c.Draining() {
+		shutdown = CheckResult{Status: StatusDown, Error: "shutdown in progress"}
+	}
+	report.Checks["shutdown"] = shutdown
+
+	for _, result := range report.Checks {
+		if result.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+
+	return report
+}
+
+// run executes a check, reporting it down if it fails, panics or exceeds CheckTimeout.
+func run(check Check) CheckResult {
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("check panicked: %v", r)
+			}
+		}()
+
+		done <- check.Fn()
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return CheckResult{Status: StatusDown, Error: err.Error()}
+		}
+
+		return CheckResult{Status: StatusUp}
+	case <-time.After(CheckTimeout):
+		return CheckResult{Status: StatusDown, Error: fmt.Sprintf("check timed out after %v", CheckTimeout)}
+	}
+}
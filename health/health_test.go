@@ -0,0 +1,80 @@
+package health
+
+import (
+	er "errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckerReady(t *testing.T) {
+	CheckTimeout = 50 * time.Millisecond
+	defer func() { CheckTimeout = 2 * time.Second }()
+
+	up := Check{Name: "store", Fn: func() error { return nil }}
+
+	testCases := []struct {
+		id             int
+		useCase        string
+		checks         []Check
+		draining       bool
+		expectedStatus string
+		expectedChecks map[string]CheckResult
+	}{
+		{
+			id: 1, useCase: "Positive case: all checks up",
+			checks:         []Check{up},
+			expectedStatus: StatusUp,
+			expectedChecks: map[string]CheckResult{"store": {Status: StatusUp}, "shutdown": {Status: StatusUp}},
+		},
+		{
+			id: 2, useCase: "Negative case: failing check",
+			checks:         []Check{up, {Name: "logFile", Fn: func() error { return er.New("file already closed") }}},
+			expectedStatus: StatusDown,
+			expectedChecks: map[string]CheckResult{
+				"store": {Status: StatusUp}, "logFile": {Status: StatusDown, Error: "file already closed"}, "shutdown": {Status: StatusUp},
+			},
+		},
+		{
+			id: 3, useCase: "Negative case: shutdown in progress",
+			checks:         []Check{up},
+			draining:       true,
+			expectedStatus: StatusDown,
+			expectedChecks: map[string]CheckResult{"store": {Status: StatusUp}, "shutdown": {Status: StatusDown, Error: "shutdown in progress"}},
+		},
+		{
+			id: 4, useCase: "Negative case: check times out",
+			checks:         []Check{{Name: "store", Fn: func() error { time.Sleep(time.Second); return nil }}},
+			expectedStatus: StatusDown,
+			expectedChecks: map[string]CheckResult{"store": {Status: StatusDown, Error: "check timed out after 50ms"}, "shutdown": {Status: StatusUp}},
+		},
+		{
+			id: 5, useCase: "Negative case: check panics",
+			checks:         []Check{{Name: "rules", Fn: func() error { panic("nil map") }}},
+			expectedStatus: StatusDown,
+			expectedChecks: map[string]CheckResult{"rules": {Status: StatusDown, Error: "check panicked: nil map"}, "shutdown": {Status: StatusUp}},
+		},
+	}
+
+	for _, tc := range testCases {
+		checker := New(tc.checks...)
+		if tc.draining {
+			checker.SetDraining()
+		}
+
+		report := checker.Ready()
+		assert.Equal(t, tc.expectedStatus, report.Status, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedChecks, report.Checks, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestCheckerLive(t *testing.T) {
+	checker := New(Check{Name: "store", Fn: func() error { return er.New("unreachable") }})
+	checker.SetDraining()
+
+	report := checker.Live()
+	assert.Equal(t, StatusUp, report.Status)
+	assert.Empty(t, report.Checks)
+}
@@ -0,0 +1,198 @@
+package idempotency
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	er "errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/responder"
+)
+
+const (
+	Header         = "Idempotency-Key"
+	ReplayedHeader = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+)
+
+// replayedHeaders are the headers of a response recorded along with its status and body.
+var replayedHeaders = []string{"Content-Type", "Location", "Access-Control-Allow-Origin"}
+
+// Middleware replays the response of a request to the retries sent with the same Idempotency-Key header,
+// so that retrying a request that timed out does not process it twice.
+// Keys are scoped to the authenticated client and are kept for the TTL of the middleware.
+type Middleware struct {
+	logger   *log.CustomLogger
+	requests data.Idempotency
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+// New creates and returns a new instance of Middleware keeping the keys of requests for ttl.
+func New(l *log.CustomLogger, requests data.Idempotency, ttl time.Duration) *Middleware {
+	return &Middleware{
+		logger:   l,
+		requests: requests,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
+
+// Replay wraps next so that requests carrying an Idempotency-Key are processed once. Requests without the header are
+// processed as usual. A retry of a completed request gets its recorded response with the Idempotent-Replayed header,
+// a retry of a request still being processed gets 409 and reusing a key for a different request gets 422.
+// Responses to requests that may be retried as is, 429 and 5xx, are not recorded.
+func (m *Middleware) Replay(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(Header)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !isValidKey(key) {
+			responder.SetErrorResponse(m.logger, errors.NewInvalidParam(errors.InvalidParam{Param: Header}), w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			responder.SetErrorResponse(m.logger, errors.NewCustomError(err, 400), w, r)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		now := m.now()
+		request := &model.IdempotentRequest{
+			Key:         scope(r) + key,
+			Fingerprint: fingerprint(r, body),
+			ExpiresAt:   now.Add(m.ttl),
+		}
+
+		if stored := m.requests.Reserve(request, now); stored != nil {
+			m.replay(stored, request, w, r)
+			return
+		}
+
+		rec := &recorder{ResponseWriter: w, statusCode: http.StatusOK}
+
+		// The key is released if the handler panics, so that the request may be retried.
+		defer func() {
+			if !request.Completed {
+				m.requests.Release(request.Key)
+			}
+		}()
+
+		next.ServeHTTP(rec, r)
+
+		if rec.statusCode == http.StatusTooManyRequests || rec.statusCode >= 500 {
+			return
+		}
+
+		request.StatusCode = rec.statusCode
+		request.Header = make(map[string]string)
+		for _, name := range replayedHeaders {
+			if value := rec.Header().Get(name); value != "" {
+				request.Header[name] = value
+			}
+		}
+
+		request.Body = rec.body.Bytes()
+		m.requests.Complete(request)
+	})
+}
+
+// replay responds to the retry of a request with the response recorded for the stored request.
+func (m *Middleware) replay(stored, request *model.IdempotentRequest, w http.ResponseWriter, r *http.Request) {
+	if stored.Fingerprint != request.Fingerprint {
+		err := errors.NewCustomError(er.New("Idempotency-Key was already used for a different request"), http.StatusUnprocessableEntity)
+		err.Code = errors.CodeIdempotencyMismatch
+		responder.SetErrorResponse(m.logger, err, w, r)
+
+		return
+	}
+
+	if !stored.Completed {
+		err := errors.NewConflict(er.New("A request with this Idempotency-Key is still being processed"))
+		err.Code = errors.CodeIdempotencyInProgress
+		responder.SetErrorResponse(m.logger, err, w, r)
+
+		return
+	}
+
+	for name, value := range stored.Header {
+		w.Header().Set(name, value)
+	}
+
+	w.Header().Set(ReplayedHeader, strconv.FormatBool(true))
+	w.WriteHeader(stored.StatusCode)
+	_, _ = w.Write(stored.Body)
+}
+
+// recorder wraps an http.ResponseWriter to capture the status code and body of the response written through it.
+type recorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rec *recorder) WriteHeader(statusCode int) {
+	if !rec.wroteHeader {
+		rec.statusCode, rec.wroteHeader = statusCode, true
+	}
+
+	rec.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	rec.body.Write(b)
+
+	return rec.ResponseWriter.Write(b)
+}
+
+// scope returns the prefix of the keys of the caller, so that clients, and users of a client, cannot replay the
+// responses of each other.
+func scope(r *http.Request) string {
+	if p := auth.FromContext(r.Context()); p != nil {
+		return p.Key() + ":"
+	}
+
+	return "anonymous:"
+}
+
+// fingerprint returns the hash of the method, path and body of the request.
+func fingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// isValidKey accepts keys of printable ASCII characters, up to 255 characters long.
+func isValidKey(key string) bool {
+	if len(key) > maxKeyLength {
+		return false
+	}
+
+	for _, c := range key {
+		if c < ' ' || c > '~' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,177 @@
+package idempotency
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestMiddlewareReplay(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	m := New(logger, data.NewIdempotency(logger, 0), time.Hour)
+	m.now = func() time.Time { return now }
+
+	// The handler answers with the number of requests it processed, or with the status of the body when it is one.
+	processed := 0
+	h := m.Replay(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		status := http.StatusCreated
+		_, _ = fmt.Sscanf(string(body), "status %d", &status)
+
+		processed++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", fmt.Sprintf("/v1/receipts/%v", processed))
+		w.WriteHeader(status)
+		_, _ = fmt.Fprintf(w, `{"processed":%v}`, processed)
+	}))
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		key              string
+		clientID         string
+		userID           string
+		path             string
+		body             string
+		after            time.Duration
+		statusCode       int
+		expectedResponse string
+		expectedReplayed bool
+		expectedLocation string
+	}{
+		{
+			id: 1, useCase: "Positive case: request without a key",
+			path: "/v1/receipts/process", body: "receipt",
+			statusCode: 201, expectedResponse: `{"processed":1}`, expectedLocation: "/v1/receipts/1",
+		},
+		{
+			id: 2, useCase: "Positive case: first request with a key",
+			key: "key-1", path: "/v1/receipts/process", body: "receipt",
+			statusCode: 201, expectedResponse: `{"processed":2}`, expectedLocation: "/v1/receipts/2",
+		},
+		{
+			id: 3, useCase: "Positive case: retry gets the recorded response",
+			key: "key-1", path: "/v1/receipts/process", body: "receipt",
+			statusCode: 201, expectedResponse: `{"processed":2}`, expectedReplayed: true, expectedLocation: "/v1/receipts/2",
+		},
+		{
+			id: 4, useCase: "Positive case: keys are scoped to the client",
+			key: "key-1", clientID: "partner-a", path: "/v1/receipts/process", body: "receipt",
+			statusCode: 201, expectedResponse: `{"processed":3}`, expectedLocation: "/v1/receipts/3",
+		},
+		{
+			id: 5, useCase: "Negative case: key reused with another body",
+			key: "key-1", path: "/v1/receipts/process", body: "another receipt",
+			statusCode: 422, expectedResponse: `"code":"idempotency.key_reused"`,
+		},
+		{
+			id: 6, useCase: "Negative case: key reused on another path",
+			key: "key-1", path: "/v1/users/user-1/redemptions", body: "receipt",
+			statusCode: 422, expectedResponse: `"code":"idempotency.key_reused"`,
+		},
+		{
+			id: 7, useCase: "Positive case: client errors are recorded",
+			key: "key-2", path: "/v1/receipts/process", body: "status 400",
+			statusCode: 400, expectedResponse: `{"processed":4}`, expectedLocation: "/v1/receipts/4",
+		},
+		{
+			id: 8, useCase: "Positive case: retry of a client error gets the recorded response",
+			key: "key-2", path: "/v1/receipts/process", body: "status 400",
+			statusCode: 400, expectedResponse: `{"processed":4}`, expectedReplayed: true, expectedLocation: "/v1/receipts/4",
+		},
+		{
+			id: 9, useCase: "Positive case: server errors are not recorded",
+			key: "key-3", path: "/v1/receipts/process", body: "status 503",
+			statusCode: 503, expectedResponse: `{"processed":5}`, expectedLocation: "/v1/receipts/5",
+		},
+		{
+			id: 10, useCase: "Positive case: retry of a server error is processed again",
+			key: "key-3", path: "/v1/receipts/process", body: "status 503",
+			statusCode: 503, expectedResponse: `{"processed":6}`, expectedLocation: "/v1/receipts/6",
+		},
+		{
+			id: 11, useCase: "Positive case: key reused once expired",
+			key: "key-1", path: "/v1/receipts/process", body: "another receipt", after: time.Hour,
+			statusCode: 201, expectedResponse: `{"processed":7}`, expectedLocation: "/v1/receipts/7",
+		},
+		{
+			id: 12, useCase: "Negative case: key with control characters",
+			key: "key\t4", path: "/v1/receipts/process", body: "receipt",
+			statusCode: 400, expectedResponse: "Incorrect value for parameter: Idempotency-Key",
+		},
+		{
+			id: 13, useCase: "Positive case: first request of a user with a key",
+			key: "key-5", clientID: "partner-a", userID: "user-1", path: "/v1/receipts/process", body: "receipt",
+			statusCode: 201, expectedResponse: `{"processed":8}`, expectedLocation: "/v1/receipts/8",
+		},
+		{
+			id: 14, useCase: "Positive case: keys are scoped to the user of the client",
+			key: "key-5", clientID: "partner-a", userID: "user-2", path: "/v1/receipts/process", body: "receipt",
+			statusCode: 201, expectedResponse: `{"processed":9}`, expectedLocation: "/v1/receipts/9",
+		},
+	}
+
+	for _, tc := range testCases {
+		now = now.Add(tc.after)
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", tc.path, strings.NewReader(tc.body))
+		if tc.key != "" {
+			r.Header.Set(Header, tc.key)
+		}
+
+		if tc.clientID != "" {
+			r = r.WithContext(auth.NewContext(r.Context(), &model.Principal{ClientID: tc.clientID, UserID: tc.userID}))
+		}
+
+		h.ServeHTTP(w, r)
+
+		resp, _ := io.ReadAll(w.Result().Body)
+		assert.Equal(t, tc.statusCode, w.Result().StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Contains(t, string(resp), tc.expectedResponse, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedReplayed, w.Result().Header.Get(ReplayedHeader) == "true", fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedLocation, w.Result().Header.Get("Location"), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestMiddlewareReplay_InProgress(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	m := New(logger, data.NewIdempotency(logger, 0), time.Hour)
+
+	// The retry is sent while the first request is processed.
+	var retry *httptest.ResponseRecorder
+	var h http.Handler
+	h = m.Replay(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if retry == nil {
+			retry = httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/v1/receipts/process", strings.NewReader("receipt"))
+			req.Header.Set(Header, "key-1")
+			h.ServeHTTP(retry, req)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/v1/receipts/process", strings.NewReader("receipt"))
+	r.Header.Set(Header, "key-1")
+	h.ServeHTTP(w, r)
+
+	body, _ := io.ReadAll(retry.Result().Body)
+	assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+	assert.Equal(t, http.StatusConflict, retry.Result().StatusCode)
+	assert.Contains(t, string(body), `"code":"idempotency.in_progress"`)
+}
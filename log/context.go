@@ -0,0 +1,39 @@
+package log
+
+import "context"
+
+type contextKey string
+
+const traceKey contextKey = "trace"
+
+// Trace identifies the request a log line belongs to.
+type Trace struct {
+	RequestID string // Value of the X-Request-ID header, echoed back to the caller.
+	TraceID   string // W3C trace-id shared by every hop of the request.
+	SpanID    string // W3C parent-id of the span handling the request in this service.
+}
+
+// NewContext returns a copy of ctx carrying the trace of the current request.
+func NewContext(ctx context.Context, trace Trace) context.Context {
+	return context.WithValue(ctx, traceKey, trace)
+}
+
+// FromContext returns the trace stored in ctx, or an empty Trace if there is none.
+func FromContext(ctx context.Context) Trace {
+	trace, _ := ctx.Value(traceKey).(Trace)
+	return trace
+}
+
+// LogContext logs a custom log message tagged with the request and trace ids stored in ctx.
+func (c *CustomLogger) LogContext(ctx context.Context, lm *Message) {
+	trace := FromContext(ctx)
+	if lm.RequestId == "" {
+		lm.RequestId = trace.RequestID
+	}
+
+	if lm.TraceId == "" {
+		lm.TraceId = trace.TraceID
+	}
+
+	c.Log(lm)
+}
@@ -14,12 +14,16 @@ type CustomLogger struct {
 
 type Message struct {
 	Level        string `json:"level,omitempty"`
+	RequestId    string `json:"requestId,omitempty"`
 	TraceId      string `json:"traceId,omitempty"`
 	Method       string `json:"method,omitempty"`
 	URI          string `json:"uri,omitempty"`
+	Route        string `json:"route,omitempty"`
+	RemoteAddr   string `json:"remoteAddr,omitempty"`
 	Msg          string `json:"msg,omitempty"`
 	StatusCode   int    `json:"statusCode,omitempty"`
-	Duration     int64  `json:"duration,omitempty"`
+	Bytes        int64  `json:"bytes,omitempty"`
+	Duration     int64  `json:"duration,omitempty"` // Duration in microseconds.
 	ErrorMessage string `json:"errorMessage,omitempty"`
 }
 
@@ -57,3 +61,17 @@ func (c *CustomLogger) Log(lm *Message) {
 
 	c.Logger.Print(logMessage)
 }
+
+// Writable verifies the log file is still open and can be appended to.
+func (c *CustomLogger) Writable() error {
+	if _, err := c.file.Stat(); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(c.file.Name(), os.O_WRONLY|os.O_APPEND, 0766)
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
@@ -1,80 +1,430 @@
 package main
 
 import (
+	"context"
+	er "errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
-	"github.com/gorilla/mux"
-	"github.com/joho/godotenv"
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/config"
 	store "github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/expiry"
+	"github/shivasaicharanruthala/backend-engineer-takehome/fraud"
 	"github/shivasaicharanruthala/backend-engineer-takehome/handler"
+	"github/shivasaicharanruthala/backend-engineer-takehome/health"
+	"github/shivasaicharanruthala/backend-engineer-takehome/idempotency"
 	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/metrics"
+	"github/shivasaicharanruthala/backend-engineer-takehome/middleware"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/openapi"
+	"github/shivasaicharanruthala/backend-engineer-takehome/outbox"
+	"github/shivasaicharanruthala/backend-engineer-takehome/queue"
+	"github/shivasaicharanruthala/backend-engineer-takehome/ratelimit"
+	"github/shivasaicharanruthala/backend-engineer-takehome/routes"
+	"github/shivasaicharanruthala/backend-engineer-takehome/rpc"
 	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+	"github/shivasaicharanruthala/backend-engineer-takehome/stream"
+	"github/shivasaicharanruthala/backend-engineer-takehome/webhook"
+	"google.golang.org/grpc"
 )
 
-func init() {
-	var EnvFilePaths = []string{".env", "/opt/receipts.dev.env", "/opt/receipts.prod.env"}
-
-	for _, envFilePath := range EnvFilePaths {
-		err := godotenv.Load(envFilePath)
-		if err != nil {
-			fmt.Printf("Error loading %v file\n", envFilePath)
-		} else {
-			break
-		}
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Receipts Server failed: %v\n", err)
+		os.Exit(1)
 	}
 }
 
-func main() {
-	logFilePath := os.Getenv("LOG_FILE_PATH")
+// run loads the configuration from args and the environment, wires up all layers and serves HTTP until the server stops.
+// It fails fast with an error when the configuration is invalid.
+func run(args []string) error {
+	cfg, err := config.Load(args)
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
 
 	// Initialize Logger
-	logger, err := log.NewCustomLogger(logFilePath)
+	logger, err := log.NewCustomLogger(cfg.LogFilePath)
 	if err != nil {
-		lm := log.Message{Level: "ERROR", ErrorMessage: fmt.Sprintf("Initiating logger with error %v", err.Error())}
-		logger.Log(&lm)
+		return fmt.Errorf("initiating logger: %w", err)
 	}
 
 	lm := log.Message{Level: "INFO", Msg: "Logger initialized successfully"}
 	logger.Log(&lm)
 
-	// Store Layer
-	receiptsStore := store.New(logger)
+	lm = log.Message{Level: "INFO", Msg: fmt.Sprintf("Effective configuration: %v", cfg)}
+	logger.Log(&lm)
+
+	// Store Layer, receipts, reviews and the ledger write their domain events to the outbox when sinks are configured.
+	var outboxStore store.Outbox
+	receiptsStore, reviewsStore, ledgerStore := store.New(logger), store.NewReviews(logger), store.NewLedger(logger)
+	if len(cfg.OutboxSinkNames()) > 0 {
+		outboxStore = store.NewOutbox(logger)
+		receiptsStore, reviewsStore = store.NewWithOutbox(logger, outboxStore), store.NewReviewsWithOutbox(logger, outboxStore)
+		ledgerStore = store.NewLedgerWithOutbox(logger, outboxStore)
+	}
+
+	metrics.Default.NewGaugeFunc("receipts_store_size", "Number of receipts in the store.", func() float64 {
+		return float64(receiptsStore.Count())
+	})
+
+	apiKeysStore := store.NewAPIKeys(logger)
+
+	apiKeys, _ := auth.ParseAPIKeys(cfg.APIKeys) // already validated by config.Load
+	for i := range apiKeys {
+		apiKeysStore.Insert(&apiKeys[i])
+	}
+
+	rewardsStore := store.NewRewards(logger)
+	adjustmentsStore := store.NewAdjustments(logger)
+	campaignsStore := store.NewCampaigns(logger)
+	retailersStore := store.NewRetailers(logger)
+	webhooksStore := store.NewWebhooks(logger)
+	deliveriesStore := store.NewDeliveries(logger)
+	deadLettersStore := store.NewDeadLetters(logger)
+	dailyPointsStore := store.NewDailyPoints(logger)
+
+	// Webhook deliveries of receipt events
+	var dispatcher *webhook.Dispatcher
+	var publisher service.Publisher
+	var redeliverer service.Redeliverer
+	if cfg.WebhooksEnabled {
+		dispatcher = webhook.New(logger, webhooksStore, deliveriesStore, deadLettersStore, webhook.NewClient(), webhook.Config{
+			Workers:     cfg.WebhookWorkers,
+			MaxAttempts: cfg.WebhookMaxAttempts,
+			Backoff:     cfg.WebhookBackoff,
+			MaxBackoff:  10 * time.Minute,
+			Timeout:     cfg.WebhookTimeout,
+		})
+		publisher = dispatcher
+		redeliverer = dispatcher
+	}
+
+	// Fraud scoring
+	var scorer *fraud.Scorer
+	if cfg.FraudScoringEnabled {
+		scorer = fraud.New(logger, fraud.Default()...)
+	}
+
+	// Live stream of scored receipts
+	receiptsStream := stream.New(cfg.StreamBufferSize, cfg.StreamClientBuffer)
+
+	metrics.Default.NewGaugeFunc("stream_clients", "Number of clients connected to the receipts stream.", func() float64 {
+		return float64(receiptsStream.Clients())
+	})
 
 	// Service Layer
-	receiptsSvc := service.New(logger, receiptsStore)
+	receiptsSvc := service.New(logger, receiptsStore,
+		service.WithLedger(ledgerStore),
+		service.WithPointsExpiry(cfg.PointsExpiryMonths),
+		service.WithCampaigns(campaignsStore),
+		service.WithRetailers(retailersStore),
+		service.WithReceiptPointsCap(cfg.MaxPointsPerReceipt),
+		service.WithDailyPointsCap(cfg.MaxUserPointsPerDay, dailyPointsStore),
+		service.WithFraudScoring(scorer, cfg.FraudHistoryWindow, cfg.FraudReviewThreshold, reviewsStore),
+		service.WithPublisher(publisher),
+		service.WithPublisher(receiptsStream),
+	)
+	usersSvc := service.NewUsers(logger, ledgerStore)
+	rewardsSvc := service.NewRewards(logger, rewardsStore, ledgerStore)
+	returnsSvc := service.NewReturns(logger, receiptsStore, adjustmentsStore, ledgerStore, reviewsStore, publisher)
+	campaignsSvc := service.NewCampaigns(logger, campaignsStore)
+	retailersSvc := service.NewRetailers(logger, retailersStore)
+	webhooksSvc := service.NewWebhooks(logger, webhooksStore, deliveriesStore, deadLettersStore, redeliverer)
+	reviewsSvc := service.NewReviews(logger, reviewsStore, ledgerStore, dailyPointsStore, cfg.PointsExpiryMonths, publisher)
+
+	// Asynchronous processing, receipts are queued and scored by a pool of workers.
+	var receiptsQueue *queue.Queue
+	var receiptsOpts []handler.Option
+	if cfg.AsyncProcessing {
+		jobsStore := store.NewJobs(logger)
+		if cfg.QueueFile != "" {
+			if jobsStore, err = store.NewFileJobs(logger, cfg.QueueFile); err != nil {
+				return fmt.Errorf("opening queue file: %w", err)
+			}
+		}
+
+		receiptsQueue = queue.New(logger, jobsStore, receiptsSvc, cfg.QueueWorkers, cfg.QueueCapacity, cfg.QueueRetention)
+		receiptsOpts = append(receiptsOpts, handler.WithJobs(receiptsQueue))
 
-	// Handler Layer
-	receiptsHandler := handler.New(logger, receiptsSvc)
+		metrics.Default.NewGaugeFunc("receipts_queue_depth", "Number of receipts waiting for a worker.", func() float64 {
+			return float64(receiptsQueue.Depth())
+		})
+	}
 
-	// Setup router using mux
-	router := mux.NewRouter().StrictSlash(true)
-	router.MethodNotAllowedHandler = http.HandlerFunc(MethodNotImplementedHandler)
+	// Outbox relay, publishing domain events to the configured sinks.
+	var relay *outbox.Relay
+	var brokerEvents <-chan model.DomainEvent
+	if outboxStore != nil {
+		sinks, broker, err := outboxSinks(cfg)
+		if err != nil {
+			return fmt.Errorf("opening outbox sinks: %w", err)
+		}
 
-	// Health check Route
-	router.HandleFunc("/v1/health", receiptsHandler.Health).Methods("GET")
+		// The broker only keeps events for its subscribers, they are consumed by the background jobs.
+		if broker != nil {
+			brokerEvents = broker.Subscribe()
+		}
+
+		relay = outbox.New(logger, outboxStore, cfg.OutboxInterval, cfg.OutboxBatchSize, sinks...)
+
+		metrics.Default.NewGaugeFunc("outbox_pending", "Number of domain events waiting to be published.", func() float64 {
+			return float64(outboxStore.Count())
+		})
+	}
 
-	// Receipts Routes
-	router.HandleFunc("/v1/receipts/{id}/points", receiptsHandler.Get).Methods("GET")
-	router.HandleFunc("/v1/receipts/process", receiptsHandler.Insert).Methods("POST")
+	// Health checks
+	checker := health.New(
+		health.Check{Name: "store", Fn: receiptsStore.Ping},
+		health.Check{Name: "logFile", Fn: logger.Writable},
+		health.Check{Name: "rules", Fn: func() error {
+			if len(model.Rules) == 0 {
+				return er.New("no scoring rules loaded")
+			}
+
+			return nil
+		}},
+	)
+
+	// Authentication
+	verifier, err := auth.NewVerifier(auth.VerifierConfig{
+		HMACSecretFile:   cfg.JWTHMACSecretFile,
+		RSAPublicKeyFile: cfg.JWTRSAPublicKeyFile,
+		JWKSFile:         cfg.JWTJWKSFile,
+		Audience:         cfg.JWTAudience,
+		Issuer:           cfg.JWTIssuer,
+		RolesClaim:       cfg.JWTRolesClaim,
+		Leeway:           cfg.JWTLeeway,
+	})
+	if err != nil {
+		return fmt.Errorf("loading JWT keys: %w", err)
+	}
+
+	authenticator := auth.New(logger, apiKeysStore, verifier, cfg.AuthEnabled)
+
+	// Rate limiting, limits are counted per IP address before authentication and per principal once authenticated.
+	var limiter *ratelimit.Limiter
+	if cfg.RateLimitEnabled {
+		limits, _ := ratelimit.ParseLimits(cfg.RateLimits) // already validated by config.Load
+		limiter = ratelimit.NewLimiter(limits)
+	}
+
+	limits := ratelimit.New(logger, limiter, store.NewQuotas(logger), cfg.DailySubmissionQuota)
+
+	// Retries of submissions sent with an Idempotency-Key are answered with the response of the first attempt.
+	idempotent := idempotency.New(logger, store.NewIdempotency(logger, cfg.IdempotencyMaxKeys), cfg.IdempotencyTTL)
+
+	// OpenAPI document, the routes are validated against it.
+	spec, err := openapi.Load(openapi.Spec)
+	if err != nil {
+		return fmt.Errorf("loading OpenAPI document: %w", err)
+	}
+
+	router, err := routes.New(logger, cfg, routes.Dependencies{
+		Receipts:        receiptsSvc,
+		ReceiptsOptions: receiptsOpts,
+		Users:           usersSvc,
+		Rewards:         rewardsSvc,
+		Returns:         returnsSvc,
+		Campaigns:       campaignsSvc,
+		Retailers:       retailersSvc,
+		Reviews:         reviewsSvc,
+		Webhooks:        webhooksSvc,
+		Stream:          receiptsStream,
+		Checker:         checker,
+		Authenticator:   authenticator,
+		Limits:          limits,
+		Idempotency:     idempotent,
+		Spec:            spec,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Middlewares wrap the whole router so that unmatched routes are covered too, requests are validated by the routes.
+	var h http.Handler = router
+	if cfg.OpenAPIValidateResponses {
+		h = openapi.ValidateResponses(logger, spec)(h)
+	}
+	h = middleware.MaxBodySize(cfg.MaxRequestBodyBytes)(h)
+	h = middleware.Metrics(router)(h)
+	h = middleware.AccessLog(logger, router, cfg.AccessLogSampleRate)(h)
+	h = middleware.RequestID(h)
 
 	// Start the server
-	port := os.Getenv("PORT")
-	server := fmt.Sprintf(":%s", port)
+	server := &http.Server{Addr: fmt.Sprintf(":%d", cfg.Port), Handler: h}
+	server.RegisterOnShutdown(receiptsStream.Close)
 
-	lm = log.Message{Level: "INFO", Msg: fmt.Sprintf("Receipts Server starting to listen on port %v", port)}
+	lm = log.Message{Level: "INFO", Msg: fmt.Sprintf("Receipts Server starting to listen on port %v", cfg.Port)}
 	logger.Log(&lm)
 
-	err = http.ListenAndServe(server, router)
-	if err != nil {
-		lm = log.Message{Level: "ERROR", ErrorMessage: fmt.Sprintf("Initializing receipts server to listen on port %v with error %v", port, err.Error())}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Background jobs run on their own context, they stop once the servers stopped serving requests so that the
+	// receipts and events of in-flight requests are still processed. Pending outbox events are flushed once more.
+	workers, stopWorkers := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	runWorker := func(run func(ctx context.Context)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run(workers)
+		}()
+	}
+
+	defer func() {
+		stopWorkers()
+		wg.Wait()
+
+		if relay != nil {
+			flushCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+			defer cancel()
+
+			relay.Flush(flushCtx)
+		}
+	}()
+
+	runWorker(expiry.New(logger, ledgerStore, cfg.PointsExpiryInterval).Run)
+	if receiptsQueue != nil {
+		runWorker(receiptsQueue.Run)
+	}
+	if dispatcher != nil {
+		runWorker(dispatcher.Run)
+	}
+	if relay != nil {
+		runWorker(relay.Run)
+	}
+	if brokerEvents != nil {
+		runWorker(func(ctx context.Context) {
+			outbox.Consume(ctx, brokerEvents, func(event model.DomainEvent) {
+				lm := log.Message{Level: "INFO", Msg: fmt.Sprintf("Domain event %v %v of receipt %v consumed from the broker", event.ID, event.Type, event.ReceiptID)}
+				logger.Log(&lm)
+			})
+		})
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	// gRPC API, stopped along with the HTTP server.
+	var grpcServer *grpc.Server
+	if cfg.GRPCEnabled {
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPCPort))
+		if err != nil {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+			defer cancel()
+
+			_ = server.Shutdown(shutdownCtx)
+
+			return fmt.Errorf("listening on gRPC port %v: %w", cfg.GRPCPort, err)
+		}
+
+		grpcServer = rpc.NewServer(logger, rpc.New(logger, receiptsSvc), authenticator, limits, cfg.GRPCMaxBatchSize)
+
+		lm = log.Message{Level: "INFO", Msg: fmt.Sprintf("Receipts gRPC Server starting to listen on port %v", cfg.GRPCPort)}
 		logger.Log(&lm)
-		return
+
+		go func() {
+			serverErr <- grpcServer.Serve(listener)
+		}()
+	}
+
+	select {
+	case err = <-serverErr:
+		lm = log.Message{Level: "ERROR", ErrorMessage: fmt.Sprintf("Initializing receipts server to listen on port %v with error %v", cfg.Port, err.Error())}
+		logger.Log(&lm)
+
+		_ = server.Close()
+		if grpcServer != nil {
+			grpcServer.Stop()
+		}
+
+		return err
+	case <-ctx.Done():
 	}
+
+	return shutdown(logger, cfg, server, grpcServer, checker)
 }
 
-func MethodNotImplementedHandler(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusMethodNotAllowed)
-	return
+// outboxSinks creates the outbox sinks named in the configuration, along with the broker when it is one of them.
+func outboxSinks(cfg *config.Config) ([]outbox.Sink, *outbox.Broker, error) {
+	var sinks []outbox.Sink
+	var broker *outbox.Broker
+	for _, name := range cfg.OutboxSinkNames() {
+		switch name {
+		case "stdout":
+			sinks = append(sinks, outbox.NewStdoutSink())
+		case "file":
+			sink, err := outbox.NewFileSink(cfg.OutboxFile)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			sinks = append(sinks, sink)
+		case "webhook":
+			sinks = append(sinks, outbox.NewWebhookSink(&http.Client{Timeout: cfg.WebhookTimeout}, cfg.OutboxWebhookURL))
+		case "broker":
+			broker = outbox.NewBroker(cfg.OutboxBatchSize)
+			sinks = append(sinks, broker)
+		}
+	}
+
+	return sinks, broker, nil
+}
+
+// shutdown drains the servers: readiness reports down for the drain delay so that load balancers stop routing to them,
+// then the servers stop accepting connections and wait up to the shutdown timeout for in-flight requests and calls.
+// grpcServer is nil when the gRPC API is disabled.
+func shutdown(logger *log.CustomLogger, cfg *config.Config, server *http.Server, grpcServer *grpc.Server, checker *health.Checker) error {
+	checker.SetDraining()
+
+	lm := log.Message{Level: "INFO", Msg: fmt.Sprintf("Receipts Server draining for %v before shutdown", cfg.ShutdownDrainDelay)}
+	logger.Log(&lm)
+
+	time.Sleep(cfg.ShutdownDrainDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// Both servers stop together, in-flight calls of the gRPC server are bounded by the same timeout.
+	grpcStopped := make(chan struct{})
+	go func() {
+		if grpcServer != nil {
+			grpcServer.GracefulStop()
+		}
+		close(grpcStopped)
+	}()
+
+	err := server.Shutdown(ctx)
+
+	select {
+	case <-grpcStopped:
+	case <-ctx.Done():
+		if grpcServer != nil {
+			grpcServer.Stop()
+		}
+		<-grpcStopped
+	}
+
+	if err != nil {
+		lm = log.Message{Level: "ERROR", ErrorMessage: fmt.Sprintf("Shutting down receipts server with error %v", err.Error())}
+		logger.Log(&lm)
+		return err
+	}
+
+	lm = log.Message{Level: "INFO", Msg: "Receipts Server stopped"}
+	logger.Log(&lm)
+
+	return nil
 }
@@ -18,6 +18,7 @@ import (
 	"github/shivasaicharanruthala/backend-engineer-takehome/data"
 	"github/shivasaicharanruthala/backend-engineer-takehome/handler"
 	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/middleware"
 	"github/shivasaicharanruthala/backend-engineer-takehome/model"
 	"github/shivasaicharanruthala/backend-engineer-takehome/service"
 )
@@ -177,7 +178,7 @@ func setUpRouter() *mux.Router {
 func TestLiveServer(t *testing.T) {
 	// Start the server in a separate goroutine to allow it to run concurrently with the tests.
 	go func() {
-		main()
+		_ = run(nil)
 	}()
 
 	// Allow some time for the server to start
@@ -207,3 +208,20 @@ func TestLiveServer(t *testing.T) {
 		t.Errorf("Expected All Integration Tests to pass but got error")
 	}
 }
+
+func TestIntegrations_RequestID(t *testing.T) {
+	server := httptest.NewServer(middleware.RequestID(setUpRouter()))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL+"/v1/receipts/1234/points", nil)
+	req.Header.Set(middleware.RequestIDHeader, "integration-request-1")
+
+	result, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+
+	resp, _ := io.ReadAll(result.Body)
+
+	assert.Equal(t, 400, result.StatusCode)
+	assert.Equal(t, "integration-request-1", result.Header.Get(middleware.RequestIDHeader))
+	assert.Regexp(t, `"requestId":"integration-request-1"`, string(resp))
+}
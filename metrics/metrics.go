@@ -0,0 +1,55 @@
+package metrics
+
+// Default is the registry served on /metrics, the metrics below are registered on it.
+var Default = NewRegistry()
+
+// PointsBuckets are the upper bounds used for the points awarded per receipt.
+var PointsBuckets = []float64{5, 10, 25, 50, 75, 100, 150, 200, 300, 500}
+
+var (
+	// HTTPRequests counts completed HTTP requests by method, route template and status code.
+	HTTPRequests = Default.NewCounterVec("http_requests_total", "Total number of HTTP requests.", "method", "route", "status")
+
+	// HTTPRequestDuration observes HTTP request latencies in seconds by method, route template and status code.
+	HTTPRequestDuration = Default.NewHistogramVec("http_request_duration_seconds", "HTTP request latencies in seconds.", DefBuckets, "method", "route", "status")
+
+	// ReceiptsInserted counts receipts successfully scored and stored.
+	ReceiptsInserted = Default.NewCounterVec("receipts_inserted_total", "Total number of receipts inserted.")
+
+	// PointsAwarded observes the points awarded per inserted receipt.
+	PointsAwarded = Default.NewHistogramVec("receipts_points_awarded", "Points awarded per receipt.", PointsBuckets)
+
+	// RuleHits counts how often each scoring rule awarded points to a receipt.
+	RuleHits = Default.NewCounterVec("receipts_rule_hits_total", "Total number of receipts each scoring rule awarded points to.", "rule")
+
+	// PointsCapped counts the points withheld from receipts by each points cap.
+	PointsCapped = Default.NewCounterVec("receipts_points_capped_total", "Total number of points withheld by the points caps.", "cap")
+
+	// FraudSignals counts the receipts each fraud detector found its pattern in.
+	FraudSignals = Default.NewCounterVec("fraud_signals_total", "Total number of receipts each fraud detector flagged.", "detector")
+
+	// ReceiptsHeld counts the receipts whose points were held for a fraud review.
+	ReceiptsHeld = Default.NewCounterVec("receipts_held_for_review_total", "Total number of receipts held for a fraud review.")
+
+	// ReviewsDecided counts the fraud reviews decided by status.
+	ReviewsDecided = Default.NewCounterVec("fraud_reviews_decided_total", "Total number of fraud reviews decided.", "status")
+
+	// ReceiptJobs counts the receipts queued for asynchronous processing, and processed or failed, by status.
+	ReceiptJobs = Default.NewCounterVec("receipt_jobs_total", "Total number of asynchronous receipt jobs by status.", "status")
+
+	// WebhookDeliveries counts the webhook delivery attempts by status.
+	WebhookDeliveries = Default.NewCounterVec("webhook_deliveries_total", "Total number of webhook delivery attempts by status.", "status")
+
+	// OutboxDeliveries counts the deliveries of outbox events to each sink by status.
+	OutboxDeliveries = Default.NewCounterVec("outbox_deliveries_total", "Total number of outbox event deliveries by sink and status.", "sink", "status")
+
+	// StreamClientsDropped counts the clients of the receipts stream disconnected for falling behind.
+	StreamClientsDropped = Default.NewCounterVec("stream_clients_dropped_total", "Total number of receipts stream clients disconnected for falling behind.")
+
+	// PointsExpired counts the points expired by the expiry job.
+	PointsExpired = Default.NewCounterVec("ledger_points_expired_total", "Total number of points expired before they were spent.")
+)
+
+func init() {
+	RegisterRuntimeMetrics(Default)
+}
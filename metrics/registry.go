@@ -0,0 +1,325 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefBuckets are the default latency buckets in seconds.
+var DefBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// collector writes its metric families in the Prometheus text exposition format.
+type collector interface {
+	name() string
+	write(w *bufio.Writer)
+}
+
+// Registry holds a set of metrics and renders them in the Prometheus text exposition format.
+// It is safe for concurrent use.
+type Registry struct {
+	mu         sync.Mutex
+	collectors []collector
+}
+
+// NewRegistry creates and returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// register adds c to the registry, it panics if a metric with the same name is already registered.
+func (reg *Registry) register(c collector) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	for _, existing := range reg.collectors {
+		if existing.name() == c.name() {
+			panic(fmt.Sprintf("metrics: duplicate metric %q", c.name()))
+		}
+	}
+
+	reg.collectors = append(reg.collectors, c)
+}
+
+// Write renders every registered metric to w, ordered by metric name.
+func (reg *Registry) Write(w io.Writer) error {
+	reg.mu.Lock()
+	collectors := make([]collector, len(reg.collectors))
+	copy(collectors, reg.collectors)
+	reg.mu.Unlock()
+
+	sort.Slice(collectors, func(i, j int) bool { return collectors[i].name() < collectors[j].name() })
+
+	bw := bufio.NewWriter(w)
+	for _, c := range collectors {
+		c.write(bw)
+	}
+
+	return bw.Flush()
+}
+
+// Handler returns an http.Handler serving the registry in the Prometheus text exposition format.
+func (reg *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_ = reg.Write(w)
+	})
+}
+
+// CounterVec is a family of counters partitioned by label values.
+type CounterVec struct {
+	family
+	mu     sync.Mutex
+	series map[string]*Counter
+}
+
+// Counter is a monotonically increasing value.
+type Counter struct {
+	mu     sync.Mutex
+	labels []string
+	value  float64
+}
+
+// NewCounterVec registers and returns a counter family with the given label names.
+func (reg *Registry) NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	cv := &CounterVec{family: family{metricName: name, help: help, labelNames: labelNames}, series: make(map[string]*Counter)}
+	reg.register(cv)
+
+	return cv
+}
+
+// WithLabelValues returns the counter for the given label values, creating it on first use.
+func (cv *CounterVec) WithLabelValues(values ...string) *Counter {
+	cv.checkLabels(values)
+
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+
+	key := strings.Join(values, "\xff")
+	c, ok := cv.series[key]
+	if !ok {
+		c = &Counter{labels: values}
+		cv.series[key] = c
+	}
+
+	return c
+}
+
+// Inc increments the counter by 1.
+func (c *Counter) Inc() {
+	c.Add(1)
+}
+
+// Add increments the counter by v, negative values are ignored.
+func (c *Counter) Add(v float64) {
+	if v < 0 {
+		return
+	}
+
+	c.mu.Lock()
+	c.value += v
+	c.mu.Unlock()
+}
+
+// Value returns the current value of the counter.
+func (c *Counter) Value() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.value
+}
+
+func (cv *CounterVec) write(w *bufio.Writer) {
+	cv.writeHeader(w, "counter")
+
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+
+	for _, key := range sortedKeys(cv.series) {
+		c := cv.series[key]
+		writeSample(w, cv.metricName, cv.labelNames, c.labels, "", "", c.Value())
+	}
+}
+
+// HistogramVec is a family of histograms partitioned by label values.
+type HistogramVec struct {
+	family
+	buckets []float64
+	mu      sync.Mutex
+	series  map[string]*Histogram
+}
+
+// Histogram counts observations in cumulative buckets and tracks their sum.
+type Histogram struct {
+	mu      sync.Mutex
+	labels  []string
+	buckets []float64
+	counts  []uint64
+	count   uint64
+	sum     float64
+}
+
+// NewHistogramVec registers and returns a histogram family with the given upper bucket bounds and label names.
+func (reg *Registry) NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	sorted := make([]float64, len(buckets))
+	copy(sorted, buckets)
+	sort.Float64s(sorted)
+
+	hv := &HistogramVec{family: family{metricName: name, help: help, labelNames: labelNames}, buckets: sorted, series: make(map[string]*Histogram)}
+	reg.register(hv)
+
+	return hv
+}
+
+// WithLabelValues returns the histogram for the given label values, creating it on first use.
+func (hv *HistogramVec) WithLabelValues(values ...string) *Histogram {
+	hv.checkLabels(values)
+
+	hv.mu.Lock()
+	defer hv.mu.Unlock()
+
+	key := strings.Join(values, "\xff")
+	h, ok := hv.series[key]
+	if !ok {
+		h = &Histogram{labels: values, buckets: hv.buckets, counts: make([]uint64, len(hv.buckets))}
+		hv.series[key] = h
+	}
+
+	return h
+}
+
+// Observe adds a single observation to the histogram.
+func (h *Histogram) Observe(v float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, upper := range h.buckets {
+		if v <= upper {
+			h.counts[i]++
+		}
+	}
+
+	h.count++
+	h.sum += v
+}
+
+// Count returns the number of observations.
+func (h *Histogram) Count() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.count
+}
+
+func (hv *HistogramVec) write(w *bufio.Writer) {
+	hv.writeHeader(w, "histogram")
+
+	hv.mu.Lock()
+	defer hv.mu.Unlock()
+
+	for _, key := range sortedKeys(hv.series) {
+		h := hv.series[key]
+
+		h.mu.Lock()
+		for i, upper := range h.buckets {
+			writeSample(w, hv.metricName+"_bucket", hv.labelNames, h.labels, "le", formatFloat(upper), float64(h.counts[i]))
+		}
+		writeSample(w, hv.metricName+"_bucket", hv.labelNames, h.labels, "le", "+Inf", float64(h.count))
+		writeSample(w, hv.metricName+"_sum", hv.labelNames, h.labels, "", "", h.sum)
+		writeSample(w, hv.metricName+"_count", hv.labelNames, h.labels, "", "", float64(h.count))
+		h.mu.Unlock()
+	}
+}
+
+// gaugeFunc is a gauge whose value is read from a function at collection time.
+type gaugeFunc struct {
+	family
+	fn func() float64
+}
+
+// NewGaugeFunc registers a gauge whose value is computed by fn every time the registry is written.
+func (reg *Registry) NewGaugeFunc(name, help string, fn func() float64) {
+	reg.register(&gaugeFunc{family: family{metricName: name, help: help}, fn: fn})
+}
+
+func (g *gaugeFunc) write(w *bufio.Writer) {
+	g.writeHeader(w, "gauge")
+	writeSample(w, g.metricName, nil, nil, "", "", g.fn())
+}
+
+// family holds the metadata shared by every series of a metric.
+type family struct {
+	metricName string
+	help       string
+	labelNames []string
+}
+
+func (f *family) name() string {
+	return f.metricName
+}
+
+func (f *family) checkLabels(values []string) {
+	if len(values) != len(f.labelNames) {
+		panic(fmt.Sprintf("metrics: %q expects %d label values, got %d", f.metricName, len(f.labelNames), len(values)))
+	}
+}
+
+func (f *family) writeHeader(w *bufio.Writer, metricType string) {
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n", f.metricName, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(f.help))
+	_, _ = fmt.Fprintf(w, "# TYPE %s %s\n", f.metricName, metricType)
+}
+
+// writeSample writes a single sample line, extraName/extraValue is an additional label such as the histogram "le".
+func writeSample(w *bufio.Writer, name string, labelNames, labelValues []string, extraName, extraValue string, value float64) {
+	_, _ = w.WriteString(name)
+
+	var pairs []string
+	for i, labelName := range labelNames {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, labelName, escapeLabelValue(labelValues[i])))
+	}
+
+	if extraName != "" {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, extraName, extraValue))
+	}
+
+	if len(pairs) > 0 {
+		_, _ = w.WriteString("{" + strings.Join(pairs, ",") + "}")
+	}
+
+	_, _ = w.WriteString(" " + formatFloat(value) + "\n")
+}
+
+func escapeLabelValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
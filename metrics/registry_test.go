@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryWrite(t *testing.T) {
+	reg := NewRegistry()
+
+	requests := reg.NewCounterVec("test_requests_total", "Total requests.", "route", "status")
+	requests.WithLabelValues("/v1/receipts/{id}/points", "200").Inc()
+	requests.WithLabelValues("/v1/receipts/{id}/points", "200").Add(2)
+	requests.WithLabelValues("/v1/receipts/process", "400").Inc()
+
+	points := reg.NewHistogramVec("test_points", "Points per receipt.", []float64{10, 50})
+	points.WithLabelValues().Observe(5)
+	points.WithLabelValues().Observe(28)
+	points.WithLabelValues().Observe(109)
+
+	reg.NewGaugeFunc("test_store_size", "Receipts \"stored\".", func() float64 { return 7 })
+
+	var buf bytes.Buffer
+	assert.NoError(t, reg.Write(&buf))
+
+	expected := `# HELP test_points Points per receipt.
+# TYPE test_points histogram
+test_points_bucket{le="10"} 1
+test_points_bucket{le="50"} 2
+test_points_bucket{le="+Inf"} 3
+test_points_sum 142
+test_points_count 3
+# HELP test_requests_total Total requests.
+# TYPE test_requests_total counter
+test_requests_total{route="/v1/receipts/process",status="400"} 1
+test_requests_total{route="/v1/receipts/{id}/points",status="200"} 3
+# HELP test_store_size Receipts "stored".
+# TYPE test_store_size gauge
+test_store_size 7
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRegistryRegister(t *testing.T) {
+	testCases := []struct {
+		id          int
+		useCase     string
+		register    func(reg *Registry)
+		expectPanic bool
+	}{
+		{
+			id: 1, useCase: "Negative case: duplicate metric name",
+			register: func(reg *Registry) {
+				reg.NewCounterVec("test_total", "Test.")
+				reg.NewCounterVec("test_total", "Test.")
+			},
+			expectPanic: true,
+		},
+		{
+			id: 2, useCase: "Negative case: wrong number of label values",
+			register: func(reg *Registry) {
+				reg.NewCounterVec("test_total", "Test.", "rule").WithLabelValues()
+			},
+			expectPanic: true,
+		},
+		{
+			id: 3, useCase: "Positive case: distinct metric names",
+			register: func(reg *Registry) {
+				reg.NewCounterVec("test_total", "Test.")
+				reg.NewHistogramVec("test_seconds", "Test.", DefBuckets)
+			},
+			expectPanic: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if tc.expectPanic {
+			assert.Panics(t, func() { tc.register(NewRegistry()) }, "Test %v Failed with use case %v", tc.id, tc.useCase)
+		} else {
+			assert.NotPanics(t, func() { tc.register(NewRegistry()) }, "Test %v Failed with use case %v", tc.id, tc.useCase)
+		}
+	}
+}
+
+func TestRegistryHandler(t *testing.T) {
+	reg := NewRegistry()
+	RegisterRuntimeMetrics(reg)
+	reg.NewCounterVec("test_label_escaping_total", "Test.", "value").WithLabelValues("a\"b\\c\nd").Inc()
+
+	w := httptest.NewRecorder()
+	reg.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := w.Body.String()
+	assert.Equal(t, 200, w.Code)
+	assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "text/plain; version=0.0.4"))
+	assert.Contains(t, body, "# TYPE go_goroutines gauge")
+	assert.Contains(t, body, "# TYPE go_gc_cycles_total counter")
+	assert.Contains(t, body, `test_label_escaping_total{value="a\"b\\c\nd"} 1`)
+}
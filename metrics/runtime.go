@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"bufio"
+	"runtime"
+)
+
+// runtimeCollector exposes Go runtime statistics read at collection time.
+type runtimeCollector struct{}
+
+// RegisterRuntimeMetrics adds goroutine, memory and garbage collection statistics of the Go runtime to reg.
+func RegisterRuntimeMetrics(reg *Registry) {
+	reg.register(runtimeCollector{})
+}
+
+func (runtimeCollector) name() string {
+	return "go_"
+}
+
+func (runtimeCollector) write(w *bufio.Writer) {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+
+	gauges := []struct {
+		name, help, metricType string
+		value                  float64
+	}{
+		{"go_goroutines", "Number of goroutines that currently exist.", "gauge", float64(runtime.NumGoroutine())},
+		{"go_memstats_alloc_bytes", "Number of bytes allocated and still in use.", "gauge", float64(ms.Alloc)},
+		{"go_memstats_heap_inuse_bytes", "Number of heap bytes that are in use.", "gauge", float64(ms.HeapInuse)},
+		{"go_memstats_heap_objects", "Number of allocated objects.", "gauge", float64(ms.HeapObjects)},
+		{"go_memstats_sys_bytes", "Number of bytes obtained from system.", "gauge", float64(ms.Sys)},
+		{"go_gc_cycles_total", "Number of completed GC cycles.", "counter", float64(ms.NumGC)},
+	}
+
+	for _, g := range gauges {
+		f := family{metricName: g.name, help: g.help}
+		f.writeHeader(w, g.metricType)
+		writeSample(w, g.name, nil, nil, "", "", g.value)
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+)
+
+// AccessLog logs every request with its status, bytes written, duration, remote address and route template.
+// Requests that fail with a status of 400 or above are always logged, successful ones only with probability sampleRate.
+func AccessLog(logger *log.CustomLogger, router *mux.Router, sampleRate float64) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := newResponseRecorder(w)
+
+			next.ServeHTTP(rec, r)
+
+			if rec.statusCode < 400 && !sampled(sampleRate) {
+				return
+			}
+
+			lm := log.Message{
+				Level:      accessLogLevel(rec.statusCode),
+				Msg:        "request completed",
+				Method:     r.Method,
+				URI:        r.RequestURI,
+				Route:      RouteTemplate(router, r),
+				RemoteAddr: r.RemoteAddr,
+				StatusCode: rec.statusCode,
+				Bytes:      rec.bytes,
+				Duration:   time.Since(start).Microseconds(),
+			}
+			logger.LogContext(r.Context(), &lm)
+		})
+	}
+}
+
+// sampled decides whether a successful request is logged for the given sample rate.
+func sampled(sampleRate float64) bool {
+	if sampleRate >= 1 {
+		return true
+	}
+
+	return rand.Float64() < sampleRate
+}
+
+func accessLogLevel(statusCode int) string {
+	switch {
+	case statusCode >= 500:
+		return "ERROR"
+	case statusCode >= 400:
+		return "WARN"
+	default:
+		return "INFO"
+	}
+}
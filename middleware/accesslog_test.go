@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+)
+
+func TestAccessLog(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+
+	var buf bytes.Buffer
+	logger.Logger.SetOutput(&buf)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/v1/receipts/{id}/points", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"points":10}`))
+	}).Methods("GET")
+
+	testCases := []struct {
+		id           int
+		useCase      string
+		path         string
+		sampleRate   float64
+		expectLogged bool
+		expectedLog  []string
+	}{
+		{
+			id: 1, useCase: "Positive case: successful request logged with route template",
+			path:         "/v1/receipts/4a77ec9d-5334-43d0-a9e1-4fca8807bf8f/points",
+			sampleRate:   1,
+			expectLogged: true,
+			expectedLog: []string{
+				`"route":"/v1/receipts/{id}/points"`, `"statusCode":200`, `"bytes":13`, `"method":"GET"`,
+				`"uri":"/v1/receipts/4a77ec9d-5334-43d0-a9e1-4fca8807bf8f/points"`, `"remoteAddr":"192.0.2.1:1234"`,
+			},
+		},
+		{
+			id: 2, useCase: "Positive case: successful request sampled out",
+			path:         "/v1/receipts/4a77ec9d-5334-43d0-a9e1-4fca8807bf8f/points",
+			sampleRate:   0,
+			expectLogged: false,
+		},
+		{
+			id: 3, useCase: "Positive case: failed request logged even when sampled out",
+			path:         "/v1/unknown",
+			sampleRate:   0,
+			expectLogged: true,
+			expectedLog:  []string{`WARN: `, `"statusCode":404`, `"uri":"/v1/unknown"`},
+		},
+	}
+
+	for _, tc := range testCases {
+		buf.Reset()
+
+		h := AccessLog(logger, router, tc.sampleRate)(router)
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", tc.path, nil))
+
+		line := buf.String()
+		assert.Equal(t, tc.expectLogged, line != "", fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		for _, expected := range tc.expectedLog {
+			assert.True(t, strings.Contains(line, expected), fmt.Sprintf("Test %v Failed with use case %v: %v not in %v", tc.id, tc.useCase, expected, line))
+		}
+	}
+}
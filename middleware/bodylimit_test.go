@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/responder"
+)
+
+func TestMaxBodySize(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+
+	// The handler reads the body as the handlers of the API do, reporting read errors as bad requests.
+	h := MaxBodySize(8)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			responder.SetErrorResponse(logger, errors.NewCustomError(err, 400), w, r)
+
+			return
+		}
+
+		_, _ = w.Write(body)
+	}))
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		body             string
+		statusCode       int
+		expectedResponse string
+	}{
+		{
+			id: 1, useCase: "Positive case: body within the limit",
+			body: "receipt", statusCode: 200, expectedResponse: "receipt",
+		},
+		{
+			id: 2, useCase: "Positive case: body at the limit",
+			body: "receipts", statusCode: 200, expectedResponse: "receipts",
+		},
+		{
+			id: 3, useCase: "Negative case: body above the limit",
+			body: "receipts!", statusCode: 413, expectedResponse: `"code":"request.too_large"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("POST", "/v1/receipts/process", strings.NewReader(tc.body)))
+
+		resp, _ := io.ReadAll(w.Result().Body)
+		assert.Equal(t, tc.statusCode, w.Result().StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Contains(t, string(resp), tc.expectedResponse, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
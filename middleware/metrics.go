@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github/shivasaicharanruthala/backend-engineer-takehome/metrics"
+)
+
+// unmatchedRoute is the route label of requests that match no route, so that unknown paths do not create new series.
+const unmatchedRoute = "unmatched"
+
+// Metrics counts requests and observes their latency by method, route template and status code.
+func Metrics(router *mux.Router) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := newResponseRecorder(w)
+
+			next.ServeHTTP(rec, r)
+
+			route := RouteTemplate(router, r)
+			if route == "" {
+				route = unmatchedRoute
+			}
+
+			status := strconv.Itoa(rec.statusCode)
+			metrics.HTTPRequests.WithLabelValues(r.Method, route, status).Inc()
+			metrics.HTTPRequestDuration.WithLabelValues(r.Method, route, status).Observe(time.Since(start).Seconds())
+		})
+	}
+}
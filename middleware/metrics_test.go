@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/metrics"
+)
+
+func TestMetrics(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc("/v1/receipts/{id}/points", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}).Methods("GET")
+
+	h := Metrics(router)(router)
+
+	testCases := []struct {
+		id      int
+		useCase string
+		path    string
+		route   string
+		status  string
+	}{
+		{
+			id: 1, useCase: "Positive case: matched route is labelled with its template",
+			path:   "/v1/receipts/6b1e4d3c-6f6c-4c41-a1de-5b0cbb1a2b07/points",
+			route:  "/v1/receipts/{id}/points",
+			status: "404",
+		},
+		{
+			id: 2, useCase: "Positive case: unmatched route is labelled as unmatched",
+			path:   "/v1/does-not-exist",
+			route:  unmatchedRoute,
+			status: "404",
+		},
+	}
+
+	for _, tc := range testCases {
+		requests := metrics.HTTPRequests.WithLabelValues("GET", tc.route, tc.status)
+		durations := metrics.HTTPRequestDuration.WithLabelValues("GET", tc.route, tc.status)
+		requestsBefore, durationsBefore := requests.Value(), durations.Count()
+
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", tc.path, nil))
+
+		assert.Equal(t, requestsBefore+1, requests.Value(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, durationsBefore+1, durations.Count(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
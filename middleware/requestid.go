@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+)
+
+const (
+	RequestIDHeader   = "X-Request-ID"
+	TraceParentHeader = "traceparent"
+
+	maxRequestIDLength = 128
+)
+
+// RequestID accepts the X-Request-ID and W3C traceparent headers of the incoming request, or generates them when they
+// are missing or malformed, stores them in the request context for logging and echoes them in the response headers.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceID, flags, ok := parseTraceParent(r.Header.Get(TraceParentHeader))
+		if !ok {
+			traceID, flags = randomHex(16), "00"
+		}
+
+		requestID := r.Header.Get(RequestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = uuid.New().String()
+		}
+
+		// A new span id identifies this service's hop in the trace.
+		trace := log.Trace{RequestID: requestID, TraceID: traceID, SpanID: randomHex(8)}
+
+		w.Header().Set(RequestIDHeader, trace.RequestID)
+		w.Header().Set(TraceParentHeader, fmt.Sprintf("00-%v-%v-%v", trace.TraceID, trace.SpanID, flags))
+
+		next.ServeHTTP(w, r.WithContext(log.NewContext(r.Context(), trace)))
+	})
+}
+
+// parseTraceParent extracts the trace-id and trace-flags from a W3C traceparent header value.
+// It reports false if the value does not follow the version-traceid-parentid-flags format.
+func parseTraceParent(value string) (traceID, flags string, ok bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 {
+		return "", "", false
+	}
+
+	version, traceID, parentID, flags := parts[0], parts[1], parts[2], parts[3]
+
+	// Version 00 has exactly four fields, unknown future versions may append more. Version ff is forbidden.
+	if !isHex(version, 2) || version == "ff" || (version == "00" && len(parts) != 4) {
+		return "", "", false
+	}
+
+	if !isHex(traceID, 32) || traceID == strings.Repeat("0", 32) {
+		return "", "", false
+	}
+
+	if !isHex(parentID, 16) || parentID == strings.Repeat("0", 16) {
+		return "", "", false
+	}
+
+	if !isHex(flags, 2) {
+		return "", "", false
+	}
+
+	return traceID, flags, true
+}
+
+// isHex reports whether s is exactly length lowercase hexadecimal characters.
+func isHex(s string, length int) bool {
+	if len(s) != length {
+		return false
+	}
+
+	for _, c := range s {
+		if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isValidRequestID accepts non-empty ids of printable ASCII characters without spaces, up to 128 characters long.
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range id {
+		if c <= ' ' || c > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// randomHex returns n random bytes encoded as lowercase hex.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
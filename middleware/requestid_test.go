@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+)
+
+func TestRequestID(t *testing.T) {
+	traceParentRegex := regexp.MustCompile(`^00-([0-9a-f]{32})-([0-9a-f]{16})-([0-9a-f]{2})$`)
+
+	testCases := []struct {
+		id                int
+		useCase           string
+		requestID         string
+		traceParent       string
+		expectedRequestID string
+		expectedTraceID   string
+		expectedFlags     string
+	}{
+		{
+			id: 1, useCase: "Positive case: no headers, ids are generated",
+			expectedFlags: "00",
+		},
+		{
+			id: 2, useCase: "Positive case: request id is accepted",
+			requestID:         "client-request-1",
+			expectedRequestID: "client-request-1",
+			expectedFlags:     "00",
+		},
+		{
+			id: 3, useCase: "Positive case: traceparent is propagated",
+			traceParent:     "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expectedTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			expectedFlags:   "01",
+		},
+		{
+			id: 4, useCase: "Negative case: malformed traceparent is replaced",
+			traceParent:   "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+			expectedFlags: "00",
+		},
+		{
+			id: 5, useCase: "Negative case: request id with spaces is replaced",
+			requestID:     "client request",
+			expectedFlags: "00",
+		},
+	}
+
+	for _, tc := range testCases {
+		var trace log.Trace
+		h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			trace = log.FromContext(r.Context())
+		}))
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/v1/health", nil)
+		if tc.requestID != "" {
+			r.Header.Set(RequestIDHeader, tc.requestID)
+		}
+
+		if tc.traceParent != "" {
+			r.Header.Set(TraceParentHeader, tc.traceParent)
+		}
+
+		h.ServeHTTP(w, r)
+
+		msg := fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase)
+		assert.NotEmpty(t, trace.RequestID, msg)
+		assert.Equal(t, trace.RequestID, w.Header().Get(RequestIDHeader), msg)
+		if tc.expectedRequestID != "" {
+			assert.Equal(t, tc.expectedRequestID, trace.RequestID, msg)
+		} else {
+			assert.NotEqual(t, tc.requestID, trace.RequestID, msg)
+		}
+
+		if tc.expectedTraceID != "" {
+			assert.Equal(t, tc.expectedTraceID, trace.TraceID, msg)
+		}
+
+		matches := traceParentRegex.FindStringSubmatch(w.Header().Get(TraceParentHeader))
+		assert.Len(t, matches, 4, msg)
+		if len(matches) == 4 {
+			assert.Equal(t, trace.TraceID, matches[1], msg)
+			assert.Equal(t, trace.SpanID, matches[2], msg)
+			assert.Equal(t, tc.expectedFlags, matches[3], msg)
+		}
+	}
+}
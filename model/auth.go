@@ -0,0 +1,100 @@
+package model
+
+import (
+	er "errors"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+)
+
+// Scopes granted to API clients.
+const (
+	ScopeSubmit = "submit" // submit receipts for scoring
+	ScopeRead   = "read"   // read receipts submitted by the same client
+	ScopeAdmin  = "admin"  // every scope, and access to receipts of all clients
+)
+
+// APIKey is an API key of a client, only the SHA-256 hash of the key is stored.
+type APIKey struct {
+	ClientID string
+	KeyHash  string
+	Scopes   []string
+}
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	ClientID string
+	UserID   string
+	Scopes   []string
+}
+
+// HasScope reports whether the principal was granted scope, the admin scope grants every scope.
+func (p *Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanAccess reports whether the principal may read a receipt submitted by clientID on behalf of userID.
+// Admins can read every receipt, other clients only their own, and users authenticated themselves only their own
+// receipts, as every user of a client shares its client ID.
+func (p *Principal) CanAccess(clientID, userID string) bool {
+	if p.HasScope(ScopeAdmin) {
+		return true
+	}
+
+	return p.ClientID == clientID && (p.UserID == "" || p.UserID == userID)
+}
+
+// BindReceipt records the principal submitting a receipt on it, shared by every transport. The receipt is recorded
+// under the client of the principal, and users authenticated themselves only submit receipts on their own behalf.
+// A nil principal, when authentication is disabled, leaves the receipt as submitted.
+func (p *Principal) BindReceipt(receipt *Receipt) error {
+	if p == nil {
+		return nil
+	}
+
+	receipt.ClientID = p.ClientID
+
+	if p.UserID != "" && !p.HasScope(ScopeAdmin) {
+		if receipt.UserID != "" && receipt.UserID != p.UserID {
+			return errors.NewForbidden(er.New("Receipts can only be submitted on behalf of the authenticated user"))
+		}
+
+		receipt.UserID = p.UserID
+	}
+
+	return nil
+}
+
+// CanAccessClient reports whether the principal may manage a resource of clientID shared by its users, like webhooks.
+func (p *Principal) CanAccessClient(clientID string) bool {
+	return p.HasScope(ScopeAdmin) || p.ClientID == clientID
+}
+
+// Key returns the key the usage of the principal is accounted under, users of a client are accounted separately.
+func (p *Principal) Key() string {
+	if p.UserID != "" {
+		return "client:" + p.ClientID + ":user:" + p.UserID
+	}
+
+	return "client:" + p.ClientID
+}
+
+// CanAccessUser reports whether the principal may read or spend the points of userID, bound to clientID.
+// Principals authenticated as a user only access their own points, API clients the users they submitted receipts for,
+// and admins every user.
+func (p *Principal) CanAccessUser(userID, clientID string) bool {
+	if p.HasScope(ScopeAdmin) {
+		return true
+	}
+
+	if p.UserID != "" {
+		return p.UserID == userID
+	}
+
+	return clientID != "" && p.ClientID == clientID
+}
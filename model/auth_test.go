@@ -0,0 +1,240 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipalHasScope(t *testing.T) {
+	testCases := []struct {
+		id           int
+		useCase      string
+		principal    *Principal
+		scope        string
+		expectedResp bool
+	}{
+		{
+			id: 1, useCase: "Positive case: granted scope",
+			principal:    &Principal{Scopes: []string{ScopeSubmit, ScopeRead}},
+			scope:        ScopeRead,
+			expectedResp: true,
+		},
+		{
+			id: 2, useCase: "Negative case: scope not granted",
+			principal:    &Principal{Scopes: []string{ScopeSubmit}},
+			scope:        ScopeRead,
+			expectedResp: false,
+		},
+		{
+			id: 3, useCase: "Positive case: admin is granted every scope",
+			principal:    &Principal{Scopes: []string{ScopeAdmin}},
+			scope:        ScopeSubmit,
+			expectedResp: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		resp := tc.principal.HasScope(tc.scope)
+		assert.Equal(t, tc.expectedResp, resp, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestPrincipalCanAccess(t *testing.T) {
+	testCases := []struct {
+		id           int
+		useCase      string
+		principal    *Principal
+		clientID     string
+		userID       string
+		expectedResp bool
+	}{
+		{
+			id: 1, useCase: "Positive case: client reads its receipt",
+			principal:    &Principal{ClientID: "partner-a", Scopes: []string{ScopeRead}},
+			clientID:     "partner-a",
+			userID:       "user-1",
+			expectedResp: true,
+		},
+		{
+			id: 2, useCase: "Negative case: client reads a receipt of another client",
+			principal:    &Principal{ClientID: "partner-a", Scopes: []string{ScopeRead}},
+			clientID:     "partner-b",
+			expectedResp: false,
+		},
+		{
+			id: 3, useCase: "Positive case: user reads own receipt",
+			principal:    &Principal{ClientID: "app", UserID: "user-1", Scopes: []string{ScopeRead}},
+			clientID:     "app",
+			userID:       "user-1",
+			expectedResp: true,
+		},
+		{
+			id: 4, useCase: "Negative case: user reads a receipt of another user of the same client",
+			principal:    &Principal{ClientID: "app", UserID: "user-1", Scopes: []string{ScopeRead}},
+			clientID:     "app",
+			userID:       "user-2",
+			expectedResp: false,
+		},
+		{
+			id: 5, useCase: "Negative case: user reads a receipt of the client without user",
+			principal:    &Principal{ClientID: "app", UserID: "user-1", Scopes: []string{ScopeRead}},
+			clientID:     "app",
+			expectedResp: false,
+		},
+		{
+			id: 6, useCase: "Positive case: admin reads every receipt",
+			principal:    &Principal{ClientID: "app", UserID: "user-1", Scopes: []string{ScopeAdmin}},
+			clientID:     "partner-b",
+			userID:       "user-2",
+			expectedResp: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		resp := tc.principal.CanAccess(tc.clientID, tc.userID)
+		assert.Equal(t, tc.expectedResp, resp, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestPrincipalKey(t *testing.T) {
+	testCases := []struct {
+		id           int
+		useCase      string
+		principal    *Principal
+		expectedResp string
+	}{
+		{
+			id: 1, useCase: "Positive case: API client",
+			principal:    &Principal{ClientID: "partner-a"},
+			expectedResp: "client:partner-a",
+		},
+		{
+			id: 2, useCase: "Positive case: user of a client",
+			principal:    &Principal{ClientID: "app", UserID: "user-1"},
+			expectedResp: "client:app:user:user-1",
+		},
+	}
+
+	for _, tc := range testCases {
+		resp := tc.principal.Key()
+		assert.Equal(t, tc.expectedResp, resp, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestPrincipalCanAccessUser(t *testing.T) {
+	testCases := []struct {
+		id           int
+		useCase      string
+		principal    *Principal
+		userID       string
+		clientID     string
+		expectedResp bool
+	}{
+		{
+			id: 1, useCase: "Positive case: user reads own points",
+			principal:    &Principal{ClientID: "app", UserID: "user-1", Scopes: []string{ScopeRead}},
+			userID:       "user-1",
+			clientID:     "app",
+			expectedResp: true,
+		},
+		{
+			id: 2, useCase: "Negative case: user reads points of another user",
+			principal:    &Principal{ClientID: "app", UserID: "user-1", Scopes: []string{ScopeRead}},
+			userID:       "user-2",
+			clientID:     "app",
+			expectedResp: false,
+		},
+		{
+			id: 3, useCase: "Positive case: API client acting on behalf of its users",
+			principal:    &Principal{ClientID: "partner-a", Scopes: []string{ScopeRead}},
+			userID:       "user-2",
+			clientID:     "partner-a",
+			expectedResp: true,
+		},
+		{
+			id: 4, useCase: "Negative case: API client reads a user of another client",
+			principal:    &Principal{ClientID: "partner-a", Scopes: []string{ScopeRead}},
+			userID:       "user-2",
+			clientID:     "partner-b",
+			expectedResp: false,
+		},
+		{
+			id: 5, useCase: "Negative case: API client reads a user bound to no client",
+			principal:    &Principal{ClientID: "partner-a", Scopes: []string{ScopeRead}},
+			userID:       "user-2",
+			expectedResp: false,
+		},
+		{
+			id: 6, useCase: "Positive case: admin user reads every user",
+			principal:    &Principal{ClientID: "app", UserID: "user-1", Scopes: []string{ScopeAdmin}},
+			userID:       "user-2",
+			clientID:     "partner-b",
+			expectedResp: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		resp := tc.principal.CanAccessUser(tc.userID, tc.clientID)
+		assert.Equal(t, tc.expectedResp, resp, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestPrincipalBindReceipt(t *testing.T) {
+	testCases := []struct {
+		id               int
+		useCase          string
+		principal        *Principal
+		userID           string
+		expectedClientID string
+		expectedUserID   string
+		expectedError    bool
+	}{
+		{
+			id: 1, useCase: "Positive case: authentication disabled keeps the receipt as submitted",
+			userID:         "user-2",
+			expectedUserID: "user-2",
+		},
+		{
+			id: 2, useCase: "Positive case: API client submits on behalf of a user",
+			principal:        &Principal{ClientID: "partner-a", Scopes: []string{ScopeSubmit}},
+			userID:           "user-2",
+			expectedClientID: "partner-a",
+			expectedUserID:   "user-2",
+		},
+		{
+			id: 3, useCase: "Positive case: user submits on their own behalf",
+			principal:        &Principal{ClientID: "app", UserID: "user-1", Scopes: []string{ScopeSubmit}},
+			expectedClientID: "app",
+			expectedUserID:   "user-1",
+		},
+		{
+			id: 4, useCase: "Negative case: user submits on behalf of another user",
+			principal:     &Principal{ClientID: "app", UserID: "user-1", Scopes: []string{ScopeSubmit}},
+			userID:        "user-2",
+			expectedError: true,
+		},
+		{
+			id: 5, useCase: "Positive case: admin user submits on behalf of another user",
+			principal:        &Principal{ClientID: "app", UserID: "user-1", Scopes: []string{ScopeAdmin}},
+			userID:           "user-2",
+			expectedClientID: "app",
+			expectedUserID:   "user-2",
+		},
+	}
+
+	for _, tc := range testCases {
+		receipt := &Receipt{UserID: tc.userID}
+
+		err := tc.principal.BindReceipt(receipt)
+		if tc.expectedError {
+			assert.Error(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			continue
+		}
+
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedClientID, receipt.ClientID, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedUserID, receipt.UserID, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
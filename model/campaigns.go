@@ -0,0 +1,120 @@
+package model
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+)
+
+// Bounds of the points a campaign awards, so that they cannot overflow.
+const (
+	MaxCampaignMultiplier = 10     // Highest multiplier of a campaign.
+	MaxCampaignBonus      = 100000 // Highest bonus of a campaign, in points.
+)
+
+// Campaign is a time-boxed promotion awarding points on top of the base rules to receipts it matches.
+// A campaign matches receipts purchased between StartDate and EndDate, both included, at Retailer when set,
+// with at least one item whose description contains ItemPattern when set. Matchers are case insensitive and
+// Retailer is compared with both the raw and the canonical retailer name of the receipt.
+type Campaign struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	StartDate   string  `json:"startDate"` // First purchase date of the campaign, in YYYY-MM-DD format.
+	EndDate     string  `json:"endDate"`   // Last purchase date of the campaign, in YYYY-MM-DD format.
+	Retailer    string  `json:"retailer,omitempty"`
+	ItemPattern string  `json:"itemPattern,omitempty"`
+	Multiplier  float64 `json:"multiplier,omitempty"` // Multiplies the base points, e.g. 2 for double points.
+	Bonus       int     `json:"bonus,omitempty"`      // Points added once to matching receipts.
+}
+
+// AppliedCampaign is a campaign that awarded points to a receipt.
+type AppliedCampaign struct {
+	CampaignID string   `json:"campaignId"`
+	Name       string   `json:"name"`
+	Points     int      `json:"points"`
+	Campaign   Campaign `json:"-"` // Campaign as it was applied, used to score the receipt again after returns.
+}
+
+// PayloadValidation performs validation on the campaign's payload fields.
+func (c *Campaign) PayloadValidation() error {
+	if c.Name == "" {
+		return errors.NewMissingParam(errors.MissingParam{Param: "name"})
+	}
+
+	start, err := time.Parse("2006-01-02", c.StartDate)
+	if err != nil {
+		return errors.NewInvalidParam(errors.InvalidParam{Param: "startDate"})
+	}
+
+	end, err := time.Parse("2006-01-02", c.EndDate)
+	if err != nil || end.Before(start) {
+		return errors.NewInvalidParam(errors.InvalidParam{Param: "endDate"})
+	}
+
+	// NaN multipliers fail the upper bound.
+	if c.Multiplier != 0 && (c.Multiplier < 1 || !(c.Multiplier <= MaxCampaignMultiplier)) {
+		return errors.NewInvalidParam(errors.InvalidParam{Param: "multiplier"})
+	}
+
+	if c.Bonus < 0 || c.Bonus > MaxCampaignBonus || (c.Bonus == 0 && c.Multiplier <= 1) {
+		return errors.NewInvalidParam(errors.InvalidParam{Param: "bonus"})
+	}
+
+	return nil
+}
+
+// Matches reports whether the campaign applies to the receipt.
+func (c *Campaign) Matches(receipt *Receipt) bool {
+	if receipt.PurchaseDate == nil || *receipt.PurchaseDate < c.StartDate || *receipt.PurchaseDate > c.EndDate {
+		return false
+	}
+
+	if c.Retailer != "" && !c.matchesRetailer(receipt) {
+		return false
+	}
+
+	if c.ItemPattern == "" {
+		return true
+	}
+
+	pattern := strings.ToLower(c.ItemPattern)
+	for _, item := range receipt.Items {
+		if item.ShortDescription != nil && strings.Contains(strings.ToLower(*item.ShortDescription), pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesRetailer reports whether the raw or the canonical retailer name of the receipt is the retailer of the campaign.
+func (c *Campaign) matchesRetailer(receipt *Receipt) bool {
+	retailer := strings.TrimSpace(c.Retailer)
+	if receipt.RetailerName != "" && strings.EqualFold(receipt.RetailerName, retailer) {
+		return true
+	}
+
+	return receipt.Retailer != nil && strings.EqualFold(strings.TrimSpace(*receipt.Retailer), retailer)
+}
+
+// Points returns the points the campaign awards on top of the base points of a receipt it matches.
+func (c *Campaign) Points(base int) int {
+	points := c.Bonus
+	if c.Multiplier > 1 {
+		points += int(math.Round(float64(base) * (c.Multiplier - 1)))
+	}
+
+	return points
+}
+
+// AppliedCampaignDefinitions returns the campaigns applied to the receipt as they were applied.
+func (receipt *Receipt) AppliedCampaignDefinitions() []Campaign {
+	campaigns := make([]Campaign, 0, len(receipt.Campaigns))
+	for _, applied := range receipt.Campaigns {
+		campaigns = append(campaigns, applied.Campaign)
+	}
+
+	return campaigns
+}
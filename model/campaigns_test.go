@@ -0,0 +1,141 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+)
+
+func TestCampaignPayloadValidation(t *testing.T) {
+	testCases := []struct {
+		id            int
+		useCase       string
+		campaign      Campaign
+		expectedError error
+	}{
+		{
+			id: 1, useCase: "Positive case: multiplier campaign",
+			campaign: Campaign{Name: "Double weekend", StartDate: "2024-03-02", EndDate: "2024-03-03", Multiplier: 2},
+		},
+		{
+			id: 2, useCase: "Positive case: bonus campaign of a single day",
+			campaign: Campaign{Name: "Gatorade", StartDate: "2024-03-02", EndDate: "2024-03-02", ItemPattern: "gatorade", Bonus: 100},
+		},
+		{
+			id: 3, useCase: "Negative case: missing name",
+			campaign:      Campaign{StartDate: "2024-03-02", EndDate: "2024-03-03", Multiplier: 2},
+			expectedError: errors.MissingParam{Param: "name"},
+		},
+		{
+			id: 4, useCase: "Negative case: invalid start date",
+			campaign:      Campaign{Name: "Double", StartDate: "03/02/2024", EndDate: "2024-03-03", Multiplier: 2},
+			expectedError: errors.InvalidParam{Param: "startDate"},
+		},
+		{
+			id: 5, useCase: "Negative case: end before start",
+			campaign:      Campaign{Name: "Double", StartDate: "2024-03-02", EndDate: "2024-03-01", Multiplier: 2},
+			expectedError: errors.InvalidParam{Param: "endDate"},
+		},
+		{
+			id: 6, useCase: "Negative case: multiplier below 1",
+			campaign:      Campaign{Name: "Half", StartDate: "2024-03-02", EndDate: "2024-03-03", Multiplier: 0.5},
+			expectedError: errors.InvalidParam{Param: "multiplier"},
+		},
+		{
+			id: 7, useCase: "Negative case: neither bonus nor multiplier",
+			campaign:      Campaign{Name: "Nothing", StartDate: "2024-03-02", EndDate: "2024-03-03"},
+			expectedError: errors.InvalidParam{Param: "bonus"},
+		},
+		{
+			id: 8, useCase: "Negative case: multiplier above the maximum",
+			campaign:      Campaign{Name: "Huge", StartDate: "2024-03-02", EndDate: "2024-03-03", Multiplier: 1e300},
+			expectedError: errors.InvalidParam{Param: "multiplier"},
+		},
+		{
+			id: 9, useCase: "Negative case: bonus above the maximum",
+			campaign:      Campaign{Name: "Huge", StartDate: "2024-03-02", EndDate: "2024-03-03", Bonus: MaxCampaignBonus + 1},
+			expectedError: errors.InvalidParam{Param: "bonus"},
+		},
+		{
+			id: 10, useCase: "Negative case: multiplier not a number",
+			campaign:      Campaign{Name: "NaN", StartDate: "2024-03-02", EndDate: "2024-03-03", Multiplier: math.NaN()},
+			expectedError: errors.InvalidParam{Param: "multiplier"},
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.campaign.PayloadValidation()
+		if tc.expectedError != nil {
+			assert.EqualError(t, err, tc.expectedError.Error(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		} else {
+			assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+	}
+}
+
+func TestCalculateTotalReceiptPoints_Campaigns(t *testing.T) {
+	// Target receipts score 22 base points: 6 for the retailer, 5 for the pair of items, 1 for the description and 10 for the time.
+	newReceipt := func(retailer, date string) *Receipt {
+		return &Receipt{
+			Retailer:     StringPointer(retailer),
+			PurchaseDate: StringPointer(date),
+			PurchaseTime: StringPointer("14:33"),
+			Total:        StringPointer("9.49"),
+			Items: []Item{
+				{ShortDescription: StringPointer("Gatorade Cool Blue"), Price: StringPointer("2.25")},
+				{ShortDescription: StringPointer("Chips"), Price: StringPointer("7.24")},
+			},
+		}
+	}
+
+	double := Campaign{ID: "c-1", Name: "2x at Target", StartDate: "2024-03-02", EndDate: "2024-03-03", Retailer: "target", Multiplier: 2}
+	gatorade := Campaign{ID: "c-2", Name: "+100 Gatorade", StartDate: "2024-03-01", EndDate: "2024-03-31", ItemPattern: "GATORADE", Bonus: 100}
+	water := Campaign{ID: "c-3", Name: "+50 Water", StartDate: "2024-03-01", EndDate: "2024-03-31", ItemPattern: "water", Bonus: 50}
+
+	testCases := []struct {
+		id                int
+		useCase           string
+		receipt           *Receipt
+		expectedPoints    int
+		expectedCampaigns []string
+	}{
+		{
+			id: 1, useCase: "Positive case: multiplier and bonus apply to the base points",
+			receipt:           newReceipt("Target", "2024-03-02"),
+			expectedPoints:    22 + 22 + 100,
+			expectedCampaigns: []string{"c-1", "c-2"},
+		},
+		{
+			id: 2, useCase: "Positive case: another retailer only gets the item bonus",
+			receipt:           newReceipt("Walmart", "2024-03-02"),
+			expectedPoints:    23 + 100,
+			expectedCampaigns: []string{"c-2"},
+		},
+		{
+			id: 3, useCase: "Positive case: purchase outside the window of the multiplier",
+			receipt:           newReceipt("Target", "2024-03-04"),
+			expectedPoints:    22 + 100,
+			expectedCampaigns: []string{"c-2"},
+		},
+		{
+			id: 4, useCase: "Negative case: purchase outside every window",
+			receipt:        newReceipt("Target", "2024-04-02"),
+			expectedPoints: 22,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.receipt.CalculateTotalReceiptPoints(double, gatorade, water)
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedPoints, tc.receipt.Points, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+
+		var applied []string
+		for _, c := range tc.receipt.Campaigns {
+			applied = append(applied, c.CampaignID)
+		}
+		assert.Equal(t, tc.expectedCampaigns, applied, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
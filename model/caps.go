@@ -0,0 +1,46 @@
+package model
+
+// Points caps limiting the points awarded, to limit the exposure to fraudulent receipts.
+const (
+	CapReceipt   = "receipt"    // Maximum number of points awarded to a single receipt.
+	CapUserDaily = "user_daily" // Maximum number of points awarded to the receipts of a user per UTC day.
+)
+
+// PointsCap records a cap that lowered the points awarded to a receipt, so that the difference is auditable.
+// When both caps apply the one that awarded the fewest points is recorded.
+type PointsCap struct {
+	Type           string `json:"type"`
+	Limit          int    `json:"limit"`
+	ComputedPoints int    `json:"computedPoints"` // Points computed by the scoring rules and campaigns.
+	AwardedPoints  int    `json:"awardedPoints"`  // Points awarded to the receipt once capped.
+}
+
+// ApplyCap lowers the points of the receipt to awarded under a cap of limit points, e.g. what is left of the daily cap
+// of its user, recording the cap when it lowered the points.
+func (receipt *Receipt) ApplyCap(capType string, limit, awarded int) {
+	if receipt.Points <= awarded {
+		return
+	}
+
+	computed := receipt.Points
+	if receipt.Cap != nil {
+		computed = receipt.Cap.ComputedPoints
+	}
+
+	receipt.Points = awarded
+	receipt.Cap = &PointsCap{Type: capType, Limit: limit, ComputedPoints: computed, AwardedPoints: awarded}
+}
+
+// AwardedShare returns the points awarded for points computed on part of the receipt, lowered in the proportion the
+// caps lowered the points of the receipt, rounded down. Points of receipts that were not capped are awarded in full.
+func (receipt *Receipt) AwardedShare(computed int) int {
+	if receipt.Cap == nil {
+		return computed
+	}
+
+	if receipt.Cap.ComputedPoints == 0 {
+		return 0
+	}
+
+	return computed * receipt.Cap.AwardedPoints / receipt.Cap.ComputedPoints
+}
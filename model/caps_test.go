@@ -0,0 +1,66 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiptApplyCap(t *testing.T) {
+	testCases := []struct {
+		id             int
+		useCase        string
+		points         int
+		caps           [][3]int // Limit and awarded points of the receipt cap, then of the daily cap, 0 when not applied.
+		expectedPoints int
+		expectedCap    *PointsCap
+	}{
+		{
+			id: 1, useCase: "Positive case: points under the caps",
+			points: 80, caps: [][3]int{{100, 100}, {500, 80}},
+			expectedPoints: 80,
+		},
+		{
+			id: 2, useCase: "Positive case: receipt cap lowers the points",
+			points: 150, caps: [][3]int{{100, 100}, {500, 100}},
+			expectedPoints: 100,
+			expectedCap:    &PointsCap{Type: CapReceipt, Limit: 100, ComputedPoints: 150, AwardedPoints: 100},
+		},
+		{
+			id: 3, useCase: "Positive case: daily cap lowers the capped points further",
+			points: 150, caps: [][3]int{{100, 100}, {500, 30}},
+			expectedPoints: 30,
+			expectedCap:    &PointsCap{Type: CapUserDaily, Limit: 500, ComputedPoints: 150, AwardedPoints: 30},
+		},
+	}
+
+	for _, tc := range testCases {
+		receipt := Receipt{Points: tc.points}
+		receipt.ApplyCap(CapReceipt, tc.caps[0][0], tc.caps[0][1])
+		receipt.ApplyCap(CapUserDaily, tc.caps[1][0], tc.caps[1][1])
+
+		assert.Equal(t, tc.expectedPoints, receipt.Points, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedCap, receipt.Cap, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestReceiptAwardedShare(t *testing.T) {
+	testCases := []struct {
+		id             int
+		useCase        string
+		cap            *PointsCap
+		computed       int
+		expectedPoints int
+	}{
+		{id: 1, useCase: "Positive case: receipt not capped", computed: 75, expectedPoints: 75},
+		{id: 2, useCase: "Positive case: share of the capped points", cap: &PointsCap{ComputedPoints: 150, AwardedPoints: 30}, computed: 100, expectedPoints: 20},
+		{id: 3, useCase: "Positive case: share rounded down", cap: &PointsCap{ComputedPoints: 28, AwardedPoints: 14}, computed: 25, expectedPoints: 12},
+		{id: 4, useCase: "Negative case: receipt without computed points", cap: &PointsCap{}, computed: 10, expectedPoints: 0},
+	}
+
+	for _, tc := range testCases {
+		receipt := Receipt{Cap: tc.cap}
+		assert.Equal(t, tc.expectedPoints, receipt.AwardedShare(tc.computed), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
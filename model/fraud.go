@@ -0,0 +1,91 @@
+package model
+
+import (
+	"time"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+)
+
+// Statuses of the review of a receipt held for its fraud risk.
+const (
+	ReviewPending  = "pending_review" // points are held until an admin decides
+	ReviewApproved = "approved"       // points were credited to the user
+	ReviewRejected = "rejected"       // points were never credited
+)
+
+// Decisions an admin takes on a receipt held for review.
+const (
+	DecisionApprove = "approve"
+	DecisionReject  = "reject"
+)
+
+// RiskSignal is the risk a single fraud detector found in a receipt.
+type RiskSignal struct {
+	Detector string `json:"detector"`
+	Score    int    `json:"score"`
+	Reason   string `json:"reason"`
+}
+
+// RiskAssessment is the fraud risk of a receipt, Score is the sum of the scores of its signals capped at 100.
+type RiskAssessment struct {
+	Score   int          `json:"score"`
+	Signals []RiskSignal `json:"signals,omitempty"`
+}
+
+// Review is a receipt whose risk score reached the review threshold, its points are held until it is decided.
+type Review struct {
+	ReceiptID string         `json:"receiptId"`
+	UserID    string         `json:"userId,omitempty"`
+	ClientID  string         `json:"clientId,omitempty"`
+	Points    int            `json:"points"` // Points held, credited to the user once approved.
+	Risk      RiskAssessment `json:"risk"`
+	Status    string         `json:"status"`
+	Note      string         `json:"note,omitempty"`
+	CreatedAt time.Time      `json:"createdAt"`
+	DecidedAt *time.Time     `json:"decidedAt,omitempty"`
+}
+
+// ReviewDecision represents the request body of a review decision.
+type ReviewDecision struct {
+	Decision string `json:"decision"`
+	Note     string `json:"note,omitempty"`
+}
+
+// PayloadValidation performs validation on the review decision's payload fields.
+func (d *ReviewDecision) PayloadValidation() error {
+	switch d.Decision {
+	case DecisionApprove, DecisionReject:
+		return nil
+	case "":
+		return errors.NewMissingParam(errors.MissingParam{Param: "decision"})
+	default:
+		return errors.NewInvalidParam(errors.InvalidParam{Param: "decision"})
+	}
+}
+
+// Status returns the review status the decision leads to.
+func (d *ReviewDecision) Status() string {
+	if d.Decision == DecisionApprove {
+		return ReviewApproved
+	}
+
+	return ReviewRejected
+}
+
+// IsValidReviewStatus reports whether status is one of the review statuses.
+func IsValidReviewStatus(status string) bool {
+	return status == ReviewPending || status == ReviewApproved || status == ReviewRejected
+}
+
+// Submitter returns the key receipts of the same submitter share: the user the receipt is submitted on behalf of,
+// otherwise the client that submitted it. It is empty for anonymous receipts.
+func (receipt *Receipt) Submitter() string {
+	switch {
+	case receipt.UserID != "":
+		return "user:" + receipt.UserID
+	case receipt.ClientID != "":
+		return "client:" + receipt.ClientID
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// IdempotentRequest is a request sent with an idempotency key, its response is replayed to the retries of the request.
+type IdempotentRequest struct {
+	Key         string            // Idempotency key scoped to the client that sent it.
+	Fingerprint string            // Hash of the method, path and body of the request, retries must send the same request.
+	Completed   bool              // False while the request is processed, the response is recorded once it completes.
+	StatusCode  int               // Status of the recorded response.
+	Header      map[string]string // Headers of the recorded response that are replayed, like Content-Type and Location.
+	Body        []byte            // Body of the recorded response.
+	ExpiresAt   time.Time         // Time the key may be reused for another request.
+}
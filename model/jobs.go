@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// Statuses of receipts submitted for asynchronous processing.
+const (
+	JobPending   = "pending"   // queued, not scored yet
+	JobProcessed = "processed" // scored and stored under the ID of the job
+	JobFailed    = "failed"    // rejected by the scoring, Error tells why
+)
+
+// ReceiptJob is a receipt queued for asynchronous processing, the receipt is stored under the ID of the job once processed.
+type ReceiptJob struct {
+	ID        string    `json:"id"`
+	Status    string    `json:"status"`
+	Receipt   Receipt   `json:"receipt"` // Receipt as submitted, dropped once the job is done.
+	ClientID  string    `json:"clientId,omitempty"`
+	UserID    string    `json:"userId,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ReceiptQueuedResponse represents the response structure after queueing a receipt for asynchronous processing.
+type ReceiptQueuedResponse struct {
+	Id        string `json:"id"`
+	Status    string `json:"status"`
+	StatusURL string `json:"statusUrl"`
+}
+
+// ReceiptStatusResponse represents the processing status of a receipt, the points are included once it is processed.
+type ReceiptStatusResponse struct {
+	Id       string `json:"id"`
+	Status   string `json:"status"`
+	Points   *int   `json:"points,omitempty"`
+	Error    string `json:"error,omitempty"`
+	ClientID string `json:"-"`
+	UserID   string `json:"-"`
+}
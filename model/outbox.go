@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Types of the domain events written to the outbox.
+const (
+	DomainReceiptInserted = "receipt.inserted" // a receipt was scored and stored
+	DomainPointsAwarded   = "points.awarded"   // the points of a receipt were credited to its user
+	DomainReceiptVoided   = "receipt.voided"   // a receipt was rejected by a fraud review, its points are never credited
+	DomainPointsReversed  = "points.reversed"  // points of a receipt were taken back after items were returned
+	DomainPointsRedeemed  = "points.redeemed"  // points were spent on a redemption
+	DomainPointsExpired   = "points.expired"   // points of a credit expired before they were spent
+)
+
+// ledgerEvents are the types of the domain events of each type of ledger entry.
+var ledgerEvents = map[string]string{
+	LedgerCredit:   DomainPointsAwarded,
+	LedgerReversal: DomainPointsReversed,
+	LedgerDebit:    DomainPointsRedeemed,
+	LedgerExpiry:   DomainPointsExpired,
+}
+
+// DomainEvent is a state change published by the outbox relay. Events are delivered at least once,
+// consumers deduplicate redeliveries by ID.
+type DomainEvent struct {
+	ID           string    `json:"id"`
+	Type         string    `json:"type"`
+	ReceiptID    string    `json:"receiptId,omitempty"`
+	RedemptionID string    `json:"redemptionId,omitempty"` // Redemption of points.redeemed events.
+	ClientID     string    `json:"clientId,omitempty"`
+	UserID       string    `json:"userId,omitempty"`
+	Points       int       `json:"points"` // Points of the change, negative when points are taken from the user.
+	OccurredAt   time.Time `json:"occurredAt"`
+}
+
+// OutboxEntry is a domain event waiting in the outbox along with its delivery attempts.
+type OutboxEntry struct {
+	Event     DomainEvent
+	Attempts  int    // Failed attempts to deliver the event.
+	LastError string // Error of the latest failed attempt.
+}
+
+// NewDomainEvent creates an event of the given type about a receipt, with a new ID consumers deduplicate it by.
+func NewDomainEvent(eventType, receiptID, clientID, userID string, points int, occurredAt time.Time) DomainEvent {
+	return DomainEvent{
+		ID:         uuid.New().String(),
+		Type:       eventType,
+		ReceiptID:  receiptID,
+		ClientID:   clientID,
+		UserID:     userID,
+		Points:     points,
+		OccurredAt: occurredAt,
+	}
+}
+
+// NewLedgerEvent creates the event of an entry appended to the ledger of a user bound to clientID.
+func NewLedgerEvent(entry *LedgerEntry, clientID string) DomainEvent {
+	event := NewDomainEvent(ledgerEvents[entry.Type], entry.ReceiptID, clientID, entry.UserID, entry.Points, entry.CreatedAt)
+	event.RedemptionID = entry.RedemptionID
+
+	return event
+}
@@ -1,6 +1,10 @@
 package model
 
-import "github/shivasaicharanruthala/backend-engineer-takehome/errors"
+import (
+	"strings"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+)
 
 // Item represents an item in a receipt
 type Item struct {
@@ -10,13 +14,27 @@ type Item struct {
 
 // Receipt represents a receipt with its details including items purchased.
 type Receipt struct {
-	Id           string
+	Id           string  `json:"-"` // Assigned by the service, never read from payloads.
 	Retailer     *string `json:"retailer"`
 	PurchaseDate *string `json:"purchaseDate"`
 	PurchaseTime *string `json:"purchaseTime"`
 	Items        []Item  `json:"items"`
 	Total        *string `json:"total"`
 	Points       int
+	UserID       string            `json:"userId,omitempty"` // User the receipt is submitted on behalf of, credited with its points.
+	RetailerID   string            `json:"-"`                // Canonical retailer the raw retailer name resolved to, empty when unknown.
+	RetailerName string            `json:"-"`                // Name of the canonical retailer, empty when unknown.
+	ClientID     string            `json:"-"`                // Client that submitted the receipt, empty when authentication is disabled.
+	Breakdown    []RulePoints      `json:"-"`                // Points earned per scoring rule, set by CalculateTotalReceiptPoints.
+	Campaigns    []AppliedCampaign `json:"-"`                // Campaigns that awarded points, set by CalculateTotalReceiptPoints.
+	Cap          *PointsCap        `json:"-"`                // Cap that lowered the points, nil when the computed points were awarded.
+	Risk         *RiskAssessment   `json:"-"`                // Fraud risk of the receipt, nil when fraud scoring is disabled.
+}
+
+// RulePoints is the number of points a single scoring rule awarded to a receipt.
+type RulePoints struct {
+	Rule   string `json:"rule"`
+	Points int    `json:"points"`
 }
 
 // PayloadValidation performs validation on the receipt's payload fields.
@@ -24,27 +42,31 @@ type Receipt struct {
 // It also delegates validation of each item in the receipt.
 func (receipt *Receipt) PayloadValidation() error {
 	if receipt == nil {
-		return errors.NewMissingParam(errors.MissingParam{Param: "receipt"})
+		return errors.NewMissingParam(errors.MissingParam{Param: "receipt", Code: errors.CodeReceiptMissingField})
+	}
+
+	if receipt.UserID != "" && !IsValidUserID(receipt.UserID) {
+		return errors.NewInvalidParam(errors.InvalidParam{Param: "userId", Code: errors.CodeReceiptInvalidField})
 	}
 
 	if receipt.Retailer == nil {
-		return errors.NewMissingParam(errors.MissingParam{Param: "retailer"})
+		return errors.NewMissingParam(errors.MissingParam{Param: "retailer", Code: errors.CodeReceiptMissingField})
 	}
 
 	if receipt.PurchaseDate == nil {
-		return errors.NewMissingParam(errors.MissingParam{Param: "purchaseDate"})
+		return errors.NewMissingParam(errors.MissingParam{Param: "purchaseDate", Code: errors.CodeReceiptMissingField})
 	}
 
 	if receipt.PurchaseTime == nil {
-		return errors.NewMissingParam(errors.MissingParam{Param: "purchaseTime"})
+		return errors.NewMissingParam(errors.MissingParam{Param: "purchaseTime", Code: errors.CodeReceiptMissingField})
 	}
 
 	if receipt.Total == nil {
-		return errors.NewMissingParam(errors.MissingParam{Param: "total"})
+		return errors.NewMissingParam(errors.MissingParam{Param: "total", Code: errors.CodeReceiptMissingField})
 	}
 
 	if receipt.Items == nil {
-		return errors.NewMissingParam(errors.MissingParam{Param: "items"})
+		return errors.NewMissingParam(errors.MissingParam{Param: "items", Code: errors.CodeReceiptMissingField})
 	}
 
 	// Validate each item in the receipt.
@@ -61,12 +83,46 @@ func (receipt *Receipt) PayloadValidation() error {
 // It checks for required fields like short description and price.
 func (i *Item) PayloadValidation() error {
 	if i.ShortDescription == nil {
-		return errors.NewMissingParam(errors.MissingParam{Param: "shortDescription"})
+		return errors.NewMissingParam(errors.MissingParam{Param: "shortDescription", Code: errors.CodeReceiptMissingField})
 	}
 
 	if i.Price == nil {
-		return errors.NewMissingParam(errors.MissingParam{Param: "price"})
+		return errors.NewMissingParam(errors.MissingParam{Param: "price", Code: errors.CodeReceiptMissingField})
 	}
 
 	return nil
 }
+
+// ReceiptFilter selects receipts, empty fields match every receipt.
+type ReceiptFilter struct {
+	ClientID  string
+	UserID    string
+	Retailer  string // Raw or canonical retailer name, compared case-insensitively after trimming.
+	MinPoints int
+}
+
+// Matches reports whether the filter selects receipt.
+func (f ReceiptFilter) Matches(receipt *Receipt) bool {
+	if f.ClientID != "" && f.ClientID != receipt.ClientID {
+		return false
+	}
+
+	if f.UserID != "" && f.UserID != receipt.UserID {
+		return false
+	}
+
+	if receipt.Points < f.MinPoints {
+		return false
+	}
+
+	if f.Retailer == "" {
+		return true
+	}
+
+	retailer := strings.TrimSpace(f.Retailer)
+	if receipt.Retailer != nil && strings.EqualFold(retailer, strings.TrimSpace(*receipt.Retailer)) {
+		return true
+	}
+
+	return receipt.RetailerName != "" && strings.EqualFold(retailer, receipt.RetailerName)
+}
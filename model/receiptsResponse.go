@@ -7,5 +7,12 @@ type ReceiptPostResponse struct {
 
 // ReceiptGetResponse represents the response structure when retrieving receipt details.
 type ReceiptGetResponse struct {
-	Points int `json:"points"`
+	Points       int               `json:"points"`
+	Status       string            `json:"status,omitempty"`       // Review status when the points are held for review, omitted otherwise.
+	RetailerID   string            `json:"retailerId,omitempty"`   // Canonical retailer, omitted when the raw name is unknown to the catalog.
+	RetailerName string            `json:"retailerName,omitempty"` // Name of the canonical retailer.
+	Campaigns    []AppliedCampaign `json:"campaigns,omitempty"`    // Campaigns that awarded part of the points.
+	Cap          *PointsCap        `json:"cap,omitempty"`          // Cap that lowered the points, omitted when the computed points were awarded.
+	ClientID     string            `json:"-"`
+	UserID       string            `json:"-"`
 }
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+)
+
+// Retailer is a canonical retailer of the catalog that raw retailer names of receipts are resolved to.
+// A raw name resolves to the retailer when it equals Name or fully matches one of the Aliases, ignoring case
+// and surrounding spaces, e.g. the alias `target( store| #\d+)?` resolves "TARGET #1234" and "Target Store".
+type Retailer struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"` // Regular expressions matched against the whole raw name.
+}
+
+// PayloadValidation performs validation on the retailer's payload fields.
+func (r *Retailer) PayloadValidation() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.NewMissingParam(errors.MissingParam{Param: "name"})
+	}
+
+	if _, err := r.Patterns(); err != nil {
+		return errors.NewInvalidParam(errors.InvalidParam{Param: "aliases"})
+	}
+
+	return nil
+}
+
+// Patterns compiles the aliases of the retailer into case insensitive patterns matching the whole raw name.
+// Every alias must compile on its own, so that unbalanced groups like `target)|(?:.*` cannot break out of the
+// anchored group and match every raw name.
+func (r *Retailer) Patterns() ([]*regexp.Regexp, error) {
+	patterns := make([]*regexp.Regexp, 0, len(r.Aliases))
+	for _, alias := range r.Aliases {
+		if _, err := regexp.Compile(alias); err != nil {
+			return nil, err
+		}
+
+		pattern, err := regexp.Compile(`(?i)^(?:` + alias + `)$`)
+		if err != nil {
+			return nil, err
+		}
+
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns, nil
+}
+
+// NormalizeRetailerName trims a raw retailer name and collapses its inner whitespace to single spaces.
+func NormalizeRetailerName(raw string) string {
+	return strings.Join(strings.Fields(raw), " ")
+}
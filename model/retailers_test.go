@@ -0,0 +1,73 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+)
+
+func TestRetailerPayloadValidation(t *testing.T) {
+	testCases := []struct {
+		id            int
+		useCase       string
+		retailer      Retailer
+		expectedError error
+	}{
+		{
+			id: 1, useCase: "Positive case: retailer with aliases",
+			retailer: Retailer{Name: "Target", Aliases: []string{`target( store| #\d+)?`}},
+		},
+		{
+			id: 2, useCase: "Positive case: retailer without aliases",
+			retailer: Retailer{Name: "Walmart"},
+		},
+		{
+			id: 3, useCase: "Negative case: blank name",
+			retailer:      Retailer{Name: "  ", Aliases: []string{"target"}},
+			expectedError: errors.MissingParam{Param: "name"},
+		},
+		{
+			id: 4, useCase: "Negative case: invalid alias pattern",
+			retailer:      Retailer{Name: "Target", Aliases: []string{"target("}},
+			expectedError: errors.InvalidParam{Param: "aliases"},
+		},
+		{
+			id: 5, useCase: "Negative case: alias breaking out of the anchored group",
+			retailer:      Retailer{Name: "Target", Aliases: []string{"target)|(?:.*"}},
+			expectedError: errors.InvalidParam{Param: "aliases"},
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.retailer.PayloadValidation()
+		if tc.expectedError != nil {
+			assert.EqualError(t, err, tc.expectedError.Error(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		} else {
+			assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+	}
+}
+
+func TestCampaignMatches_CanonicalRetailer(t *testing.T) {
+	campaign := Campaign{Name: "Target double", StartDate: "2024-03-01", EndDate: "2024-03-31", Retailer: "Target", Multiplier: 2}
+
+	testCases := []struct {
+		id           int
+		useCase      string
+		retailer     string
+		retailerName string
+		expected     bool
+	}{
+		{id: 1, useCase: "Positive case: raw name matches", retailer: " target ", expected: true},
+		{id: 2, useCase: "Positive case: canonical name matches", retailer: "TARGET #1234", retailerName: "Target", expected: true},
+		{id: 3, useCase: "Negative case: unresolved alias", retailer: "TARGET #1234"},
+		{id: 4, useCase: "Negative case: other canonical retailer", retailer: "Target Foods", retailerName: "Target Foods"},
+	}
+
+	for _, tc := range testCases {
+		receipt := Receipt{Retailer: StringPointer(tc.retailer), RetailerName: tc.retailerName, PurchaseDate: StringPointer("2024-03-02")}
+		assert.Equal(t, tc.expected, campaign.Matches(&receipt), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
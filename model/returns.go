@@ -0,0 +1,103 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+)
+
+// Types of receipt adjustments.
+const (
+	AdjustmentReturn = "return" // items of the receipt were returned
+)
+
+// ReturnRequest represents the request body of a return, listing the returned items of a receipt.
+type ReturnRequest struct {
+	Items []Item `json:"items"`
+}
+
+// Adjustment is a change applied to the points of a receipt after it was scored. The receipt itself is never modified,
+// its current points are its original points plus the points of all its adjustments.
+type Adjustment struct {
+	ID             string    `json:"id"`
+	ReceiptID      string    `json:"receiptId"`
+	Type           string    `json:"type"`
+	Items          []Item    `json:"items"`
+	RemainingTotal string    `json:"remainingTotal"` // Total of the items kept after the adjustment.
+	PointsBefore   int       `json:"pointsBefore"`
+	PointsAfter    int       `json:"pointsAfter"`
+	Points         int       `json:"points"` // Difference applied to the points of the receipt, never positive.
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// AdjustmentHistory represents the original and current points of a receipt along with its adjustments, oldest first.
+type AdjustmentHistory struct {
+	ReceiptID      string       `json:"receiptId"`
+	OriginalPoints int          `json:"originalPoints"`
+	Points         int          `json:"points"`
+	Adjustments    []Adjustment `json:"adjustments"`
+}
+
+// PayloadValidation performs validation on the return request's payload fields.
+func (req *ReturnRequest) PayloadValidation() error {
+	if len(req.Items) == 0 {
+		return errors.NewMissingParam(errors.MissingParam{Param: "items"})
+	}
+
+	for _, item := range req.Items {
+		if err := item.PayloadValidation(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WithoutItems returns a copy of the receipt without the returned items, its total reduced by their prices.
+// Each returned item removes one item with the same trimmed description and price.
+// It returns an error naming the first returned item that is not on the receipt.
+func (receipt *Receipt) WithoutItems(returned []Item) (*Receipt, error) {
+	remaining := make([]Item, len(receipt.Items))
+	copy(remaining, receipt.Items)
+
+	total := toCents(*receipt.Total)
+	for _, r := range returned {
+		i := indexOfItem(remaining, r)
+		if i < 0 {
+			return nil, errors.NewInvalidParam(fmt.Errorf("Item '%v' priced %v is not on the receipt or was already returned", strings.TrimSpace(*r.ShortDescription), *r.Price))
+		}
+
+		total -= toCents(*remaining[i].Price)
+		remaining = append(remaining[:i], remaining[i+1:]...)
+	}
+
+	// Receipts whose item prices add up to more than their total never go below zero.
+	total = max(total, 0)
+
+	adjusted := *receipt
+	adjusted.Items = remaining
+	adjusted.Total = StringPointer(fmt.Sprintf("%d.%02d", total/100, total%100))
+
+	return &adjusted, nil
+}
+
+func indexOfItem(items []Item, item Item) int {
+	for i, it := range items {
+		if strings.TrimSpace(*it.ShortDescription) == strings.TrimSpace(*item.ShortDescription) && toCents(*it.Price) == toCents(*item.Price) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// toCents converts a decimal amount to cents, amounts that are not numbers count as zero.
+func toCents(amount string) int64 {
+	f, _ := strconv.ParseFloat(strings.TrimSpace(amount), 64)
+
+	return int64(math.Round(f * 100))
+}
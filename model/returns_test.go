@@ -0,0 +1,78 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiptWithoutItems(t *testing.T) {
+	receipt := &Receipt{
+		Retailer: StringPointer("Target"),
+		Total:    StringPointer("35.35"),
+		Items: []Item{
+			{ShortDescription: StringPointer("Mountain Dew 12PK"), Price: StringPointer("6.49")},
+			{ShortDescription: StringPointer("Emils Cheese Pizza"), Price: StringPointer("12.25")},
+			{ShortDescription: StringPointer("   Klarbrunn 12-PK 12 FL OZ  "), Price: StringPointer("12.00")},
+			{ShortDescription: StringPointer("Knorr Creamy Chicken"), Price: StringPointer("1.26")},
+			{ShortDescription: StringPointer("Knorr Creamy Chicken"), Price: StringPointer("1.26")},
+		},
+	}
+
+	testCases := []struct {
+		id            int
+		useCase       string
+		returned      []Item
+		expectedTotal string
+		expectedItems int
+		expectedError string
+	}{
+		{
+			id: 1, useCase: "Positive case: single item",
+			returned:      []Item{{ShortDescription: StringPointer("Emils Cheese Pizza"), Price: StringPointer("12.25")}},
+			expectedTotal: "23.10", expectedItems: 4,
+		},
+		{
+			id: 2, useCase: "Positive case: description matched trimmed and price matched by value",
+			returned:      []Item{{ShortDescription: StringPointer("Klarbrunn 12-PK 12 FL OZ"), Price: StringPointer("12")}},
+			expectedTotal: "23.35", expectedItems: 4,
+		},
+		{
+			id: 3, useCase: "Positive case: duplicate items are returned one by one",
+			returned: []Item{
+				{ShortDescription: StringPointer("Knorr Creamy Chicken"), Price: StringPointer("1.26")},
+				{ShortDescription: StringPointer("Knorr Creamy Chicken"), Price: StringPointer("1.26")},
+			},
+			expectedTotal: "32.83", expectedItems: 3,
+		},
+		{
+			id: 4, useCase: "Negative case: more items returned than purchased",
+			returned: []Item{
+				{ShortDescription: StringPointer("Mountain Dew 12PK"), Price: StringPointer("6.49")},
+				{ShortDescription: StringPointer("Mountain Dew 12PK"), Price: StringPointer("6.49")},
+			},
+			expectedError: "Item 'Mountain Dew 12PK' priced 6.49 is not on the receipt or was already returned",
+		},
+		{
+			id: 5, useCase: "Negative case: price differs",
+			returned:      []Item{{ShortDescription: StringPointer("Emils Cheese Pizza"), Price: StringPointer("10.00")}},
+			expectedError: "Item 'Emils Cheese Pizza' priced 10.00 is not on the receipt or was already returned",
+		},
+	}
+
+	for _, tc := range testCases {
+		adjusted, err := receipt.WithoutItems(tc.returned)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			continue
+		}
+
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedTotal, *adjusted.Total, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Len(t, adjusted.Items, tc.expectedItems, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+
+	assert.Equal(t, "35.35", *receipt.Total, "the original receipt is not modified")
+	assert.Len(t, receipt.Items, 5, "the original receipt is not modified")
+}
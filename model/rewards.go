@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+)
+
+// Reward is an item of the reward catalog users redeem their points for.
+type Reward struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Cost      int    `json:"cost"`      // Points debited per redemption.
+	Inventory int    `json:"inventory"` // Redemptions left before the reward is out of stock.
+}
+
+// RedemptionRequest represents the request body of a redemption.
+type RedemptionRequest struct {
+	RewardID string `json:"rewardId"`
+}
+
+// Redemption is a reward redeemed by a user, Balance is the balance of the user after the redemption.
+type Redemption struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"userId"`
+	RewardID  string    `json:"rewardId"`
+	Points    int       `json:"points"`
+	Balance   int       `json:"balance"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// PayloadValidation performs validation on the reward's payload fields.
+func (reward *Reward) PayloadValidation() error {
+	if reward.Name == "" {
+		return errors.NewMissingParam(errors.MissingParam{Param: "name"})
+	}
+
+	if reward.Cost <= 0 {
+		return errors.NewInvalidParam(errors.InvalidParam{Param: "cost"})
+	}
+
+	if reward.Inventory < 0 {
+		return errors.NewInvalidParam(errors.InvalidParam{Param: "inventory"})
+	}
+
+	return nil
+}
+
+// PayloadValidation performs validation on the redemption request's payload fields.
+func (req *RedemptionRequest) PayloadValidation() error {
+	if req.RewardID == "" {
+		return errors.NewMissingParam(errors.MissingParam{Param: "rewardId"})
+	}
+
+	return nil
+}
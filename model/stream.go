@@ -0,0 +1,39 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// StreamEvent is a scored receipt sent to the clients of the receipts stream. IDs increase with every event,
+// clients resume a stream after the ID of the last event they received.
+type StreamEvent struct {
+	ID        uint64    `json:"-"`
+	ReceiptID string    `json:"receiptId"`
+	Retailer  string    `json:"retailer"`
+	Points    int       `json:"points"`
+	Status    string    `json:"status,omitempty"` // Review status when the points are held for review.
+	ClientID  string    `json:"clientId,omitempty"`
+	UserID    string    `json:"userId,omitempty"`
+	ScoredAt  time.Time `json:"scoredAt"`
+}
+
+// StreamFilter selects the events of a stream, empty fields match every event.
+type StreamFilter struct {
+	Retailer string // Retailer name, compared case-insensitively after trimming.
+	ClientID string
+	UserID   string
+}
+
+// Matches reports whether the filter selects event.
+func (f StreamFilter) Matches(event *StreamEvent) bool {
+	if f.ClientID != "" && f.ClientID != event.ClientID {
+		return false
+	}
+
+	if f.UserID != "" && f.UserID != event.UserID {
+		return false
+	}
+
+	return f.Retailer == "" || strings.EqualFold(strings.TrimSpace(f.Retailer), strings.TrimSpace(event.Retailer))
+}
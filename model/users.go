@@ -0,0 +1,65 @@
+package model
+
+import (
+	"regexp"
+	"time"
+)
+
+// Types of ledger entries.
+const (
+	LedgerCredit   = "credit"   // points earned by a scored receipt
+	LedgerDebit    = "debit"    // points spent on a redemption
+	LedgerExpiry   = "expiry"   // points of a credit that expired before they were spent
+	LedgerReversal = "reversal" // points of a receipt taken back after an adjustment of the receipt
+)
+
+var userIDPattern = regexp.MustCompile(`^[A-Za-z0-9._@-]{1,64}$`)
+
+// LedgerEntry is an entry of the append-only points ledger of a user.
+// Credits carry positive points, debits, expiries and reversals negative points, the balance of a user is the sum of the points of all entries.
+type LedgerEntry struct {
+	ID           string     `json:"id"`
+	UserID       string     `json:"userId"`
+	ReceiptID    string     `json:"receiptId,omitempty"`
+	RedemptionID string     `json:"redemptionId,omitempty"`
+	Type         string     `json:"type"`
+	Points       int        `json:"points"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	ExpiresAt    *time.Time `json:"expiresAt,omitempty"` // Expiry of the points of a credit, nil when they never expire.
+}
+
+// BalanceResponse represents the response structure when retrieving the points balance of a user.
+type BalanceResponse struct {
+	UserID string `json:"userId"`
+	Points int    `json:"points"`
+}
+
+// LedgerResponse represents a page of the ledger history of a user, newest entries first.
+type LedgerResponse struct {
+	UserID   string        `json:"userId"`
+	Entries  []LedgerEntry `json:"entries"`
+	Page     int           `json:"page"`
+	PageSize int           `json:"pageSize"`
+	Total    int           `json:"total"`
+}
+
+// ExpiringPoints are the points of a credit not spent yet that expire at ExpiresAt.
+type ExpiringPoints struct {
+	EntryID   string    `json:"entryId"`
+	ReceiptID string    `json:"receiptId,omitempty"`
+	Points    int       `json:"points"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// ExpiringResponse represents the points of a user that expire within the next Days days, soonest first.
+type ExpiringResponse struct {
+	UserID  string           `json:"userId"`
+	Days    int              `json:"days"`
+	Points  int              `json:"points"`
+	Entries []ExpiringPoints `json:"entries"`
+}
+
+// IsValidUserID checks if a given string is a valid user ID, 1 to 64 letters, digits or any of "._@-".
+func IsValidUserID(userID string) bool {
+	return userIDPattern.MatchString(userID)
+}
@@ -80,7 +80,7 @@ func (receipt *Receipt) CountTrimmedItemDescriptionPoints() {
 func (receipt *Receipt) SixPointRule() error {
 	parsedPurchaseDate, err := time.Parse("2006-01-02", *receipt.PurchaseDate) // parse PurchaseDate in "YYYY-MM-DD" format
 	if err != nil {
-		return errors.NewInvalidParam(errors.InvalidParam{Param: "purchaseDate"})
+		return errors.NewInvalidParam(errors.InvalidParam{Param: "purchaseDate", Code: errors.CodeReceiptInvalidField})
 	}
 
 	purchaseDay := parsedPurchaseDate.Day()
@@ -95,7 +95,7 @@ func (receipt *Receipt) SixPointRule() error {
 func (receipt *Receipt) TenPointRule() error {
 	parsedPurchaseTime, err := time.Parse("15:04", *receipt.PurchaseTime) // parse PurchaseTime in 24hrs format
 	if err != nil {
-		return errors.NewInvalidParam(errors.InvalidParam{Param: "purchaseTime"})
+		return errors.NewInvalidParam(errors.InvalidParam{Param: "purchaseTime", Code: errors.CodeReceiptInvalidField})
 	}
 
 	// Create time objects for 2 PM and 4 PM
@@ -110,35 +110,64 @@ func (receipt *Receipt) TenPointRule() error {
 	return nil
 }
 
-// CalculateTotalReceiptPoints calculates total points for a receipt based on various criteria.
-// It computes points from retailer name, total amount, item descriptions, purchase date,
-// purchase time, and specific time conditions.
-// It updates the Points field of the Receipt struct and returns an error if there are parsing issues.
-func (receipt *Receipt) CalculateTotalReceiptPoints() error {
-	// Rule-1: One point for every alphanumeric character in the retailer name.
-	receipt.CountAlphanumericCharacters()
+// Rule is a named scoring rule applied to a receipt by CalculateTotalReceiptPoints.
+type Rule struct {
+	Name  string
+	Apply func(receipt *Receipt) error
+}
 
+// Rules are the scoring rules in the order they are applied.
+var Rules = []Rule{
+	// Rule-1: One point for every alphanumeric character in the retailer name.
+	{Name: "retailer_name", Apply: func(receipt *Receipt) error { receipt.CountAlphanumericCharacters(); return nil }},
 	// Rule-2: 50 points if the total is a round dollar amount with no cents.
-	receipt.FiftyPointRule()
-
+	{Name: "round_dollar_total", Apply: func(receipt *Receipt) error { receipt.FiftyPointRule(); return nil }},
 	// Rule-3: 25 points if the total is a multiple of 0.25.
-	receipt.TwentyFivePointRule()
-
+	{Name: "quarter_multiple_total", Apply: func(receipt *Receipt) error { receipt.TwentyFivePointRule(); return nil }},
 	// Rule-4: 5 points for every two items on the receipt.
-	receipt.FivePointRule()
-
+	{Name: "item_pairs", Apply: func(receipt *Receipt) error { receipt.FivePointRule(); return nil }},
 	// Rule-5: if the trimmed length of the item description is a multiple of 3, multiply the price by 0.2 and
 	// round up to the nearest integer. The result is the number of points earned.
-	receipt.CountTrimmedItemDescriptionPoints()
-
+	{Name: "item_description_length", Apply: func(receipt *Receipt) error { receipt.CountTrimmedItemDescriptionPoints(); return nil }},
 	// Rule-6: 6 points if the day in the purchase date is odd.
-	if err := receipt.SixPointRule(); err != nil {
-		return err
+	{Name: "odd_purchase_day", Apply: (*Receipt).SixPointRule},
+	// Rule-7: 10 points if the time of purchase is after 2:00pm and before 4:00pm.
+	{Name: "afternoon_purchase_time", Apply: (*Receipt).TenPointRule},
+}
+
+// CalculateTotalReceiptPoints calculates total points for a receipt based on various criteria.
+// It computes points from retailer name, total amount, item descriptions, purchase date,
+// purchase time, and specific time conditions.
+// The given campaigns are evaluated after the base rules, each matching campaign awarding points computed on the base points.
+// It sets the Points, Breakdown and Campaigns fields of the Receipt struct, clears its Cap, and returns an error if there are parsing issues.
+func (receipt *Receipt) CalculateTotalReceiptPoints(campaigns ...Campaign) error {
+	receipt.Points = 0
+	receipt.Breakdown = nil
+	receipt.Campaigns = nil
+	receipt.Cap = nil
+
+	for _, rule := range Rules {
+		before := receipt.Points
+		if err := rule.Apply(receipt); err != nil {
+			return err
+		}
+
+		// Only rules that awarded points are part of the breakdown.
+		if earned := receipt.Points - before; earned > 0 {
+			receipt.Breakdown = append(receipt.Breakdown, RulePoints{Rule: rule.Name, Points: earned})
+		}
 	}
 
-	// Rule-7: 10 points if the time of purchase is after 2:00pm and before 4:00pm.
-	if err := receipt.TenPointRule(); err != nil {
-		return err
+	base := receipt.Points
+	for _, c := range campaigns {
+		if !c.Matches(receipt) {
+			continue
+		}
+
+		if earned := c.Points(base); earned > 0 {
+			receipt.Points += earned
+			receipt.Campaigns = append(receipt.Campaigns, AppliedCampaign{CampaignID: c.ID, Name: c.Name, Points: earned, Campaign: c})
+		}
 	}
 
 	return nil
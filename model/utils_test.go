@@ -148,3 +148,69 @@ func TestTenPointRule(t *testing.T) {
 		}
 	}
 }
+
+func TestCalculateTotalReceiptPoints(t *testing.T) {
+	testCase := []struct {
+		id                int
+		useCase           string
+		receipt           *Receipt
+		expectedPoints    int
+		expectedBreakdown []RulePoints
+	}{
+		{
+			id: 1, useCase: "Positive case: breakdown of the target receipt",
+			receipt: &Receipt{
+				Retailer:     StringPointer("Target"),
+				PurchaseDate: StringPointer("2022-01-01"),
+				PurchaseTime: StringPointer("13:01"),
+				Total:        StringPointer("35.35"),
+				Items: []Item{
+					{ShortDescription: StringPointer("Mountain Dew 12PK"), Price: StringPointer("6.49")},
+					{ShortDescription: StringPointer("Emils Cheese Pizza"), Price: StringPointer("12.25")},
+					{ShortDescription: StringPointer("Knorr Creamy Chicken"), Price: StringPointer("1.26")},
+					{ShortDescription: StringPointer("Doritos Nacho Cheese"), Price: StringPointer("3.35")},
+					{ShortDescription: StringPointer("   Klarbrunn 12-PK 12 FL OZ  "), Price: StringPointer("12.00")},
+				},
+			},
+			expectedPoints: 28,
+			expectedBreakdown: []RulePoints{
+				{Rule: "retailer_name", Points: 6},
+				{Rule: "item_pairs", Points: 10},
+				{Rule: "item_description_length", Points: 6},
+				{Rule: "odd_purchase_day", Points: 6},
+			},
+		},
+		{
+			id: 2, useCase: "Positive case: points sent by the client are not carried over",
+			receipt: &Receipt{
+				Retailer:     StringPointer("M&M Corner Market"),
+				PurchaseDate: StringPointer("2022-03-20"),
+				PurchaseTime: StringPointer("14:33"),
+				Total:        StringPointer("9.00"),
+				Items: []Item{
+					{ShortDescription: StringPointer("Gatorade"), Price: StringPointer("2.25")},
+					{ShortDescription: StringPointer("Gatorade"), Price: StringPointer("2.25")},
+					{ShortDescription: StringPointer("Gatorade"), Price: StringPointer("2.25")},
+					{ShortDescription: StringPointer("Gatorade"), Price: StringPointer("2.25")},
+				},
+				Points: 1000,
+			},
+			expectedPoints: 109,
+			expectedBreakdown: []RulePoints{
+				{Rule: "retailer_name", Points: 14},
+				{Rule: "round_dollar_total", Points: 50},
+				{Rule: "quarter_multiple_total", Points: 25},
+				{Rule: "item_pairs", Points: 10},
+				{Rule: "afternoon_purchase_time", Points: 10},
+			},
+		},
+	}
+
+	for _, tc := range testCase {
+		err := tc.receipt.CalculateTotalReceiptPoints()
+
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedPoints, tc.receipt.Points, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedBreakdown, tc.receipt.Breakdown, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
@@ -0,0 +1,158 @@
+package model
+
+import (
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+)
+
+// Types of the receipt events webhooks subscribe to.
+const (
+	EventReceiptScored   = "receipt.scored"   // a receipt was scored, its points may be held for review
+	EventReceiptAdjusted = "receipt.adjusted" // items of a receipt were returned and its points adjusted
+	EventReceiptVoided   = "receipt.voided"   // a receipt was rejected by a fraud review, its points are never credited
+)
+
+// EventTypes are the event types webhooks can subscribe to.
+var EventTypes = []string{EventReceiptScored, EventReceiptAdjusted, EventReceiptVoided}
+
+// Statuses of webhook delivery attempts.
+const (
+	DeliverySucceeded = "succeeded" // the receiver responded with a 2xx status
+	DeliveryRetrying  = "retrying"  // the attempt failed and will be retried
+	DeliveryDead      = "dead"      // the last attempt failed, the delivery is in the dead-letter list
+)
+
+// Event is a change of a receipt notified to the webhooks of the client that submitted it.
+type Event struct {
+	ID        string           `json:"id"`
+	Type      string           `json:"type"`
+	ClientID  string           `json:"-"`
+	UserID    string           `json:"-"` // User the receipt was submitted on behalf of, empty for receipts without user.
+	CreatedAt time.Time        `json:"createdAt"`
+	Data      ReceiptEventData `json:"data"`
+}
+
+// ReceiptEventData is the receipt an event is about.
+type ReceiptEventData struct {
+	ReceiptID  string      `json:"receiptId"`
+	Retailer   string      `json:"retailer,omitempty"` // Retailer name as submitted, set on receipt.scored events.
+	Points     int         `json:"points"`
+	Status     string      `json:"status,omitempty"`     // Review status when the points are held for review.
+	Adjustment *Adjustment `json:"adjustment,omitempty"` // Adjustment of receipt.adjusted events.
+}
+
+// NewEvent creates an event of the given type for a receipt submitted by clientID.
+func NewEvent(eventType, clientID string, data ReceiptEventData) *Event {
+	return &Event{
+		ID:        uuid.New().String(),
+		Type:      eventType,
+		ClientID:  clientID,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	}
+}
+
+// Webhook is a subscription of a client to receipt events, delivered as signed POST requests to URL.
+// Webhooks created by a user authenticated themselves only receive the events of the receipts of that user.
+// The secret signing the deliveries is only returned when the webhook is created.
+type Webhook struct {
+	ID        string    `json:"id"`
+	ClientID  string    `json:"-"`
+	UserID    string    `json:"-"` // User the events are restricted to, empty for the events of every user of the client.
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// PayloadValidation performs validation on the webhook's payload fields.
+func (w *Webhook) PayloadValidation() error {
+	if w.URL == "" {
+		return errors.NewMissingParam(errors.MissingParam{Param: "url"})
+	}
+
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" || isInternalHost(u.Hostname()) {
+		return errors.NewInvalidParam(errors.InvalidParam{Param: "url"})
+	}
+
+	if len(w.Events) == 0 {
+		return errors.NewMissingParam(errors.MissingParam{Param: "events"})
+	}
+
+	for _, e := range w.Events {
+		if !isEventType(e) {
+			return errors.NewInvalidParam(errors.InvalidParam{Param: "events"})
+		}
+	}
+
+	return nil
+}
+
+// Subscribes reports whether the webhook subscribes to events of the given type.
+func (w *Webhook) Subscribes(eventType string) bool {
+	for _, e := range w.Events {
+		if e == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Delivery is an attempt to deliver an event to a webhook. Dead deliveries carry the event so that it can be inspected.
+type Delivery struct {
+	ID         string    `json:"id"`
+	WebhookID  string    `json:"webhookId"`
+	EventID    string    `json:"eventId"`
+	EventType  string    `json:"eventType"`
+	Attempt    int       `json:"attempt"`
+	Status     string    `json:"status"`
+	StatusCode int       `json:"statusCode,omitempty"` // Status code of the response, 0 when the request failed.
+	Error      string    `json:"error,omitempty"`
+	Event      *Event    `json:"event,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// IsValidDeliveryStatus reports whether status is one of the delivery statuses.
+func IsValidDeliveryStatus(status string) bool {
+	return status == DeliverySucceeded || status == DeliveryRetrying || status == DeliveryDead
+}
+
+// IsPublicAddr reports whether webhooks may be delivered to addr, loopback, link-local, private, multicast and
+// unspecified addresses being internal to the network of the service.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	return addr.IsValid() && !addr.IsLoopback() && !addr.IsLinkLocalUnicast() && !addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() && !addr.IsMulticast() && !addr.IsPrivate() && !addr.IsUnspecified()
+}
+
+// isInternalHost reports whether host names the service itself or is an address internal to its network.
+// Names resolving to internal addresses are refused when the deliveries dial them, see webhook.NewClient.
+func isInternalHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	addr, err := netip.ParseAddr(host)
+
+	return err == nil && !IsPublicAddr(addr)
+}
+
+// isEventType reports whether eventType is one of the event types.
+func isEventType(eventType string) bool {
+	for _, e := range EventTypes {
+		if e == eventType {
+			return true
+		}
+	}
+
+	return false
+}
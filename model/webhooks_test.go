@@ -0,0 +1,41 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+)
+
+func TestWebhookPayloadValidation(t *testing.T) {
+	testCases := []struct {
+		id            int
+		useCase       string
+		url           string
+		expectedError error
+	}{
+		{id: 1, useCase: "Positive case: public host name", url: "https://partner.example/hooks"},
+		{id: 2, useCase: "Positive case: public address", url: "http://203.0.113.10:8080/hooks"},
+		{id: 3, useCase: "Negative case: missing url", expectedError: errors.MissingParam{Param: "url"}},
+		{id: 4, useCase: "Negative case: unsupported scheme", url: "ftp://partner.example", expectedError: errors.InvalidParam{Param: "url"}},
+		{id: 5, useCase: "Negative case: localhost", url: "http://localhost:8080/hooks", expectedError: errors.InvalidParam{Param: "url"}},
+		{id: 6, useCase: "Negative case: loopback address", url: "http://127.0.0.1/hooks", expectedError: errors.InvalidParam{Param: "url"}},
+		{id: 7, useCase: "Negative case: IPv6 loopback address", url: "http://[::1]/hooks", expectedError: errors.InvalidParam{Param: "url"}},
+		{id: 8, useCase: "Negative case: link-local metadata address", url: "http://169.254.169.254/latest/meta-data", expectedError: errors.InvalidParam{Param: "url"}},
+		{id: 9, useCase: "Negative case: private address", url: "https://10.0.0.5/hooks", expectedError: errors.InvalidParam{Param: "url"}},
+		{id: 10, useCase: "Negative case: IPv4-mapped private address", url: "https://[::ffff:192.168.1.1]/hooks", expectedError: errors.InvalidParam{Param: "url"}},
+		{id: 11, useCase: "Negative case: unspecified address", url: "http://0.0.0.0/hooks", expectedError: errors.InvalidParam{Param: "url"}},
+	}
+
+	for _, tc := range testCases {
+		webhook := Webhook{URL: tc.url, Events: []string{EventReceiptScored}}
+
+		err := webhook.PayloadValidation()
+		if tc.expectedError != nil {
+			assert.EqualError(t, err, tc.expectedError.Error(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		} else {
+			assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	er "errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/responder"
+)
+
+// ValidateRequests rejects requests not matching their operation of the document with a 400 before they reach next.
+// It is meant to wrap handlers behind authentication and rate limiting, so that anonymous callers never reach it.
+// Requests matching no operation are left to next.
+func ValidateRequests(logger *log.CustomLogger, doc *Document) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if rt, pathValues := doc.find(r.Method, r.URL.Path); rt != nil {
+				if err := rt.validateRequest(r, pathValues); err != nil {
+					responder.SetErrorResponse(logger, err, w, r)
+
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ValidateResponses replaces responses not matching their operation of the document with a 500, meant for tests.
+// Responses of requests matching no operation, and streams, are left as is.
+func ValidateResponses(logger *log.CustomLogger, doc *Document) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rt, _ := doc.find(r.Method, r.URL.Path)
+
+			// Streams are never buffered, they would not reach the client before the handler returns.
+			if rt == nil || rt.streaming() {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			rec := &recorder{header: make(http.Header), statusCode: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			if err := rt.validateResponse(rec.statusCode, rec.header.Get("Content-Type"), rec.body.Bytes()); err != nil {
+				err = fmt.Errorf("Response of %v %v does not match the OpenAPI document: %w", rt.method, rt.template, err)
+				responder.SetErrorResponse(logger, errors.NewCustomError(err, http.StatusInternalServerError), w, r)
+
+				return
+			}
+
+			for key, values := range rec.header {
+				w.Header()[key] = values
+			}
+
+			w.WriteHeader(rec.statusCode)
+			_, _ = w.Write(rec.body.Bytes())
+		})
+	}
+}
+
+// validateRequest checks the parameters and the body of a request, the body is left readable for the handler.
+func (rt *route) validateRequest(r *http.Request, pathValues map[string]string) error {
+	for _, p := range rt.params {
+		var raw string
+		var present bool
+
+		switch p.In {
+		case "path":
+			raw, present = pathValues[p.Name]
+		case "query":
+			_, present = r.URL.Query()[p.Name]
+			raw = r.URL.Query().Get(p.Name)
+		case "header":
+			raw = r.Header.Get(p.Name)
+			present = raw != ""
+		}
+
+		if !present {
+			if p.Required {
+				return errors.NewMissingParam(errors.MissingParam{Param: p.Name})
+			}
+
+			continue
+		}
+
+		value, ok := p.Schema.parse(raw)
+		if !ok || p.Schema.validate(value, p.Name) != nil {
+			return errors.NewInvalidParam(errors.InvalidParam{Param: p.Name})
+		}
+	}
+
+	body := rt.operation.RequestBody
+	if body == nil {
+		return nil
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errors.NewCustomError(err, http.StatusBadRequest)
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(data))
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		if body.Required {
+			return errors.NewMissingParam(errors.MissingParam{Param: "body"})
+		}
+
+		return nil
+	}
+
+	media, ok := body.Content["application/json"]
+	if !ok {
+		return nil
+	}
+
+	var value interface{}
+	if err = json.Unmarshal(data, &value); err != nil {
+		return errors.NewCustomError(err, http.StatusBadRequest)
+	}
+
+	return requestError(media.Schema.validate(value, ""))
+}
+
+// requestError converts a field of a request body not matching its schema to the error sent to the client.
+func requestError(err error) error {
+	var field *fieldError
+	if !er.As(err, &field) {
+		return err
+	}
+
+	if field.missing {
+		return errors.NewMissingParam(errors.MissingParam{Param: field.name()})
+	}
+
+	return errors.NewInvalidParam(fmt.Errorf("Incorrect value for parameter: %v, it %v", field.name(), field.reason))
+}
+
+// validateResponse checks that the status of a response is documented and that its body matches the documented schema.
+// Error statuses may be documented by the default response, success statuses are always documented on their own.
+func (rt *route) validateResponse(statusCode int, contentType string, body []byte) error {
+	resp, ok := rt.operation.Responses[strconv.Itoa(statusCode)]
+	if !ok && statusCode >= 400 {
+		resp, ok = rt.operation.Responses["default"]
+	}
+
+	if !ok {
+		return fmt.Errorf("status %v is not documented", statusCode)
+	}
+
+	if len(resp.Content) == 0 {
+		if len(body) > 0 {
+			return fmt.Errorf("status %v is documented without a body", statusCode)
+		}
+
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	media, ok := resp.Content[mediaType]
+	if !ok {
+		return fmt.Errorf("content type %q is not documented for status %v", contentType, statusCode)
+	}
+
+	if media.Schema == nil || (mediaType != "application/json" && mediaType != "application/problem+json") {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return err
+	}
+
+	return media.Schema.validate(value, "")
+}
+
+// streaming tells whether the operation responds with a stream of Server-Sent Events.
+func (rt *route) streaming() bool {
+	for _, resp := range rt.operation.Responses {
+		if _, ok := resp.Content["text/event-stream"]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recorder buffers a response until it is validated.
+type recorder struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (rec *recorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *recorder) WriteHeader(statusCode int) {
+	rec.statusCode = statusCode
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	return rec.body.Write(b)
+}
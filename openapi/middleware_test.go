@@ -0,0 +1,204 @@
+package openapi
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+)
+
+func TestValidate_Requests(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	doc, err := Load([]byte(testSpec))
+	assert.NoError(t, err)
+
+	// The handler echoes the body it reads, so that the body is known to be left readable.
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	})
+	h := ValidateRequests(logger, doc)(echo)
+
+	bookID := "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f"
+	const maxBody = 1 << 10
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		method           string
+		target           string
+		header           map[string]string
+		body             string
+		statusCode       int
+		expectedResponse string
+	}{
+		{
+			id: 1, useCase: "Positive case: valid body reaches the handler",
+			method: "POST", target: "/books", body: `{"title": "Dune", "pages": 412, "published": "1965-08-01", "sequel": {"title": "Dune Messiah"}}`,
+			statusCode: 200, expectedResponse: `{"title": "Dune", "pages": 412, "published": "1965-08-01", "sequel": {"title": "Dune Messiah"}}`,
+		},
+		{
+			id: 2, useCase: "Positive case: valid parameters",
+			method: "GET", target: "/books/" + bookID + "?fields=title,pages", header: map[string]string{"If-Match": "3"},
+			statusCode: 200,
+		},
+		{
+			id: 3, useCase: "Positive case: undocumented routes are left to the router",
+			method: "PUT", target: "/books/1", body: `not json`,
+			statusCode: 200, expectedResponse: "not json",
+		},
+		{
+			id: 4, useCase: "Negative case: invalid path parameter",
+			method: "GET", target: "/books/1",
+			statusCode: 400, expectedResponse: "Incorrect value for parameter: id",
+		},
+		{
+			id: 5, useCase: "Negative case: query parameter not in its enum",
+			method: "GET", target: "/books/" + bookID + "?fields=title,author",
+			statusCode: 400, expectedResponse: "Incorrect value for parameter: fields",
+		},
+		{
+			id: 6, useCase: "Negative case: header parameter of the wrong type",
+			method: "GET", target: "/books/" + bookID, header: map[string]string{"If-Match": "three"},
+			statusCode: 400, expectedResponse: "Incorrect value for parameter: If-Match",
+		},
+		{
+			id: 7, useCase: "Negative case: missing body",
+			method: "POST", target: "/books",
+			statusCode: 400, expectedResponse: "Parameter body is required for this request",
+		},
+		{
+			id: 8, useCase: "Negative case: malformed body",
+			method: "POST", target: "/books", body: `{"title": `,
+			statusCode: 400, expectedResponse: "unexpected end of JSON input",
+		},
+		{
+			id: 9, useCase: "Negative case: body of the wrong type",
+			method: "POST", target: "/books", body: `["Dune"]`,
+			statusCode: 400, expectedResponse: "Incorrect value for parameter: body, it must be an object",
+		},
+		{
+			id: 10, useCase: "Negative case: missing nested property",
+			method: "POST", target: "/books", body: `{"title": "Dune", "sequel": {"pages": 256}}`,
+			statusCode: 400, expectedResponse: "Parameter sequel.title is required for this request",
+		},
+		{
+			id: 11, useCase: "Negative case: number where an integer is expected",
+			method: "POST", target: "/books", body: `{"title": "Dune", "pages": 41.5}`,
+			statusCode: 400, expectedResponse: "Incorrect value for parameter: pages, it must be an integer",
+		},
+		{
+			id: 12, useCase: "Negative case: too many items",
+			method: "POST", target: "/books", body: `{"title": "Dune", "tags": ["a", "b", "c"]}`,
+			statusCode: 400, expectedResponse: "Incorrect value for parameter: tags, it must have at most 2 items",
+		},
+		{
+			id: 13, useCase: "Negative case: invalid format",
+			method: "POST", target: "/books", body: `{"title": "Dune", "published": "1965-13-01"}`,
+			statusCode: 400, expectedResponse: "Incorrect value for parameter: published, it must be a valid date",
+		},
+		{
+			id: 14, useCase: "Negative case: null property",
+			method: "POST", target: "/books", body: `{"title": null}`,
+			statusCode: 400, expectedResponse: "Incorrect value for parameter: title, it must not be null",
+		},
+		{
+			id: 15, useCase: "Negative case: body larger than the limit",
+			method: "POST", target: "/books", body: `{"title": "` + strings.Repeat("a", maxBody) + `"}`,
+			statusCode: 413, expectedResponse: `"code":"request.too_large"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+		r.Body = http.MaxBytesReader(w, r.Body, maxBody)
+		for k, v := range tc.header {
+			r.Header.Set(k, v)
+		}
+
+		h.ServeHTTP(w, r)
+
+		resp, _ := io.ReadAll(w.Result().Body)
+		assert.Equal(t, tc.statusCode, w.Result().StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Contains(t, string(resp), tc.expectedResponse, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestValidate_Responses(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	doc, err := Load([]byte(testSpec))
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		method           string
+		target           string
+		status           int
+		contentType      string
+		body             string
+		statusCode       int
+		expectedResponse string
+	}{
+		{
+			id: 1, useCase: "Positive case: documented response",
+			method: "POST", target: "/books", status: 201, contentType: "application/json", body: `{"title":"Dune"}`,
+			statusCode: 201, expectedResponse: `{"title":"Dune"}`,
+		},
+		{
+			id: 2, useCase: "Positive case: error documented by the default response",
+			method: "GET", target: "/books/4a77ec9d-5334-43d0-a9e1-4fca8807bf8f", status: 404, contentType: "application/problem+json", body: `{"code":"resource.not_found"}`,
+			statusCode: 404, expectedResponse: `{"code":"resource.not_found"}`,
+		},
+		{
+			id: 3, useCase: "Positive case: response without a body",
+			method: "DELETE", target: "/books", status: 204,
+			statusCode: 204,
+		},
+		{
+			id: 4, useCase: "Negative case: success status not documented",
+			method: "POST", target: "/books", status: 200, contentType: "application/json", body: `{"title":"Dune"}`,
+			statusCode: 500, expectedResponse: `"detail":"Internal Server Error"`,
+		},
+		{
+			id: 5, useCase: "Negative case: body missing a required property",
+			method: "POST", target: "/books", status: 201, contentType: "application/json", body: `{"pages":412}`,
+			statusCode: 500, expectedResponse: `"detail":"Internal Server Error"`,
+		},
+		{
+			id: 6, useCase: "Negative case: content type not documented",
+			method: "POST", target: "/books", status: 201, contentType: "text/plain", body: `Dune`,
+			statusCode: 500, expectedResponse: `"detail":"Internal Server Error"`,
+		},
+		{
+			id: 7, useCase: "Negative case: body of a response documented without one",
+			method: "DELETE", target: "/books", status: 204, contentType: "application/json", body: `{}`,
+			statusCode: 500, expectedResponse: `"detail":"Internal Server Error"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		h := ValidateResponses(logger, doc)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if tc.contentType != "" {
+				w.Header().Set("Content-Type", tc.contentType)
+			}
+
+			w.WriteHeader(tc.status)
+			_, _ = w.Write([]byte(tc.body))
+		}))
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(tc.method, tc.target, nil))
+
+		resp, _ := io.ReadAll(w.Result().Body)
+		assert.Equal(t, tc.statusCode, w.Result().StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Contains(t, string(resp), tc.expectedResponse, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
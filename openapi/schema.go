@@ -0,0 +1,265 @@
+package openapi
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Schema is the subset of the OpenAPI schema object used to validate payloads and parameters.
+// Schemas without a type accept any value.
+type Schema struct {
+	Ref                  string             `yaml:"$ref"`
+	Type                 string             `yaml:"type"`
+	Format               string             `yaml:"format"`
+	Pattern              string             `yaml:"pattern"`
+	Enum                 []interface{}      `yaml:"enum"`
+	Nullable             bool               `yaml:"nullable"`
+	Required             []string           `yaml:"required"`
+	Properties           map[string]*Schema `yaml:"properties"`
+	AdditionalProperties *Schema            `yaml:"additionalProperties"` // Schema of the properties not listed, any value when nil.
+	Items                *Schema            `yaml:"items"`
+	MinItems             *int               `yaml:"minItems"`
+	MaxItems             *int               `yaml:"maxItems"`
+	MinLength            *int               `yaml:"minLength"`
+	MaxLength            *int               `yaml:"maxLength"`
+	Minimum              *float64           `yaml:"minimum"`
+	Maximum              *float64           `yaml:"maximum"`
+
+	pattern *regexp.Regexp
+}
+
+// fieldError is a value not matching its schema, field is the location of the value like items[0].price, empty for the body itself.
+type fieldError struct {
+	field   string
+	reason  string
+	missing bool // The value is required but absent.
+}
+
+func (e *fieldError) Error() string {
+	if e.missing {
+		return fmt.Sprintf("%v is missing", e.name())
+	}
+
+	return fmt.Sprintf("%v %v", e.name(), e.reason)
+}
+
+func (e *fieldError) name() string {
+	if e.field == "" {
+		return "body"
+	}
+
+	return e.field
+}
+
+// validate checks a value decoded from JSON against the schema, at is the location of the value.
+func (s *Schema) validate(value interface{}, at string) error {
+	if s == nil {
+		return nil
+	}
+
+	if value == nil {
+		if s.Nullable || s.Type == "" {
+			return nil
+		}
+
+		return &fieldError{field: at, reason: "must not be null"}
+	}
+
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return &fieldError{field: at, reason: "must be an object"}
+		}
+
+		return s.validateObject(object, at)
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return &fieldError{field: at, reason: "must be an array"}
+		}
+
+		return s.validateArray(array, at)
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return &fieldError{field: at, reason: "must be a string"}
+		}
+
+		if err := s.validateString(str); err != "" {
+			return &fieldError{field: at, reason: err}
+		}
+	case "integer", "number":
+		number, ok := value.(float64)
+		if !ok {
+			return &fieldError{field: at, reason: "must be a number"}
+		}
+
+		if s.Type == "integer" && number != math.Trunc(number) {
+			return &fieldError{field: at, reason: "must be an integer"}
+		}
+
+		if s.Minimum != nil && number < *s.Minimum {
+			return &fieldError{field: at, reason: fmt.Sprintf("must be at least %v", *s.Minimum)}
+		}
+
+		if s.Maximum != nil && number > *s.Maximum {
+			return &fieldError{field: at, reason: fmt.Sprintf("must be at most %v", *s.Maximum)}
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return &fieldError{field: at, reason: "must be a boolean"}
+		}
+	}
+
+	if len(s.Enum) > 0 && !s.allows(value) {
+		return &fieldError{field: at, reason: fmt.Sprintf("must be one of %v", s.Enum)}
+	}
+
+	return nil
+}
+
+func (s *Schema) validateObject(object map[string]interface{}, at string) error {
+	for _, name := range s.Required {
+		if _, ok := object[name]; !ok {
+			return &fieldError{field: join(at, name), missing: true}
+		}
+	}
+
+	// Properties are checked in order so that the first invalid one is always the same.
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := s.Properties[name]
+		if !ok {
+			property = s.AdditionalProperties
+		}
+
+		if err := property.validate(object[name], join(at, name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) validateArray(array []interface{}, at string) error {
+	if s.MinItems != nil && len(array) < *s.MinItems {
+		return &fieldError{field: at, reason: fmt.Sprintf("must have at least %v items", *s.MinItems)}
+	}
+
+	if s.MaxItems != nil && len(array) > *s.MaxItems {
+		return &fieldError{field: at, reason: fmt.Sprintf("must have at most %v items", *s.MaxItems)}
+	}
+
+	for i, item := range array {
+		if err := s.Items.validate(item, fmt.Sprintf("%v[%v]", at, i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateString returns why the string does not match the schema, empty when it does.
+func (s *Schema) validateString(str string) string {
+	if s.MinLength != nil && len(str) < *s.MinLength {
+		return fmt.Sprintf("must be at least %v characters long", *s.MinLength)
+	}
+
+	if s.MaxLength != nil && len(str) > *s.MaxLength {
+		return fmt.Sprintf("must be at most %v characters long", *s.MaxLength)
+	}
+
+	if s.pattern != nil && !s.pattern.MatchString(str) {
+		return fmt.Sprintf("must match the pattern %v", s.Pattern)
+	}
+
+	var err error
+	switch s.Format {
+	case "uuid":
+		_, err = uuid.Parse(str)
+	case "date":
+		_, err = time.Parse(time.DateOnly, str)
+	case "time":
+		_, err = time.Parse("15:04", str)
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, str)
+	case "uri":
+		var u *url.URL
+		if u, err = url.Parse(str); err == nil && !u.IsAbs() {
+			err = fmt.Errorf("relative URI")
+		}
+	}
+
+	if err != nil {
+		return "must be a valid " + s.Format
+	}
+
+	return ""
+}
+
+func (s *Schema) allows(value interface{}) bool {
+	for _, allowed := range s.Enum {
+		if fmt.Sprint(allowed) == fmt.Sprint(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parse converts the raw value of a parameter to the type of the schema.
+func (s *Schema) parse(raw string) (interface{}, bool) {
+	if s == nil {
+		return raw, true
+	}
+
+	switch s.Type {
+	case "integer":
+		n, err := strconv.ParseInt(raw, 10, 64)
+		return float64(n), err == nil
+	case "number":
+		n, err := strconv.ParseFloat(raw, 64)
+		return n, err == nil
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		return b, err == nil
+	case "array":
+		var items []interface{}
+		for _, item := range strings.Split(raw, ",") {
+			v, ok := s.Items.parse(item)
+			if !ok {
+				return nil, false
+			}
+
+			items = append(items, v)
+		}
+
+		return items, true
+	default:
+		return raw, true
+	}
+}
+
+// join returns the location of a property of the value at.
+func join(at, name string) string {
+	if at == "" {
+		return name
+	}
+
+	return at + "." + name
+}
@@ -0,0 +1,375 @@
+package openapi
+
+import (
+	_ "embed"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is the OpenAPI document of the API, the single source of truth of its routes and payloads.
+//
+//go:embed openapi.yml
+var Spec []byte
+
+// Document is a parsed OpenAPI 3 document with its references resolved.
+// Only the parts of the specification needed to validate requests and responses are supported.
+type Document struct {
+	OpenAPI    string               `yaml:"openapi"`
+	Paths      map[string]*PathItem `yaml:"paths"`
+	Components Components           `yaml:"components"`
+
+	raw    []byte
+	routes []*route
+}
+
+type PathItem struct {
+	Parameters []*Parameter `yaml:"parameters"`
+	Get        *Operation   `yaml:"get"`
+	Put        *Operation   `yaml:"put"`
+	Post       *Operation   `yaml:"post"`
+	Delete     *Operation   `yaml:"delete"`
+	Patch      *Operation   `yaml:"patch"`
+}
+
+// Operations returns the operations of the path item with their HTTP methods as keys.
+func (p *PathItem) Operations() map[string]*Operation {
+	operations := make(map[string]*Operation)
+	for method, op := range map[string]*Operation{http.MethodGet: p.Get, http.MethodPut: p.Put, http.MethodPost: p.Post, http.MethodDelete: p.Delete, http.MethodPatch: p.Patch} {
+		if op != nil {
+			operations[method] = op
+		}
+	}
+
+	return operations
+}
+
+type Operation struct {
+	OperationID string               `yaml:"operationId"`
+	Parameters  []*Parameter         `yaml:"parameters"`
+	RequestBody *RequestBody         `yaml:"requestBody"`
+	Responses   map[string]*Response `yaml:"responses"` // Status codes, or default, as keys.
+}
+
+type Parameter struct {
+	Ref      string  `yaml:"$ref"`
+	Name     string  `yaml:"name"`
+	In       string  `yaml:"in"` // path, query or header.
+	Required bool    `yaml:"required"`
+	Schema   *Schema `yaml:"schema"`
+}
+
+type RequestBody struct {
+	Ref      string                `yaml:"$ref"`
+	Required bool                  `yaml:"required"`
+	Content  map[string]*MediaType `yaml:"content"`
+}
+
+type Response struct {
+	Ref         string                `yaml:"$ref"`
+	Description string                `yaml:"description"`
+	Content     map[string]*MediaType `yaml:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `yaml:"schema"`
+}
+
+type Components struct {
+	Schemas       map[string]*Schema      `yaml:"schemas"`
+	Parameters    map[string]*Parameter   `yaml:"parameters"`
+	RequestBodies map[string]*RequestBody `yaml:"requestBodies"`
+	Responses     map[string]*Response    `yaml:"responses"`
+}
+
+// route is an operation of the document along with the path template it is served at.
+type route struct {
+	method    string
+	template  string
+	segments  []string
+	operation *Operation
+	params    []*Parameter // Parameters of the path item and of the operation, the latter overriding the former.
+}
+
+// Load parses an OpenAPI 3 document in YAML and resolves its references.
+func Load(data []byte) (*Document, error) {
+	doc := &Document{raw: data}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("openapi: %w", err)
+	}
+
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("openapi: version %q is not supported", doc.OpenAPI)
+	}
+
+	r := &resolver{components: &doc.Components, schemas: make(map[*Schema]bool)}
+	for template, item := range doc.Paths {
+		for method, op := range item.Operations() {
+			if err := r.operation(item, op); err != nil {
+				return nil, fmt.Errorf("openapi: %v %v: %w", method, template, err)
+			}
+
+			doc.routes = append(doc.routes, &route{
+				method:    method,
+				template:  template,
+				segments:  strings.Split(strings.Trim(template, "/"), "/"),
+				operation: op,
+				params:    mergeParameters(item.Parameters, op.Parameters),
+			})
+		}
+	}
+
+	// Templates with more literal segments are matched first, so that /v1/receipts/stream wins over /v1/receipts/{id}.
+	sort.Slice(doc.routes, func(i, j int) bool {
+		if a, b := doc.routes[i].literals(), doc.routes[j].literals(); a != b {
+			return a > b
+		}
+
+		return doc.routes[i].template < doc.routes[j].template
+	})
+
+	return doc, nil
+}
+
+// Routes returns the operations of the document as "METHOD template", sorted.
+func (d *Document) Routes() []string {
+	routes := make([]string, 0, len(d.routes))
+	for _, rt := range d.routes {
+		routes = append(routes, rt.method+" "+rt.template)
+	}
+
+	sort.Strings(routes)
+
+	return routes
+}
+
+// Handler returns a HTTP handler serving the document as it was loaded.
+func (d *Document) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Content-Type", "application/yaml")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(d.raw)
+	})
+}
+
+// find returns the route of the method and path along with the values of its path parameters, nil when none matches.
+func (d *Document) find(method, path string) (*route, map[string]string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for _, rt := range d.routes {
+		if rt.method != method || len(rt.segments) != len(segments) {
+			continue
+		}
+
+		if values, ok := rt.match(segments); ok {
+			return rt, values
+		}
+	}
+
+	return nil, nil
+}
+
+func (rt *route) match(segments []string) (map[string]string, bool) {
+	values := make(map[string]string)
+	for i, segment := range rt.segments {
+		if name, ok := pathParam(segment); ok {
+			if segments[i] == "" {
+				return nil, false
+			}
+
+			values[name] = segments[i]
+		} else if segment != segments[i] {
+			return nil, false
+		}
+	}
+
+	return values, true
+}
+
+// literals returns the number of segments of the template that are not parameters.
+func (rt *route) literals() int {
+	var n int
+	for _, segment := range rt.segments {
+		if _, ok := pathParam(segment); !ok {
+			n++
+		}
+	}
+
+	return n
+}
+
+// pathParam returns the name of the parameter of a segment of a path template, like id for {id}.
+func pathParam(segment string) (string, bool) {
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+
+	return "", false
+}
+
+// mergeParameters returns the parameters of the path item overridden by the parameters of the operation with the same name and location.
+func mergeParameters(item, op []*Parameter) []*Parameter {
+	params := append([]*Parameter{}, op...)
+	for _, p := range item {
+		overridden := false
+		for _, o := range op {
+			overridden = overridden || (o.Name == p.Name && o.In == p.In)
+		}
+
+		if !overridden {
+			params = append(params, p)
+		}
+	}
+
+	return params
+}
+
+// resolver replaces the references of a document by the components they point to and compiles the patterns of schemas.
+type resolver struct {
+	components *Components
+	schemas    map[*Schema]bool // Schemas already resolved, schemas may refer to themselves.
+}
+
+func (r *resolver) operation(item *PathItem, op *Operation) error {
+	for _, params := range [][]*Parameter{item.Parameters, op.Parameters} {
+		for i, p := range params {
+			resolved, err := r.parameter(p)
+			if err != nil {
+				return err
+			}
+
+			params[i] = resolved
+		}
+	}
+
+	if op.RequestBody != nil {
+		body := op.RequestBody
+		if body.Ref != "" {
+			target, ok := r.components.RequestBodies[strings.TrimPrefix(body.Ref, "#/components/requestBodies/")]
+			if !ok {
+				return fmt.Errorf("unknown reference %q", body.Ref)
+			}
+
+			body = target
+		}
+
+		if err := r.content(body.Content); err != nil {
+			return err
+		}
+
+		op.RequestBody = body
+	}
+
+	if len(op.Responses) == 0 {
+		return fmt.Errorf("no responses")
+	}
+
+	for status, resp := range op.Responses {
+		if resp.Ref != "" {
+			target, ok := r.components.Responses[strings.TrimPrefix(resp.Ref, "#/components/responses/")]
+			if !ok {
+				return fmt.Errorf("unknown reference %q", resp.Ref)
+			}
+
+			resp = target
+		}
+
+		if err := r.content(resp.Content); err != nil {
+			return err
+		}
+
+		op.Responses[status] = resp
+	}
+
+	return nil
+}
+
+func (r *resolver) parameter(p *Parameter) (*Parameter, error) {
+	if p.Ref != "" {
+		target, ok := r.components.Parameters[strings.TrimPrefix(p.Ref, "#/components/parameters/")]
+		if !ok {
+			return nil, fmt.Errorf("unknown reference %q", p.Ref)
+		}
+
+		p = target
+	}
+
+	if p.In != "path" && p.In != "query" && p.In != "header" {
+		return nil, fmt.Errorf("parameter %q: location %q is not supported", p.Name, p.In)
+	}
+
+	schema, err := r.schema(p.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("parameter %q: %w", p.Name, err)
+	}
+
+	p.Schema = schema
+
+	return p, nil
+}
+
+func (r *resolver) content(content map[string]*MediaType) error {
+	for _, media := range content {
+		schema, err := r.schema(media.Schema)
+		if err != nil {
+			return err
+		}
+
+		media.Schema = schema
+	}
+
+	return nil
+}
+
+// schema returns the schema a reference points to, or the schema itself, with the schemas it is made of resolved.
+func (r *resolver) schema(s *Schema) (*Schema, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	if s.Ref != "" {
+		target, ok := r.components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+		if !ok {
+			return nil, fmt.Errorf("unknown reference %q", s.Ref)
+		}
+
+		s = target
+	}
+
+	if r.schemas[s] {
+		return s, nil
+	}
+
+	r.schemas[s] = true
+
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("pattern %q: %w", s.Pattern, err)
+		}
+
+		s.pattern = re
+	}
+
+	var err error
+	for name, property := range s.Properties {
+		if s.Properties[name], err = r.schema(property); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.Items, err = r.schema(s.Items); err != nil {
+		return nil, err
+	}
+
+	if s.AdditionalProperties, err = r.schema(s.AdditionalProperties); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
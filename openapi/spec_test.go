@@ -0,0 +1,204 @@
+package openapi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testSpec documents a small API of books, its routes overlap so that literal segments must win over parameters.
+const testSpec = `
+openapi: 3.0.3
+paths:
+  /books/{id}:
+    parameters:
+      - $ref: "#/components/parameters/BookId"
+    get:
+      parameters:
+        - name: fields
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+              enum: [title, pages]
+        - name: If-Match
+          in: header
+          schema:
+            type: integer
+      responses:
+        200:
+          description: The book
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Book"
+        default:
+          description: An error
+          content:
+            application/problem+json:
+              schema:
+                type: object
+                required: [code]
+  /books/latest:
+    get:
+      responses:
+        200:
+          description: The latest book
+  /books:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Book"
+      responses:
+        201:
+          description: The book created
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Book"
+    delete:
+      responses:
+        204:
+          description: Every book was deleted
+components:
+  parameters:
+    BookId:
+      name: id
+      in: path
+      required: true
+      schema:
+        type: string
+        format: uuid
+  schemas:
+    Book:
+      type: object
+      required: [title]
+      properties:
+        title:
+          type: string
+          minLength: 1
+        pages:
+          type: integer
+          minimum: 1
+        published:
+          type: string
+          format: date
+        tags:
+          type: array
+          maxItems: 2
+          items:
+            type: string
+        sequel:
+          $ref: "#/components/schemas/Book"
+`
+
+func TestLoad(t *testing.T) {
+	testCases := []struct {
+		id            int
+		useCase       string
+		spec          string
+		expectedError string
+	}{
+		{
+			id: 1, useCase: "Positive case: document with recursive schemas",
+			spec: testSpec,
+		},
+		{
+			id: 2, useCase: "Positive case: document of the API",
+			spec: string(Spec),
+		},
+		{
+			id: 3, useCase: "Negative case: unsupported version",
+			spec:          "swagger: \"2.0\"\nopenapi: 2.0.0\n",
+			expectedError: `openapi: version "2.0.0" is not supported`,
+		},
+		{
+			id: 4, useCase: "Negative case: unknown schema reference",
+			spec: `
+openapi: 3.0.3
+paths:
+  /books:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Book"
+      responses:
+        201:
+          description: The book created
+`,
+			expectedError: `openapi: POST /books: unknown reference "#/components/schemas/Book"`,
+		},
+		{
+			id: 5, useCase: "Negative case: invalid pattern",
+			spec: `
+openapi: 3.0.3
+paths:
+  /books/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          schema:
+            type: string
+            pattern: "[a-"
+      responses:
+        200:
+          description: The book
+`,
+			expectedError: `openapi: GET /books/{id}: parameter "id": pattern "[a-"`,
+		},
+		{
+			id: 6, useCase: "Negative case: operation without responses",
+			spec:          "openapi: 3.0.3\npaths:\n  /books:\n    get:\n      summary: Lists books\n",
+			expectedError: "openapi: GET /books: no responses",
+		},
+	}
+
+	for _, tc := range testCases {
+		doc, err := Load([]byte(tc.spec))
+		if tc.expectedError == "" {
+			assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			assert.NotEmpty(t, doc.Routes(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		} else {
+			assert.ErrorContains(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+	}
+}
+
+func TestDocumentFind(t *testing.T) {
+	doc, err := Load([]byte(testSpec))
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		method           string
+		path             string
+		expectedTemplate string
+		expectedValues   map[string]string
+	}{
+		{id: 1, useCase: "Literal segments win over parameters", method: "GET", path: "/books/latest", expectedTemplate: "/books/latest", expectedValues: map[string]string{}},
+		{id: 2, useCase: "Parameters take the value of their segment", method: "GET", path: "/books/42/", expectedTemplate: "/books/{id}", expectedValues: map[string]string{"id": "42"}},
+		{id: 3, useCase: "Method not documented for the path", method: "PUT", path: "/books/42"},
+		{id: 4, useCase: "Path not documented", method: "GET", path: "/books/42/pages"},
+	}
+
+	for _, tc := range testCases {
+		rt, values := doc.find(tc.method, tc.path)
+		if tc.expectedTemplate == "" {
+			assert.Nil(t, rt, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+
+			continue
+		}
+
+		assert.Equal(t, tc.expectedTemplate, rt.template, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedValues, values, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
@@ -0,0 +1,85 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// dedupWindow is the number of latest event IDs the broker remembers to drop redeliveries.
+const dedupWindow = 10000
+
+// Broker is an in-process stand-in for a message broker, it implements Sink. Every subscriber receives each event once:
+// redeliveries of the latest events are dropped by ID.
+type Broker struct {
+	mu          sync.Mutex
+	subscribers []chan model.DomainEvent
+	seen        map[string]struct{} // IDs of the latest events delivered to every subscriber.
+	order       []string            // IDs of seen in delivery order, to forget the oldest ones.
+	buffer      int
+}
+
+// NewBroker creates and returns a Broker whose subscriptions buffer up to buffer events.
+func NewBroker(buffer int) *Broker {
+	return &Broker{
+		seen:   make(map[string]struct{}),
+		buffer: buffer,
+	}
+}
+
+// Subscribe returns a channel receiving every event published after the call.
+func (b *Broker) Subscribe() <-chan model.DomainEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan model.DomainEvent, b.buffer)
+	b.subscribers = append(b.subscribers, ch)
+
+	return ch
+}
+
+// Consume hands the events of a subscription to handle one after the other until ctx is done.
+func Consume(ctx context.Context, events <-chan model.DomainEvent, handle func(event model.DomainEvent)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			handle(event)
+		}
+	}
+}
+
+// Name returns the name of the sink.
+func (b *Broker) Name() string {
+	return "broker"
+}
+
+// Send delivers the event to every subscriber, blocking while a subscription is full until ctx is done.
+// Events already delivered are dropped, an event is only seen once every subscriber received it.
+func (b *Broker) Send(ctx context.Context, event model.DomainEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.seen[event.ID]; ok {
+		return nil
+	}
+
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	b.seen[event.ID] = struct{}{}
+	b.order = append(b.order, event.ID)
+	if len(b.order) > dedupWindow {
+		delete(b.seen, b.order[0])
+		b.order = b.order[1:]
+	}
+
+	return nil
+}
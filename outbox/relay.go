@@ -0,0 +1,74 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/metrics"
+)
+
+// Relay publishes the events of the outbox to its sinks, oldest first. An event leaves the outbox only once every
+// sink received it, so events are delivered at least once: a failed or interrupted publication is retried on the
+// next tick, including to the sinks that already received the event.
+type Relay struct {
+	logger   *log.CustomLogger
+	outbox   data.Outbox
+	sinks    []Sink
+	interval time.Duration
+	batch    int
+}
+
+// New creates and returns a Relay publishing up to batch events of outbox to sinks every interval.
+func New(l *log.CustomLogger, outbox data.Outbox, interval time.Duration, batch int, sinks ...Sink) *Relay {
+	return &Relay{
+		logger:   l,
+		outbox:   outbox,
+		sinks:    sinks,
+		interval: interval,
+		batch:    batch,
+	}
+}
+
+// Run publishes the pending events every interval until ctx is done.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Flush(ctx)
+		}
+	}
+}
+
+// Flush publishes a batch of pending events and returns the number published. It stops at the first event a sink
+// fails to receive, so that events are never published out of order.
+func (r *Relay) Flush(ctx context.Context) int {
+	published := 0
+	for _, entry := range r.outbox.Pending(r.batch) {
+		for _, sink := range r.sinks {
+			if err := sink.Send(ctx, entry.Event); err != nil {
+				r.outbox.MarkFailed(entry.Event.ID, fmt.Sprintf("%v: %v", sink.Name(), err.Error()))
+				metrics.OutboxDeliveries.WithLabelValues(sink.Name(), "failed").Inc()
+
+				lm := log.Message{Level: "ERROR", ErrorMessage: fmt.Sprintf("Publishing event %v to sink %v failed after %v attempts: %v", entry.Event.ID, sink.Name(), entry.Attempts+1, err.Error())}
+				r.logger.Log(&lm)
+
+				return published
+			}
+
+			metrics.OutboxDeliveries.WithLabelValues(sink.Name(), "published").Inc()
+		}
+
+		r.outbox.MarkPublished(entry.Event.ID)
+		published++
+	}
+
+	return published
+}
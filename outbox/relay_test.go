@@ -0,0 +1,157 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	store "github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+// flakySink fails the given number of sends, then records the IDs of the events it receives.
+type flakySink struct {
+	failures int
+	received []string
+}
+
+func (s *flakySink) Name() string { return "flaky" }
+
+func (s *flakySink) Send(_ context.Context, event model.DomainEvent) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("sink down")
+	}
+
+	s.received = append(s.received, event.ID)
+
+	return nil
+}
+
+func TestRelay_AtLeastOnce(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	outbox := store.NewOutbox(logger)
+	outbox.Append(
+		model.DomainEvent{ID: "1", Type: model.DomainReceiptInserted},
+		model.DomainEvent{ID: "2", Type: model.DomainPointsAwarded},
+	)
+
+	reliable, flaky := &flakySink{}, &flakySink{failures: 1}
+	relay := New(logger, outbox, time.Second, 10, reliable, flaky)
+
+	testCases := []struct {
+		id                int
+		useCase           string
+		expectedPublished int
+		expectedReliable  []string
+		expectedFlaky     []string
+	}{
+		{id: 1, useCase: "Negative case: failing sink keeps the event in the outbox", expectedPublished: 0, expectedReliable: []string{"1"}, expectedFlaky: nil},
+		{id: 2, useCase: "Positive case: event redelivered to every sink on retry", expectedPublished: 2, expectedReliable: []string{"1", "1", "2"}, expectedFlaky: []string{"1", "2"}},
+		{id: 3, useCase: "Positive case: nothing left to publish", expectedPublished: 0, expectedReliable: []string{"1", "1", "2"}, expectedFlaky: []string{"1", "2"}},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedPublished, relay.Flush(context.Background()), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedReliable, reliable.received, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedFlaky, flaky.received, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+
+	assert.Equal(t, 0, outbox.Count())
+}
+
+func TestRelay_Sinks(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	outbox := store.NewOutbox(logger)
+	receipts, ledger := store.NewWithOutbox(logger, outbox), store.NewLedgerWithOutbox(logger, outbox)
+	svc := service.New(logger, receipts, service.WithLedger(ledger))
+
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "outbox.log")
+	file, err := NewFileSink(path)
+	assert.NoError(t, err)
+
+	broker := NewBroker(10)
+	events := broker.Subscribe()
+
+	relay := New(logger, outbox, time.Second, 10, file, NewWebhookSink(nil, server.URL), broker)
+
+	resp, err := svc.Insert(context.Background(), &model.Receipt{
+		UserID:       "user-1",
+		ClientID:     "partner",
+		Retailer:     model.StringPointer("Target"),
+		PurchaseDate: model.StringPointer("2024-03-02"),
+		PurchaseTime: model.StringPointer("14:33"),
+		Total:        model.StringPointer("6.49"),
+		Items:        []model.Item{{ShortDescription: model.StringPointer("Emils Cheese Pizza"), Price: model.StringPointer("6.49")}},
+	})
+	assert.NoError(t, err)
+
+	pending := outbox.Pending(10)
+	assert.Equal(t, 2, relay.Flush(context.Background()))
+
+	// Redelivering the same events, as after a crash before they were marked published, is dropped by the broker.
+	outbox.Append(pending[0].Event, pending[1].Event)
+	assert.Equal(t, 2, relay.Flush(context.Background()))
+
+	var received []model.DomainEvent
+	for len(events) > 0 {
+		received = append(received, <-events)
+	}
+
+	assert.Len(t, received, 2)
+	assert.Equal(t, model.DomainReceiptInserted, received[0].Type)
+	assert.Equal(t, model.DomainPointsAwarded, received[1].Type)
+	assert.Equal(t, resp.Id, received[1].ReceiptID)
+	assert.Equal(t, 18, received[1].Points)
+
+	assert.Equal(t, []string{received[0].ID, received[1].ID, received[0].ID, received[1].ID}, keys)
+
+	content, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 4)
+
+	var written model.DomainEvent
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &written))
+	assert.Equal(t, received[0], written)
+}
+
+func TestConsume(t *testing.T) {
+	broker := NewBroker(10)
+	events := broker.Subscribe()
+
+	for _, id := range []string{"1", "2"} {
+		assert.NoError(t, broker.Send(context.Background(), model.DomainEvent{ID: id, Type: model.DomainReceiptInserted}))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	consumed := make(chan string, 2)
+	done := make(chan struct{})
+	go func() {
+		Consume(ctx, events, func(event model.DomainEvent) { consumed <- event.ID })
+		close(done)
+	}()
+
+	assert.Equal(t, "1", <-consumed)
+	assert.Equal(t, "2", <-consumed)
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,126 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// Sink is a destination the relay publishes domain events to. Send may be called again with an event it already
+// received when publishing failed or the process stopped in between, sinks pass the event ID on for deduplication.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, event model.DomainEvent) error
+}
+
+// writerSink writes every event as a JSON line to a writer.
+type writerSink struct {
+	name string
+	mu   sync.Mutex
+	w    io.Writer
+	sync func() error // Flushes the writer to stable storage, optional.
+}
+
+// NewWriterSink creates and returns a sink writing every event as a JSON line to w.
+func NewWriterSink(name string, w io.Writer) Sink {
+	return &writerSink{name: name, w: w}
+}
+
+// NewStdoutSink creates and returns a sink writing every event as a JSON line to the standard output.
+func NewStdoutSink() Sink {
+	return NewWriterSink("stdout", os.Stdout)
+}
+
+// NewFileSink creates and returns a sink appending every event as a JSON line to the file at path, it is created if missing.
+// Every line is synced before the event counts as published.
+func NewFileSink(path string) (Sink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening outbox file: %w", err)
+	}
+
+	return &writerSink{name: "file", w: file, sync: file.Sync}, nil
+}
+
+// Name returns the name of the sink.
+func (ws *writerSink) Name() string {
+	return ws.name
+}
+
+// Send writes the event as a JSON line.
+func (ws *writerSink) Send(_ context.Context, event model.DomainEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	if _, err = ws.w.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	if ws.sync != nil {
+		return ws.sync()
+	}
+
+	return nil
+}
+
+// webhookSink posts every event as JSON to a URL.
+type webhookSink struct {
+	client *http.Client
+	url    string
+}
+
+// NewWebhookSink creates and returns a sink posting every event to url with client, http.DefaultClient when nil.
+// The event ID is sent in the Idempotency-Key header, any status other than 2xx fails the delivery.
+func NewWebhookSink(client *http.Client, url string) Sink {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &webhookSink{client: client, url: url}
+}
+
+// Name returns the name of the sink.
+func (ws *webhookSink) Name() string {
+	return "webhook"
+}
+
+// Send posts the event to the URL of the sink.
+func (ws *webhookSink) Send(ctx context.Context, event model.DomainEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ws.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", event.ID)
+	req.Header.Set("X-Event-Type", event.Type)
+
+	resp, err := ws.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("receiver responded with status %v", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package queue
+
+import (
+	"context"
+	er "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/metrics"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+// requeueInterval is how often a recovered job is offered again to a full queue.
+const requeueInterval = 10 * time.Millisecond
+
+// Queue scores receipts asynchronously: receipts are stored as pending jobs and scored by a pool of workers,
+// each receipt being stored under the ID of its job. It implements service.Jobs.
+type Queue struct {
+	logger    *log.CustomLogger
+	jobs      data.Jobs
+	svc       service.Receipts
+	workers   int
+	retention time.Duration // How long done jobs are kept for their status to be read.
+	mu        sync.Mutex    // Serializes sends so that the capacity check of Enqueue holds.
+	pending   chan string   // IDs of the jobs waiting for a worker.
+	now       func() time.Time
+}
+
+// New creates and returns a Queue scoring receipts with svc in the given number of workers,
+// it accepts up to capacity receipts waiting for a worker and keeps the jobs done for retention.
+func New(l *log.CustomLogger, jobs data.Jobs, svc service.Receipts, workers, capacity int, retention time.Duration) *Queue {
+	return &Queue{
+		logger:    l,
+		jobs:      jobs,
+		svc:       svc,
+		workers:   workers,
+		retention: retention,
+		pending:   make(chan string, capacity),
+		now:       time.Now,
+	}
+}
+
+// Enqueue validates a receipt and queues it for scoring. It returns the pending job,
+// or an error when the receipt is invalid or the queue is full.
+func (q *Queue) Enqueue(receipt *model.Receipt) (*model.ReceiptJob, error) {
+	if err := receipt.PayloadValidation(); err != nil {
+		return nil, err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.pending) == cap(q.pending) {
+		return nil, errors.NewCustomError(er.New("receipt queue is full"), 503)
+	}
+
+	now := q.now().UTC()
+	job := &model.ReceiptJob{
+		ID:        uuid.New().String(),
+		Status:    model.JobPending,
+		Receipt:   *receipt,
+		ClientID:  receipt.ClientID,
+		UserID:    receipt.UserID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := q.jobs.Insert(job); err != nil {
+		return nil, err
+	}
+
+	q.pending <- job.ID
+	metrics.ReceiptJobs.WithLabelValues(model.JobPending).Inc()
+
+	return job, nil
+}
+
+// Get retrieves a job by its ID.
+func (q *Queue) Get(jobID string) (*model.ReceiptJob, error) {
+	return q.jobs.Get(jobID)
+}
+
+// Depth returns the number of receipts waiting for a worker.
+func (q *Queue) Depth() int {
+	return len(q.pending)
+}
+
+// Run starts the workers, queues again the jobs left pending by a previous run and blocks until ctx is done.
+// Workers finish the receipt they are scoring before Run returns, the receipts still waiting stay pending.
+// Jobs done for longer than the retention are evicted every retention period.
+func (q *Queue) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < q.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.work(ctx)
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		q.evict(ctx)
+	}()
+
+	if recovered := q.jobs.Pending(); len(recovered) > 0 {
+		lm := log.Message{Level: "INFO", Msg: fmt.Sprintf("Resuming %v pending receipt jobs", len(recovered))}
+		q.logger.Log(&lm)
+
+		for _, job := range recovered {
+			if !q.requeue(ctx, job.ID) {
+				break
+			}
+		}
+	}
+
+	<-ctx.Done()
+	wg.Wait()
+}
+
+// requeue queues a recovered job, waiting for room in the queue. It returns false once ctx is done.
+// The lock is only held while the job is sent to a queue with room, so that Enqueue rejects submissions to a full
+// queue instead of waiting for the recovered jobs.
+func (q *Queue) requeue(ctx context.Context, jobID string) bool {
+	ticker := time.NewTicker(requeueInterval)
+	defer ticker.Stop()
+
+	for {
+		if q.offer(jobID) {
+			return true
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// offer sends a job to the queue unless it is full, it returns whether the job was queued.
+func (q *Queue) offer(jobID string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.pending) == cap(q.pending) {
+		return false
+	}
+
+	q.pending <- jobID
+
+	return true
+}
+
+// evict discards the jobs done for longer than the retention until ctx is done.
+func (q *Queue) evict(ctx context.Context) {
+	ticker := time.NewTicker(q.retention)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if evicted := q.jobs.Evict(q.now().UTC().Add(-q.retention)); evicted > 0 {
+				lm := log.Message{Level: "INFO", Msg: fmt.Sprintf("Evicted %v receipt jobs done for longer than %v", evicted, q.retention)}
+				q.logger.Log(&lm)
+			}
+		}
+	}
+}
+
+// work scores queued receipts until ctx is done.
+func (q *Queue) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case jobID := <-q.pending:
+			q.process(ctx, jobID)
+		}
+	}
+}
+
+// process scores the receipt of a job and records the outcome on the job.
+func (q *Queue) process(ctx context.Context, jobID string) {
+	job, err := q.jobs.Get(jobID)
+	if err != nil || job.Status != model.JobPending {
+		return
+	}
+
+	// A receipt already stored under the job ID was scored by a run that stopped before recording it,
+	// scoring it again would credit its points twice.
+	if _, err = q.svc.Get(job.ID); err != nil {
+		receipt := job.Receipt
+		receipt.Id, receipt.ClientID = job.ID, job.ClientID
+
+		_, err = q.svc.Insert(ctx, &receipt)
+	}
+
+	job.Status, job.Receipt, job.UpdatedAt = model.JobProcessed, model.Receipt{}, q.now().UTC()
+	if err != nil {
+		job.Status, job.Error = model.JobFailed, err.Error()
+	}
+
+	if err = q.jobs.Update(job); err != nil {
+		lm := log.Message{Level: "ERROR", ErrorMessage: fmt.Sprintf("Recording the outcome of job %v with error %v", job.ID, err.Error())}
+		q.logger.LogContext(ctx, &lm)
+	}
+
+	metrics.ReceiptJobs.WithLabelValues(job.Status).Inc()
+}
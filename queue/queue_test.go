@@ -0,0 +1,187 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	store "github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+// newReceipt returns a receipt scoring 18 points: 6 for the retailer, 10 for the purchase time and 2 for the item description.
+func newReceipt(userID string) *model.Receipt {
+	return &model.Receipt{
+		UserID:       userID,
+		ClientID:     "partner",
+		Retailer:     model.StringPointer("Target"),
+		PurchaseDate: model.StringPointer("2024-03-02"),
+		PurchaseTime: model.StringPointer("14:33"),
+		Total:        model.StringPointer("6.49"),
+		Items:        []model.Item{{ShortDescription: model.StringPointer("Emils Cheese Pizza"), Price: model.StringPointer("6.49")}},
+	}
+}
+
+// waitFor waits until the job is no longer pending and returns it.
+func waitFor(t *testing.T, q *Queue, jobID string) *model.ReceiptJob {
+	var job *model.ReceiptJob
+	assert.Eventually(t, func() bool {
+		job, _ = q.Get(jobID)
+		return job != nil && job.Status != model.JobPending
+	}, time.Second, 5*time.Millisecond)
+
+	return job
+}
+
+func TestQueue(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	receipts, ledger := store.New(logger), store.NewLedger(logger)
+	svc := service.New(logger, receipts, service.WithLedger(ledger))
+
+	q := New(logger, store.NewJobs(logger), svc, 2, 10, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.Run(ctx)
+
+	invalid := newReceipt("")
+	invalid.Total = nil
+
+	testCases := []struct {
+		id             int
+		useCase        string
+		receipt        *model.Receipt
+		expectedStatus string
+		expectedPoints int
+		expectedError  string
+	}{
+		{id: 1, useCase: "Positive case: receipt scored under the job ID", receipt: newReceipt("user-1"), expectedStatus: model.JobProcessed, expectedPoints: 18},
+		{id: 2, useCase: "Negative case: invalid receipt rejected when queued", receipt: invalid, expectedError: "Parameter total is required for this request"},
+	}
+
+	for _, tc := range testCases {
+		job, err := q.Enqueue(tc.receipt)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			continue
+		}
+
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, model.JobPending, job.Status, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+
+		job = waitFor(t, q, job.ID)
+		assert.Equal(t, tc.expectedStatus, job.Status, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Nil(t, job.Receipt.Retailer, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+
+		points, err := receipts.Get(job.ID)
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedPoints, points.Points, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, "partner", points.ClientID, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+
+	balance, _ := ledger.Balance("user-1")
+	assert.Equal(t, 18, balance)
+}
+
+func TestQueue_Evict(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	svc := service.New(logger, store.New(logger))
+
+	q := New(logger, store.NewJobs(logger), svc, 1, 10, 20*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.Run(ctx)
+
+	job, err := q.Enqueue(newReceipt(""))
+	assert.NoError(t, err)
+	assert.Equal(t, model.JobProcessed, waitFor(t, q, job.ID).Status)
+
+	// The job is evicted once done for longer than the retention, the receipt stays stored.
+	assert.Eventually(t, func() bool {
+		_, err = q.Get(job.ID)
+		return err != nil
+	}, time.Second, 5*time.Millisecond)
+
+	_, err = svc.Get(job.ID)
+	assert.NoError(t, err)
+}
+
+func TestQueue_Full(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	q := New(logger, store.NewJobs(logger), service.New(logger, store.New(logger)), 1, 1, time.Hour)
+
+	// Without workers running the first receipt fills the queue.
+	_, err := q.Enqueue(newReceipt(""))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, q.Depth())
+
+	_, err = q.Enqueue(newReceipt(""))
+	assert.EqualError(t, err, "receipt queue is full")
+}
+
+func TestQueue_Resume(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	receipts, ledger, jobs := store.New(logger), store.NewLedger(logger), store.NewJobs(logger)
+	svc := service.New(logger, receipts, service.WithLedger(ledger))
+
+	// Jobs left pending by a previous run, the second one was scored before the run stopped.
+	now := time.Now().UTC()
+	_ = jobs.Insert(&model.ReceiptJob{ID: "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f", Status: model.JobPending, Receipt: *newReceipt("user-1"), CreatedAt: now})
+	_ = jobs.Insert(&model.ReceiptJob{ID: "5a77ec9d-5334-43d0-a9e1-4fca8807bf8f", Status: model.JobPending, Receipt: *newReceipt("user-1"), CreatedAt: now})
+
+	scored := newReceipt("user-1")
+	scored.Id = "5a77ec9d-5334-43d0-a9e1-4fca8807bf8f"
+	_, err := svc.Insert(context.Background(), scored)
+	assert.NoError(t, err)
+
+	q := New(logger, jobs, svc, 1, 10, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.Run(ctx)
+
+	for _, jobID := range []string{"4a77ec9d-5334-43d0-a9e1-4fca8807bf8f", "5a77ec9d-5334-43d0-a9e1-4fca8807bf8f"} {
+		assert.Equal(t, model.JobProcessed, waitFor(t, q, jobID).Status)
+	}
+
+	// The receipt scored by the previous run is not credited twice.
+	balance, _ := ledger.Balance("user-1")
+	assert.Equal(t, 36, balance)
+}
+
+func TestQueue_ResumeFull(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	jobs := store.NewJobs(logger)
+
+	// More jobs left pending by a previous run than the queue holds, without workers to take them.
+	now := time.Now().UTC()
+	_ = jobs.Insert(&model.ReceiptJob{ID: "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f", Status: model.JobPending, Receipt: *newReceipt(""), CreatedAt: now})
+	_ = jobs.Insert(&model.ReceiptJob{ID: "5a77ec9d-5334-43d0-a9e1-4fca8807bf8f", Status: model.JobPending, Receipt: *newReceipt(""), CreatedAt: now})
+
+	q := New(logger, jobs, service.New(logger, store.New(logger)), 0, 1, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.Run(ctx)
+
+	assert.Eventually(t, func() bool { return q.Depth() == 1 }, time.Second, 5*time.Millisecond)
+
+	// Submissions are rejected while the second recovered job waits for room.
+	done := make(chan error)
+	go func() {
+		_, err := q.Enqueue(newReceipt(""))
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		assert.EqualError(t, err, "receipt queue is full")
+	case <-time.After(time.Second):
+		t.Fatal("Enqueue blocked on the recovered jobs")
+	}
+}
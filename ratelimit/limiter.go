@@ -0,0 +1,148 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRoute is the key of the limit applied to routes without a limit of their own.
+const DefaultRoute = "*"
+
+// maxIdleBuckets bounds the number of buckets kept before buckets that refilled completely are evicted.
+const maxIdleBuckets = 10000
+
+// Limit is the sustained rate in requests per second and the burst of a token bucket.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Decision is the outcome of taking a token from a bucket.
+type Decision struct {
+	Allowed    bool
+	Limit      int           // Burst of the bucket.
+	Remaining  int           // Whole tokens left in the bucket.
+	Reset      time.Duration // Time until the bucket is full again.
+	RetryAfter time.Duration // Time until the next token is available, zero when allowed.
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter is a set of token buckets keyed by caller, each route having its own limit. It is safe for concurrent use.
+type Limiter struct {
+	mu      sync.Mutex
+	limits  map[string]Limit
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+// NewLimiter creates and returns a Limiter enforcing limits by route template, see ParseLimits.
+func NewLimiter(limits map[string]Limit) *Limiter {
+	return &Limiter{
+		limits:  limits,
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token from the bucket of caller on route. Routes without a limit, and no default limit, are not limited.
+func (l *Limiter) Allow(route, caller string) (Decision, bool) {
+	limit, ok := l.limits[route]
+	if !ok {
+		if limit, ok = l.limits[DefaultRoute]; !ok {
+			return Decision{}, false
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if len(l.buckets) > maxIdleBuckets {
+		l.evict(now)
+	}
+
+	key := route + " " + caller
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), last: now}
+		l.buckets[key] = b
+	}
+
+	// Refill the tokens earned since the last request, up to the burst.
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.last).Seconds()*limit.Rate)
+	b.last = now
+
+	decision := Decision{Limit: limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = seconds((1 - b.tokens) / limit.Rate)
+	}
+
+	decision.Remaining = int(b.tokens)
+	decision.Reset = seconds((float64(limit.Burst) - b.tokens) / limit.Rate)
+
+	return decision, true
+}
+
+// evict drops the buckets that would have refilled completely by now, they are recreated full on demand.
+func (l *Limiter) evict(now time.Time) {
+	for key, b := range l.buckets {
+		route := key[:strings.Index(key, " ")]
+
+		limit, ok := l.limits[route]
+		if !ok {
+			limit = l.limits[DefaultRoute]
+		}
+
+		if b.tokens+now.Sub(b.last).Seconds()*limit.Rate >= float64(limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// ParseLimits parses a comma separated list of route=rate:burst entries, e.g. "*=50:100,/v1/receipts/process=5:10".
+// The route is a route template, "*" sets the limit of every route without an entry of its own.
+func ParseLimits(value string) (map[string]Limit, error) {
+	limits := make(map[string]Limit)
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, spec, ok := strings.Cut(entry, "=")
+		rate, burst, ok2 := strings.Cut(spec, ":")
+		if !ok || !ok2 || route == "" {
+			return nil, fmt.Errorf("rate limit entry %q must have the format route=rate:burst", entry)
+		}
+
+		r, err := strconv.ParseFloat(rate, 64)
+		if err != nil || r <= 0 {
+			return nil, fmt.Errorf("rate limit entry %q must have a positive rate", entry)
+		}
+
+		b, err := strconv.Atoi(burst)
+		if err != nil || b < 1 {
+			return nil, fmt.Errorf("rate limit entry %q must have a burst of at least 1", entry)
+		}
+
+		limits[route] = Limit{Rate: r, Burst: b}
+	}
+
+	return limits, nil
+}
@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	limiter := NewLimiter(map[string]Limit{DefaultRoute: {Rate: 10, Burst: 2}, "/v1/receipts/process": {Rate: 1, Burst: 1}})
+	limiter.now = func() time.Time { return now }
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		advance          time.Duration
+		route            string
+		caller           string
+		expectedDecision Decision
+	}{
+		{
+			id: 1, useCase: "Positive case: full bucket",
+			route: "/v1/receipts/process", caller: "client:partner-a",
+			expectedDecision: Decision{Allowed: true, Limit: 1, Remaining: 0, Reset: time.Second},
+		},
+		{
+			id: 2, useCase: "Negative case: bucket empty",
+			route: "/v1/receipts/process", caller: "client:partner-a",
+			expectedDecision: Decision{Allowed: false, Limit: 1, Remaining: 0, Reset: time.Second, RetryAfter: time.Second},
+		},
+		{
+			id: 3, useCase: "Positive case: buckets are kept per caller",
+			route: "/v1/receipts/process", caller: "client:partner-b",
+			expectedDecision: Decision{Allowed: true, Limit: 1, Remaining: 0, Reset: time.Second},
+		},
+		{
+			id: 4, useCase: "Positive case: route without a limit uses the default limit",
+			route: "/v1/receipts/{id}/points", caller: "client:partner-a",
+			expectedDecision: Decision{Allowed: true, Limit: 2, Remaining: 1, Reset: 100 * time.Millisecond},
+		},
+		{
+			id: 5, useCase: "Positive case: bucket refilled after a second",
+			advance: time.Second, route: "/v1/receipts/process", caller: "client:partner-a",
+			expectedDecision: Decision{Allowed: true, Limit: 1, Remaining: 0, Reset: time.Second},
+		},
+	}
+
+	for _, tc := range testCases {
+		now = now.Add(tc.advance)
+
+		decision, limited := limiter.Allow(tc.route, tc.caller)
+
+		assert.True(t, limited, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedDecision, decision, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+
+	_, limited := NewLimiter(map[string]Limit{}).Allow("/v1/receipts/process", "client:partner-a")
+	assert.False(t, limited)
+}
+
+func TestParseLimits(t *testing.T) {
+	testCases := []struct {
+		id             int
+		useCase        string
+		value          string
+		expectedLimits map[string]Limit
+		expectedError  string
+	}{
+		{
+			id: 1, useCase: "Positive case: default and route limits",
+			value:          "*=50:100, /v1/receipts/process=0.5:10",
+			expectedLimits: map[string]Limit{"*": {Rate: 50, Burst: 100}, "/v1/receipts/process": {Rate: 0.5, Burst: 10}},
+		},
+		{
+			id: 2, useCase: "Positive case: empty value",
+			expectedLimits: map[string]Limit{},
+		},
+		{
+			id: 3, useCase: "Negative case: missing burst",
+			value:         "*=50",
+			expectedError: "rate limit entry \"*=50\" must have the format route=rate:burst",
+		},
+		{
+			id: 4, useCase: "Negative case: zero rate",
+			value:         "*=0:10",
+			expectedError: "rate limit entry \"*=0:10\" must have a positive rate",
+		},
+		{
+			id: 5, useCase: "Negative case: zero burst",
+			value:         "*=1:0",
+			expectedError: "rate limit entry \"*=1:0\" must have a burst of at least 1",
+		},
+	}
+
+	for _, tc := range testCases {
+		limits, err := ParseLimits(tc.value)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			continue
+		}
+
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedLimits, limits, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
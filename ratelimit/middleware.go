@@ -0,0 +1,260 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/responder"
+)
+
+// PreAuthRoute is the key of the limit every caller IP address is held to before authentication, so that
+// unauthenticated requests and attempts with invalid credentials are limited too. Without a limit of its own the
+// default limit applies.
+const PreAuthRoute = "preauth"
+
+// Middleware enforces per route rate limits and daily quotas for each caller.
+// Rate limits are counted by principal and daily quotas by client, see Key and QuotaKey, or by IP address when the
+// request is not authenticated.
+type Middleware struct {
+	logger     *log.CustomLogger
+	limiter    *Limiter
+	quotas     data.Quotas
+	dailyQuota int
+	now        func() time.Time
+}
+
+// New creates and returns a new instance of Middleware. A nil limiter disables rate limiting and a dailyQuota of 0 disables quotas.
+func New(l *log.CustomLogger, limiter *Limiter, quotas data.Quotas, dailyQuota int) *Middleware {
+	return &Middleware{
+		logger:     l,
+		limiter:    limiter,
+		quotas:     quotas,
+		dailyQuota: dailyQuota,
+		now:        time.Now,
+	}
+}
+
+// Limit wraps next with the token bucket of the route, answering 429 once the caller exhausted it.
+// Allowed requests carry RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers.
+func (m *Middleware) Limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		decision, limited, err := m.allow(routeTemplate(r), caller(r))
+		if limited {
+			setRateLimitHeaders(w, decision.Limit, decision.Remaining, decision.Reset)
+		}
+
+		if err != nil {
+			responder.SetErrorResponse(m.logger, err, w, r)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// LimitAddr wraps next with the token bucket of the IP address of the caller, answering 429 once the caller exhausted
+// it. It runs in front of authentication.
+func (m *Middleware) LimitAddr(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := m.AllowAddr(r.RemoteAddr); err != nil {
+			responder.SetErrorResponse(m.logger, err, w, r)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Quota wraps next with the daily quota of the caller, answering 429 until the next UTC day once the caller used it up.
+// Only successful submissions count, the charge of a request answered with an error status is refunded.
+func (m *Middleware) Quota(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if m.dailyQuota <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		used, reset, refund, err := m.charge(quotaCaller(r))
+
+		w.Header().Set("X-Quota-Limit", strconv.Itoa(m.dailyQuota))
+		w.Header().Set("X-Quota-Remaining", strconv.Itoa(m.dailyQuota-used))
+		w.Header().Set("X-Quota-Reset", strconv.Itoa(ceilSeconds(reset)))
+
+		if err != nil {
+			responder.SetErrorResponse(m.logger, err, w, r)
+
+			return
+		}
+
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+
+		if sw.status >= http.StatusBadRequest {
+			refund()
+		}
+	})
+}
+
+// chargeKey is the context key of the function charging the daily quota of the caller, see Deferred.
+type chargeKey struct{}
+
+// Deferred wraps next so that it charges the daily quota of the caller itself with ChargeContext, on routes like
+// GraphQL where only some requests submit receipts.
+func (m *Middleware) Deferred(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := quotaCaller(r)
+		charge := func() (func(), error) { return m.Charge(key) }
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), chargeKey{}, charge)))
+	})
+}
+
+// ChargeContext counts a submission against the daily quota of the caller of ctx set up by Deferred, it returns an
+// errors.TooManyRequests once the caller used it up. The returned refund gives the charge back when the submission
+// fails. Nothing is charged when ctx was not set up by Deferred.
+func ChargeContext(ctx context.Context) (refund func(), err error) {
+	charge, ok := ctx.Value(chargeKey{}).(func() (func(), error))
+	if !ok {
+		return func() {}, nil
+	}
+
+	return charge()
+}
+
+// Allow takes a token from the bucket of the caller key on route, it returns an errors.TooManyRequests once the caller
+// exhausted it. It enforces the limits of Limit on transports other than HTTP, like gRPC.
+func (m *Middleware) Allow(route, key string) error {
+	_, _, err := m.allow(route, key)
+
+	return err
+}
+
+// AllowAddr takes a token from the bucket of the IP address of remoteAddr on PreAuthRoute, it returns an
+// errors.TooManyRequests once the caller exhausted it. It enforces the limits of LimitAddr on transports other than
+// HTTP, like gRPC.
+func (m *Middleware) AllowAddr(remoteAddr string) error {
+	return m.Allow(PreAuthRoute, Key(nil, remoteAddr))
+}
+
+// Charge counts a submission of the caller key, see QuotaKey, against its daily quota, it returns an errors.TooManyRequests once the
+// caller used it up. The returned refund gives the charge back when the submission fails. It enforces the quota of
+// Quota on transports other than HTTP, like gRPC.
+func (m *Middleware) Charge(key string) (refund func(), err error) {
+	if m.dailyQuota <= 0 {
+		return func() {}, nil
+	}
+
+	_, _, refund, err = m.charge(key)
+
+	return refund, err
+}
+
+// allow takes a token from the bucket of key on route, it reports whether the route is limited at all.
+func (m *Middleware) allow(route, key string) (Decision, bool, error) {
+	if m.limiter == nil {
+		return Decision{}, false, nil
+	}
+
+	decision, limited := m.limiter.Allow(route, key)
+	if !limited || decision.Allowed {
+		return decision, limited, nil
+	}
+
+	retryAfter := ceilSeconds(decision.RetryAfter)
+
+	return decision, true, errors.NewTooManyRequests(fmt.Errorf("Rate limit exceeded, retry after %v seconds", retryAfter), retryAfter)
+}
+
+// charge counts a submission of key against the daily quota, it returns the submissions used, the time until the
+// quota resets at the next UTC day and a function refunding the submission to the day it was counted on.
+func (m *Middleware) charge(key string) (int, time.Duration, func(), error) {
+	now := m.now().UTC()
+	day := now.Format("2006-01-02")
+	reset := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC).Sub(now)
+
+	used, ok := m.quotas.Increment(key, day, m.dailyQuota)
+	if !ok {
+		return used, reset, func() {}, errors.NewTooManyRequests(fmt.Errorf("Daily quota of %v submissions exceeded", m.dailyQuota), ceilSeconds(reset))
+	}
+
+	return used, reset, func() { m.quotas.Refund(key, day) }, nil
+}
+
+// Key returns the key the limits of a caller are counted against, shared by every transport. Authenticated callers are
+// counted by principal, every user of a client having their own limits, others by the host of their remote address.
+func Key(p *model.Principal, remoteAddr string) string {
+	if p != nil {
+		return p.Key()
+	}
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+
+	return "ip:" + host
+}
+
+// QuotaKey returns the key the daily quota of a caller is counted against, shared by every transport. Authenticated
+// callers are counted by client, the users of a client sharing its quota, others by the host of their remote address.
+func QuotaKey(p *model.Principal, remoteAddr string) string {
+	if p != nil {
+		return "client:" + p.ClientID
+	}
+
+	return Key(nil, remoteAddr)
+}
+
+// caller returns the key the limits of the request are counted against.
+func caller(r *http.Request) string {
+	return Key(auth.FromContext(r.Context()), r.RemoteAddr)
+}
+
+// quotaCaller returns the key the daily quota of the request is counted against.
+func quotaCaller(r *http.Request) string {
+	return QuotaKey(auth.FromContext(r.Context()), r.RemoteAddr)
+}
+
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+
+	return r.URL.Path
+}
+
+func setRateLimitHeaders(w http.ResponseWriter, limit, remaining int, reset time.Duration) {
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(limit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(remaining))
+	w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(reset)))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// statusWriter records the status code written to a response.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sw *statusWriter) WriteHeader(status int) {
+	sw.status = status
+	sw.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,204 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestMiddleware(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	now := time.Date(2024, 3, 1, 23, 59, 0, 0, time.UTC)
+
+	limiter := NewLimiter(map[string]Limit{"/v1/receipts/{id}/points": {Rate: 1, Burst: 1}})
+	limiter.now = func() time.Time { return now }
+
+	m := New(logger, limiter, data.NewQuotas(logger), 1)
+	m.now = func() time.Time { return now }
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	invalid := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusBadRequest) })
+
+	router := mux.NewRouter()
+	router.Handle("/v1/receipts/{id}/points", m.Limit(ok)).Methods("GET")
+	router.Handle("/v1/receipts/process", m.Limit(m.Quota(ok))).Methods("POST")
+	router.Handle("/v1/receipts/invalid", m.Limit(m.Quota(invalid))).Methods("POST")
+
+	testCases := []struct {
+		id              int
+		useCase         string
+		method          string
+		path            string
+		clientID        string
+		userID          string
+		remoteAddr      string
+		expectedStatus  int
+		expectedHeaders map[string]string
+	}{
+		{
+			id: 1, useCase: "Positive case: within the rate limit",
+			method: "GET", path: "/v1/receipts/1/points", clientID: "partner-a",
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{"RateLimit-Limit": "1", "RateLimit-Remaining": "0", "RateLimit-Reset": "1"},
+		},
+		{
+			id: 2, useCase: "Negative case: rate limit exceeded on another receipt of the same route",
+			method: "GET", path: "/v1/receipts/2/points", clientID: "partner-a",
+			expectedStatus:  http.StatusTooManyRequests,
+			expectedHeaders: map[string]string{"Retry-After": "1", "RateLimit-Remaining": "0"},
+		},
+		{
+			id: 3, useCase: "Positive case: unauthenticated callers are limited by IP",
+			method: "GET", path: "/v1/receipts/1/points", remoteAddr: "10.0.0.1:5123",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			id: 4, useCase: "Negative case: same IP from another port",
+			method: "GET", path: "/v1/receipts/1/points", remoteAddr: "10.0.0.1:6234",
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			id: 5, useCase: "Positive case: route without a limit within the daily quota",
+			method: "POST", path: "/v1/receipts/process", clientID: "partner-a",
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{"X-Quota-Limit": "1", "X-Quota-Remaining": "0", "X-Quota-Reset": "60"},
+		},
+		{
+			id: 6, useCase: "Negative case: daily quota used up, retry at UTC midnight",
+			method: "POST", path: "/v1/receipts/process", clientID: "partner-a",
+			expectedStatus:  http.StatusTooManyRequests,
+			expectedHeaders: map[string]string{"Retry-After": "60"},
+		},
+		{
+			id: 7, useCase: "Negative case: invalid submission is refunded to the daily quota",
+			method: "POST", path: "/v1/receipts/invalid", clientID: "partner-b",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			id: 8, useCase: "Positive case: quota left by a refunded submission",
+			method: "POST", path: "/v1/receipts/process", clientID: "partner-b",
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{"X-Quota-Remaining": "0"},
+		},
+		{
+			id: 9, useCase: "Positive case: user of a client within its daily quota",
+			method: "POST", path: "/v1/receipts/process", clientID: "partner-c", userID: "user-1",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			id: 10, useCase: "Negative case: users of a client share its daily quota",
+			method: "POST", path: "/v1/receipts/process", clientID: "partner-c", userID: "user-2",
+			expectedStatus: http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tc := range testCases {
+		r := httptest.NewRequest(tc.method, tc.path, nil)
+		if tc.remoteAddr != "" {
+			r.RemoteAddr = tc.remoteAddr
+		}
+
+		if tc.clientID != "" {
+			r = r.WithContext(auth.NewContext(r.Context(), &model.Principal{ClientID: tc.clientID, UserID: tc.userID}))
+		}
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		assert.Equal(t, tc.expectedStatus, w.Code, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		for k, v := range tc.expectedHeaders {
+			assert.Equal(t, v, w.Header().Get(k), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+	}
+}
+
+func TestMiddleware_Deferred(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	m := New(logger, nil, data.NewQuotas(logger), 1)
+
+	// Charges the quota like a GraphQL mutation, refunding the submissions asking to fail.
+	submit := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		refund, err := ChargeContext(r.Context())
+		if err != nil {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		if r.URL.Query().Get("fail") != "" {
+			refund()
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	testCases := []struct {
+		id             int
+		useCase        string
+		path           string
+		expectedStatus int
+	}{
+		{id: 1, useCase: "Negative case: failed submission is refunded", path: "/graphql?fail=1", expectedStatus: http.StatusBadRequest},
+		{id: 2, useCase: "Positive case: submission within the daily quota", path: "/graphql", expectedStatus: http.StatusOK},
+		{id: 3, useCase: "Negative case: daily quota used up", path: "/graphql", expectedStatus: http.StatusTooManyRequests},
+	}
+
+	for _, tc := range testCases {
+		r := httptest.NewRequest("POST", tc.path, nil)
+		r = r.WithContext(auth.NewContext(r.Context(), &model.Principal{ClientID: "partner-a"}))
+
+		w := httptest.NewRecorder()
+		m.Deferred(submit).ServeHTTP(w, r)
+
+		assert.Equal(t, tc.expectedStatus, w.Code, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+
+	refund, err := ChargeContext(context.Background())
+	assert.NoError(t, err)
+	refund()
+}
+
+func TestMiddleware_LimitAddr(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	now := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	limiter := NewLimiter(map[string]Limit{PreAuthRoute: {Rate: 1, Burst: 1}})
+	limiter.now = func() time.Time { return now }
+
+	m := New(logger, limiter, data.NewQuotas(logger), 0)
+
+	// Rejects every request like invalid credentials would.
+	unauthorized := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusUnauthorized) })
+
+	testCases := []struct {
+		id             int
+		useCase        string
+		remoteAddr     string
+		expectedStatus int
+	}{
+		{id: 1, useCase: "Positive case: first attempt of the IP address", remoteAddr: "10.0.0.1:5123", expectedStatus: http.StatusUnauthorized},
+		{id: 2, useCase: "Negative case: attempts beyond the limit of the IP address", remoteAddr: "10.0.0.1:6234", expectedStatus: http.StatusTooManyRequests},
+		{id: 3, useCase: "Positive case: IP addresses are limited separately", remoteAddr: "10.0.0.2:5123", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		r := httptest.NewRequest("GET", "/v1/receipts/1/points", nil)
+		r.RemoteAddr = tc.remoteAddr
+
+		w := httptest.NewRecorder()
+		m.LimitAddr(unauthorized).ServeHTTP(w, r)
+
+		assert.Equal(t, tc.expectedStatus, w.Code, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
@@ -0,0 +1,243 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: receipts.proto
+
+// Receipts scores receipts and serves their points, backed by the same service layer as the REST endpoints.
+// Credentials are sent in the authorization (bearer token) or x-api-key metadata, as the REST headers.
+
+package receiptspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Receipts_ProcessReceipt_FullMethodName = "/receipts.v1.Receipts/ProcessReceipt"
+	Receipts_GetPoints_FullMethodName      = "/receipts.v1.Receipts/GetPoints"
+	Receipts_GetReceipt_FullMethodName     = "/receipts.v1.Receipts/GetReceipt"
+	Receipts_BatchProcess_FullMethodName   = "/receipts.v1.Receipts/BatchProcess"
+)
+
+// ReceiptsClient is the client API for Receipts service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReceiptsClient interface {
+	// ProcessReceipt scores a receipt and returns its ID, as POST /v1/receipts/process.
+	ProcessReceipt(ctx context.Context, in *ProcessReceiptRequest, opts ...grpc.CallOption) (*ProcessReceiptResponse, error)
+	// GetPoints returns the points of a receipt, as GET /v1/receipts/{id}/points.
+	GetPoints(ctx context.Context, in *GetPointsRequest, opts ...grpc.CallOption) (*GetPointsResponse, error)
+	// GetReceipt returns the scoring outcome of a receipt: its points, canonical retailer, campaigns and cap.
+	GetReceipt(ctx context.Context, in *GetReceiptRequest, opts ...grpc.CallOption) (*GetReceiptResponse, error)
+	// BatchProcess scores every receipt of the request stream, responding with the outcome of each in order.
+	// A receipt failing validation does not end the stream.
+	BatchProcess(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ProcessReceiptRequest, BatchProcessResponse], error)
+}
+
+type receiptsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReceiptsClient(cc grpc.ClientConnInterface) ReceiptsClient {
+	return &receiptsClient{cc}
+}
+
+func (c *receiptsClient) ProcessReceipt(ctx context.Context, in *ProcessReceiptRequest, opts ...grpc.CallOption) (*ProcessReceiptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProcessReceiptResponse)
+	err := c.cc.Invoke(ctx, Receipts_ProcessReceipt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *receiptsClient) GetPoints(ctx context.Context, in *GetPointsRequest, opts ...grpc.CallOption) (*GetPointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPointsResponse)
+	err := c.cc.Invoke(ctx, Receipts_GetPoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *receiptsClient) GetReceipt(ctx context.Context, in *GetReceiptRequest, opts ...grpc.CallOption) (*GetReceiptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReceiptResponse)
+	err := c.cc.Invoke(ctx, Receipts_GetReceipt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *receiptsClient) BatchProcess(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ProcessReceiptRequest, BatchProcessResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Receipts_ServiceDesc.Streams[0], Receipts_BatchProcess_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ProcessReceiptRequest, BatchProcessResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Receipts_BatchProcessClient = grpc.BidiStreamingClient[ProcessReceiptRequest, BatchProcessResponse]
+
+// ReceiptsServer is the server API for Receipts service.
+// All implementations must embed UnimplementedReceiptsServer
+// for forward compatibility.
+type ReceiptsServer interface {
+	// ProcessReceipt scores a receipt and returns its ID, as POST /v1/receipts/process.
+	ProcessReceipt(context.Context, *ProcessReceiptRequest) (*ProcessReceiptResponse, error)
+	// GetPoints returns the points of a receipt, as GET /v1/receipts/{id}/points.
+	GetPoints(context.Context, *GetPointsRequest) (*GetPointsResponse, error)
+	// GetReceipt returns the scoring outcome of a receipt: its points, canonical retailer, campaigns and cap.
+	GetReceipt(context.Context, *GetReceiptRequest) (*GetReceiptResponse, error)
+	// BatchProcess scores every receipt of the request stream, responding with the outcome of each in order.
+	// A receipt failing validation does not end the stream.
+	BatchProcess(grpc.BidiStreamingServer[ProcessReceiptRequest, BatchProcessResponse]) error
+	mustEmbedUnimplementedReceiptsServer()
+}
+
+// UnimplementedReceiptsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReceiptsServer struct{}
+
+func (UnimplementedReceiptsServer) ProcessReceipt(context.Context, *ProcessReceiptRequest) (*ProcessReceiptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessReceipt not implemented")
+}
+func (UnimplementedReceiptsServer) GetPoints(context.Context, *GetPointsRequest) (*GetPointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPoints not implemented")
+}
+func (UnimplementedReceiptsServer) GetReceipt(context.Context, *GetReceiptRequest) (*GetReceiptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReceipt not implemented")
+}
+func (UnimplementedReceiptsServer) BatchProcess(grpc.BidiStreamingServer[ProcessReceiptRequest, BatchProcessResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method BatchProcess not implemented")
+}
+func (UnimplementedReceiptsServer) mustEmbedUnimplementedReceiptsServer() {}
+func (UnimplementedReceiptsServer) testEmbeddedByValue()                  {}
+
+// UnsafeReceiptsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReceiptsServer will
+// result in compilation errors.
+type UnsafeReceiptsServer interface {
+	mustEmbedUnimplementedReceiptsServer()
+}
+
+func RegisterReceiptsServer(s grpc.ServiceRegistrar, srv ReceiptsServer) {
+	// If the following call pancis, it indicates UnimplementedReceiptsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Receipts_ServiceDesc, srv)
+}
+
+func _Receipts_ProcessReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessReceiptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReceiptsServer).ProcessReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Receipts_ProcessReceipt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReceiptsServer).ProcessReceipt(ctx, req.(*ProcessReceiptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Receipts_GetPoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReceiptsServer).GetPoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Receipts_GetPoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReceiptsServer).GetPoints(ctx, req.(*GetPointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Receipts_GetReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReceiptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReceiptsServer).GetReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Receipts_GetReceipt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReceiptsServer).GetReceipt(ctx, req.(*GetReceiptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Receipts_BatchProcess_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ReceiptsServer).BatchProcess(&grpc.GenericServerStream[ProcessReceiptRequest, BatchProcessResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Receipts_BatchProcessServer = grpc.BidiStreamingServer[ProcessReceiptRequest, BatchProcessResponse]
+
+// Receipts_ServiceDesc is the grpc.ServiceDesc for Receipts service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Receipts_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "receipts.v1.Receipts",
+	HandlerType: (*ReceiptsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ProcessReceipt",
+			Handler:    _Receipts_ProcessReceipt_Handler,
+		},
+		{
+			MethodName: "GetPoints",
+			Handler:    _Receipts_GetPoints_Handler,
+		},
+		{
+			MethodName: "GetReceipt",
+			Handler:    _Receipts_GetReceipt_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BatchProcess",
+			Handler:       _Receipts_BatchProcess_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "receipts.proto",
+}
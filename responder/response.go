@@ -2,63 +2,81 @@ package responder
 
 import (
 	"encoding/json"
+	er "errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
 	"github/shivasaicharanruthala/backend-engineer-takehome/log"
 )
 
-// SetErrorResponse sends an error response with the appropriate status code and error message.
-// It accepts an error and an http.ResponseWriter as parameters and handles different types of custom errors.
+// ProblemTypePrefix prefixes the code of an error to build the type URI of its problem details.
+const ProblemTypePrefix = "urn:receipts:problem:"
+
+// Problem is the body of error responses, the problem details of RFC 7807 extended with the code and request id of the error.
+type Problem struct {
+	Type      string    `json:"type"`
+	Title     string    `json:"title"`
+	Status    int       `json:"status"`
+	Detail    string    `json:"detail"`
+	Instance  string    `json:"instance"`
+	Code      string    `json:"code"`
+	RequestID string    `json:"requestId,omitempty"`
+	TimeStamp time.Time `json:"timestamp"`
+}
+
+// NewProblem returns the problem details of err for the request at instance. Errors wrapping no errors.Error are
+// internal errors. The message of server errors is never exposed to clients, it may disclose internals like file
+// paths, SetErrorResponse logs it instead.
+func NewProblem(err error, instance, requestID string) Problem {
+	status, code, detail := http.StatusInternalServerError, errors.CodeInternal, ""
+
+	var e errors.Error
+	if er.As(err, &e) {
+		status, code, detail = e.Status(), e.ErrorCode(), e.Error()
+	}
+
+	if status >= http.StatusInternalServerError {
+		detail = http.StatusText(status)
+	}
+
+	return Problem{
+		Type:      ProblemTypePrefix + code,
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  instance,
+		Code:      code,
+		RequestID: requestID,
+		TimeStamp: time.Now().UTC(),
+	}
+}
+
+// SetErrorResponse sends the problem details of err as an application/problem+json response with the status of the error.
+// The request id of the request is included in the error body and in the logged message.
 func SetErrorResponse(logger *log.CustomLogger, err error, w http.ResponseWriter, r *http.Request) {
-	switch val := err.(type) {
-	case errors.InvalidParam:
-		errJson, _ := json.Marshal(val)
-
-		lm := log.Message{Level: "ERROR", Method: r.Method, URI: r.RequestURI, StatusCode: val.StatusCode, ErrorMessage: val.Error()}
-		logger.Log(&lm)
-
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(val.StatusCode)
-		_, _ = w.Write(errJson)
-	case errors.MissingParam:
-		errJson, _ := json.Marshal(val)
-
-		lm := log.Message{Level: "ERROR", Method: r.Method, URI: r.RequestURI, StatusCode: val.StatusCode, ErrorMessage: val.Error()}
-		logger.Log(&lm)
-
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(val.StatusCode)
-		_, _ = w.Write(errJson)
-	case errors.EntityNotFound:
-		errJson, _ := json.Marshal(val)
-
-		lm := log.Message{Level: "ERROR", Method: r.Method, URI: r.RequestURI, StatusCode: val.StatusCode, ErrorMessage: val.Error()}
-		logger.Log(&lm)
-
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(val.StatusCode)
-		_, _ = w.Write(errJson)
-	case errors.CustomError:
-		errJson, _ := json.Marshal(val)
-
-		lm := log.Message{Level: "ERROR", Method: r.Method, URI: r.RequestURI, StatusCode: val.StatusCode, ErrorMessage: val.Error()}
-		logger.Log(&lm)
-
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(val.StatusCode)
-		_, _ = w.Write(errJson)
-	default:
-		errJson, _ := json.Marshal(val)
-
-		lm := log.Message{Level: "ERROR", Method: r.Method, URI: r.RequestURI, StatusCode: 500, ErrorMessage: val.Error()}
-		logger.Log(&lm)
-
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(500)
-		_, _ = w.Write(errJson)
+	problem := NewProblem(err, r.URL.Path, log.FromContext(r.Context()).RequestID)
+
+	lm := log.Message{Level: "ERROR", Method: r.Method, URI: r.RequestURI, StatusCode: problem.Status, ErrorMessage: err.Error()}
+	logger.LogContext(r.Context(), &lm)
+
+	var unauthorized errors.Unauthorized
+	if er.As(err, &unauthorized) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="receipts", ApiKey realm="receipts"`)
 	}
+
+	var tooManyRequests errors.TooManyRequests
+	if er.As(err, &tooManyRequests) {
+		w.Header().Set("Retry-After", strconv.Itoa(tooManyRequests.RetryAfter))
+	}
+
+	problemJson, _ := json.Marshal(problem)
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	_, _ = w.Write(problemJson)
 }
 
 // SetResponse sends a successful response with the specified status code and response body.
@@ -0,0 +1,125 @@
+package responder
+
+import (
+	"encoding/json"
+	er "errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+)
+
+func TestSetErrorResponse(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+
+	testCases := []struct {
+		id              int
+		useCase         string
+		err             error
+		expectedProblem Problem
+		expectedHeaders map[string]string
+	}{
+		{
+			id: 1, useCase: "Missing field of a receipt keeps its resource specific code",
+			err: errors.NewMissingParam(errors.MissingParam{Param: "retailer", Code: errors.CodeReceiptMissingField}),
+			expectedProblem: Problem{
+				Type: "urn:receipts:problem:receipt.missing_field", Title: "Bad Request", Status: 400,
+				Detail: "Parameter retailer is required for this request", Code: "receipt.missing_field",
+			},
+		},
+		{
+			id: 2, useCase: "Invalid parameter without a specific code",
+			err: errors.NewInvalidParam(errors.InvalidParam{Param: "id"}),
+			expectedProblem: Problem{
+				Type: "urn:receipts:problem:request.invalid_field", Title: "Bad Request", Status: 400,
+				Detail: "Incorrect value for parameter: id", Code: "request.invalid_field",
+			},
+		},
+		{
+			id: 3, useCase: "Entity not found built without its constructor",
+			err: errors.EntityNotFound{Entity: "receipts", ID: "1234"},
+			expectedProblem: Problem{
+				Type: "urn:receipts:problem:resource.not_found", Title: "Not Found", Status: 404,
+				Detail: "No 'receipts' found for Id: '1234'", Code: "resource.not_found",
+			},
+		},
+		{
+			id: 4, useCase: "Custom error with its code derived from its status, without its message",
+			err: errors.NewCustomError(er.New("receipt queue is full"), 503),
+			expectedProblem: Problem{
+				Type: "urn:receipts:problem:server.unavailable", Title: "Service Unavailable", Status: 503,
+				Detail: "Service Unavailable", Code: "server.unavailable",
+			},
+		},
+		{
+			id: 5, useCase: "Unauthorized requests are challenged",
+			err: errors.NewUnauthorized(errors.Unauthorized{Reason: "missing credentials"}),
+			expectedProblem: Problem{
+				Type: "urn:receipts:problem:auth.unauthenticated", Title: "Unauthorized", Status: 401,
+				Detail: "Authentication required: missing credentials", Code: "auth.unauthenticated",
+			},
+			expectedHeaders: map[string]string{"WWW-Authenticate": `Bearer realm="receipts", ApiKey realm="receipts"`},
+		},
+		{
+			id: 6, useCase: "Rate limited requests are told when to retry",
+			err: errors.NewTooManyRequests(errors.TooManyRequests{RetryAfter: 3}, 3),
+			expectedProblem: Problem{
+				Type: "urn:receipts:problem:rate_limit.exceeded", Title: "Too Many Requests", Status: 429,
+				Detail: "Too many requests, retry after 3 seconds", Code: "rate_limit.exceeded",
+			},
+			expectedHeaders: map[string]string{"Retry-After": "3"},
+		},
+		{
+			id: 7, useCase: "Unknown errors are internal errors without their message",
+			err: er.New("connection reset by peer"),
+			expectedProblem: Problem{
+				Type: "urn:receipts:problem:server.internal", Title: "Internal Server Error", Status: 500,
+				Detail: "Internal Server Error", Code: "server.internal",
+			},
+		},
+		{
+			id: 8, useCase: "Server errors do not disclose their cause",
+			err: errors.NewCustomError(er.New("open /var/lib/receipts/jobs.json: permission denied")),
+			expectedProblem: Problem{
+				Type: "urn:receipts:problem:server.internal", Title: "Internal Server Error", Status: 500,
+				Detail: "Internal Server Error", Code: "server.internal",
+			},
+		},
+		{
+			id: 9, useCase: "Wrapped errors keep their status and headers",
+			err: fmt.Errorf("submitting receipt: %w", errors.NewTooManyRequests(errors.TooManyRequests{RetryAfter: 5}, 5)),
+			expectedProblem: Problem{
+				Type: "urn:receipts:problem:rate_limit.exceeded", Title: "Too Many Requests", Status: 429,
+				Detail: "Too many requests, retry after 5 seconds", Code: "rate_limit.exceeded",
+			},
+			expectedHeaders: map[string]string{"Retry-After": "5"},
+		},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/v1/receipts/1234/points?verbose=true", nil)
+		r = r.WithContext(log.NewContext(r.Context(), log.Trace{RequestID: "request-1"}))
+
+		SetErrorResponse(logger, tc.err, w, r)
+
+		var problem Problem
+		err := json.NewDecoder(w.Result().Body).Decode(&problem)
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+
+		assert.False(t, problem.TimeStamp.IsZero(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		problem.TimeStamp = tc.expectedProblem.TimeStamp
+
+		tc.expectedProblem.Instance, tc.expectedProblem.RequestID = "/v1/receipts/1234/points", "request-1"
+		assert.Equal(t, tc.expectedProblem, problem, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedProblem.Status, w.Result().StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+
+		for header, value := range tc.expectedHeaders {
+			assert.Equal(t, value, w.Header().Get(header), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/config"
+	"github/shivasaicharanruthala/backend-engineer-takehome/graphql"
+	"github/shivasaicharanruthala/backend-engineer-takehome/handler"
+	"github/shivasaicharanruthala/backend-engineer-takehome/health"
+	"github/shivasaicharanruthala/backend-engineer-takehome/idempotency"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/metrics"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/openapi"
+	"github/shivasaicharanruthala/backend-engineer-takehome/ratelimit"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+)
+
+// Dependencies are the services and middlewares behind the routes of the API.
+type Dependencies struct {
+	Receipts        service.Receipts
+	ReceiptsOptions []handler.Option // Options of the receipts handler, like asynchronous processing.
+	Users           service.Users
+	Rewards         service.Rewards
+	Returns         service.Returns
+	Campaigns       service.Campaigns
+	Retailers       service.Retailers
+	Reviews         service.Reviews
+	Webhooks        service.Webhooks
+	Stream          service.Stream
+	Checker         *health.Checker
+	Authenticator   *auth.Authenticator
+	Limits          *ratelimit.Middleware
+	Idempotency     *idempotency.Middleware
+	Spec            *openapi.Document // Served as is, every route registered here must be documented in it.
+}
+
+// New creates the router of the HTTP API, the routes are documented by the OpenAPI document of deps.
+func New(logger *log.CustomLogger, cfg *config.Config, deps Dependencies) (*mux.Router, error) {
+	// Handler Layer
+	receiptsHandler := handler.New(logger, deps.Receipts, deps.ReceiptsOptions...)
+	healthHandler := handler.NewHealth(logger, deps.Checker)
+	usersHandler := handler.NewUsers(logger, deps.Users)
+	rewardsHandler := handler.NewRewards(logger, deps.Rewards, deps.Users)
+	returnsHandler := handler.NewReturns(logger, deps.Returns)
+	campaignsHandler := handler.NewCampaigns(logger, deps.Campaigns)
+	retailersHandler := handler.NewRetailers(logger, deps.Retailers)
+	reviewsHandler := handler.NewReviews(logger, deps.Reviews)
+	webhooksHandler := handler.NewWebhooks(logger, deps.Webhooks)
+	streamHandler := handler.NewStream(logger, deps.Stream, cfg.StreamHeartbeat)
+
+	schema, err := graphql.NewReceiptsSchema(deps.Receipts, deps.Users)
+	if err != nil {
+		return nil, fmt.Errorf("building GraphQL schema: %w", err)
+	}
+
+	graphqlHandler := handler.NewGraphQL(logger, schema, graphql.Options{MaxDepth: cfg.GraphQLMaxDepth, MaxComplexity: cfg.GraphQLMaxComplexity})
+
+	// Callers are held to the limit of their IP address before authentication, so that unauthenticated requests and
+	// guessed credentials are limited, then to the limits of their principal once authenticated.
+	authenticator, limits, idempotent := deps.Authenticator, deps.Limits, deps.Idempotency
+
+	// Requests are validated against the OpenAPI document once authenticated and within their limits, so that
+	// anonymous callers can neither learn the schemas nor have large bodies parsed.
+	validate := func(next http.Handler) http.Handler { return next }
+	if cfg.OpenAPIValidateRequests {
+		validate = openapi.ValidateRequests(logger, deps.Spec)
+	}
+
+	// Setup router using mux
+	router := mux.NewRouter().StrictSlash(true)
+	router.MethodNotAllowedHandler = http.HandlerFunc(MethodNotImplementedHandler)
+
+	// Metrics Route
+	router.Handle("/metrics", metrics.Default.Handler()).Methods("GET")
+
+	// Health check Routes
+	router.HandleFunc("/v1/health", healthHandler.Live).Methods("GET")
+	router.HandleFunc("/v1/health/live", healthHandler.Live).Methods("GET")
+	router.HandleFunc("/v1/health/ready", healthHandler.Ready).Methods("GET")
+
+	// OpenAPI Route
+	router.Handle("/v1/openapi.yml", deps.Spec.Handler()).Methods("GET")
+
+	// Receipts Routes, the stream is registered before the receipt IDs it would otherwise match.
+	// Receipt submissions, retried by the client package, are replayed to retries sending the same Idempotency-Key,
+	// replays do not count against the quota.
+	router.Handle("/v1/receipts/stream", limits.LimitAddr(authenticator.Require(model.ScopeRead, limits.Limit(validate(http.HandlerFunc(streamHandler.Stream)))))).Methods("GET")
+	router.Handle("/v1/receipts/{id}", limits.LimitAddr(authenticator.Require(model.ScopeRead, limits.Limit(validate(http.HandlerFunc(receiptsHandler.Status)))))).Methods("GET")
+	router.Handle("/v1/receipts/{id}/points", limits.LimitAddr(authenticator.Require(model.ScopeRead, limits.Limit(validate(http.HandlerFunc(receiptsHandler.Get)))))).Methods("GET")
+	router.Handle("/v1/receipts/process", limits.LimitAddr(authenticator.Require(model.ScopeSubmit, limits.Limit(validate(idempotent.Replay(limits.Quota(http.HandlerFunc(receiptsHandler.Insert)))))))).Methods("POST")
+	router.Handle("/v1/receipts/{id}/returns", limits.LimitAddr(authenticator.Require(model.ScopeSubmit, limits.Limit(validate(http.HandlerFunc(returnsHandler.Insert)))))).Methods("POST")
+	router.Handle("/v1/receipts/{id}/adjustments", limits.LimitAddr(authenticator.Require(model.ScopeRead, limits.Limit(validate(http.HandlerFunc(returnsHandler.History)))))).Methods("GET")
+
+	// Users Routes
+	router.Handle("/v1/users/{userId}/balance", limits.LimitAddr(authenticator.Require(model.ScopeRead, limits.Limit(validate(http.HandlerFunc(usersHandler.Balance)))))).Methods("GET")
+	router.Handle("/v1/users/{userId}/ledger", limits.LimitAddr(authenticator.Require(model.ScopeRead, limits.Limit(validate(http.HandlerFunc(usersHandler.Ledger)))))).Methods("GET")
+	router.Handle("/v1/users/{userId}/expiring", limits.LimitAddr(authenticator.Require(model.ScopeRead, limits.Limit(validate(http.HandlerFunc(usersHandler.Expiring)))))).Methods("GET")
+	router.Handle("/v1/users/{userId}/redemptions", limits.LimitAddr(authenticator.Require(model.ScopeSubmit, limits.Limit(validate(http.HandlerFunc(rewardsHandler.Redeem)))))).Methods("POST")
+
+	// Rewards Routes
+	router.Handle("/v1/rewards", limits.LimitAddr(authenticator.Require(model.ScopeRead, limits.Limit(validate(http.HandlerFunc(rewardsHandler.List)))))).Methods("GET")
+	router.Handle("/v1/rewards", limits.LimitAddr(authenticator.Require(model.ScopeAdmin, limits.Limit(validate(http.HandlerFunc(rewardsHandler.Insert)))))).Methods("POST")
+
+	// Campaigns Routes
+	router.Handle("/v1/campaigns", limits.LimitAddr(authenticator.Require(model.ScopeRead, limits.Limit(validate(http.HandlerFunc(campaignsHandler.List)))))).Methods("GET")
+	router.Handle("/v1/campaigns", limits.LimitAddr(authenticator.Require(model.ScopeAdmin, limits.Limit(validate(http.HandlerFunc(campaignsHandler.Insert)))))).Methods("POST")
+	router.Handle("/v1/campaigns/{id}", limits.LimitAddr(authenticator.Require(model.ScopeRead, limits.Limit(validate(http.HandlerFunc(campaignsHandler.Get)))))).Methods("GET")
+	router.Handle("/v1/campaigns/{id}", limits.LimitAddr(authenticator.Require(model.ScopeAdmin, limits.Limit(validate(http.HandlerFunc(campaignsHandler.Update)))))).Methods("PUT")
+	router.Handle("/v1/campaigns/{id}", limits.LimitAddr(authenticator.Require(model.ScopeAdmin, limits.Limit(validate(http.HandlerFunc(campaignsHandler.Delete)))))).Methods("DELETE")
+
+	// Retailers Routes
+	router.Handle("/v1/retailers", limits.LimitAddr(authenticator.Require(model.ScopeRead, limits.Limit(validate(http.HandlerFunc(retailersHandler.List)))))).Methods("GET")
+	router.Handle("/v1/retailers", limits.LimitAddr(authenticator.Require(model.ScopeAdmin, limits.Limit(validate(http.HandlerFunc(retailersHandler.Insert)))))).Methods("POST")
+	router.Handle("/v1/retailers/{id}", limits.LimitAddr(authenticator.Require(model.ScopeRead, limits.Limit(validate(http.HandlerFunc(retailersHandler.Get)))))).Methods("GET")
+	router.Handle("/v1/retailers/{id}", limits.LimitAddr(authenticator.Require(model.ScopeAdmin, limits.Limit(validate(http.HandlerFunc(retailersHandler.Update)))))).Methods("PUT")
+	router.Handle("/v1/retailers/{id}", limits.LimitAddr(authenticator.Require(model.ScopeAdmin, limits.Limit(validate(http.HandlerFunc(retailersHandler.Delete)))))).Methods("DELETE")
+
+	// Fraud Review Routes
+	router.Handle("/v1/reviews", limits.LimitAddr(authenticator.Require(model.ScopeAdmin, limits.Limit(validate(http.HandlerFunc(reviewsHandler.List)))))).Methods("GET")
+	router.Handle("/v1/reviews/{id}/decision", limits.LimitAddr(authenticator.Require(model.ScopeAdmin, limits.Limit(validate(http.HandlerFunc(reviewsHandler.Decide)))))).Methods("POST")
+
+	// Webhooks Routes
+	router.Handle("/v1/webhooks", limits.LimitAddr(authenticator.Require(model.ScopeRead, limits.Limit(validate(http.HandlerFunc(webhooksHandler.List)))))).Methods("GET")
+	router.Handle("/v1/webhooks", limits.LimitAddr(authenticator.Require(model.ScopeSubmit, limits.Limit(validate(http.HandlerFunc(webhooksHandler.Insert)))))).Methods("POST")
+	router.Handle("/v1/webhooks/{id}", limits.LimitAddr(authenticator.Require(model.ScopeSubmit, limits.Limit(validate(http.HandlerFunc(webhooksHandler.Delete)))))).Methods("DELETE")
+	router.Handle("/v1/webhooks/{id}/deliveries", limits.LimitAddr(authenticator.Require(model.ScopeRead, limits.Limit(validate(http.HandlerFunc(webhooksHandler.Deliveries)))))).Methods("GET")
+	router.Handle("/v1/webhooks/{id}/dead-letters", limits.LimitAddr(authenticator.Require(model.ScopeRead, limits.Limit(validate(http.HandlerFunc(webhooksHandler.DeadLetters)))))).Methods("GET")
+	router.Handle("/v1/webhooks/{id}/dead-letters/{deliveryId}", limits.LimitAddr(authenticator.Require(model.ScopeSubmit, limits.Limit(validate(http.HandlerFunc(webhooksHandler.Acknowledge)))))).Methods("DELETE")
+	router.Handle("/v1/webhooks/{id}/dead-letters/{deliveryId}/replay", limits.LimitAddr(authenticator.Require(model.ScopeSubmit, limits.Limit(validate(http.HandlerFunc(webhooksHandler.Replay)))))).Methods("POST")
+
+	// GraphQL Route, the mutation checks the submit scope and charges the daily quota itself.
+	router.Handle("/graphql", limits.LimitAddr(authenticator.Require(model.ScopeRead, limits.Limit(validate(limits.Deferred(http.HandlerFunc(graphqlHandler.Query))))))).Methods("GET", "POST")
+
+	return router, nil
+}
+
+func MethodNotImplementedHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusMethodNotAllowed)
+	return
+}
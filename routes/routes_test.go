@@ -0,0 +1,149 @@
+package routes
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/config"
+	store "github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/health"
+	"github/shivasaicharanruthala/backend-engineer-takehome/idempotency"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/openapi"
+	"github/shivasaicharanruthala/backend-engineer-takehome/ratelimit"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+	"github/shivasaicharanruthala/backend-engineer-takehome/stream"
+)
+
+// newTestRouter creates the router of the API over in-memory stores, with rate limiting disabled and authentication
+// enabled as given, without any API key.
+func newTestRouter(t *testing.T, authEnabled bool) (*mux.Router, *openapi.Document) {
+	logger, _ := log.NewCustomLogger("test.log")
+
+	cfg, err := config.Load([]string{})
+	assert.NoError(t, err)
+
+	spec, err := openapi.Load(openapi.Spec)
+	assert.NoError(t, err)
+
+	ledger := store.NewLedger(logger)
+
+	router, err := New(logger, cfg, Dependencies{
+		Receipts:      service.New(logger, store.New(logger), service.WithLedger(ledger)),
+		Users:         service.NewUsers(logger, ledger),
+		Rewards:       service.NewRewards(logger, store.NewRewards(logger), ledger),
+		Returns:       service.NewReturns(logger, store.New(logger), store.NewAdjustments(logger), ledger, store.NewReviews(logger), nil),
+		Campaigns:     service.NewCampaigns(logger, store.NewCampaigns(logger)),
+		Retailers:     service.NewRetailers(logger, store.NewRetailers(logger)),
+		Reviews:       service.NewReviews(logger, store.NewReviews(logger), ledger, nil, cfg.PointsExpiryMonths, nil),
+		Webhooks:      service.NewWebhooks(logger, store.NewWebhooks(logger), store.NewDeliveries(logger), store.NewDeadLetters(logger), nil),
+		Stream:        stream.New(cfg.StreamBufferSize, cfg.StreamClientBuffer),
+		Checker:       health.New(),
+		Authenticator: auth.New(logger, store.NewAPIKeys(logger), nil, authEnabled),
+		Limits:        ratelimit.New(logger, nil, store.NewQuotas(logger), 0),
+		Idempotency:   idempotency.New(logger, store.NewIdempotency(logger, cfg.IdempotencyMaxKeys), cfg.IdempotencyTTL),
+		Spec:          spec,
+	})
+	assert.NoError(t, err)
+
+	return router, spec
+}
+
+// TestRoutes_MatchOpenAPI fails when a route is added to the router without being documented, or the other way around.
+func TestRoutes_MatchOpenAPI(t *testing.T) {
+	router, spec := newTestRouter(t, false)
+
+	var registered []string
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+
+		methods, err := route.GetMethods()
+		if err != nil {
+			return err
+		}
+
+		for _, method := range methods {
+			registered = append(registered, method+" "+template)
+		}
+
+		return nil
+	})
+	assert.NoError(t, err)
+
+	sort.Strings(registered)
+	assert.Equal(t, spec.Routes(), registered, "the routes of the router and of openapi/openapi.yml differ")
+}
+
+// TestRoutes_ResponsesMatchOpenAPI sends requests through the router with the responses validated against the document.
+func TestRoutes_ResponsesMatchOpenAPI(t *testing.T) {
+	router, spec := newTestRouter(t, false)
+	logger, _ := log.NewCustomLogger("test.log")
+
+	server := httptest.NewServer(openapi.ValidateResponses(logger, spec)(router))
+	defer server.Close()
+
+	receipt := `{"retailer": "Target", "purchaseDate": "2022-01-01", "purchaseTime": "13:01", "items": [{"shortDescription": "Mountain Dew 12PK", "price": "6.49"}], "total": "6.49", "userId": "user-1"}`
+
+	testCases := []struct {
+		id               int
+		useCase          string
+		method           string
+		path             string
+		body             string
+		statusCode       int
+		expectedResponse string
+	}{
+		{id: 1, useCase: "Positive case: receipt processed", method: "POST", path: "/v1/receipts/process", body: receipt, statusCode: 201, expectedResponse: `"id":`},
+		{id: 2, useCase: "Positive case: balance of a user", method: "GET", path: "/v1/users/user-1/balance", statusCode: 200, expectedResponse: `"userId":"user-1"`},
+		{id: 3, useCase: "Positive case: ledger of a user", method: "GET", path: "/v1/users/user-1/ledger?pageSize=5", statusCode: 200, expectedResponse: `"pageSize":5`},
+		{id: 4, useCase: "Positive case: expiring points of a user", method: "GET", path: "/v1/users/user-1/expiring", statusCode: 200, expectedResponse: `"days":30`},
+		{id: 5, useCase: "Positive case: campaigns", method: "GET", path: "/v1/campaigns", statusCode: 200, expectedResponse: `[]`},
+		{id: 6, useCase: "Positive case: reviews", method: "GET", path: "/v1/reviews?status=approved", statusCode: 200, expectedResponse: `[]`},
+		{id: 7, useCase: "Positive case: readiness", method: "GET", path: "/v1/health/ready", statusCode: 200, expectedResponse: `"status":"up"`},
+		{id: 8, useCase: "Positive case: OpenAPI document", method: "GET", path: "/v1/openapi.yml", statusCode: 200, expectedResponse: "openapi: 3.0.3"},
+		{id: 9, useCase: "Positive case: GraphQL query", method: "POST", path: "/graphql", body: `{"query":"{ receipts { totalCount } }"}`, statusCode: 200, expectedResponse: `{"data":{"receipts":{"totalCount":1}}}`},
+		{id: 10, useCase: "Negative case: unknown receipt", method: "GET", path: "/v1/receipts/4a77ec9d-5334-43d0-a9e1-4fca8807bf8f/points", statusCode: 404, expectedResponse: `"code":"resource.not_found"`},
+		{id: 11, useCase: "Negative case: receipt without items", method: "POST", path: "/v1/receipts/process", body: `{"retailer": "Target", "purchaseDate": "2022-01-01", "purchaseTime": "13:01", "total": "6.49"}`, statusCode: 400, expectedResponse: "Parameter items is required for this request"},
+		{id: 12, useCase: "Negative case: price not matching its pattern", method: "POST", path: "/v1/receipts/process", body: strings.Replace(receipt, `"price": "6.49"`, `"price": "6.4"`, 1), statusCode: 400, expectedResponse: `Incorrect value for parameter: items[0].price, it must match the pattern`},
+		{id: 13, useCase: "Negative case: page size above its maximum", method: "GET", path: "/v1/users/user-1/ledger?pageSize=500", statusCode: 400, expectedResponse: "Incorrect value for parameter: pageSize"},
+		{id: 14, useCase: "Negative case: invalid receipt ID", method: "GET", path: "/v1/receipts/1234", statusCode: 400, expectedResponse: "Incorrect value for parameter: id"},
+	}
+
+	for _, tc := range testCases {
+		req, _ := http.NewRequest(tc.method, server.URL+tc.path, strings.NewReader(tc.body))
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+
+		assert.Equal(t, tc.statusCode, resp.StatusCode, fmt.Sprintf("Test %v Failed with use case %v: %s", tc.id, tc.useCase, body))
+		assert.Contains(t, string(body), tc.expectedResponse, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+// TestRoutes_ValidateAuthenticatedRequests checks that requests are authenticated before they are validated against
+// the document, so that anonymous callers never learn the schemas of the API.
+func TestRoutes_ValidateAuthenticatedRequests(t *testing.T) {
+	router, _ := newTestRouter(t, true)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/v1/receipts/process", strings.NewReader(`{"retailer": 1}`)))
+
+	body, _ := io.ReadAll(w.Result().Body)
+	assert.Equal(t, http.StatusUnauthorized, w.Result().StatusCode)
+	assert.Contains(t, string(body), `"code":"auth.unauthenticated"`)
+	assert.NotContains(t, string(body), "retailer")
+}
@@ -0,0 +1,296 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/ratelimit"
+	"github/shivasaicharanruthala/backend-engineer-takehome/receiptspb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// scopes are the scopes required by each method, as by the matching REST routes.
+var scopes = map[string]string{
+	receiptspb.Receipts_ProcessReceipt_FullMethodName: model.ScopeSubmit,
+	receiptspb.Receipts_GetPoints_FullMethodName:      model.ScopeRead,
+	receiptspb.Receipts_GetReceipt_FullMethodName:     model.ScopeRead,
+	receiptspb.Receipts_BatchProcess_FullMethodName:   model.ScopeSubmit,
+}
+
+// routes are the REST routes matching each method, methods share the rate limits of their route.
+var routes = map[string]string{
+	receiptspb.Receipts_ProcessReceipt_FullMethodName: "/v1/receipts/process",
+	receiptspb.Receipts_GetPoints_FullMethodName:      "/v1/receipts/{id}/points",
+	receiptspb.Receipts_GetReceipt_FullMethodName:     "/v1/receipts/{id}",
+	receiptspb.Receipts_BatchProcess_FullMethodName:   "/v1/receipts/process",
+}
+
+// submissions are the methods submitting receipts, every receipt counts against the daily quota of the caller.
+var submissions = map[string]bool{
+	receiptspb.Receipts_ProcessReceipt_FullMethodName: true,
+	receiptspb.Receipts_BatchProcess_FullMethodName:   true,
+}
+
+// NewServer creates a gRPC server with the receipts server registered. Callers are held to the limit of their IP
+// address, authenticated by authenticator from the authorization and x-api-key metadata, then held to the rate limits and daily quota of limits as REST
+// callers are, every receipt of a BatchProcess stream counting as a request. Streams carry at most maxBatchSize
+// receipts. Errors are mapped to gRPC status codes and logged.
+func NewServer(l *log.CustomLogger, srv receiptspb.ReceiptsServer, authenticator *auth.Authenticator, limits *ratelimit.Middleware, maxBatchSize int) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryErrors(l), unaryAddrLimits(limits), unaryAuth(authenticator), unaryLimits(limits)),
+		grpc.ChainStreamInterceptor(streamErrors(l), streamAddrLimits(limits), streamAuth(authenticator), streamLimits(limits, maxBatchSize)),
+	)
+
+	receiptspb.RegisterReceiptsServer(server, srv)
+
+	return server
+}
+
+// authorize authenticates the caller of a method from the metadata of ctx and checks it was granted the scope of the method.
+// It returns ctx carrying the principal, see auth.FromContext.
+func authorize(ctx context.Context, authenticator *auth.Authenticator, method string) (context.Context, error) {
+	if !authenticator.Enabled() {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	principal, err := authenticator.Authenticate(first(md, "authorization"), first(md, strings.ToLower(auth.APIKeyHeader)))
+	if err != nil {
+		return nil, err
+	}
+
+	if scope := scopes[method]; !principal.HasScope(scope) {
+		return nil, errors.NewForbidden(errors.Forbidden{Scope: scope})
+	}
+
+	return auth.NewContext(ctx, principal), nil
+}
+
+// unaryAuth authorizes the callers of unary methods.
+func unaryAuth(authenticator *auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authorize(ctx, authenticator, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// streamAuth authorizes the callers of streaming methods.
+func streamAuth(authenticator *auth.Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), authenticator, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// unaryAddrLimits holds the callers of unary methods to the limit of their IP address before authentication.
+func unaryAddrLimits(limits *ratelimit.Middleware) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := limits.AllowAddr(remoteAddr(ctx)); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// streamAddrLimits holds the callers of streaming methods to the limit of their IP address before authentication.
+func streamAddrLimits(limits *ratelimit.Middleware) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := limits.AllowAddr(remoteAddr(ss.Context())); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// unaryLimits holds the callers of unary methods to the rate limit of their route, and submissions to the daily quota.
+func unaryLimits(limits *ratelimit.Middleware) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		refund, err := limit(ctx, limits, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			refund()
+		}
+
+		return resp, err
+	}
+}
+
+// streamLimits holds every message of streaming methods to the rate limit of their route, and submissions to the
+// daily quota, receipts failing to be processed are refunded. Streams end once they received more than maxBatchSize messages.
+func streamLimits(limits *ratelimit.Middleware, maxBatchSize int) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &limitedStream{ServerStream: ss, limits: limits, method: info.FullMethod, max: maxBatchSize})
+	}
+}
+
+// limit takes a token of the route of method from the bucket of the caller of ctx, and charges submissions to the
+// quota of its client.
+// The returned refund gives the charge back when the submission fails.
+func limit(ctx context.Context, limits *ratelimit.Middleware, method string) (func(), error) {
+	principal, addr := auth.FromContext(ctx), remoteAddr(ctx)
+	if err := limits.Allow(routes[method], ratelimit.Key(principal, addr)); err != nil {
+		return nil, err
+	}
+
+	if submissions[method] {
+		return limits.Charge(ratelimit.QuotaKey(principal, addr))
+	}
+
+	return func() {}, nil
+}
+
+// unaryErrors maps the errors of unary methods to gRPC status codes and logs them.
+func unaryErrors(l *log.CustomLogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, logStatus(ctx, l, info.FullMethod, err)
+		}
+
+		return resp, nil
+	}
+}
+
+// streamErrors maps the errors of streaming methods to gRPC status codes and logs them.
+func streamErrors(l *log.CustomLogger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return logStatus(ss.Context(), l, info.FullMethod, err)
+		}
+
+		return nil
+	}
+}
+
+// logStatus logs the failure of a method and returns its gRPC status.
+func logStatus(ctx context.Context, l *log.CustomLogger, method string, err error) error {
+	st := toStatus(err)
+
+	lm := log.Message{Level: "ERROR", Method: "gRPC", URI: method, ErrorMessage: st.Code().String() + ": " + st.Message()}
+	l.LogContext(ctx, &lm)
+
+	return st.Err()
+}
+
+// toStatus returns the gRPC status of an error of the errors package, errors already carrying a status keep it.
+func toStatus(err error) *status.Status {
+	switch val := err.(type) {
+	case errors.MissingParam, errors.InvalidParam:
+		return status.New(codes.InvalidArgument, val.Error())
+	case errors.EntityNotFound:
+		return status.New(codes.NotFound, val.Error())
+	case errors.Unauthorized:
+		return status.New(codes.Unauthenticated, val.Error())
+	case errors.Forbidden:
+		return status.New(codes.PermissionDenied, val.Error())
+	case errors.Conflict:
+		return status.New(codes.FailedPrecondition, val.Error())
+	case errors.TooManyRequests:
+		return status.New(codes.ResourceExhausted, val.Error())
+	case errors.CustomError:
+		switch {
+		case val.StatusCode == http.StatusServiceUnavailable:
+			return status.New(codes.Unavailable, val.Error())
+		case val.StatusCode >= 400 && val.StatusCode < 500:
+			return status.New(codes.InvalidArgument, val.Error())
+		default:
+			return status.New(codes.Internal, val.Error())
+		}
+	}
+
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	return status.New(codes.Internal, err.Error())
+}
+
+// first returns the first value of a metadata key, empty when it is absent.
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// limitedStream is a server stream whose received messages are limited one by one.
+type limitedStream struct {
+	grpc.ServerStream
+	limits   *ratelimit.Middleware
+	method   string
+	max      int
+	received int
+	refund   func() // Refunds the charge of the last received message.
+}
+
+// RecvMsg receives the next message once the caller is within its limits, and the stream within its maximum size.
+func (ls *limitedStream) RecvMsg(m any) error {
+	if err := ls.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	ls.received++
+	if ls.max > 0 && ls.received > ls.max {
+		return errors.NewInvalidParam(fmt.Errorf("Streams carry at most %v receipts", ls.max))
+	}
+
+	refund, err := limit(ls.Context(), ls.limits, ls.method)
+	ls.refund = refund
+
+	return err
+}
+
+// SendMsg sends the response to the last received message, refunding its charge when it reports a failure.
+func (ls *limitedStream) SendMsg(m any) error {
+	if resp, ok := m.(*receiptspb.BatchProcessResponse); ok && resp.GetError() != nil && ls.refund != nil {
+		ls.refund()
+	}
+	ls.refund = nil
+
+	return ls.ServerStream.SendMsg(m)
+}
+
+// contextStream is a server stream whose context carries the authenticated principal.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream carrying the principal.
+func (cs *contextStream) Context() context.Context {
+	return cs.ctx
+}
+
+// remoteAddr returns the address of the peer of ctx, empty when it is unknown.
+func remoteAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+
+	return ""
+}
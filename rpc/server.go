@@ -0,0 +1,160 @@
+package rpc
+
+import (
+	"context"
+	er "errors"
+	"io"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/receiptspb"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+	"google.golang.org/grpc"
+)
+
+// Server is the gRPC server of receipts, it serves the same service layer as receiptsHandler.
+// Its methods return the errors of the errors package, the interceptors map them to gRPC status codes.
+type Server struct {
+	receiptspb.UnimplementedReceiptsServer
+	logger *log.CustomLogger
+	svc    service.Receipts
+}
+
+// New creates and returns a new instance of Server.
+func New(l *log.CustomLogger, svc service.Receipts) *Server {
+	return &Server{
+		logger: l,
+		svc:    svc,
+	}
+}
+
+// ProcessReceipt scores a receipt and returns its ID.
+func (s *Server) ProcessReceipt(ctx context.Context, req *receiptspb.ProcessReceiptRequest) (*receiptspb.ProcessReceiptResponse, error) {
+	resp, err := s.process(ctx, req.GetReceipt())
+	if err != nil {
+		return nil, err
+	}
+
+	return &receiptspb.ProcessReceiptResponse{Id: resp.Id}, nil
+}
+
+// GetPoints returns the points of a receipt.
+func (s *Server) GetPoints(ctx context.Context, req *receiptspb.GetPointsRequest) (*receiptspb.GetPointsResponse, error) {
+	receipt, err := s.get(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &receiptspb.GetPointsResponse{Points: int64(receipt.Points)}, nil
+}
+
+// GetReceipt returns the scoring outcome of a receipt.
+func (s *Server) GetReceipt(ctx context.Context, req *receiptspb.GetReceiptRequest) (*receiptspb.GetReceiptResponse, error) {
+	receipt, err := s.get(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &receiptspb.GetReceiptResponse{
+		Id:           req.GetId(),
+		Points:       int64(receipt.Points),
+		Status:       receipt.Status,
+		RetailerId:   receipt.RetailerID,
+		RetailerName: receipt.RetailerName,
+	}
+
+	for _, c := range receipt.Campaigns {
+		resp.Campaigns = append(resp.Campaigns, &receiptspb.AppliedCampaign{CampaignId: c.CampaignID, Name: c.Name, Points: int64(c.Points)})
+	}
+
+	if receipt.Cap != nil {
+		resp.Cap = &receiptspb.PointsCap{
+			Type:           receipt.Cap.Type,
+			Limit:          int64(receipt.Cap.Limit),
+			ComputedPoints: int64(receipt.Cap.ComputedPoints),
+			AwardedPoints:  int64(receipt.Cap.AwardedPoints),
+		}
+	}
+
+	return resp, nil
+}
+
+// BatchProcess scores every receipt of the stream and responds with the outcome of each, in order.
+// Receipts that fail are reported with their status and do not end the stream.
+func (s *Server) BatchProcess(stream grpc.BidiStreamingServer[receiptspb.ProcessReceiptRequest, receiptspb.BatchProcessResponse]) error {
+	for index := int64(0); ; index++ {
+		req, err := stream.Recv()
+		if er.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		resp := &receiptspb.BatchProcessResponse{Index: index}
+		if receipt, err := s.process(stream.Context(), req.GetReceipt()); err != nil {
+			st := toStatus(err)
+			resp.Error = &receiptspb.Error{Code: int32(st.Code()), Message: st.Message()}
+		} else {
+			resp.Id = receipt.Id
+		}
+
+		if err = stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// process scores a receipt submitted by the principal of ctx, with the same rules as receiptsHandler.Insert.
+func (s *Server) process(ctx context.Context, pb *receiptspb.Receipt) (*model.ReceiptPostResponse, error) {
+	if pb == nil {
+		return nil, errors.NewMissingParam(errors.MissingParam{Param: "receipt"})
+	}
+
+	receipt := toReceipt(pb)
+
+	// Records the submitting client on the receipt, users authenticated themselves only submit receipts on their own behalf.
+	if err := auth.FromContext(ctx).BindReceipt(receipt); err != nil {
+		return nil, err
+	}
+
+	return s.svc.Insert(ctx, receipt)
+}
+
+// get retrieves a receipt readable by the principal of ctx, receipts of other clients are reported as not found.
+func (s *Server) get(ctx context.Context, receiptID string) (*model.ReceiptGetResponse, error) {
+	if !model.IsValidUUID(receiptID) {
+		return nil, errors.NewInvalidParam(errors.InvalidParam{Param: "id"})
+	}
+
+	receipt, err := s.svc.Get(receiptID)
+	if err != nil {
+		return nil, err
+	}
+
+	if principal := auth.FromContext(ctx); principal != nil && !principal.CanAccess(receipt.ClientID, receipt.UserID) {
+		return nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "receipts", ID: receiptID})
+	}
+
+	return receipt, nil
+}
+
+// toReceipt converts a protobuf receipt to the model, absent fields stay nil so that validation reports them missing.
+func toReceipt(pb *receiptspb.Receipt) *model.Receipt {
+	receipt := &model.Receipt{
+		Retailer:     pb.Retailer,
+		PurchaseDate: pb.PurchaseDate,
+		PurchaseTime: pb.PurchaseTime,
+		Total:        pb.Total,
+		UserID:       pb.GetUserId(),
+	}
+
+	for _, item := range pb.GetItems() {
+		receipt.Items = append(receipt.Items, model.Item{ShortDescription: item.ShortDescription, Price: item.Price})
+	}
+
+	return receipt
+}
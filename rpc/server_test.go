@@ -0,0 +1,327 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/auth"
+	store "github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+	"github/shivasaicharanruthala/backend-engineer-takehome/ratelimit"
+	"github/shivasaicharanruthala/backend-engineer-takehome/receiptspb"
+	"github/shivasaicharanruthala/backend-engineer-takehome/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+const receiptID = "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f"
+
+// newClient serves svc over an in-memory connection with API key authentication and returns a client of it.
+// The key "partner-key" may submit and read, "reader-key" may only read.
+func newClient(t *testing.T, svc service.Receipts) receiptspb.ReceiptsClient {
+	logger, _ := log.NewCustomLogger("test.log")
+
+	return newLimitedClient(t, svc, ratelimit.New(logger, nil, store.NewQuotas(logger), 0), 1000)
+}
+
+// newLimitedClient is newClient holding callers to limits, with streams of at most maxBatchSize receipts.
+func newLimitedClient(t *testing.T, svc service.Receipts, limits *ratelimit.Middleware, maxBatchSize int) receiptspb.ReceiptsClient {
+	logger, _ := log.NewCustomLogger("test.log")
+
+	keys := store.NewAPIKeys(logger)
+	keys.Insert(&model.APIKey{ClientID: "partner", KeyHash: auth.HashKey("partner-key"), Scopes: []string{model.ScopeSubmit, model.ScopeRead}})
+	keys.Insert(&model.APIKey{ClientID: "reader", KeyHash: auth.HashKey("reader-key"), Scopes: []string{model.ScopeRead}})
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(logger, New(logger, svc), auth.New(logger, keys, nil, true), limits, maxBatchSize)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return receiptspb.NewReceiptsClient(conn)
+}
+
+// withKey returns a context sending key as API key.
+func withKey(key string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+}
+
+// newReceipt returns a valid protobuf receipt.
+func newReceipt() *receiptspb.Receipt {
+	return &receiptspb.Receipt{
+		Retailer:     proto.String("Target"),
+		PurchaseDate: proto.String("2022-01-01"),
+		PurchaseTime: proto.String("13:01"),
+		Total:        proto.String("6.49"),
+		Items:        []*receiptspb.Item{{ShortDescription: proto.String("Mountain Dew 12PK"), Price: proto.String("6.49")}},
+	}
+}
+
+func TestServer_ProcessReceipt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	receiptService := service.NewMockReceipts(ctrl)
+	client := newClient(t, receiptService)
+
+	expected := &model.Receipt{
+		Retailer:     model.StringPointer("Target"),
+		PurchaseDate: model.StringPointer("2022-01-01"),
+		PurchaseTime: model.StringPointer("13:01"),
+		Total:        model.StringPointer("6.49"),
+		Items:        []model.Item{{ShortDescription: model.StringPointer("Mountain Dew 12PK"), Price: model.StringPointer("6.49")}},
+		ClientID:     "partner",
+	}
+
+	missingTotal := newReceipt()
+	missingTotal.Total = nil
+	expectedMissing := *expected
+	expectedMissing.Total = nil
+
+	testCases := []struct {
+		id           int
+		useCase      string
+		ctx          context.Context
+		receipt      *receiptspb.Receipt
+		mockCall     *gomock.Call
+		expectedID   string
+		expectedCode codes.Code
+	}{
+		{
+			id: 1, useCase: "Positive case: receipt processed for the client of the key",
+			ctx: withKey("partner-key"), receipt: newReceipt(),
+			mockCall:   receiptService.EXPECT().Insert(gomock.Any(), expected).Return(&model.ReceiptPostResponse{Id: receiptID}, nil),
+			expectedID: receiptID, expectedCode: codes.OK,
+		},
+		{
+			id: 2, useCase: "Negative case: missing total",
+			ctx: withKey("partner-key"), receipt: missingTotal,
+			mockCall:     receiptService.EXPECT().Insert(gomock.Any(), &expectedMissing).Return(nil, errors.NewMissingParam(errors.MissingParam{Param: "total"})),
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			id: 3, useCase: "Negative case: missing receipt",
+			ctx: withKey("partner-key"), receipt: nil, expectedCode: codes.InvalidArgument,
+		},
+		{
+			id: 4, useCase: "Negative case: key without the submit scope",
+			ctx: withKey("reader-key"), receipt: newReceipt(), expectedCode: codes.PermissionDenied,
+		},
+		{
+			id: 5, useCase: "Negative case: unauthenticated",
+			ctx: context.Background(), receipt: newReceipt(), expectedCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, tc := range testCases {
+		resp, err := client.ProcessReceipt(tc.ctx, &receiptspb.ProcessReceiptRequest{Receipt: tc.receipt})
+
+		assert.Equal(t, tc.expectedCode, status.Code(err), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedID, resp.GetId(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestServer_GetReceipt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	receiptService := service.NewMockReceipts(ctrl)
+	client := newClient(t, receiptService)
+
+	otherID := "9b2f5f36-0b4e-4b5b-8e0a-3cf0e8ad7d38"
+	missingID := "0c0c1b5e-97b8-43a6-a51f-3a9c1e5dbe4f"
+	receiptService.EXPECT().Get(receiptID).Return(&model.ReceiptGetResponse{
+		Points:     56,
+		RetailerID: "retailer-1", RetailerName: "Target",
+		Campaigns: []model.AppliedCampaign{{CampaignID: "campaign-1", Name: "Double", Points: 28}},
+		Cap:       &model.PointsCap{Type: model.CapReceipt, Limit: 56, ComputedPoints: 60, AwardedPoints: 56},
+		ClientID:  "partner",
+	}, nil).Times(2)
+	receiptService.EXPECT().Get(otherID).Return(&model.ReceiptGetResponse{Points: 10, ClientID: "other"}, nil)
+	receiptService.EXPECT().Get(missingID).Return(nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "receipts", ID: missingID}))
+
+	points, err := client.GetPoints(withKey("partner-key"), &receiptspb.GetPointsRequest{Id: receiptID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(56), points.GetPoints())
+
+	receipt, err := client.GetReceipt(withKey("partner-key"), &receiptspb.GetReceiptRequest{Id: receiptID})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&receiptspb.GetReceiptResponse{
+		Id: receiptID, Points: 56, RetailerId: "retailer-1", RetailerName: "Target",
+		Campaigns: []*receiptspb.AppliedCampaign{{CampaignId: "campaign-1", Name: "Double", Points: 28}},
+		Cap:       &receiptspb.PointsCap{Type: model.CapReceipt, Limit: 56, ComputedPoints: 60, AwardedPoints: 56},
+	}, receipt), receipt.String())
+
+	testCases := []struct {
+		id              int
+		useCase         string
+		receiptID       string
+		expectedCode    codes.Code
+		expectedMessage string
+	}{
+		{id: 1, useCase: "Negative case: receipt of another client", receiptID: otherID, expectedCode: codes.NotFound, expectedMessage: fmt.Sprintf("No 'receipts' found for Id: '%v'", otherID)},
+		{id: 2, useCase: "Negative case: unknown receipt", receiptID: missingID, expectedCode: codes.NotFound, expectedMessage: fmt.Sprintf("No 'receipts' found for Id: '%v'", missingID)},
+		{id: 3, useCase: "Negative case: invalid ID", receiptID: "123", expectedCode: codes.InvalidArgument, expectedMessage: "Incorrect value for parameter: id"},
+	}
+
+	for _, tc := range testCases {
+		_, err := client.GetPoints(withKey("partner-key"), &receiptspb.GetPointsRequest{Id: tc.receiptID})
+
+		assert.Equal(t, tc.expectedCode, status.Code(err), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedMessage, status.Convert(err).Message(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestServer_BatchProcess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	receiptService := service.NewMockReceipts(ctrl)
+	client := newClient(t, receiptService)
+
+	invalid := newReceipt()
+	invalid.PurchaseDate = proto.String("2022-13-01")
+
+	gomock.InOrder(
+		receiptService.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(&model.ReceiptPostResponse{Id: "a"}, nil),
+		receiptService.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(nil, errors.NewInvalidParam(errors.InvalidParam{Param: "purchaseDate"})),
+		receiptService.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(&model.ReceiptPostResponse{Id: "c"}, nil),
+	)
+
+	stream, err := client.BatchProcess(withKey("partner-key"))
+	assert.NoError(t, err)
+
+	for _, receipt := range []*receiptspb.Receipt{newReceipt(), invalid, newReceipt()} {
+		assert.NoError(t, stream.Send(&receiptspb.ProcessReceiptRequest{Receipt: receipt}))
+	}
+	assert.NoError(t, stream.CloseSend())
+
+	var responses []*receiptspb.BatchProcessResponse
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		assert.NoError(t, err)
+		responses = append(responses, resp)
+	}
+
+	assert.Len(t, responses, 3)
+	assert.Equal(t, "a", responses[0].GetId())
+	assert.Equal(t, int64(1), responses[1].GetIndex())
+	assert.Equal(t, int32(codes.InvalidArgument), responses[1].GetError().GetCode())
+	assert.Equal(t, "Incorrect value for parameter: purchaseDate", responses[1].GetError().GetMessage())
+	assert.Equal(t, "c", responses[2].GetId())
+
+	// Streams require the submit scope too.
+	stream, err = client.BatchProcess(withKey("reader-key"))
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestServer_Limits(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+
+	testCases := []struct {
+		id       int
+		useCase  string
+		limiter  *ratelimit.Limiter
+		quota    int
+		calls    int
+		failing  bool
+		expected []codes.Code
+	}{
+		{id: 1, useCase: "Calls within the rate limit of the route", limiter: ratelimit.NewLimiter(map[string]ratelimit.Limit{"/v1/receipts/process": {Rate: 0.001, Burst: 2}}), calls: 2, expected: []codes.Code{codes.OK, codes.OK}},
+		{id: 2, useCase: "Calls beyond the rate limit of the route", limiter: ratelimit.NewLimiter(map[string]ratelimit.Limit{"/v1/receipts/process": {Rate: 0.001, Burst: 1}}), calls: 2, expected: []codes.Code{codes.OK, codes.ResourceExhausted}},
+		{id: 3, useCase: "Calls beyond the daily quota", quota: 1, calls: 2, expected: []codes.Code{codes.OK, codes.ResourceExhausted}},
+		{id: 4, useCase: "Failed calls are refunded to the daily quota", quota: 1, calls: 2, failing: true, expected: []codes.Code{codes.InvalidArgument, codes.InvalidArgument}},
+	}
+
+	for _, tc := range testCases {
+		ctrl := gomock.NewController(t)
+		receiptService := service.NewMockReceipts(ctrl)
+		if tc.failing {
+			receiptService.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(nil, errors.NewInvalidParam(errors.InvalidParam{Param: "total"})).AnyTimes()
+		} else {
+			receiptService.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(&model.ReceiptPostResponse{Id: receiptID}, nil).AnyTimes()
+		}
+
+		client := newLimitedClient(t, receiptService, ratelimit.New(logger, tc.limiter, store.NewQuotas(logger), tc.quota), 1000)
+
+		for i := 0; i < tc.calls; i++ {
+			_, err := client.ProcessReceipt(withKey("partner-key"), &receiptspb.ProcessReceiptRequest{Receipt: newReceipt()})
+			assert.Equal(t, tc.expected[i], status.Code(err), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+	}
+}
+
+func TestServer_BatchProcessLimits(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+
+	testCases := []struct {
+		id        int
+		useCase   string
+		quota     int
+		maxBatch  int
+		failing   bool
+		responses int
+		expected  codes.Code
+	}{
+		{id: 1, useCase: "Stream within its maximum size", maxBatch: 3, responses: 3, expected: codes.OK},
+		{id: 2, useCase: "Stream beyond its maximum size", maxBatch: 2, responses: 2, expected: codes.InvalidArgument},
+		{id: 3, useCase: "Every receipt of the stream counts against the daily quota", quota: 1, maxBatch: 3, responses: 1, expected: codes.ResourceExhausted},
+		{id: 4, useCase: "Receipts failing to be processed are refunded to the daily quota", quota: 1, maxBatch: 3, failing: true, responses: 3, expected: codes.OK},
+	}
+
+	for _, tc := range testCases {
+		ctrl := gomock.NewController(t)
+		receiptService := service.NewMockReceipts(ctrl)
+		if tc.failing {
+			receiptService.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(nil, errors.NewInvalidParam(errors.InvalidParam{Param: "total"})).AnyTimes()
+		} else {
+			receiptService.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(&model.ReceiptPostResponse{Id: receiptID}, nil).AnyTimes()
+		}
+
+		client := newLimitedClient(t, receiptService, ratelimit.New(logger, nil, store.NewQuotas(logger), tc.quota), tc.maxBatch)
+
+		stream, err := client.BatchProcess(withKey("partner-key"))
+		assert.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			_ = stream.Send(&receiptspb.ProcessReceiptRequest{Receipt: newReceipt()})
+		}
+		_ = stream.CloseSend()
+
+		responses := 0
+		for {
+			_, err = stream.Recv()
+			if err != nil {
+				break
+			}
+
+			responses++
+		}
+
+		if tc.expected == codes.OK {
+			assert.Equal(t, io.EOF, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		} else {
+			assert.Equal(t, tc.expected, status.Code(err), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+
+		assert.Equal(t, tc.responses, responses, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// campaignsService is a service layer structure for managing promotional campaigns.
+type campaignsService struct {
+	logger    *log.CustomLogger
+	campaigns data.Campaigns // Data layer interface for interacting with the campaign store.
+}
+
+// NewCampaigns creates and returns a new instance of campaignsService which implements all methods of the interface service.Campaigns.
+func NewCampaigns(l *log.CustomLogger, campaigns data.Campaigns) Campaigns {
+	return &campaignsService{
+		logger:    l,
+		campaigns: campaigns,
+	}
+}
+
+// Get retrieves a campaign by its ID.
+func (cs campaignsService) Get(campaignID string) (*model.Campaign, error) {
+	return cs.campaigns.Get(campaignID)
+}
+
+// List returns every campaign in evaluation order.
+func (cs campaignsService) List() []model.Campaign {
+	return cs.campaigns.List()
+}
+
+// Insert validates a campaign, generates its ID and stores it.
+func (cs campaignsService) Insert(campaign *model.Campaign) (*model.Campaign, error) {
+	if err := campaign.PayloadValidation(); err != nil {
+		return nil, err
+	}
+
+	campaign.ID = uuid.New().String()
+	cs.campaigns.Insert(campaign)
+
+	return campaign, nil
+}
+
+// Update validates a campaign and replaces the stored campaign with the given ID.
+// Receipts already scored keep the points of the campaign as it was when they were submitted.
+func (cs campaignsService) Update(campaignID string, campaign *model.Campaign) (*model.Campaign, error) {
+	if err := campaign.PayloadValidation(); err != nil {
+		return nil, err
+	}
+
+	campaign.ID = campaignID
+	if err := cs.campaigns.Update(campaign); err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+// Delete removes the campaign with the given ID.
+func (cs campaignsService) Delete(campaignID string) error {
+	return cs.campaigns.Delete(campaignID)
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	store "github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestServiceCampaignUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	logger, _ := log.NewCustomLogger("test.log")
+	campaigns := store.NewMockCampaigns(ctrl)
+	campaignsService := NewCampaigns(logger, campaigns)
+
+	campaignID := "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f"
+	valid := func() *model.Campaign {
+		return &model.Campaign{Name: "Double", StartDate: "2024-03-02", EndDate: "2024-03-03", Multiplier: 2}
+	}
+	notFound := errors.NewEntityNotFound(errors.EntityNotFound{Entity: "campaigns", ID: campaignID})
+
+	testCases := []struct {
+		id            int
+		useCase       string
+		campaign      *model.Campaign
+		mockCall      *gomock.Call
+		expectedError error
+	}{
+		{
+			id: 1, useCase: "Negative case: invalid campaign",
+			campaign:      &model.Campaign{Name: "Double"},
+			expectedError: errors.InvalidParam{Param: "startDate"},
+		},
+		{
+			id: 2, useCase: "Negative case: unknown campaign",
+			campaign:      valid(),
+			mockCall:      campaigns.EXPECT().Update(gomock.Any()).Return(notFound),
+			expectedError: notFound,
+		},
+		{
+			id: 3, useCase: "Positive case: campaign replaced",
+			campaign: valid(),
+			mockCall: campaigns.EXPECT().Update(&model.Campaign{ID: campaignID, Name: "Double", StartDate: "2024-03-02", EndDate: "2024-03-03", Multiplier: 2}).Return(nil),
+		},
+	}
+
+	for _, tc := range testCases {
+		campaign, err := campaignsService.Update(campaignID, tc.campaign)
+		if tc.expectedError != nil {
+			assert.EqualError(t, err, tc.expectedError.Error(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			continue
+		}
+
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, campaignID, campaign.ID, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestServiceInsert_Campaigns(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	receipts, campaigns := store.New(logger), store.NewCampaigns(logger)
+	receiptService := New(logger, receipts, WithCampaigns(campaigns))
+	returnsService := NewReturns(logger, receipts, store.NewAdjustments(logger), nil, nil, nil)
+
+	campaign, err := NewCampaigns(logger, campaigns).Insert(&model.Campaign{Name: "+100 Gatorade", StartDate: "2024-03-01", EndDate: "2024-03-31", ItemPattern: "gatorade", Bonus: 100})
+	assert.NoError(t, err)
+
+	// Scores 13 base points: 6 for the retailer, 5 for the pair of items and 2 for the description of the chips.
+	resp, err := receiptService.Insert(context.Background(), &model.Receipt{
+		Retailer:     model.StringPointer("Target"),
+		PurchaseDate: model.StringPointer("2024-03-02"),
+		PurchaseTime: model.StringPointer("09:00"),
+		Total:        model.StringPointer("9.49"),
+		Items: []model.Item{
+			{ShortDescription: model.StringPointer("Gatorade"), Price: model.StringPointer("2.25")},
+			{ShortDescription: model.StringPointer("Potato Chips"), Price: model.StringPointer("7.24")},
+		},
+	})
+	assert.NoError(t, err)
+
+	points, err := receipts.Get(resp.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, 113, points.Points)
+	assert.Equal(t, []model.AppliedCampaign{{CampaignID: campaign.ID, Name: "+100 Gatorade", Points: 100, Campaign: *campaign}}, points.Campaigns)
+
+	// Returning the Gatorade loses the bonus along with the points of the pair of items.
+	adjustment, err := returnsService.Return(resp.Id, &model.ReturnRequest{Items: []model.Item{
+		{ShortDescription: model.StringPointer("Gatorade"), Price: model.StringPointer("2.25")},
+	}}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 8, adjustment.PointsAfter)
+}
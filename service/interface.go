@@ -1,10 +1,80 @@
 package service
 
 import (
+	"context"
+
 	"github/shivasaicharanruthala/backend-engineer-takehome/model"
 )
 
 type Receipts interface {
 	Get(receiptID string) (*model.ReceiptGetResponse, error)
-	Insert(receipt *model.Receipt) (*model.ReceiptPostResponse, error)
+	Insert(ctx context.Context, receipt *model.Receipt) (*model.ReceiptPostResponse, error)
+	Find(receiptID string) (*model.Receipt, error)
+	List(filter model.ReceiptFilter, offset, limit int) ([]model.Receipt, int)
+}
+
+type Users interface {
+	Client(userID string) string
+	Balance(userID string) (*model.BalanceResponse, error)
+	Ledger(userID string, page, pageSize int) (*model.LedgerResponse, error)
+	Expiring(userID string, days int) (*model.ExpiringResponse, error)
+}
+
+type Rewards interface {
+	List() []model.Reward
+	Insert(reward *model.Reward) (*model.Reward, error)
+	Redeem(userID string, req *model.RedemptionRequest) (*model.Redemption, error)
+}
+
+type Returns interface {
+	Return(receiptID string, req *model.ReturnRequest, principal *model.Principal) (*model.Adjustment, error)
+	History(receiptID string, principal *model.Principal) (*model.AdjustmentHistory, error)
+}
+
+type Campaigns interface {
+	Get(campaignID string) (*model.Campaign, error)
+	List() []model.Campaign
+	Insert(campaign *model.Campaign) (*model.Campaign, error)
+	Update(campaignID string, campaign *model.Campaign) (*model.Campaign, error)
+	Delete(campaignID string) error
+}
+
+type Retailers interface {
+	Get(retailerID string) (*model.Retailer, error)
+	List() []model.Retailer
+	Insert(ctx context.Context, retailer *model.Retailer) (*model.Retailer, error)
+	Update(ctx context.Context, retailerID string, retailer *model.Retailer) (*model.Retailer, error)
+	Delete(retailerID string) error
+}
+
+type Reviews interface {
+	List(status string) []model.Review
+	Decide(ctx context.Context, receiptID string, decision *model.ReviewDecision) (*model.Review, error)
+}
+
+type Jobs interface {
+	Enqueue(receipt *model.Receipt) (*model.ReceiptJob, error)
+	Get(jobID string) (*model.ReceiptJob, error)
+}
+
+type Webhooks interface {
+	Insert(webhook *model.Webhook, principal *model.Principal) (*model.Webhook, error)
+	List(principal *model.Principal) []model.Webhook
+	Delete(webhookID string, principal *model.Principal) error
+	Deliveries(webhookID string, status string, principal *model.Principal) ([]model.Delivery, error)
+	DeadLetters(webhookID string, principal *model.Principal) ([]model.Delivery, error)
+	Acknowledge(webhookID string, deliveryID string, principal *model.Principal) error
+	Replay(webhookID string, deliveryID string, principal *model.Principal) error
+}
+
+type Publisher interface {
+	Publish(event *model.Event)
+}
+
+type Redeliverer interface {
+	Redeliver(webhook *model.Webhook, event *model.Event)
+}
+
+type Stream interface {
+	Subscribe(filter model.StreamFilter, lastEventID uint64) (<-chan model.StreamEvent, []model.StreamEvent, func())
 }
@@ -5,6 +5,7 @@
 package service
 
 import (
+	context "context"
 	model "github/shivasaicharanruthala/backend-engineer-takehome/model"
 	reflect "reflect"
 
@@ -34,6 +35,21 @@ func (m *MockReceipts) EXPECT() *MockReceiptsMockRecorder {
 	return m.recorder
 }
 
+// Find mocks base method.
+func (m *MockReceipts) Find(receiptID string) (*model.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", receiptID)
+	ret0, _ := ret[0].(*model.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockReceiptsMockRecorder) Find(receiptID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockReceipts)(nil).Find), receiptID)
+}
+
 // Get mocks base method.
 func (m *MockReceipts) Get(receiptID string) (*model.ReceiptGetResponse, error) {
 	m.ctrl.T.Helper()
@@ -50,16 +66,763 @@ func (mr *MockReceiptsMockRecorder) Get(receiptID interface{}) *gomock.Call {
 }
 
 // Insert mocks base method.
-func (m *MockReceipts) Insert(receipt *model.Receipt) (*model.ReceiptPostResponse, error) {
+func (m *MockReceipts) Insert(ctx context.Context, receipt *model.Receipt) (*model.ReceiptPostResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Insert", receipt)
+	ret := m.ctrl.Call(m, "Insert", ctx, receipt)
 	ret0, _ := ret[0].(*model.ReceiptPostResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Insert indicates an expected call of Insert.
-func (mr *MockReceiptsMockRecorder) Insert(receipt interface{}) *gomock.Call {
+func (mr *MockReceiptsMockRecorder) Insert(ctx, receipt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockReceipts)(nil).Insert), ctx, receipt)
+}
+
+// List mocks base method.
+func (m *MockReceipts) List(filter model.ReceiptFilter, offset, limit int) ([]model.Receipt, int) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", filter, offset, limit)
+	ret0, _ := ret[0].([]model.Receipt)
+	ret1, _ := ret[1].(int)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockReceiptsMockRecorder) List(filter, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReceipts)(nil).List), filter, offset, limit)
+}
+
+// MockUsers is a mock of Users interface.
+type MockUsers struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsersMockRecorder
+}
+
+// MockUsersMockRecorder is the mock recorder for MockUsers.
+type MockUsersMockRecorder struct {
+	mock *MockUsers
+}
+
+// NewMockUsers creates a new mock instance.
+func NewMockUsers(ctrl *gomock.Controller) *MockUsers {
+	mock := &MockUsers{ctrl: ctrl}
+	mock.recorder = &MockUsersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsers) EXPECT() *MockUsersMockRecorder {
+	return m.recorder
+}
+
+// Balance mocks base method.
+func (m *MockUsers) Balance(userID string) (*model.BalanceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Balance", userID)
+	ret0, _ := ret[0].(*model.BalanceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Balance indicates an expected call of Balance.
+func (mr *MockUsersMockRecorder) Balance(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balance", reflect.TypeOf((*MockUsers)(nil).Balance), userID)
+}
+
+// Client mocks base method.
+func (m *MockUsers) Client(userID string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Client", userID)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Client indicates an expected call of Client.
+func (mr *MockUsersMockRecorder) Client(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Client", reflect.TypeOf((*MockUsers)(nil).Client), userID)
+}
+
+// Expiring mocks base method.
+func (m *MockUsers) Expiring(userID string, days int) (*model.ExpiringResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expiring", userID, days)
+	ret0, _ := ret[0].(*model.ExpiringResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Expiring indicates an expected call of Expiring.
+func (mr *MockUsersMockRecorder) Expiring(userID, days interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expiring", reflect.TypeOf((*MockUsers)(nil).Expiring), userID, days)
+}
+
+// Ledger mocks base method.
+func (m *MockUsers) Ledger(userID string, page, pageSize int) (*model.LedgerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ledger", userID, page, pageSize)
+	ret0, _ := ret[0].(*model.LedgerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Ledger indicates an expected call of Ledger.
+func (mr *MockUsersMockRecorder) Ledger(userID, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ledger", reflect.TypeOf((*MockUsers)(nil).Ledger), userID, page, pageSize)
+}
+
+// MockRewards is a mock of Rewards interface.
+type MockRewards struct {
+	ctrl     *gomock.Controller
+	recorder *MockRewardsMockRecorder
+}
+
+// MockRewardsMockRecorder is the mock recorder for MockRewards.
+type MockRewardsMockRecorder struct {
+	mock *MockRewards
+}
+
+// NewMockRewards creates a new mock instance.
+func NewMockRewards(ctrl *gomock.Controller) *MockRewards {
+	mock := &MockRewards{ctrl: ctrl}
+	mock.recorder = &MockRewardsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRewards) EXPECT() *MockRewardsMockRecorder {
+	return m.recorder
+}
+
+// Insert mocks base method.
+func (m *MockRewards) Insert(reward *model.Reward) (*model.Reward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", reward)
+	ret0, _ := ret[0].(*model.Reward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockRewardsMockRecorder) Insert(reward interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockRewards)(nil).Insert), reward)
+}
+
+// List mocks base method.
+func (m *MockRewards) List() []model.Reward {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]model.Reward)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockRewardsMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRewards)(nil).List))
+}
+
+// Redeem mocks base method.
+func (m *MockRewards) Redeem(userID string, req *model.RedemptionRequest) (*model.Redemption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeem", userID, req)
+	ret0, _ := ret[0].(*model.Redemption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeem indicates an expected call of Redeem.
+func (mr *MockRewardsMockRecorder) Redeem(userID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockRewards)(nil).Redeem), userID, req)
+}
+
+// MockReturns is a mock of Returns interface.
+type MockReturns struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnsMockRecorder
+}
+
+// MockReturnsMockRecorder is the mock recorder for MockReturns.
+type MockReturnsMockRecorder struct {
+	mock *MockReturns
+}
+
+// NewMockReturns creates a new mock instance.
+func NewMockReturns(ctrl *gomock.Controller) *MockReturns {
+	mock := &MockReturns{ctrl: ctrl}
+	mock.recorder = &MockReturnsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturns) EXPECT() *MockReturnsMockRecorder {
+	return m.recorder
+}
+
+// History mocks base method.
+func (m *MockReturns) History(receiptID string, principal *model.Principal) (*model.AdjustmentHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", receiptID, principal)
+	ret0, _ := ret[0].(*model.AdjustmentHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockReturnsMockRecorder) History(receiptID, principal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockReturns)(nil).History), receiptID, principal)
+}
+
+// Return mocks base method.
+func (m *MockReturns) Return(receiptID string, req *model.ReturnRequest, principal *model.Principal) (*model.Adjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Return", receiptID, req, principal)
+	ret0, _ := ret[0].(*model.Adjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Return indicates an expected call of Return.
+func (mr *MockReturnsMockRecorder) Return(receiptID, req, principal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Return", reflect.TypeOf((*MockReturns)(nil).Return), receiptID, req, principal)
+}
+
+// MockCampaigns is a mock of Campaigns interface.
+type MockCampaigns struct {
+	ctrl     *gomock.Controller
+	recorder *MockCampaignsMockRecorder
+}
+
+// MockCampaignsMockRecorder is the mock recorder for MockCampaigns.
+type MockCampaignsMockRecorder struct {
+	mock *MockCampaigns
+}
+
+// NewMockCampaigns creates a new mock instance.
+func NewMockCampaigns(ctrl *gomock.Controller) *MockCampaigns {
+	mock := &MockCampaigns{ctrl: ctrl}
+	mock.recorder = &MockCampaignsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCampaigns) EXPECT() *MockCampaignsMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCampaigns) Delete(campaignID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", campaignID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCampaignsMockRecorder) Delete(campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCampaigns)(nil).Delete), campaignID)
+}
+
+// Get mocks base method.
+func (m *MockCampaigns) Get(campaignID string) (*model.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", campaignID)
+	ret0, _ := ret[0].(*model.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCampaignsMockRecorder) Get(campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCampaigns)(nil).Get), campaignID)
+}
+
+// Insert mocks base method.
+func (m *MockCampaigns) Insert(campaign *model.Campaign) (*model.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", campaign)
+	ret0, _ := ret[0].(*model.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockCampaignsMockRecorder) Insert(campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockCampaigns)(nil).Insert), campaign)
+}
+
+// List mocks base method.
+func (m *MockCampaigns) List() []model.Campaign {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]model.Campaign)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockCampaignsMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCampaigns)(nil).List))
+}
+
+// Update mocks base method.
+func (m *MockCampaigns) Update(campaignID string, campaign *model.Campaign) (*model.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", campaignID, campaign)
+	ret0, _ := ret[0].(*model.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCampaignsMockRecorder) Update(campaignID, campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCampaigns)(nil).Update), campaignID, campaign)
+}
+
+// MockRetailers is a mock of Retailers interface.
+type MockRetailers struct {
+	ctrl     *gomock.Controller
+	recorder *MockRetailersMockRecorder
+}
+
+// MockRetailersMockRecorder is the mock recorder for MockRetailers.
+type MockRetailersMockRecorder struct {
+	mock *MockRetailers
+}
+
+// NewMockRetailers creates a new mock instance.
+func NewMockRetailers(ctrl *gomock.Controller) *MockRetailers {
+	mock := &MockRetailers{ctrl: ctrl}
+	mock.recorder = &MockRetailersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRetailers) EXPECT() *MockRetailersMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRetailers) Delete(retailerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", retailerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRetailersMockRecorder) Delete(retailerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRetailers)(nil).Delete), retailerID)
+}
+
+// Get mocks base method.
+func (m *MockRetailers) Get(retailerID string) (*model.Retailer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", retailerID)
+	ret0, _ := ret[0].(*model.Retailer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRetailersMockRecorder) Get(retailerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRetailers)(nil).Get), retailerID)
+}
+
+// Insert mocks base method.
+func (m *MockRetailers) Insert(ctx context.Context, retailer *model.Retailer) (*model.Retailer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, retailer)
+	ret0, _ := ret[0].(*model.Retailer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockRetailersMockRecorder) Insert(ctx, retailer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockRetailers)(nil).Insert), ctx, retailer)
+}
+
+// List mocks base method.
+func (m *MockRetailers) List() []model.Retailer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]model.Retailer)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockRetailersMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRetailers)(nil).List))
+}
+
+// Update mocks base method.
+func (m *MockRetailers) Update(ctx context.Context, retailerID string, retailer *model.Retailer) (*model.Retailer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, retailerID, retailer)
+	ret0, _ := ret[0].(*model.Retailer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRetailersMockRecorder) Update(ctx, retailerID, retailer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRetailers)(nil).Update), ctx, retailerID, retailer)
+}
+
+// MockReviews is a mock of Reviews interface.
+type MockReviews struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewsMockRecorder
+}
+
+// MockReviewsMockRecorder is the mock recorder for MockReviews.
+type MockReviewsMockRecorder struct {
+	mock *MockReviews
+}
+
+// NewMockReviews creates a new mock instance.
+func NewMockReviews(ctrl *gomock.Controller) *MockReviews {
+	mock := &MockReviews{ctrl: ctrl}
+	mock.recorder = &MockReviewsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviews) EXPECT() *MockReviewsMockRecorder {
+	return m.recorder
+}
+
+// Decide mocks base method.
+func (m *MockReviews) Decide(ctx context.Context, receiptID string, decision *model.ReviewDecision) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decide", ctx, receiptID, decision)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decide indicates an expected call of Decide.
+func (mr *MockReviewsMockRecorder) Decide(ctx, receiptID, decision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decide", reflect.TypeOf((*MockReviews)(nil).Decide), ctx, receiptID, decision)
+}
+
+// List mocks base method.
+func (m *MockReviews) List(status string) []model.Review {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", status)
+	ret0, _ := ret[0].([]model.Review)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockReviewsMockRecorder) List(status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReviews)(nil).List), status)
+}
+
+// MockJobs is a mock of Jobs interface.
+type MockJobs struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobsMockRecorder
+}
+
+// MockJobsMockRecorder is the mock recorder for MockJobs.
+type MockJobsMockRecorder struct {
+	mock *MockJobs
+}
+
+// NewMockJobs creates a new mock instance.
+func NewMockJobs(ctrl *gomock.Controller) *MockJobs {
+	mock := &MockJobs{ctrl: ctrl}
+	mock.recorder = &MockJobsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobs) EXPECT() *MockJobsMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockJobs) Enqueue(receipt *model.Receipt) (*model.ReceiptJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", receipt)
+	ret0, _ := ret[0].(*model.ReceiptJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockJobsMockRecorder) Enqueue(receipt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockJobs)(nil).Enqueue), receipt)
+}
+
+// Get mocks base method.
+func (m *MockJobs) Get(jobID string) (*model.ReceiptJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", jobID)
+	ret0, _ := ret[0].(*model.ReceiptJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockJobsMockRecorder) Get(jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockJobs)(nil).Get), jobID)
+}
+
+// MockWebhooks is a mock of Webhooks interface.
+type MockWebhooks struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhooksMockRecorder
+}
+
+// MockWebhooksMockRecorder is the mock recorder for MockWebhooks.
+type MockWebhooksMockRecorder struct {
+	mock *MockWebhooks
+}
+
+// NewMockWebhooks creates a new mock instance.
+func NewMockWebhooks(ctrl *gomock.Controller) *MockWebhooks {
+	mock := &MockWebhooks{ctrl: ctrl}
+	mock.recorder = &MockWebhooksMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhooks) EXPECT() *MockWebhooksMockRecorder {
+	return m.recorder
+}
+
+// Acknowledge mocks base method.
+func (m *MockWebhooks) Acknowledge(webhookID, deliveryID string, principal *model.Principal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acknowledge", webhookID, deliveryID, principal)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Acknowledge indicates an expected call of Acknowledge.
+func (mr *MockWebhooksMockRecorder) Acknowledge(webhookID, deliveryID, principal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acknowledge", reflect.TypeOf((*MockWebhooks)(nil).Acknowledge), webhookID, deliveryID, principal)
+}
+
+// DeadLetters mocks base method.
+func (m *MockWebhooks) DeadLetters(webhookID string, principal *model.Principal) ([]model.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeadLetters", webhookID, principal)
+	ret0, _ := ret[0].([]model.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeadLetters indicates an expected call of DeadLetters.
+func (mr *MockWebhooksMockRecorder) DeadLetters(webhookID, principal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadLetters", reflect.TypeOf((*MockWebhooks)(nil).DeadLetters), webhookID, principal)
+}
+
+// Delete mocks base method.
+func (m *MockWebhooks) Delete(webhookID string, principal *model.Principal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", webhookID, principal)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhooksMockRecorder) Delete(webhookID, principal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhooks)(nil).Delete), webhookID, principal)
+}
+
+// Deliveries mocks base method.
+func (m *MockWebhooks) Deliveries(webhookID, status string, principal *model.Principal) ([]model.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliveries", webhookID, status, principal)
+	ret0, _ := ret[0].([]model.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deliveries indicates an expected call of Deliveries.
+func (mr *MockWebhooksMockRecorder) Deliveries(webhookID, status, principal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliveries", reflect.TypeOf((*MockWebhooks)(nil).Deliveries), webhookID, status, principal)
+}
+
+// Insert mocks base method.
+func (m *MockWebhooks) Insert(webhook *model.Webhook, principal *model.Principal) (*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", webhook, principal)
+	ret0, _ := ret[0].(*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockWebhooksMockRecorder) Insert(webhook, principal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockWebhooks)(nil).Insert), webhook, principal)
+}
+
+// List mocks base method.
+func (m *MockWebhooks) List(principal *model.Principal) []model.Webhook {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", principal)
+	ret0, _ := ret[0].([]model.Webhook)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockWebhooksMockRecorder) List(principal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWebhooks)(nil).List), principal)
+}
+
+// Replay mocks base method.
+func (m *MockWebhooks) Replay(webhookID, deliveryID string, principal *model.Principal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replay", webhookID, deliveryID, principal)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replay indicates an expected call of Replay.
+func (mr *MockWebhooksMockRecorder) Replay(webhookID, deliveryID, principal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replay", reflect.TypeOf((*MockWebhooks)(nil).Replay), webhookID, deliveryID, principal)
+}
+
+// MockPublisher is a mock of Publisher interface.
+type MockPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherMockRecorder
+}
+
+// MockPublisherMockRecorder is the mock recorder for MockPublisher.
+type MockPublisherMockRecorder struct {
+	mock *MockPublisher
+}
+
+// NewMockPublisher creates a new mock instance.
+func NewMockPublisher(ctrl *gomock.Controller) *MockPublisher {
+	mock := &MockPublisher{ctrl: ctrl}
+	mock.recorder = &MockPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisher) EXPECT() *MockPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockPublisher) Publish(event *model.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", event)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockPublisherMockRecorder) Publish(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPublisher)(nil).Publish), event)
+}
+
+// MockRedeliverer is a mock of Redeliverer interface.
+type MockRedeliverer struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedelivererMockRecorder
+}
+
+// MockRedelivererMockRecorder is the mock recorder for MockRedeliverer.
+type MockRedelivererMockRecorder struct {
+	mock *MockRedeliverer
+}
+
+// NewMockRedeliverer creates a new mock instance.
+func NewMockRedeliverer(ctrl *gomock.Controller) *MockRedeliverer {
+	mock := &MockRedeliverer{ctrl: ctrl}
+	mock.recorder = &MockRedelivererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedeliverer) EXPECT() *MockRedelivererMockRecorder {
+	return m.recorder
+}
+
+// Redeliver mocks base method.
+func (m *MockRedeliverer) Redeliver(webhook *model.Webhook, event *model.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Redeliver", webhook, event)
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockRedelivererMockRecorder) Redeliver(webhook, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockRedeliverer)(nil).Redeliver), webhook, event)
+}
+
+// MockStream is a mock of Stream interface.
+type MockStream struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamMockRecorder
+}
+
+// MockStreamMockRecorder is the mock recorder for MockStream.
+type MockStreamMockRecorder struct {
+	mock *MockStream
+}
+
+// NewMockStream creates a new mock instance.
+func NewMockStream(ctrl *gomock.Controller) *MockStream {
+	mock := &MockStream{ctrl: ctrl}
+	mock.recorder = &MockStreamMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStream) EXPECT() *MockStreamMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockStream) Subscribe(filter model.StreamFilter, lastEventID uint64) (<-chan model.StreamEvent, []model.StreamEvent, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", filter, lastEventID)
+	ret0, _ := ret[0].(<-chan model.StreamEvent)
+	ret1, _ := ret[1].([]model.StreamEvent)
+	ret2, _ := ret[2].(func())
+	return ret0, ret1, ret2
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockStreamMockRecorder) Subscribe(filter, lastEventID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockReceipts)(nil).Insert), receipt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockStream)(nil).Subscribe), filter, lastEventID)
 }
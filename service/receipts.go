@@ -1,50 +1,289 @@
 package service
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/google/uuid"
 	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/fraud"
 	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/metrics"
 	"github/shivasaicharanruthala/backend-engineer-takehome/model"
 )
 
 // receiptsService is a service layer structure for handling receipt-related operations.
 type receiptsService struct {
 	logger    *log.CustomLogger
-	dataStore data.Receipts // Data layer interface for interacting with the receipt data store.
+	dataStore data.Receipts  // Data layer interface for interacting with the receipt data store.
+	ledger    data.Ledger    // Points ledger credited with the points of receipts submitted on behalf of a user, optional.
+	expiry    int            // Months after which credited points expire, 0 when they never expire.
+	campaigns data.Campaigns // Campaigns evaluated after the base rules, optional.
+	retailers data.Retailers // Catalog raw retailer names are resolved against, optional.
+
+	receiptCap  int              // Maximum number of points awarded to a receipt, 0 when uncapped.
+	dailyCap    int              // Maximum number of points awarded to the receipts of a user per UTC day, 0 when uncapped.
+	dailyPoints data.DailyPoints // Points reserved for each user on the current day, required by the daily cap.
+
+	scorer          *fraud.Scorer // Fraud scoring of receipts, optional.
+	riskWindow      int           // Number of latest receipts of the same submitter the detectors look at.
+	reviewThreshold int           // Risk score from which points are held for review, 0 never holds points.
+	reviews         data.Reviews  // Review queue of the receipts whose points are held.
+
+	publishers []Publisher // Notified of every scored receipt.
+}
+
+// Option configures optional dependencies of receiptsService.
+type Option func(*receiptsService)
+
+// WithLedger credits the points of every receipt submitted on behalf of a user to the ledger.
+func WithLedger(ledger data.Ledger) Option {
+	return func(rs *receiptsService) {
+		rs.ledger = ledger
+	}
+}
+
+// WithPointsExpiry makes credited points expire the given number of months after they were earned, 0 disables expiry.
+func WithPointsExpiry(months int) Option {
+	return func(rs *receiptsService) {
+		rs.expiry = months
+	}
+}
+
+// WithCampaigns evaluates the stored campaigns when scoring receipts.
+func WithCampaigns(campaigns data.Campaigns) Option {
+	return func(rs *receiptsService) {
+		rs.campaigns = campaigns
+	}
+}
+
+// WithRetailers resolves the raw retailer name of every receipt to a canonical retailer of the catalog.
+func WithRetailers(retailers data.Retailers) Option {
+	return func(rs *receiptsService) {
+		rs.retailers = retailers
+	}
+}
+
+// WithReceiptPointsCap caps the points awarded to a single receipt, 0 disables the cap.
+func WithReceiptPointsCap(limit int) Option {
+	return func(rs *receiptsService) {
+		rs.receiptCap = limit
+	}
+}
+
+// WithDailyPointsCap caps the points awarded to the receipts of a user per UTC day, counted in dailyPoints, 0 disables the cap.
+// Receipts submitted without a user are not subject to it.
+func WithDailyPointsCap(limit int, dailyPoints data.DailyPoints) Option {
+	return func(rs *receiptsService) {
+		rs.dailyCap = limit
+		rs.dailyPoints = dailyPoints
+	}
+}
+
+// WithFraudScoring assesses the fraud risk of every receipt against the window latest receipts of its submitter.
+// Points of receipts with a risk score of at least threshold are held in the reviews queue instead of being credited,
+// a threshold of 0 only records the risk.
+func WithFraudScoring(scorer *fraud.Scorer, window, threshold int, reviews data.Reviews) Option {
+	return func(rs *receiptsService) {
+		rs.scorer = scorer
+		rs.riskWindow = window
+		rs.reviewThreshold = threshold
+		rs.reviews = reviews
+	}
+}
+
+// WithPublisher publishes a receipt.scored event for every scored receipt, it may be given for several publishers.
+// A nil publisher is ignored.
+func WithPublisher(publisher Publisher) Option {
+	return func(rs *receiptsService) {
+		if publisher != nil {
+			rs.publishers = append(rs.publishers, publisher)
+		}
+	}
 }
 
 // New creates and returns a new instance of receiptsService which implements all methods of the interface service.Receipts.
-func New(l *log.CustomLogger, ds data.Receipts) Receipts {
-	return &receiptsService{
+func New(l *log.CustomLogger, ds data.Receipts, opts ...Option) Receipts {
+	rs := &receiptsService{
 		logger:    l,
 		dataStore: ds,
 	}
+
+	for _, opt := range opts {
+		opt(rs)
+	}
+
+	return rs
 }
 
 // Get retrieves a receipt from the data store by its ID.
 // It returns a ReceiptGetResponse containing the points if the receipt is found,
 // otherwise, it returns an error indicating that the receipt was not found.
+// The status of the review of receipts held for their fraud risk is included.
 func (rs receiptsService) Get(receiptID string) (*model.ReceiptGetResponse, error) {
-	return rs.dataStore.Get(receiptID)
+	resp, err := rs.dataStore.Get(receiptID)
+	if err != nil || rs.reviews == nil {
+		return resp, err
+	}
+
+	if review, err := rs.reviews.Get(receiptID); err == nil {
+		resp.Status = review.Status
+	}
+
+	return resp, nil
+}
+
+// Find retrieves the full receipt stored under an ID, with its items and the breakdown of its points.
+func (rs receiptsService) Find(receiptID string) (*model.Receipt, error) {
+	return rs.dataStore.Find(receiptID)
+}
+
+// List retrieves the receipts selected by filter, newest first, skipping offset receipts and returning at most limit.
+// It also returns the number of receipts selected by filter.
+func (rs receiptsService) List(filter model.ReceiptFilter, offset, limit int) ([]model.Receipt, int) {
+	return rs.dataStore.List(filter, offset, limit)
 }
 
 // Insert adds a new receipt to the data store after validating and calculating its points.
 // It validates the receipt payload, calculates the receipt points, generates a new UUID for the receipt,
 // and then inserts it into the data store. It returns a ReceiptPostResponse containing the ID of the newly inserted receipt.
-func (rs receiptsService) Insert(receipt *model.Receipt) (*model.ReceiptPostResponse, error) {
+func (rs receiptsService) Insert(ctx context.Context, receipt *model.Receipt) (*model.ReceiptPostResponse, error) {
 	// Validates the receipt payload.
 	err := receipt.PayloadValidation()
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculates the points for the receipt.
-	if err = receipt.CalculateTotalReceiptPoints(); err != nil {
+	// Resolves the raw retailer name, the raw name is kept as submitted and still drives the scoring rules.
+	if rs.retailers != nil {
+		if retailer, ok := rs.retailers.Resolve(*receipt.Retailer); ok {
+			receipt.RetailerID, receipt.RetailerName = retailer.ID, retailer.Name
+		}
+	}
+
+	// Calculates the points for the receipt, campaigns are evaluated after the base rules.
+	var campaigns []model.Campaign
+	if rs.campaigns != nil {
+		campaigns = rs.campaigns.List()
+	}
+
+	if err = receipt.CalculateTotalReceiptPoints(campaigns...); err != nil {
 		return nil, err
 	}
 
-	// Generates a new UUID for the receipt.
-	receipt.Id = uuid.New().String()
+	// Binds the user to the submitting client once the receipt is scored, clients cannot credit nor read the points of
+	// users of other clients. Nothing fails past this point, receipts that are not stored leave the user unbound.
+	if rs.ledger != nil && receipt.UserID != "" && receipt.ClientID != "" {
+		if err = rs.ledger.Bind(receipt.UserID, receipt.ClientID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Caps the points awarded, the computed points are kept on the cap for auditing.
+	scoredAt := time.Now().UTC()
+	rs.applyCaps(receipt, scoredAt)
+
+	// Assesses the fraud risk against the previous receipts of the submitter.
+	held := false
+	if rs.scorer != nil {
+		risk := rs.scorer.Score(receipt, rs.dataStore.Recent(receipt.Submitter(), rs.riskWindow))
+		receipt.Risk = &risk
+		held = rs.reviewThreshold > 0 && risk.Score >= rs.reviewThreshold
+	}
+
+	// Generates a new UUID for the receipt, unless it was assigned when the receipt was queued.
+	if receipt.Id == "" {
+		receipt.Id = uuid.New().String()
+	}
+
+	inserted := model.NewDomainEvent(model.DomainReceiptInserted, receipt.Id, receipt.ClientID, receipt.UserID, receipt.Points, time.Now().UTC())
+
+	// Holds the points of risky receipts for review, they are credited once approved. The review is stored along with
+	// the receipt, so that returns never find a held receipt without its review.
+	var resp *model.ReceiptPostResponse
+	if held {
+		review := &model.Review{
+			ReceiptID: receipt.Id,
+			UserID:    receipt.UserID,
+			ClientID:  receipt.ClientID,
+			Points:    receipt.Points,
+			Risk:      *receipt.Risk,
+			Status:    model.ReviewPending,
+			CreatedAt: scoredAt,
+		}
+
+		resp = rs.dataStore.InsertHeld(receipt, review, rs.reviews, inserted)
+
+		metrics.ReceiptsHeld.WithLabelValues().Inc()
+
+		lm := log.Message{Level: "INFO", Msg: fmt.Sprintf("Receipt %v held for review with risk score %v", receipt.Id, receipt.Risk.Score)}
+		rs.logger.LogContext(ctx, &lm)
+	} else {
+		resp = rs.dataStore.Insert(receipt, inserted)
+	}
+
+	// Credits the points of the receipt to the user it was submitted on behalf of, unless they are held for review.
+	// The ledger writes the points.awarded event of the credit.
+	if rs.ledger != nil && receipt.UserID != "" && !held {
+		rs.ledger.Append(newCredit(receipt.UserID, receipt.Id, receipt.Points, rs.expiry, time.Now().UTC()))
+	}
+
+	// Records the scoring outcome of the receipt.
+	metrics.ReceiptsInserted.WithLabelValues().Inc()
+	metrics.PointsAwarded.WithLabelValues().Observe(float64(receipt.Points))
+	for _, rp := range receipt.Breakdown {
+		metrics.RuleHits.WithLabelValues(rp.Rule).Inc()
+	}
+	if receipt.Cap != nil {
+		metrics.PointsCapped.WithLabelValues(receipt.Cap.Type).Add(float64(receipt.Cap.ComputedPoints - receipt.Cap.AwardedPoints))
+	}
+
+	if len(rs.publishers) > 0 {
+		data := model.ReceiptEventData{ReceiptID: receipt.Id, Retailer: *receipt.Retailer, Points: receipt.Points}
+		if held {
+			data.Status = model.ReviewPending
+		}
+
+		event := model.NewEvent(model.EventReceiptScored, receipt.ClientID, data)
+		event.UserID = receipt.UserID
+		for _, publisher := range rs.publishers {
+			publisher.Publish(event)
+		}
+	}
+
+	return resp, nil
+}
+
+// applyCaps lowers the points of a receipt to the per receipt cap, then to what is left of the daily cap of its user.
+// The points are reserved against the daily cap of the day the receipt is scored, held points included, and
+// released if a review rejects the receipt.
+func (rs receiptsService) applyCaps(receipt *model.Receipt, scoredAt time.Time) {
+	if rs.receiptCap > 0 {
+		receipt.ApplyCap(model.CapReceipt, rs.receiptCap, rs.receiptCap)
+	}
+
+	if rs.dailyCap > 0 && rs.dailyPoints != nil && receipt.UserID != "" {
+		day := scoredAt.Format("2006-01-02")
+		receipt.ApplyCap(model.CapUserDaily, rs.dailyCap, rs.dailyPoints.Reserve(receipt.UserID, day, receipt.Points, rs.dailyCap))
+	}
+}
+
+// newCredit returns the ledger credit of the points of a receipt, expiring the given number of months after createdAt, 0 never expires.
+func newCredit(userID, receiptID string, points, expiry int, createdAt time.Time) *model.LedgerEntry {
+	credit := &model.LedgerEntry{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		ReceiptID: receiptID,
+		Type:      model.LedgerCredit,
+		Points:    points,
+		CreatedAt: createdAt,
+	}
+
+	if expiry > 0 {
+		expiresAt := createdAt.AddDate(0, expiry, 0)
+		credit.ExpiresAt = &expiresAt
+	}
 
-	return rs.dataStore.Insert(receipt), nil
+	return credit
 }
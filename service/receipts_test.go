@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -9,6 +10,7 @@ import (
 	store "github/shivasaicharanruthala/backend-engineer-takehome/data"
 	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
 	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/metrics"
 	"github/shivasaicharanruthala/backend-engineer-takehome/model"
 )
 
@@ -178,7 +180,7 @@ func TestServiceInsert_Failure(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		_, err := receiptService.Insert(tc.receipt)
+		_, err := receiptService.Insert(context.Background(), tc.receipt)
 		assert.Equal(t, tc.expectedError.Error(), err.Error())
 	}
 }
@@ -346,9 +348,160 @@ func TestServiceInsert_Success(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		receiptResp, _ := receiptService.Insert(tc.receipt)
+		receiptResp, _ := receiptService.Insert(context.Background(), tc.receipt)
 
 		receipt, _ := receiptStore.Get(receiptResp.Id)
 		assert.Equal(t, tc.expectedPoints, receipt.Points, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
 	}
 }
+
+func TestServiceInsert_Metrics(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	receiptStore := store.New(logger)
+	receiptService := New(logger, receiptStore)
+
+	inserted := metrics.ReceiptsInserted.WithLabelValues()
+	points := metrics.PointsAwarded.WithLabelValues()
+	roundDollar := metrics.RuleHits.WithLabelValues("round_dollar_total")
+	insertedBefore, pointsBefore, roundDollarBefore := inserted.Value(), points.Count(), roundDollar.Value()
+
+	_, err := receiptService.Insert(context.Background(), &model.Receipt{
+		Retailer:     model.StringPointer("Target"),
+		PurchaseDate: model.StringPointer("2022-01-02"),
+		PurchaseTime: model.StringPointer("13:01"),
+		Total:        model.StringPointer("5.00"),
+		Items:        []model.Item{{ShortDescription: model.StringPointer("Mountain Dew 12PK"), Price: model.StringPointer("5.00")}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, insertedBefore+1, inserted.Value())
+	assert.Equal(t, pointsBefore+1, points.Count())
+	assert.Equal(t, roundDollarBefore+1, roundDollar.Value())
+}
+
+func TestServiceInsert_CreditsLedger(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	ledger := store.NewLedger(logger)
+	receiptService := New(logger, store.New(logger), WithLedger(ledger), WithPointsExpiry(12))
+
+	newReceipt := func(userID string) *model.Receipt {
+		return &model.Receipt{
+			UserID:       userID,
+			Retailer:     model.StringPointer("Target"),
+			PurchaseDate: model.StringPointer("2022-01-02"),
+			PurchaseTime: model.StringPointer("13:01"),
+			Total:        model.StringPointer("5.00"),
+			Items:        []model.Item{{ShortDescription: model.StringPointer("Mountain Dew 12PK"), Price: model.StringPointer("5.00")}},
+		}
+	}
+
+	receipt := newReceipt("user-1")
+	resp, err := receiptService.Insert(context.Background(), receipt)
+	assert.NoError(t, err)
+
+	_, err = receiptService.Insert(context.Background(), newReceipt(""))
+	assert.NoError(t, err)
+
+	entries, total, err := ledger.List("user-1", 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, resp.Id, entries[0].ReceiptID)
+	assert.Equal(t, model.LedgerCredit, entries[0].Type)
+	assert.Equal(t, receipt.Points, entries[0].Points)
+	assert.Equal(t, entries[0].CreatedAt.AddDate(1, 0, 0), *entries[0].ExpiresAt)
+}
+
+func TestServiceInsert_BindsUser(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	receiptService := New(logger, store.New(logger), WithLedger(store.NewLedger(logger)))
+
+	newReceipt := func(clientID, purchaseDate string) *model.Receipt {
+		return &model.Receipt{
+			ClientID:     clientID,
+			UserID:       "user-1",
+			Retailer:     model.StringPointer("Target"),
+			PurchaseDate: model.StringPointer(purchaseDate),
+			PurchaseTime: model.StringPointer("13:01"),
+			Total:        model.StringPointer("5.00"),
+			Items:        []model.Item{{ShortDescription: model.StringPointer("Mountain Dew 12PK"), Price: model.StringPointer("5.00")}},
+		}
+	}
+
+	testCases := []struct {
+		id          int
+		useCase     string
+		receipt     *model.Receipt
+		expectedErr bool
+	}{
+		{id: 1, useCase: "Negative case: receipt failing to score", receipt: newReceipt("partner-a", "2022-13-45"), expectedErr: true},
+		{id: 2, useCase: "Positive case: user left unbound by a receipt failing to score", receipt: newReceipt("partner-b", "2022-01-02")},
+		{id: 3, useCase: "Negative case: user bound to another client", receipt: newReceipt("partner-a", "2022-01-02"), expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		_, err := receiptService.Insert(context.Background(), tc.receipt)
+		assert.Equal(t, tc.expectedErr, err != nil, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestServiceInsert_PointsCaps(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	receipts, ledger := store.New(logger), store.NewLedger(logger)
+	receiptService := New(logger, receipts, WithLedger(ledger), WithReceiptPointsCap(50), WithDailyPointsCap(120, store.NewDailyPoints(logger)))
+
+	// Scores 81 points: 6 for the retailer, 50 for the round total and 25 for the multiple of 0.25.
+	newReceipt := func(userID string) *model.Receipt {
+		return &model.Receipt{
+			UserID:       userID,
+			Retailer:     model.StringPointer("Target"),
+			PurchaseDate: model.StringPointer("2022-01-02"),
+			PurchaseTime: model.StringPointer("13:01"),
+			Total:        model.StringPointer("5.00"),
+			Items:        []model.Item{{ShortDescription: model.StringPointer("Mountain Dew 12PK"), Price: model.StringPointer("5.00")}},
+		}
+	}
+
+	testCases := []struct {
+		id             int
+		useCase        string
+		userID         string
+		expectedPoints int
+		expectedCap    *model.PointsCap
+	}{
+		{
+			id: 1, useCase: "Positive case: receipt cap applied",
+			userID: "user-1", expectedPoints: 50,
+			expectedCap: &model.PointsCap{Type: model.CapReceipt, Limit: 50, ComputedPoints: 81, AwardedPoints: 50},
+		},
+		{
+			id: 2, useCase: "Positive case: receipt cap applied within the daily cap",
+			userID: "user-1", expectedPoints: 50,
+			expectedCap: &model.PointsCap{Type: model.CapReceipt, Limit: 50, ComputedPoints: 81, AwardedPoints: 50},
+		},
+		{
+			id: 3, useCase: "Positive case: daily cap applied to what is left",
+			userID: "user-1", expectedPoints: 20,
+			expectedCap: &model.PointsCap{Type: model.CapUserDaily, Limit: 120, ComputedPoints: 81, AwardedPoints: 20},
+		},
+		{
+			id: 4, useCase: "Positive case: daily cap does not apply without user",
+			expectedPoints: 50,
+			expectedCap:    &model.PointsCap{Type: model.CapReceipt, Limit: 50, ComputedPoints: 81, AwardedPoints: 50},
+		},
+	}
+
+	for _, tc := range testCases {
+		resp, err := receiptService.Insert(context.Background(), newReceipt(tc.userID))
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+
+		points, err := receipts.Get(resp.Id)
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedPoints, points.Points, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedCap, points.Cap, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+
+	// Only the awarded points are credited.
+	balance, err := ledger.Balance("user-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 120, balance)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// retailersService is a service layer structure for managing the retailer catalog.
+type retailersService struct {
+	logger    *log.CustomLogger
+	retailers data.Retailers // Data layer interface for interacting with the retailer catalog.
+}
+
+// NewRetailers creates and returns a new instance of retailersService which implements all methods of the interface service.Retailers.
+func NewRetailers(l *log.CustomLogger, retailers data.Retailers) Retailers {
+	return &retailersService{
+		logger:    l,
+		retailers: retailers,
+	}
+}
+
+// Get retrieves a retailer by its ID.
+func (rs retailersService) Get(retailerID string) (*model.Retailer, error) {
+	return rs.retailers.Get(retailerID)
+}
+
+// List returns every retailer of the catalog in resolution order.
+func (rs retailersService) List() []model.Retailer {
+	return rs.retailers.List()
+}
+
+// Insert validates a retailer, generates its ID and adds it to the catalog.
+func (rs retailersService) Insert(ctx context.Context, retailer *model.Retailer) (*model.Retailer, error) {
+	if err := retailer.PayloadValidation(); err != nil {
+		return nil, err
+	}
+
+	retailer.ID = uuid.New().String()
+	retailer.Name = model.NormalizeRetailerName(retailer.Name)
+	if err := rs.retailers.Insert(retailer); err != nil {
+		return nil, err
+	}
+
+	return retailer, nil
+}
+
+// Update validates a retailer and replaces the retailer of the catalog with the given ID.
+// Receipts already submitted keep the retailer they were resolved to.
+func (rs retailersService) Update(ctx context.Context, retailerID string, retailer *model.Retailer) (*model.Retailer, error) {
+	if err := retailer.PayloadValidation(); err != nil {
+		return nil, err
+	}
+
+	retailer.ID = retailerID
+	retailer.Name = model.NormalizeRetailerName(retailer.Name)
+	if err := rs.retailers.Update(retailer); err != nil {
+		return nil, err
+	}
+
+	return retailer, nil
+}
+
+// Delete removes the retailer with the given ID from the catalog.
+func (rs retailersService) Delete(retailerID string) error {
+	return rs.retailers.Delete(retailerID)
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	store "github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestServiceRetailerInsert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	logger, _ := log.NewCustomLogger("test.log")
+	retailers := store.NewMockRetailers(ctrl)
+	retailersService := NewRetailers(logger, retailers)
+
+	conflict := errors.NewConflict(fmt.Errorf("Retailer 'Target' already exists with Id: 'a'"))
+
+	testCases := []struct {
+		id            int
+		useCase       string
+		retailer      *model.Retailer
+		mockCall      *gomock.Call
+		expectedName  string
+		expectedError error
+	}{
+		{
+			id: 1, useCase: "Negative case: invalid alias",
+			retailer:      &model.Retailer{Name: "Target", Aliases: []string{"["}},
+			expectedError: errors.InvalidParam{Param: "aliases"},
+		},
+		{
+			id: 2, useCase: "Negative case: duplicate name",
+			retailer:      &model.Retailer{Name: "Target"},
+			mockCall:      retailers.EXPECT().Insert(gomock.Any()).Return(conflict),
+			expectedError: conflict,
+		},
+		{
+			id: 3, useCase: "Positive case: name is normalized",
+			retailer:     &model.Retailer{Name: "  Trader   Joe's "},
+			mockCall:     retailers.EXPECT().Insert(gomock.Any()).Return(nil),
+			expectedName: "Trader Joe's",
+		},
+	}
+
+	for _, tc := range testCases {
+		retailer, err := retailersService.Insert(context.Background(), tc.retailer)
+		if tc.expectedError != nil {
+			assert.EqualError(t, err, tc.expectedError.Error(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			continue
+		}
+
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.True(t, model.IsValidUUID(retailer.ID), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedName, retailer.Name, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestServiceInsert_ResolvesRetailer(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	receipts, retailers := store.New(logger), store.NewRetailers(logger)
+	receiptService := New(logger, receipts, WithRetailers(retailers))
+
+	target, err := NewRetailers(logger, retailers).Insert(context.Background(), &model.Retailer{Name: "Target", Aliases: []string{`target #\d+`}})
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		id           int
+		useCase      string
+		retailer     string
+		retailerID   string
+		retailerName string
+		points       int
+	}{
+		{id: 1, useCase: "Positive case: alias resolved, raw name scored", retailer: "TARGET #1234", retailerID: target.ID, retailerName: "Target", points: 10},
+		{id: 2, useCase: "Positive case: unknown retailer kept raw", retailer: "Corner Market", points: 12},
+	}
+
+	for _, tc := range testCases {
+		resp, err := receiptService.Insert(context.Background(), &model.Receipt{
+			Retailer:     model.StringPointer(tc.retailer),
+			PurchaseDate: model.StringPointer("2024-03-02"),
+			PurchaseTime: model.StringPointer("09:00"),
+			Total:        model.StringPointer("1.49"),
+			Items:        []model.Item{{ShortDescription: model.StringPointer("Pepsi"), Price: model.StringPointer("1.49")}},
+		})
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+
+		receipt, err := receipts.Find(resp.Id)
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.retailer, *receipt.Retailer, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.retailerID, receipt.RetailerID, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.retailerName, receipt.RetailerName, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.points, receipt.Points, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// returnsService is a service layer structure for returns of receipt items and the resulting points reversals.
+type returnsService struct {
+	logger      *log.CustomLogger
+	mu          sync.Mutex       // Serializes returns so that each one is computed on the items left by the previous ones.
+	receipts    data.Receipts    // Data layer interface for reading the original receipts.
+	adjustments data.Adjustments // Data layer interface for the adjustment history of receipts.
+	ledger      data.Ledger      // Points ledger the reversals are written to, optional.
+	reviews     data.Reviews     // Fraud reviews of receipts whose points were held, optional.
+	publisher   Publisher        // Notified of every adjustment, optional.
+}
+
+// NewReturns creates and returns a new instance of returnsService which implements all methods of the interface service.Returns.
+// A nil ledger records adjustments without reversing points of users, nil reviews treat every receipt as credited.
+// A non nil publisher is notified of every adjustment with a receipt.adjusted event.
+func NewReturns(l *log.CustomLogger, receipts data.Receipts, adjustments data.Adjustments, ledger data.Ledger, reviews data.Reviews, publisher Publisher) Returns {
+	return &returnsService{
+		logger:      l,
+		receipts:    receipts,
+		adjustments: adjustments,
+		ledger:      ledger,
+		reviews:     reviews,
+		publisher:   publisher,
+	}
+}
+
+// Return records the return of items of a receipt. The points of the items kept are recomputed with the scoring rules
+// and the difference is recorded as an adjustment of the receipt, and as a reversal in the ledger of its user.
+// The points of capped receipts are reversed in the proportion the caps lowered them, see model.Receipt.AwardedShare.
+// Returns never increase the points of a receipt, even if the items kept happen to score higher.
+// Receipts pending a fraud review cannot be adjusted, and rejected receipts are adjusted without reversal as their points were never credited.
+func (rs *returnsService) Return(receiptID string, req *model.ReturnRequest, principal *model.Principal) (*model.Adjustment, error) {
+	if err := req.PayloadValidation(); err != nil {
+		return nil, err
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	receipt, history, err := rs.find(receiptID, principal)
+	if err != nil {
+		return nil, err
+	}
+
+	credited, err := rs.credited(receiptID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replays the previous returns on the original receipt to get the items still kept.
+	current, pointsBefore := receipt, receipt.Points
+	for _, adj := range history {
+		if current, err = current.WithoutItems(adj.Items); err != nil {
+			return nil, err
+		}
+
+		pointsBefore += adj.Points
+	}
+
+	adjusted, err := current.WithoutItems(req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	// Campaigns applied to the receipt still apply to the items kept, as long as they match them.
+	if err = adjusted.CalculateTotalReceiptPoints(receipt.AppliedCampaignDefinitions()...); err != nil {
+		return nil, err
+	}
+
+	adjustment := &model.Adjustment{
+		ID:             uuid.New().String(),
+		ReceiptID:      receiptID,
+		Type:           model.AdjustmentReturn,
+		Items:          req.Items,
+		RemainingTotal: *adjusted.Total,
+		PointsBefore:   pointsBefore,
+		PointsAfter:    min(receipt.AwardedShare(adjusted.Points), pointsBefore),
+		CreatedAt:      time.Now().UTC(),
+	}
+	adjustment.Points = adjustment.PointsAfter - adjustment.PointsBefore
+
+	rs.adjustments.Insert(adjustment)
+
+	if rs.ledger != nil && credited && receipt.UserID != "" && adjustment.Points < 0 {
+		rs.ledger.Append(&model.LedgerEntry{
+			ID:        uuid.New().String(),
+			UserID:    receipt.UserID,
+			ReceiptID: receiptID,
+			Type:      model.LedgerReversal,
+			Points:    adjustment.Points,
+			CreatedAt: adjustment.CreatedAt,
+		})
+	}
+
+	if rs.publisher != nil {
+		event := model.NewEvent(model.EventReceiptAdjusted, receipt.ClientID, model.ReceiptEventData{
+			ReceiptID:  receiptID,
+			Points:     adjustment.PointsAfter,
+			Adjustment: adjustment,
+		})
+		event.UserID = receipt.UserID
+		rs.publisher.Publish(event)
+	}
+
+	return adjustment, nil
+}
+
+// History retrieves the original and current points of a receipt along with its adjustments.
+func (rs *returnsService) History(receiptID string, principal *model.Principal) (*model.AdjustmentHistory, error) {
+	receipt, adjustments, err := rs.find(receiptID, principal)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &model.AdjustmentHistory{
+		ReceiptID:      receiptID,
+		OriginalPoints: receipt.Points,
+		Points:         receipt.Points,
+		Adjustments:    adjustments,
+	}
+
+	for _, adj := range adjustments {
+		history.Points += adj.Points
+	}
+
+	return history, nil
+}
+
+// credited reports whether the points of a receipt were credited, they are not for receipts rejected by a fraud review.
+// It returns a conflict for receipts pending a review.
+func (rs *returnsService) credited(receiptID string) (bool, error) {
+	if rs.reviews == nil {
+		return true, nil
+	}
+
+	review, err := rs.reviews.Get(receiptID)
+	if err != nil {
+		return true, nil // never held for review
+	}
+
+	switch review.Status {
+	case model.ReviewPending:
+		return false, errors.NewConflict(fmt.Errorf("Receipt '%v' is pending a fraud review", receiptID))
+	case model.ReviewRejected:
+		return false, nil
+	default:
+		return true, nil
+	}
+}
+
+// find retrieves a receipt and its adjustments. Receipts of other clients are reported as not found to not disclose they exist.
+func (rs *returnsService) find(receiptID string, principal *model.Principal) (*model.Receipt, []model.Adjustment, error) {
+	receipt, err := rs.receipts.Find(receiptID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if principal != nil && !principal.CanAccess(receipt.ClientID, receipt.UserID) {
+		return nil, nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "receipts", ID: receiptID})
+	}
+
+	return receipt, rs.adjustments.List(receiptID), nil
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	store "github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestServiceReturn(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	receipts, ledger := store.New(logger), store.NewLedger(logger)
+	receiptService := New(logger, receipts, WithLedger(ledger))
+	returnsService := NewReturns(logger, receipts, store.NewAdjustments(logger), ledger, nil, nil)
+
+	// Scores 28 points: 6 for the retailer, 10 for two pairs of items, 3 + 3 for descriptions and 6 for the odd day.
+	resp, err := receiptService.Insert(context.Background(), &model.Receipt{
+		UserID:       "user-1",
+		ClientID:     "partner-a",
+		Retailer:     model.StringPointer("Target"),
+		PurchaseDate: model.StringPointer("2022-01-01"),
+		PurchaseTime: model.StringPointer("13:01"),
+		Total:        model.StringPointer("35.35"),
+		Items: []model.Item{
+			{ShortDescription: model.StringPointer("Mountain Dew 12PK"), Price: model.StringPointer("6.49")},
+			{ShortDescription: model.StringPointer("Emils Cheese Pizza"), Price: model.StringPointer("12.25")},
+			{ShortDescription: model.StringPointer("Knorr Creamy Chicken"), Price: model.StringPointer("1.26")},
+			{ShortDescription: model.StringPointer("Doritos Nacho Cheese"), Price: model.StringPointer("3.35")},
+			{ShortDescription: model.StringPointer("   Klarbrunn 12-PK 12 FL OZ  "), Price: model.StringPointer("12.00")},
+		},
+	})
+	assert.NoError(t, err)
+
+	pizza := model.Item{ShortDescription: model.StringPointer("Emils Cheese Pizza"), Price: model.StringPointer("12.25")}
+	klarbrunn := model.Item{ShortDescription: model.StringPointer("Klarbrunn 12-PK 12 FL OZ"), Price: model.StringPointer("12.00")}
+	owner := &model.Principal{ClientID: "partner-a", Scopes: []string{model.ScopeSubmit}}
+
+	testCases := []struct {
+		id             int
+		useCase        string
+		items          []model.Item
+		principal      *model.Principal
+		expectedBefore int
+		expectedAfter  int
+		expectedTotal  string
+		expectedError  error
+	}{
+		{
+			id: 1, useCase: "Negative case: receipt of another client",
+			items:         []model.Item{pizza},
+			principal:     &model.Principal{ClientID: "partner-b", Scopes: []string{model.ScopeSubmit}},
+			expectedError: errors.EntityNotFound{Entity: "receipts", ID: resp.Id},
+		},
+		{
+			id: 2, useCase: "Negative case: no items",
+			principal:     owner,
+			expectedError: errors.MissingParam{Param: "items"},
+		},
+		{
+			id: 3, useCase: "Positive case: return loses the description points of the item",
+			items:          []model.Item{pizza},
+			principal:      owner,
+			expectedBefore: 28, expectedAfter: 25, expectedTotal: "23.10",
+		},
+		{
+			id: 4, useCase: "Negative case: item already returned",
+			items:         []model.Item{pizza},
+			principal:     owner,
+			expectedError: fmt.Errorf("Item 'Emils Cheese Pizza' priced 12.25 is not on the receipt or was already returned"),
+		},
+		{
+			id: 5, useCase: "Positive case: second return applies to the items kept",
+			items:          []model.Item{klarbrunn},
+			principal:      owner,
+			expectedBefore: 25, expectedAfter: 17, expectedTotal: "11.10",
+		},
+	}
+
+	for _, tc := range testCases {
+		adjustment, err := returnsService.Return(resp.Id, &model.ReturnRequest{Items: tc.items}, tc.principal)
+		if tc.expectedError != nil {
+			assert.EqualError(t, err, tc.expectedError.Error(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			continue
+		}
+
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedBefore, adjustment.PointsBefore, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedAfter, adjustment.PointsAfter, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedAfter-tc.expectedBefore, adjustment.Points, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedTotal, adjustment.RemainingTotal, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+
+	history, err := returnsService.History(resp.Id, owner)
+	assert.NoError(t, err)
+	assert.Equal(t, 28, history.OriginalPoints)
+	assert.Equal(t, 17, history.Points)
+	assert.Len(t, history.Adjustments, 2)
+
+	// The original receipt is kept as submitted.
+	original, _ := receipts.Get(resp.Id)
+	assert.Equal(t, 28, original.Points)
+
+	balance, _ := ledger.Balance("user-1")
+	assert.Equal(t, 17, balance)
+
+	entries, _, _ := ledger.List("user-1", 0, 10)
+	assert.Equal(t, model.LedgerReversal, entries[0].Type)
+	assert.Equal(t, -8, entries[0].Points)
+}
+
+func TestServiceReturn_CappedReceipt(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	receipts, ledger := store.New(logger), store.NewLedger(logger)
+	receiptService := New(logger, receipts, WithLedger(ledger), WithReceiptPointsCap(14))
+	returnsService := NewReturns(logger, receipts, store.NewAdjustments(logger), ledger, nil, nil)
+
+	// Scores 28 points capped to 14, half of the points recomputed on the items kept are awarded.
+	resp, err := receiptService.Insert(context.Background(), &model.Receipt{
+		UserID:       "user-1",
+		ClientID:     "partner-a",
+		Retailer:     model.StringPointer("Target"),
+		PurchaseDate: model.StringPointer("2022-01-01"),
+		PurchaseTime: model.StringPointer("13:01"),
+		Total:        model.StringPointer("35.35"),
+		Items: []model.Item{
+			{ShortDescription: model.StringPointer("Mountain Dew 12PK"), Price: model.StringPointer("6.49")},
+			{ShortDescription: model.StringPointer("Emils Cheese Pizza"), Price: model.StringPointer("12.25")},
+			{ShortDescription: model.StringPointer("Knorr Creamy Chicken"), Price: model.StringPointer("1.26")},
+			{ShortDescription: model.StringPointer("Doritos Nacho Cheese"), Price: model.StringPointer("3.35")},
+			{ShortDescription: model.StringPointer("   Klarbrunn 12-PK 12 FL OZ  "), Price: model.StringPointer("12.00")},
+		},
+	})
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		id             int
+		useCase        string
+		item           model.Item
+		expectedBefore int
+		expectedAfter  int
+	}{
+		{
+			id: 1, useCase: "Positive case: return reverses the share of the capped points, 25 points recomputed",
+			item:           model.Item{ShortDescription: model.StringPointer("Emils Cheese Pizza"), Price: model.StringPointer("12.25")},
+			expectedBefore: 14, expectedAfter: 12,
+		},
+		{
+			id: 2, useCase: "Positive case: second return reverses the share of the capped points, 17 points recomputed",
+			item:           model.Item{ShortDescription: model.StringPointer("Klarbrunn 12-PK 12 FL OZ"), Price: model.StringPointer("12.00")},
+			expectedBefore: 12, expectedAfter: 8,
+		},
+	}
+
+	for _, tc := range testCases {
+		adjustment, err := returnsService.Return(resp.Id, &model.ReturnRequest{Items: []model.Item{tc.item}}, nil)
+
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedBefore, adjustment.PointsBefore, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedAfter, adjustment.PointsAfter, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+
+	balance, _ := ledger.Balance("user-1")
+	assert.Equal(t, 8, balance)
+}
+
+func TestServiceReturn_NeverIncreasesPoints(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	receipts := store.New(logger)
+	returnsService := NewReturns(logger, receipts, store.NewAdjustments(logger), nil, nil, nil)
+
+	// Returning the 0.35 item makes the total a round dollar amount, which would score 75 more points.
+	receipts.Insert(&model.Receipt{
+		Id:           "4a77ec9d-5334-43d0-a9e1-4fca8807bf8f",
+		Points:       6,
+		Retailer:     model.StringPointer("Target"),
+		PurchaseDate: model.StringPointer("2022-01-02"),
+		PurchaseTime: model.StringPointer("13:01"),
+		Total:        model.StringPointer("5.35"),
+		Items: []model.Item{
+			{ShortDescription: model.StringPointer("Gum"), Price: model.StringPointer("0.35")},
+			{ShortDescription: model.StringPointer("Gatorade"), Price: model.StringPointer("5.00")},
+		},
+	})
+
+	adjustment, err := returnsService.Return("4a77ec9d-5334-43d0-a9e1-4fca8807bf8f", &model.ReturnRequest{Items: []model.Item{
+		{ShortDescription: model.StringPointer("Gum"), Price: model.StringPointer("0.35")},
+	}}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 6, adjustment.PointsAfter)
+	assert.Equal(t, 0, adjustment.Points)
+}
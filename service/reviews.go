@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/metrics"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// reviewsService is a service layer structure for the review queue of receipts held for their fraud risk.
+type reviewsService struct {
+	logger      *log.CustomLogger
+	reviews     data.Reviews     // Data layer interface for the review queue.
+	ledger      data.Ledger      // Points ledger the held points are credited to once approved, optional.
+	dailyPoints data.DailyPoints // Daily cap the held points were reserved against, released once rejected, optional.
+	expiry      int              // Months after which credited points expire, 0 when they never expire.
+	publisher   Publisher        // Notified of rejected receipts, optional.
+}
+
+// NewReviews creates and returns a new instance of reviewsService which implements all methods of the interface service.Reviews.
+// Approved points expire the given number of months after the approval, 0 never expires.
+// The points of rejected receipts are released from the daily cap counted in dailyPoints, when it is not nil.
+// A non nil publisher is notified of every rejected receipt with a receipt.voided event.
+func NewReviews(l *log.CustomLogger, reviews data.Reviews, ledger data.Ledger, dailyPoints data.DailyPoints, expiry int, publisher Publisher) Reviews {
+	return &reviewsService{
+		logger:      l,
+		reviews:     reviews,
+		ledger:      ledger,
+		dailyPoints: dailyPoints,
+		expiry:      expiry,
+		publisher:   publisher,
+	}
+}
+
+// List returns the reviews with the given status, every review when status is empty, oldest first.
+func (rs reviewsService) List(status string) []model.Review {
+	return rs.reviews.List(status)
+}
+
+// Decide approves or rejects a receipt pending review. The held points are credited to the user of an approved receipt
+// and never credited for a rejected one. Deciding a review twice is a conflict.
+func (rs reviewsService) Decide(ctx context.Context, receiptID string, decision *model.ReviewDecision) (*model.Review, error) {
+	if err := decision.PayloadValidation(); err != nil {
+		return nil, err
+	}
+
+	pending, err := rs.reviews.Get(receiptID)
+	if err != nil {
+		return nil, err
+	}
+
+	decidedAt := time.Now().UTC()
+
+	review, err := rs.reviews.Decide(receiptID, decision.Status(), decision.Note, decidedAt, rs.events(pending, decision.Status(), decidedAt)...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The held points are credited once approved, the ledger writes the points.awarded event of the credit.
+	if review.Status == model.ReviewApproved && rs.ledger != nil && review.UserID != "" {
+		rs.ledger.Append(newCredit(review.UserID, review.ReceiptID, review.Points, rs.expiry, decidedAt))
+	}
+
+	// The points of a rejected receipt no longer count against the daily cap of the day it was scored.
+	if review.Status == model.ReviewRejected && rs.dailyPoints != nil && review.UserID != "" {
+		rs.dailyPoints.Release(review.UserID, review.CreatedAt.UTC().Format("2006-01-02"), review.Points)
+	}
+
+	if review.Status == model.ReviewRejected && rs.publisher != nil {
+		event := model.NewEvent(model.EventReceiptVoided, review.ClientID, model.ReceiptEventData{
+			ReceiptID: review.ReceiptID,
+			Points:    review.Points,
+			Status:    review.Status,
+		})
+		event.UserID = review.UserID
+		rs.publisher.Publish(event)
+	}
+
+	metrics.ReviewsDecided.WithLabelValues(review.Status).Inc()
+
+	lm := log.Message{Level: "INFO", Msg: fmt.Sprintf("Review of receipt %v decided: %v", receiptID, review.Status)}
+	rs.logger.LogContext(ctx, &lm)
+
+	return review, nil
+}
+
+// events returns the domain events of a decision: a rejected receipt is voided. The points of an approved receipt
+// are awarded by the ledger credit.
+func (rs reviewsService) events(review *model.Review, status string, decidedAt time.Time) []model.DomainEvent {
+	if status != model.ReviewRejected {
+		return nil
+	}
+
+	return []model.DomainEvent{model.NewDomainEvent(model.DomainReceiptVoided, review.ReceiptID, review.ClientID, review.UserID, review.Points, decidedAt)}
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	store "github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/fraud"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// flagRetailer is a fraud detector flagging every receipt of a retailer.
+type flagRetailer string
+
+func (d flagRetailer) Name() string { return "flag_retailer" }
+
+func (d flagRetailer) Detect(receipt *model.Receipt, recent []model.Receipt) (int, string) {
+	if *receipt.Retailer != string(d) {
+		return 0, ""
+	}
+
+	return 60, "flagged retailer"
+}
+
+func TestServiceReviews(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	receipts, ledger, reviews := store.New(logger), store.NewLedger(logger), store.NewReviews(logger)
+
+	scorer := fraud.New(logger, flagRetailer("Shady Mart"))
+	receiptService := New(logger, receipts, WithLedger(ledger), WithFraudScoring(scorer, 20, 50, reviews))
+	returnsService := NewReturns(logger, receipts, store.NewAdjustments(logger), ledger, reviews, nil)
+	reviewsService := NewReviews(logger, reviews, ledger, nil, 0, nil)
+
+	// Scores the retailer points, 5 for the pair of items and 1 for the description of the gum: 18 at Corner Market, 15 at Shady Mart.
+	newReceipt := func(retailer string) *model.Receipt {
+		return &model.Receipt{
+			UserID:       "user-1",
+			Retailer:     model.StringPointer(retailer),
+			PurchaseDate: model.StringPointer("2024-03-02"),
+			PurchaseTime: model.StringPointer("09:00"),
+			Total:        model.StringPointer("3.49"),
+			Items: []model.Item{
+				{ShortDescription: model.StringPointer("Pepsi"), Price: model.StringPointer("1.49")},
+				{ShortDescription: model.StringPointer("Gum"), Price: model.StringPointer("2.00")},
+			},
+		}
+	}
+
+	genuine, err := receiptService.Insert(context.Background(), newReceipt("Corner Market"))
+	assert.NoError(t, err)
+	approved, err := receiptService.Insert(context.Background(), newReceipt("Shady Mart"))
+	assert.NoError(t, err)
+	rejected, err := receiptService.Insert(context.Background(), newReceipt("Shady Mart"))
+	assert.NoError(t, err)
+
+	// Only the genuine receipt is credited, the others are held.
+	balance, _ := ledger.Balance("user-1")
+	assert.Equal(t, 18, balance)
+	assert.Len(t, reviewsService.List(model.ReviewPending), 2)
+
+	points, err := receiptService.Get(approved.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ReviewPending, points.Status)
+
+	points, err = receiptService.Get(genuine.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "", points.Status)
+
+	stored, _ := receipts.Find(approved.Id)
+	assert.Equal(t, &model.RiskAssessment{Score: 60, Signals: []model.RiskSignal{{Detector: "flag_retailer", Score: 60, Reason: "flagged retailer"}}}, stored.Risk)
+
+	returned := &model.ReturnRequest{Items: []model.Item{{ShortDescription: model.StringPointer("Gum"), Price: model.StringPointer("2.00")}}}
+
+	testCases := []struct {
+		id              int
+		useCase         string
+		run             func() error
+		expectedBalance int
+		expectedError   string
+	}{
+		{
+			id: 1, useCase: "Negative case: return of a receipt pending review",
+			run: func() error {
+				_, err := returnsService.Return(approved.Id, returned, nil)
+				return err
+			},
+			expectedBalance: 18,
+			expectedError:   fmt.Sprintf("Receipt '%v' is pending a fraud review", approved.Id),
+		},
+		{
+			id: 2, useCase: "Negative case: invalid decision",
+			run: func() error {
+				_, err := reviewsService.Decide(context.Background(), approved.Id, &model.ReviewDecision{Decision: "maybe"})
+				return err
+			},
+			expectedBalance: 18,
+			expectedError:   "Incorrect value for parameter: decision",
+		},
+		{
+			id: 3, useCase: "Positive case: approval credits the held points",
+			run: func() error {
+				_, err := reviewsService.Decide(context.Background(), approved.Id, &model.ReviewDecision{Decision: model.DecisionApprove})
+				return err
+			},
+			expectedBalance: 33,
+		},
+		{
+			id: 4, useCase: "Negative case: approval decided twice",
+			run: func() error {
+				_, err := reviewsService.Decide(context.Background(), approved.Id, &model.ReviewDecision{Decision: model.DecisionApprove})
+				return err
+			},
+			expectedBalance: 33,
+			expectedError:   fmt.Sprintf("Review of receipt '%v' was already decided: approved", approved.Id),
+		},
+		{
+			id: 5, useCase: "Positive case: rejection credits nothing",
+			run: func() error {
+				_, err := reviewsService.Decide(context.Background(), rejected.Id, &model.ReviewDecision{Decision: model.DecisionReject, Note: "farming"})
+				return err
+			},
+			expectedBalance: 33,
+		},
+		{
+			id: 6, useCase: "Positive case: return of a rejected receipt reverses nothing",
+			run: func() error {
+				_, err := returnsService.Return(rejected.Id, returned, nil)
+				return err
+			},
+			expectedBalance: 33,
+		},
+		{
+			id: 7, useCase: "Positive case: return of an approved receipt reverses its points",
+			run: func() error {
+				_, err := returnsService.Return(approved.Id, returned, nil)
+				return err
+			},
+			expectedBalance: 27,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.run()
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		} else {
+			assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+
+		balance, _ := ledger.Balance("user-1")
+		assert.Equal(t, tc.expectedBalance, balance, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+
+	assert.Len(t, reviewsService.List(model.ReviewPending), 0)
+}
+
+func TestServiceReviews_DailyCap(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	receipts, ledger, reviews, dailyPoints := store.New(logger), store.NewLedger(logger), store.NewReviews(logger), store.NewDailyPoints(logger)
+
+	scorer := fraud.New(logger, flagRetailer("Shady Mart"))
+	receiptService := New(logger, receipts, WithLedger(ledger), WithDailyPointsCap(20, dailyPoints), WithFraudScoring(scorer, 20, 50, reviews))
+	reviewsService := NewReviews(logger, reviews, ledger, dailyPoints, 0, nil)
+
+	newReceipt := func(retailer string) *model.Receipt {
+		return &model.Receipt{
+			UserID:       "user-1",
+			Retailer:     model.StringPointer(retailer),
+			PurchaseDate: model.StringPointer("2024-03-02"),
+			PurchaseTime: model.StringPointer("09:00"),
+			Total:        model.StringPointer("3.49"),
+			Items: []model.Item{
+				{ShortDescription: model.StringPointer("Pepsi"), Price: model.StringPointer("1.49")},
+				{ShortDescription: model.StringPointer("Gum"), Price: model.StringPointer("2.00")},
+			},
+		}
+	}
+
+	// The 15 points held for review are reserved against the daily cap of 20 points.
+	held, err := receiptService.Insert(context.Background(), newReceipt("Shady Mart"))
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		id              int
+		useCase         string
+		decision        string
+		expectedBalance int
+	}{
+		{id: 1, useCase: "Positive case: points of a rejected receipt are released from the daily cap", decision: model.DecisionReject, expectedBalance: 18},
+		{id: 2, useCase: "Positive case: approval credits the points left of the daily cap when the receipt was held", decision: model.DecisionApprove, expectedBalance: 20},
+	}
+
+	for _, tc := range testCases {
+		if tc.id == 2 {
+			held, err = receiptService.Insert(context.Background(), newReceipt("Shady Mart"))
+			assert.NoError(t, err)
+		}
+
+		_, err = reviewsService.Decide(context.Background(), held.Id, &model.ReviewDecision{Decision: tc.decision})
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+
+		if tc.id == 1 {
+			_, err = receiptService.Insert(context.Background(), newReceipt("Corner Market"))
+			assert.NoError(t, err)
+		}
+
+		balance, _ := ledger.Balance("user-1")
+		assert.Equal(t, tc.expectedBalance, balance, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
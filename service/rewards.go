@@ -0,0 +1,75 @@
+package service
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// rewardsService is a service layer structure for the reward catalog and redemptions.
+type rewardsService struct {
+	logger  *log.CustomLogger
+	rewards data.Rewards // Data layer interface for interacting with the reward catalog.
+	ledger  data.Ledger  // Data layer interface for interacting with the points ledger.
+}
+
+// NewRewards creates and returns a new instance of rewardsService which implements all methods of the interface service.Rewards.
+func NewRewards(l *log.CustomLogger, rewards data.Rewards, ledger data.Ledger) Rewards {
+	return &rewardsService{
+		logger:  l,
+		rewards: rewards,
+		ledger:  ledger,
+	}
+}
+
+// List returns the reward catalog.
+func (rs rewardsService) List() []model.Reward {
+	return rs.rewards.List()
+}
+
+// Insert validates a reward, generates its ID and adds it to the catalog.
+func (rs rewardsService) Insert(reward *model.Reward) (*model.Reward, error) {
+	if err := reward.PayloadValidation(); err != nil {
+		return nil, err
+	}
+
+	reward.ID = uuid.New().String()
+	rs.rewards.Insert(reward)
+
+	return reward, nil
+}
+
+// Redeem spends points of a user on a reward and saves the redemption. The stock of the reward is checked and the
+// cost debited from the ledger in a single store operation, taking a unit of the inventory only once the debit
+// succeeded, so parallel redemptions can neither oversell a reward nor overspend a balance.
+func (rs rewardsService) Redeem(userID string, req *model.RedemptionRequest) (*model.Redemption, error) {
+	if err := req.PayloadValidation(); err != nil {
+		return nil, err
+	}
+
+	redemption := &model.Redemption{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		RewardID:  req.RewardID,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	err := rs.rewards.Redeem(redemption, func(cost int) (int, error) {
+		return rs.ledger.Debit(&model.LedgerEntry{
+			ID:           uuid.New().String(),
+			UserID:       userID,
+			RedemptionID: redemption.ID,
+			Type:         model.LedgerDebit,
+			Points:       -cost,
+			CreatedAt:    redemption.CreatedAt,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return redemption, nil
+}
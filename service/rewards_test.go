@@ -0,0 +1,175 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	store "github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestServiceRewardInsert(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	rewardsService := NewRewards(logger, store.NewRewards(logger), store.NewLedger(logger))
+
+	testCases := []struct {
+		id            int
+		useCase       string
+		reward        *model.Reward
+		expectedError error
+	}{
+		{id: 1, useCase: "Negative case: missing name", reward: &model.Reward{Cost: 10}, expectedError: errors.MissingParam{Param: "name"}},
+		{id: 2, useCase: "Negative case: cost not positive", reward: &model.Reward{Name: "Mug"}, expectedError: errors.InvalidParam{Param: "cost"}},
+		{id: 3, useCase: "Negative case: negative inventory", reward: &model.Reward{Name: "Mug", Cost: 10, Inventory: -1}, expectedError: errors.InvalidParam{Param: "inventory"}},
+		{id: 4, useCase: "Positive case: valid reward", reward: &model.Reward{Name: "Mug", Cost: 10, Inventory: 3}},
+	}
+
+	for _, tc := range testCases {
+		reward, err := rewardsService.Insert(tc.reward)
+		if tc.expectedError != nil {
+			assert.EqualError(t, err, tc.expectedError.Error(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			continue
+		}
+
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.True(t, model.IsValidUUID(reward.ID), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+
+	assert.Len(t, rewardsService.List(), 1)
+}
+
+func TestServiceRedeem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	logger, _ := log.NewCustomLogger("test.log")
+	rewards := store.NewMockRewards(ctrl)
+	ledger := store.NewMockLedger(ctrl)
+	rewardsService := NewRewards(logger, rewards, ledger)
+
+	// redeem stands for a redemption of a reward costing 30 points in stock.
+	redeem := func(redemption *model.Redemption, debit func(cost int) (int, error)) error {
+		balance, err := debit(30)
+		if err != nil {
+			return err
+		}
+
+		redemption.Points, redemption.Balance = 30, balance
+
+		return nil
+	}
+	insufficient := errors.NewConflict(fmt.Errorf("Insufficient points balance: 10 available, 30 required"))
+	outOfStock := errors.NewConflict(fmt.Errorf("Reward 'mug' is out of stock"))
+
+	testCases := []struct {
+		id              int
+		useCase         string
+		req             *model.RedemptionRequest
+		mockCalls       func()
+		expectedBalance int
+		expectedError   error
+	}{
+		{
+			id: 1, useCase: "Negative case: missing reward id",
+			req:           &model.RedemptionRequest{},
+			mockCalls:     func() {},
+			expectedError: errors.MissingParam{Param: "rewardId"},
+		},
+		{
+			id: 2, useCase: "Negative case: reward out of stock",
+			req:           &model.RedemptionRequest{RewardID: "mug"},
+			mockCalls:     func() { rewards.EXPECT().Redeem(gomock.Any(), gomock.Any()).Return(outOfStock) },
+			expectedError: outOfStock,
+		},
+		{
+			id: 3, useCase: "Negative case: insufficient balance fails the redemption",
+			req: &model.RedemptionRequest{RewardID: "mug"},
+			mockCalls: func() {
+				rewards.EXPECT().Redeem(gomock.Any(), gomock.Any()).DoAndReturn(redeem)
+				ledger.EXPECT().Debit(gomock.Any()).Return(10, insufficient)
+			},
+			expectedError: insufficient,
+		},
+		{
+			id: 4, useCase: "Positive case: redemption debits the cost",
+			req: &model.RedemptionRequest{RewardID: "mug"},
+			mockCalls: func() {
+				rewards.EXPECT().Redeem(gomock.Any(), gomock.Any()).DoAndReturn(redeem)
+				ledger.EXPECT().Debit(gomock.Any()).DoAndReturn(func(entry *model.LedgerEntry) (int, error) {
+					assert.Equal(t, "user-1", entry.UserID)
+					assert.Equal(t, model.LedgerDebit, entry.Type)
+					assert.Equal(t, -30, entry.Points)
+
+					return 70, nil
+				})
+			},
+			expectedBalance: 70,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc.mockCalls()
+
+		redemption, err := rewardsService.Redeem("user-1", tc.req)
+		if tc.expectedError != nil {
+			assert.EqualError(t, err, tc.expectedError.Error(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			continue
+		}
+
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedBalance, redemption.Balance, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, 30, redemption.Points, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+// TestServiceRedeem_Concurrent redeems in parallel against the in-memory stores, neither the balance nor the inventory may be overspent.
+func TestServiceRedeem_Concurrent(t *testing.T) {
+	testCases := []struct {
+		id                  int
+		useCase             string
+		balance             int
+		inventory           int
+		expectedRedemptions int
+	}{
+		{id: 1, useCase: "Balance runs out first", balance: 100, inventory: 10, expectedRedemptions: 3},
+		{id: 2, useCase: "Inventory runs out first", balance: 1000, inventory: 2, expectedRedemptions: 2},
+	}
+
+	for _, tc := range testCases {
+		logger, _ := log.NewCustomLogger("test.log")
+		rewards, ledger := store.NewRewards(logger), store.NewLedger(logger)
+		rewardsService := NewRewards(logger, rewards, ledger)
+
+		rewards.Insert(&model.Reward{ID: "mug", Name: "Mug", Cost: 30, Inventory: tc.inventory})
+		ledger.Append(&model.LedgerEntry{ID: "1", UserID: "user-1", Type: model.LedgerCredit, Points: tc.balance})
+
+		var wg sync.WaitGroup
+		var succeeded atomic.Int32
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				if _, err := rewardsService.Redeem("user-1", &model.RedemptionRequest{RewardID: "mug"}); err == nil {
+					succeeded.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		balance, _ := ledger.Balance("user-1")
+		reward, _ := rewards.Get("mug")
+		_, total, _ := ledger.List("user-1", 0, 100)
+
+		assert.Equal(t, int32(tc.expectedRedemptions), succeeded.Load(), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.balance-30*tc.expectedRedemptions, balance, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.inventory-tc.expectedRedemptions, reward.Inventory, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Len(t, rewards.Redemptions("user-1"), tc.expectedRedemptions, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, 1+tc.expectedRedemptions, total, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
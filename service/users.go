@@ -0,0 +1,71 @@
+package service
+
+import (
+	"time"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// usersService is a service layer structure for reading the points of users.
+type usersService struct {
+	logger *log.CustomLogger
+	ledger data.Ledger // Data layer interface for interacting with the points ledger.
+}
+
+// NewUsers creates and returns a new instance of usersService which implements all methods of the interface service.Users.
+func NewUsers(l *log.CustomLogger, ledger data.Ledger) Users {
+	return &usersService{
+		logger: l,
+		ledger: ledger,
+	}
+}
+
+// Client returns the client the user belongs to, empty when no client submitted receipts on behalf of the user.
+func (us usersService) Client(userID string) string {
+	return us.ledger.Client(userID)
+}
+
+// Balance retrieves the points balance of a user, it returns an error if the user is not known.
+func (us usersService) Balance(userID string) (*model.BalanceResponse, error) {
+	points, err := us.ledger.Balance(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.BalanceResponse{UserID: userID, Points: points}, nil
+}
+
+// Ledger retrieves a page of the ledger history of a user, newest entries first. Pages are numbered from 1.
+func (us usersService) Ledger(userID string, page, pageSize int) (*model.LedgerResponse, error) {
+	entries, total, err := us.ledger.List(userID, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.LedgerResponse{
+		UserID:   userID,
+		Entries:  entries,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	}, nil
+}
+
+// Expiring retrieves the points of a user that expire within the next days days, soonest first.
+func (us usersService) Expiring(userID string, days int) (*model.ExpiringResponse, error) {
+	now := time.Now().UTC()
+
+	expiring, err := us.ledger.Expiring(userID, now, now.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &model.ExpiringResponse{UserID: userID, Days: days, Entries: expiring}
+	for _, e := range expiring {
+		resp.Points += e.Points
+	}
+
+	return resp, nil
+}
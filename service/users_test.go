@@ -0,0 +1,92 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	store "github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+func TestServiceBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	logger, _ := log.NewCustomLogger("test.log")
+	ledger := store.NewMockLedger(ctrl)
+	usersService := NewUsers(logger, ledger)
+
+	ledger.EXPECT().Balance("user-1").Return(40, nil)
+	resp, err := usersService.Balance("user-1")
+	assert.NoError(t, err)
+	assert.Equal(t, &model.BalanceResponse{UserID: "user-1", Points: 40}, resp)
+
+	notFound := errors.NewEntityNotFound(errors.EntityNotFound{Entity: "users", ID: "user-2"})
+	ledger.EXPECT().Balance("user-2").Return(0, notFound)
+	resp, err = usersService.Balance("user-2")
+	assert.Nil(t, resp)
+	assert.Equal(t, notFound, err)
+}
+
+func TestServiceLedger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	logger, _ := log.NewCustomLogger("test.log")
+	ledger := store.NewMockLedger(ctrl)
+	usersService := NewUsers(logger, ledger)
+
+	entries := []model.LedgerEntry{{ID: "3", UserID: "user-1", Type: model.LedgerCredit, Points: 5}}
+
+	testCases := []struct {
+		id             int
+		useCase        string
+		page           int
+		pageSize       int
+		expectedOffset int
+		expectedResp   *model.LedgerResponse
+	}{
+		{
+			id: 1, useCase: "Positive case: first page",
+			page: 1, pageSize: 20, expectedOffset: 0,
+			expectedResp: &model.LedgerResponse{UserID: "user-1", Entries: entries, Page: 1, PageSize: 20, Total: 21},
+		},
+		{
+			id: 2, useCase: "Positive case: second page",
+			page: 2, pageSize: 20, expectedOffset: 20,
+			expectedResp: &model.LedgerResponse{UserID: "user-1", Entries: entries, Page: 2, PageSize: 20, Total: 21},
+		},
+	}
+
+	for _, tc := range testCases {
+		ledger.EXPECT().List("user-1", tc.expectedOffset, tc.pageSize).Return(entries, 21, nil)
+
+		resp, err := usersService.Ledger("user-1", tc.page, tc.pageSize)
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Equal(t, tc.expectedResp, resp, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestServiceExpiring(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	logger, _ := log.NewCustomLogger("test.log")
+	ledger := store.NewMockLedger(ctrl)
+	usersService := NewUsers(logger, ledger)
+
+	expiresAt := time.Now().UTC().AddDate(0, 0, 5)
+	expiring := []model.ExpiringPoints{{EntryID: "1", Points: 30, ExpiresAt: expiresAt}, {EntryID: "2", Points: 12, ExpiresAt: expiresAt}}
+
+	ledger.EXPECT().Expiring("user-1", gomock.Any(), gomock.Any()).DoAndReturn(func(userID string, from, until time.Time) ([]model.ExpiringPoints, error) {
+		assert.Equal(t, from.AddDate(0, 0, 7), until)
+
+		return expiring, nil
+	})
+
+	resp, err := usersService.Expiring("user-1", 7)
+	assert.NoError(t, err)
+	assert.Equal(t, &model.ExpiringResponse{UserID: "user-1", Days: 7, Points: 42, Entries: expiring}, resp)
+}
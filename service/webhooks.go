@@ -0,0 +1,162 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	er "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/errors"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// webhooksService is a service layer structure for managing the webhook subscriptions of clients.
+type webhooksService struct {
+	logger      *log.CustomLogger
+	webhooks    data.Webhooks    // Data layer interface for the webhook subscriptions.
+	deliveries  data.Deliveries  // Data layer interface for the delivery log of webhooks.
+	deadLetters data.DeadLetters // Data layer interface for the deliveries that failed their last attempt.
+	redeliverer Redeliverer      // Replays dead letters, nil when webhook deliveries are disabled.
+}
+
+// NewWebhooks creates and returns a new instance of webhooksService which implements all methods of the interface service.Webhooks.
+// Dead letters are replayed with redeliverer, which is nil when webhook deliveries are disabled.
+func NewWebhooks(l *log.CustomLogger, webhooks data.Webhooks, deliveries data.Deliveries, deadLetters data.DeadLetters, redeliverer Redeliverer) Webhooks {
+	return &webhooksService{
+		logger:      l,
+		webhooks:    webhooks,
+		deliveries:  deliveries,
+		deadLetters: deadLetters,
+		redeliverer: redeliverer,
+	}
+}
+
+// Insert validates a webhook and subscribes the client of the principal to its events, restricted to the events of
+// the user of the principal when it is a user. The generated secret signing the deliveries is only returned here.
+func (ws webhooksService) Insert(webhook *model.Webhook, principal *model.Principal) (*model.Webhook, error) {
+	if err := webhook.PayloadValidation(); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, errors.NewCustomError(err, 500)
+	}
+
+	webhook.ID = uuid.New().String()
+	webhook.Secret = hex.EncodeToString(secret)
+	webhook.CreatedAt = time.Now().UTC()
+	if principal != nil {
+		webhook.ClientID, webhook.UserID = principal.ClientID, principal.UserID
+	}
+
+	ws.webhooks.Insert(webhook)
+
+	return webhook, nil
+}
+
+// List returns the webhooks of the client of the principal without their secrets, oldest first.
+// Users authenticated themselves only list their own webhooks.
+func (ws webhooksService) List(principal *model.Principal) []model.Webhook {
+	clientID := ""
+	if principal != nil {
+		clientID = principal.ClientID
+	}
+
+	webhooks := make([]model.Webhook, 0)
+	for _, webhook := range ws.webhooks.List(clientID) {
+		if principal != nil && !principal.CanAccess(webhook.ClientID, webhook.UserID) {
+			continue
+		}
+
+		webhook.Secret = ""
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks
+}
+
+// Delete removes a webhook of the client of the principal, deliveries already queued are still attempted.
+func (ws webhooksService) Delete(webhookID string, principal *model.Principal) error {
+	if _, err := ws.find(webhookID, principal); err != nil {
+		return err
+	}
+
+	return ws.webhooks.Delete(webhookID)
+}
+
+// Deliveries returns the latest delivery attempts of a webhook with the given status, every attempt when status is empty, newest first.
+func (ws webhooksService) Deliveries(webhookID string, status string, principal *model.Principal) ([]model.Delivery, error) {
+	if status != "" && !model.IsValidDeliveryStatus(status) {
+		return nil, errors.NewInvalidParam(errors.InvalidParam{Param: "status"})
+	}
+
+	if _, err := ws.find(webhookID, principal); err != nil {
+		return nil, err
+	}
+
+	return ws.deliveries.List(webhookID, status), nil
+}
+
+// DeadLetters returns the deliveries of a webhook that failed their last attempt and were neither acknowledged nor
+// replayed, newest first.
+func (ws webhooksService) DeadLetters(webhookID string, principal *model.Principal) ([]model.Delivery, error) {
+	if _, err := ws.find(webhookID, principal); err != nil {
+		return nil, err
+	}
+
+	return ws.deadLetters.List(webhookID), nil
+}
+
+// Acknowledge removes a dead letter of a webhook without delivering its event again.
+func (ws webhooksService) Acknowledge(webhookID string, deliveryID string, principal *model.Principal) error {
+	if _, err := ws.find(webhookID, principal); err != nil {
+		return err
+	}
+
+	_, err := ws.deadLetters.Remove(webhookID, deliveryID)
+
+	return err
+}
+
+// Replay removes a dead letter of a webhook and delivers its event again, starting over with its first attempt.
+// A replay failing its last attempt is a new dead letter.
+func (ws webhooksService) Replay(webhookID string, deliveryID string, principal *model.Principal) error {
+	webhook, err := ws.find(webhookID, principal)
+	if err != nil {
+		return err
+	}
+
+	if ws.redeliverer == nil {
+		return errors.NewConflict(er.New("Webhook deliveries are disabled"))
+	}
+
+	delivery, err := ws.deadLetters.Remove(webhookID, deliveryID)
+	if err != nil {
+		return err
+	}
+
+	if delivery.Event != nil {
+		ws.redeliverer.Redeliver(webhook, delivery.Event)
+	}
+
+	return nil
+}
+
+// find retrieves a webhook. Webhooks of other clients, and of the client itself for users authenticated themselves,
+// are reported as not found to not disclose they exist.
+func (ws webhooksService) find(webhookID string, principal *model.Principal) (*model.Webhook, error) {
+	webhook, err := ws.webhooks.Get(webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if principal != nil && !principal.CanAccess(webhook.ClientID, webhook.UserID) {
+		return nil, errors.NewEntityNotFound(errors.EntityNotFound{Entity: "webhooks", ID: webhookID})
+	}
+
+	return webhook, nil
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	store "github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/fraud"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// recorder is a Publisher keeping the published events.
+type recorder []model.Event
+
+func (r *recorder) Publish(event *model.Event) { *r = append(*r, *event) }
+
+// redeliveries is a Redeliverer keeping the redelivered events.
+type redeliveries []model.Event
+
+func (r *redeliveries) Redeliver(_ *model.Webhook, event *model.Event) { *r = append(*r, *event) }
+
+func TestServiceWebhooks(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	webhooks, deliveries := store.NewWebhooks(logger), store.NewDeliveries(logger)
+	webhooksService := NewWebhooks(logger, webhooks, deliveries, store.NewDeadLetters(logger), nil)
+
+	partner := &model.Principal{ClientID: "partner", Scopes: []string{model.ScopeSubmit}}
+	other := &model.Principal{ClientID: "other", Scopes: []string{model.ScopeSubmit}}
+	user := &model.Principal{ClientID: "partner", UserID: "user-1", Scopes: []string{model.ScopeSubmit}}
+
+	created, err := webhooksService.Insert(&model.Webhook{URL: "https://partner.example/hooks", Events: []string{model.EventReceiptScored}}, partner)
+	assert.NoError(t, err)
+	assert.Len(t, created.Secret, 64)
+	assert.Equal(t, "partner", created.ClientID)
+
+	own, err := webhooksService.Insert(&model.Webhook{URL: "https://user.example/hooks", Events: []string{model.EventReceiptScored}}, user)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", own.UserID)
+
+	deliveries.Insert(&model.Delivery{ID: "1", WebhookID: created.ID, Status: model.DeliveryDead})
+
+	listed := webhooksService.List(partner)
+	assert.Len(t, listed, 2)
+	assert.Empty(t, listed[0].Secret)
+	assert.Empty(t, webhooksService.List(other))
+
+	userListed := webhooksService.List(user)
+	assert.Len(t, userListed, 1)
+	assert.Equal(t, own.ID, userListed[0].ID)
+
+	testCases := []struct {
+		id            int
+		useCase       string
+		webhookID     string
+		status        string
+		principal     *model.Principal
+		expectedCount int
+		expectedError string
+	}{
+		{id: 1, useCase: "Positive case: dead letters of own webhook", webhookID: created.ID, status: model.DeliveryDead, principal: partner, expectedCount: 1},
+		{id: 2, useCase: "Positive case: no successful delivery", webhookID: created.ID, status: model.DeliverySucceeded, principal: partner, expectedCount: 0},
+		{id: 3, useCase: "Negative case: webhook of another client", webhookID: created.ID, principal: other, expectedError: fmt.Sprintf("No 'webhooks' found for Id: '%v'", created.ID)},
+		{id: 4, useCase: "Negative case: unknown status", webhookID: created.ID, status: "lost", principal: partner, expectedError: "Incorrect value for parameter: status"},
+		{id: 5, useCase: "Negative case: webhook of the client read by one of its users", webhookID: created.ID, principal: user, expectedError: fmt.Sprintf("No 'webhooks' found for Id: '%v'", created.ID)},
+		{id: 6, useCase: "Positive case: webhook of a user read by its client", webhookID: own.ID, principal: partner, expectedCount: 0},
+	}
+
+	for _, tc := range testCases {
+		resp, err := webhooksService.Deliveries(tc.webhookID, tc.status, tc.principal)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			continue
+		}
+
+		assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		assert.Len(t, resp, tc.expectedCount, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+
+	assert.Error(t, webhooksService.Delete(created.ID, other))
+	assert.NoError(t, webhooksService.Delete(created.ID, partner))
+
+	_, err = webhooksService.Insert(&model.Webhook{URL: "ftp://partner.example", Events: []string{model.EventReceiptScored}}, partner)
+	assert.EqualError(t, err, "Incorrect value for parameter: url")
+}
+
+func TestServiceWebhookDeadLetters(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	webhooks, deadLetters := store.NewWebhooks(logger), store.NewDeadLetters(logger)
+	replayed := &redeliveries{}
+	webhooksService := NewWebhooks(logger, webhooks, store.NewDeliveries(logger), deadLetters, replayed)
+
+	partner := &model.Principal{ClientID: "partner", Scopes: []string{model.ScopeSubmit}}
+	other := &model.Principal{ClientID: "other", Scopes: []string{model.ScopeSubmit}}
+
+	created, err := webhooksService.Insert(&model.Webhook{URL: "https://partner.example/hooks", Events: []string{model.EventReceiptScored}}, partner)
+	assert.NoError(t, err)
+
+	for _, id := range []string{"1", "2", "3"} {
+		deadLetters.Insert(&model.Delivery{ID: id, WebhookID: created.ID, Status: model.DeliveryDead, Event: &model.Event{ID: "event-" + id}})
+	}
+
+	testCases := []struct {
+		id            int
+		useCase       string
+		replay        bool
+		deliveryID    string
+		principal     *model.Principal
+		expectedError string
+	}{
+		{id: 1, useCase: "Positive case: acknowledge own dead letter", deliveryID: "1", principal: partner},
+		{id: 2, useCase: "Positive case: replay own dead letter", replay: true, deliveryID: "2", principal: partner},
+		{id: 3, useCase: "Negative case: dead letter already replayed", replay: true, deliveryID: "2", principal: partner, expectedError: "No 'dead-letters' found for Id: '2'"},
+		{id: 4, useCase: "Negative case: webhook of another client", deliveryID: "3", principal: other, expectedError: fmt.Sprintf("No 'webhooks' found for Id: '%v'", created.ID)},
+	}
+
+	for _, tc := range testCases {
+		if tc.replay {
+			err = webhooksService.Replay(created.ID, tc.deliveryID, tc.principal)
+		} else {
+			err = webhooksService.Acknowledge(created.ID, tc.deliveryID, tc.principal)
+		}
+
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		} else {
+			assert.NoError(t, err, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+	}
+
+	remaining, err := webhooksService.DeadLetters(created.ID, partner)
+	assert.NoError(t, err)
+	assert.Len(t, remaining, 1)
+	assert.Equal(t, "3", remaining[0].ID)
+
+	assert.Len(t, *replayed, 1)
+	assert.Equal(t, "event-2", (*replayed)[0].ID)
+
+	// Without deliveries dead letters cannot be replayed, they are kept.
+	disabled := NewWebhooks(logger, webhooks, store.NewDeliveries(logger), deadLetters, nil)
+	assert.EqualError(t, disabled.Replay(created.ID, "3", partner), "Webhook deliveries are disabled")
+	assert.Len(t, deadLetters.List(created.ID), 1)
+}
+
+func TestServiceReceiptEvents(t *testing.T) {
+	logger, _ := log.NewCustomLogger("test.log")
+	receipts, ledger, reviews := store.New(logger), store.NewLedger(logger), store.NewReviews(logger)
+
+	events := &recorder{}
+	scorer := fraud.New(logger, flagRetailer("Shady Mart"))
+	receiptService := New(logger, receipts, WithLedger(ledger), WithFraudScoring(scorer, 20, 50, reviews), WithPublisher(events))
+	returnsService := NewReturns(logger, receipts, store.NewAdjustments(logger), ledger, reviews, events)
+	reviewsService := NewReviews(logger, reviews, ledger, nil, 0, events)
+
+	// Scores 18 points at Corner Market and 15 at Shady Mart, returning the gum leaves 12 at Corner Market.
+	newReceipt := func(retailer string) *model.Receipt {
+		return &model.Receipt{
+			UserID:       "user-1",
+			ClientID:     "partner",
+			Retailer:     model.StringPointer(retailer),
+			PurchaseDate: model.StringPointer("2024-03-02"),
+			PurchaseTime: model.StringPointer("09:00"),
+			Total:        model.StringPointer("3.49"),
+			Items: []model.Item{
+				{ShortDescription: model.StringPointer("Pepsi"), Price: model.StringPointer("1.49")},
+				{ShortDescription: model.StringPointer("Gum"), Price: model.StringPointer("2.00")},
+			},
+		}
+	}
+
+	genuine, err := receiptService.Insert(context.Background(), newReceipt("Corner Market"))
+	assert.NoError(t, err)
+	held, err := receiptService.Insert(context.Background(), newReceipt("Shady Mart"))
+	assert.NoError(t, err)
+
+	_, err = returnsService.Return(genuine.Id, &model.ReturnRequest{Items: []model.Item{{ShortDescription: model.StringPointer("Gum"), Price: model.StringPointer("2.00")}}}, nil)
+	assert.NoError(t, err)
+
+	_, err = reviewsService.Decide(context.Background(), held.Id, &model.ReviewDecision{Decision: model.DecisionReject})
+	assert.NoError(t, err)
+
+	expected := []struct {
+		eventType string
+		receiptID string
+		points    int
+		status    string
+	}{
+		{eventType: model.EventReceiptScored, receiptID: genuine.Id, points: 18},
+		{eventType: model.EventReceiptScored, receiptID: held.Id, points: 15, status: model.ReviewPending},
+		{eventType: model.EventReceiptAdjusted, receiptID: genuine.Id, points: 12},
+		{eventType: model.EventReceiptVoided, receiptID: held.Id, points: 15, status: model.ReviewRejected},
+	}
+
+	assert.Len(t, *events, len(expected))
+	for i, e := range *events {
+		assert.Equal(t, expected[i].eventType, e.Type, fmt.Sprintf("Test %v Failed with use case %v", i+1, e.Type))
+		assert.Equal(t, expected[i].receiptID, e.Data.ReceiptID, fmt.Sprintf("Test %v Failed with use case %v", i+1, e.Type))
+		assert.Equal(t, expected[i].points, e.Data.Points, fmt.Sprintf("Test %v Failed with use case %v", i+1, e.Type))
+		assert.Equal(t, expected[i].status, e.Data.Status, fmt.Sprintf("Test %v Failed with use case %v", i+1, e.Type))
+		assert.Equal(t, "partner", e.ClientID, fmt.Sprintf("Test %v Failed with use case %v", i+1, e.Type))
+	}
+}
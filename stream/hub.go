@@ -0,0 +1,137 @@
+package stream
+
+import (
+	"sync"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/metrics"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// subscriber is a client of the stream, receiving the events matching its filter.
+type subscriber struct {
+	filter model.StreamFilter
+	events chan model.StreamEvent
+}
+
+// Hub fans scored receipts out to the clients of the receipts stream. It keeps the latest events in a bounded buffer
+// so that clients resume after a reconnection. Publishing never blocks: a client whose buffer is full is disconnected
+// and resumes from the buffer when it reconnects. It implements service.Publisher and service.Stream.
+type Hub struct {
+	mu          sync.Mutex
+	buffer      []model.StreamEvent // Latest events, oldest first.
+	size        int                 // Maximum number of events in buffer.
+	lastID      uint64              // ID of the latest event.
+	subscribers map[*subscriber]struct{}
+	clientSize  int  // Number of events buffered for each client.
+	closed      bool // Clients are no longer accepted once the hub is closed.
+}
+
+// New creates and returns a Hub keeping the latest size events, buffering up to clientSize events for each client.
+func New(size, clientSize int) *Hub {
+	return &Hub{
+		size:        size,
+		subscribers: make(map[*subscriber]struct{}),
+		clientSize:  clientSize,
+	}
+}
+
+// Publish sends the receipt of a receipt.scored event to the matching clients, other events are ignored.
+func (h *Hub) Publish(event *model.Event) {
+	if event.Type != model.EventReceiptScored {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastID++
+	se := model.StreamEvent{
+		ID:        h.lastID,
+		ReceiptID: event.Data.ReceiptID,
+		Retailer:  event.Data.Retailer,
+		Points:    event.Data.Points,
+		Status:    event.Data.Status,
+		ClientID:  event.ClientID,
+		UserID:    event.UserID,
+		ScoredAt:  event.CreatedAt,
+	}
+
+	h.buffer = append(h.buffer, se)
+	if len(h.buffer) > h.size {
+		h.buffer = h.buffer[len(h.buffer)-h.size:]
+	}
+
+	for s := range h.subscribers {
+		if !s.filter.Matches(&se) {
+			continue
+		}
+
+		select {
+		case s.events <- se:
+		default:
+			// Slow client, disconnects it rather than blocking the insert.
+			delete(h.subscribers, s)
+			close(s.events)
+
+			metrics.StreamClientsDropped.WithLabelValues().Inc()
+		}
+	}
+}
+
+// Subscribe registers a client and returns the channel of its events along with the buffered events matching filter
+// published after lastEventID, 0 for none. The channel is closed when the client is too slow to keep up,
+// cancel unregisters the client.
+func (h *Hub) Subscribe(filter model.StreamFilter, lastEventID uint64) (<-chan model.StreamEvent, []model.StreamEvent, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var replay []model.StreamEvent
+	if lastEventID > 0 {
+		for _, se := range h.buffer {
+			if se.ID > lastEventID && filter.Matches(&se) {
+				replay = append(replay, se)
+			}
+		}
+	}
+
+	s := &subscriber{filter: filter, events: make(chan model.StreamEvent, h.clientSize)}
+	if h.closed {
+		close(s.events)
+
+		return s.events, replay, func() {}
+	}
+
+	h.subscribers[s] = struct{}{}
+
+	cancel := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		if _, ok := h.subscribers[s]; ok {
+			delete(h.subscribers, s)
+			close(s.events)
+		}
+	}
+
+	return s.events, replay, cancel
+}
+
+// Close disconnects every client and stops accepting new ones, so that open streams do not hold up a shutdown.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for s := range h.subscribers {
+		delete(h.subscribers, s)
+		close(s.events)
+	}
+}
+
+// Clients returns the number of connected clients.
+func (h *Hub) Clients() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.subscribers)
+}
@@ -0,0 +1,87 @@
+package stream
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// scored returns the receipt.scored event of a receipt.
+func scored(receiptID, clientID, retailer string, points int) *model.Event {
+	return model.NewEvent(model.EventReceiptScored, clientID, model.ReceiptEventData{ReceiptID: receiptID, Retailer: retailer, Points: points})
+}
+
+// receiptIDs returns the receipt IDs of events.
+func receiptIDs(events []model.StreamEvent) []string {
+	var ids []string
+	for _, e := range events {
+		ids = append(ids, e.ReceiptID)
+	}
+
+	return ids
+}
+
+func TestHub_Resume(t *testing.T) {
+	hub := New(3, 10)
+	hub.Publish(scored("a", "partner", "Target", 10))
+	hub.Publish(scored("b", "other", "Walmart", 20))
+	hub.Publish(model.NewEvent(model.EventReceiptVoided, "partner", model.ReceiptEventData{ReceiptID: "a"}))
+	hub.Publish(scored("c", "partner", "target ", 30))
+	hub.Publish(scored("d", "partner", "Walmart", 40))
+
+	testCases := []struct {
+		id          int
+		useCase     string
+		filter      model.StreamFilter
+		lastEventID uint64
+		expectedIDs []string
+	}{
+		{id: 1, useCase: "Positive case: new stream replays nothing", filter: model.StreamFilter{}, lastEventID: 0, expectedIDs: nil},
+		{id: 2, useCase: "Positive case: resume after an event", filter: model.StreamFilter{}, lastEventID: 2, expectedIDs: []string{"c", "d"}},
+		{id: 3, useCase: "Positive case: resume from the oldest buffered event", filter: model.StreamFilter{}, lastEventID: 1, expectedIDs: []string{"b", "c", "d"}},
+		{id: 4, useCase: "Positive case: filtered by retailer", filter: model.StreamFilter{Retailer: "TARGET"}, lastEventID: 1, expectedIDs: []string{"c"}},
+		{id: 5, useCase: "Positive case: filtered by client", filter: model.StreamFilter{ClientID: "partner"}, lastEventID: 1, expectedIDs: []string{"c", "d"}},
+	}
+
+	for _, tc := range testCases {
+		_, replay, cancel := hub.Subscribe(tc.filter, tc.lastEventID)
+		assert.Equal(t, tc.expectedIDs, receiptIDs(replay), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		cancel()
+	}
+
+	assert.Equal(t, 0, hub.Clients())
+}
+
+func TestHub_Backpressure(t *testing.T) {
+	hub := New(10, 2)
+
+	slow, _, cancelSlow := hub.Subscribe(model.StreamFilter{}, 0)
+	defer cancelSlow()
+	filtered, _, cancelFiltered := hub.Subscribe(model.StreamFilter{Retailer: "Walmart"}, 0)
+	defer cancelFiltered()
+
+	// Publishing never blocks on the slow client, which is disconnected once its buffer is full.
+	for _, id := range []string{"a", "b", "c", "d"} {
+		hub.Publish(scored(id, "partner", "Target", 10))
+	}
+
+	var received []model.StreamEvent
+	for e := range slow {
+		received = append(received, e)
+	}
+
+	assert.Equal(t, []string{"a", "b"}, receiptIDs(received))
+	assert.Equal(t, uint64(2), received[1].ID)
+	assert.Equal(t, 1, hub.Clients())
+
+	hub.Close()
+
+	_, open := <-filtered
+	assert.False(t, open)
+
+	events, _, _ := hub.Subscribe(model.StreamFilter{}, 0)
+	_, open = <-events
+	assert.False(t, open)
+}
@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// NewClient returns the HTTP client deliveries are sent with. It refuses to connect to addresses internal to the network
+// of the service, see model.IsPublicAddr, checking the address actually dialed so that names resolving to internal
+// addresses, and redirects to them, are refused too. Proxies are not used, they would dial on behalf of the client.
+func NewClient() *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: publicOnly}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+		},
+	}
+}
+
+// publicOnly is the dial control of NewClient, it fails connections to internal addresses.
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	if !model.IsPublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("webhook destination %v is an internal address", addrPort.Addr())
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/metrics"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+// Config configures the deliveries of a Dispatcher.
+type Config struct {
+	Workers     int           // Number of concurrent deliveries.
+	MaxAttempts int           // Attempts of a delivery before it goes to the dead-letter list.
+	Backoff     time.Duration // Delay before the first retry, doubled on every retry.
+	MaxBackoff  time.Duration // Bound of the delay between retries.
+	Timeout     time.Duration // Bound of a single delivery request.
+}
+
+// attempt is a pending attempt to deliver an event to a webhook.
+type attempt struct {
+	webhook model.Webhook
+	event   model.Event
+	number  int
+}
+
+// Dispatcher delivers events to the webhooks subscribed to them, retrying failed deliveries with exponential backoff.
+// Every attempt is recorded in the delivery log, deliveries failing their last attempt are kept as dead letters too.
+// It implements service.Publisher and service.Redeliverer.
+type Dispatcher struct {
+	logger      *log.CustomLogger
+	webhooks    data.Webhooks
+	deliveries  data.Deliveries
+	deadLetters data.DeadLetters
+	client      *http.Client
+	cfg         Config
+	attempts    chan attempt
+	now         func() time.Time
+}
+
+// New creates and returns a Dispatcher delivering events with client, http.DefaultClient when nil.
+func New(l *log.CustomLogger, webhooks data.Webhooks, deliveries data.Deliveries, deadLetters data.DeadLetters, client *http.Client, cfg Config) *Dispatcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &Dispatcher{
+		logger:      l,
+		webhooks:    webhooks,
+		deliveries:  deliveries,
+		deadLetters: deadLetters,
+		client:      client,
+		cfg:         cfg,
+		attempts:    make(chan attempt, 1024),
+		now:         time.Now,
+	}
+}
+
+// Publish queues the delivery of an event to every webhook of its client subscribed to its type, it never blocks.
+func (d *Dispatcher) Publish(event *model.Event) {
+	for _, webhook := range d.webhooks.Subscribed(event.ClientID, event.UserID, event.Type) {
+		d.schedule(attempt{webhook: webhook, event: *event, number: 1})
+	}
+}
+
+// Redeliver queues a new delivery of an event to a webhook, starting over with its first attempt. It never blocks.
+func (d *Dispatcher) Redeliver(webhook *model.Webhook, event *model.Event) {
+	d.schedule(attempt{webhook: *webhook, event: *event, number: 1})
+}
+
+// Run delivers queued events in the configured number of workers until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < d.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case a := <-d.attempts:
+					d.deliver(ctx, a)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// schedule queues an attempt, it is recorded as a dead letter when the queue is full.
+func (d *Dispatcher) schedule(a attempt) {
+	select {
+	case d.attempts <- a:
+	default:
+		d.record(a, model.DeliveryDead, 0, "delivery queue is full")
+	}
+}
+
+// deliver sends an event to a webhook and records the outcome, failed attempts are retried after a backoff.
+func (d *Dispatcher) deliver(ctx context.Context, a attempt) {
+	statusCode, err := d.send(ctx, a)
+	if err == nil {
+		d.record(a, model.DeliverySucceeded, statusCode, "")
+
+		return
+	}
+
+	if a.number >= d.cfg.MaxAttempts {
+		d.record(a, model.DeliveryDead, statusCode, err.Error())
+
+		lm := log.Message{Level: "ERROR", ErrorMessage: fmt.Sprintf("Delivering event %v to webhook %v failed after %v attempts: %v", a.event.ID, a.webhook.ID, a.number, err.Error())}
+		d.logger.Log(&lm)
+
+		return
+	}
+
+	d.record(a, model.DeliveryRetrying, statusCode, err.Error())
+
+	next := a
+	next.number++
+	time.AfterFunc(d.backoff(a.number), func() { d.schedule(next) })
+}
+
+// send posts the signed event to the webhook, it returns an error unless the receiver responds with a 2xx status.
+func (d *Dispatcher) send(ctx context.Context, a attempt) (int, error) {
+	body, err := json.Marshal(a.event)
+	if err != nil {
+		return 0, err
+	}
+
+	if d.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.cfg.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-ID", a.webhook.ID)
+	req.Header.Set("X-Webhook-Event", a.event.Type)
+	req.Header.Set("X-Webhook-Delivery-Attempt", fmt.Sprint(a.number))
+	req.Header.Set(SignatureHeader, Sign(a.webhook.Secret, d.now(), body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with status %v", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff returns the delay before the retry following the given attempt.
+func (d *Dispatcher) backoff(number int) time.Duration {
+	delay := d.cfg.Backoff
+	for i := 1; i < number && delay < d.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, d.cfg.MaxBackoff)
+}
+
+// record appends an attempt to the delivery log, dead attempts carry the event and are kept as dead letters.
+func (d *Dispatcher) record(a attempt, status string, statusCode int, errMsg string) {
+	delivery := &model.Delivery{
+		ID:         uuid.New().String(),
+		WebhookID:  a.webhook.ID,
+		EventID:    a.event.ID,
+		EventType:  a.event.Type,
+		Attempt:    a.number,
+		Status:     status,
+		StatusCode: statusCode,
+		Error:      errMsg,
+		CreatedAt:  d.now().UTC(),
+	}
+
+	if status == model.DeliveryDead {
+		event := a.event
+		delivery.Event = &event
+	}
+
+	d.deliveries.Insert(delivery)
+	if status == model.DeliveryDead {
+		d.deadLetters.Insert(delivery)
+	}
+
+	metrics.WebhookDeliveries.WithLabelValues(status).Inc()
+}
@@ -0,0 +1,156 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	store "github/shivasaicharanruthala/backend-engineer-takehome/data"
+	"github/shivasaicharanruthala/backend-engineer-takehome/log"
+	"github/shivasaicharanruthala/backend-engineer-takehome/model"
+)
+
+const secret = "s3cr3t"
+
+// newDispatcher returns a running dispatcher with a webhook of the client partner posting to url, subscribed to scored receipts.
+func newDispatcher(t *testing.T, url string, maxAttempts int) (*Dispatcher, store.Deliveries, store.DeadLetters) {
+	logger, _ := log.NewCustomLogger("test.log")
+	webhooks, deliveries, deadLetters := store.NewWebhooks(logger), store.NewDeliveries(logger), store.NewDeadLetters(logger)
+	webhooks.Insert(&model.Webhook{ID: "hook", ClientID: "partner", URL: url, Events: []string{model.EventReceiptScored}, Secret: secret})
+
+	d := New(logger, webhooks, deliveries, deadLetters, nil, Config{Workers: 2, MaxAttempts: maxAttempts, Backoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond, Timeout: time.Second})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go d.Run(ctx)
+
+	return d, deliveries, deadLetters
+}
+
+func TestDispatcher(t *testing.T) {
+	testCases := []struct {
+		id               int
+		useCase          string
+		failures         int32
+		maxAttempts      int
+		expectedStatuses []string
+	}{
+		{id: 1, useCase: "Positive case: delivered on the first attempt", failures: 0, maxAttempts: 3, expectedStatuses: []string{model.DeliverySucceeded}},
+		{id: 2, useCase: "Positive case: delivered after retries", failures: 2, maxAttempts: 3, expectedStatuses: []string{model.DeliverySucceeded, model.DeliveryRetrying, model.DeliveryRetrying}},
+		{id: 3, useCase: "Negative case: dead letter after the last attempt", failures: 5, maxAttempts: 2, expectedStatuses: []string{model.DeliveryDead, model.DeliveryRetrying}},
+	}
+
+	for _, tc := range testCases {
+		var calls atomic.Int32
+		var received atomic.Value
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			if !Verify(secret, r.Header.Get(SignatureHeader), body, time.Now(), time.Minute) || r.Header.Get("X-Webhook-Event") != model.EventReceiptScored {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			if calls.Add(1) <= tc.failures {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			received.Store(body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		d, deliveries, deadLetters := newDispatcher(t, server.URL, tc.maxAttempts)
+		d.Publish(model.NewEvent(model.EventReceiptScored, "partner", model.ReceiptEventData{ReceiptID: "receipt-1", Points: 28}))
+		d.Publish(model.NewEvent(model.EventReceiptVoided, "partner", model.ReceiptEventData{ReceiptID: "receipt-1"}))
+		d.Publish(model.NewEvent(model.EventReceiptScored, "other", model.ReceiptEventData{ReceiptID: "receipt-2"}))
+
+		assert.Eventually(t, func() bool {
+			return len(deliveries.List("hook", "")) == len(tc.expectedStatuses)
+		}, time.Second, 5*time.Millisecond, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+
+		var statuses []string
+		for i, delivery := range deliveries.List("hook", "") {
+			statuses = append(statuses, delivery.Status)
+			assert.Equal(t, len(tc.expectedStatuses)-i, delivery.Attempt, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+
+		assert.Equal(t, tc.expectedStatuses, statuses, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+
+		if tc.expectedStatuses[0] == model.DeliveryDead {
+			dead := deadLetters.List("hook")
+			assert.Len(t, dead, 1, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			assert.Equal(t, http.StatusInternalServerError, dead[0].StatusCode, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+			assert.Equal(t, "receipt-1", dead[0].Event.Data.ReceiptID, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		} else {
+			assert.Empty(t, deadLetters.List("hook"), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+
+			var event model.Event
+			_ = json.Unmarshal(received.Load().([]byte), &event)
+			assert.Equal(t, 28, event.Data.Points, fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+		}
+
+		server.Close()
+	}
+}
+
+func TestDispatcher_Redeliver(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	d, deliveries, _ := newDispatcher(t, server.URL, 1)
+	webhook := &model.Webhook{ID: "hook", ClientID: "partner", URL: server.URL, Secret: secret}
+
+	// Redeliveries reach the webhook even when it no longer subscribes to the type of the event.
+	d.Redeliver(webhook, model.NewEvent(model.EventReceiptAdjusted, "partner", model.ReceiptEventData{ReceiptID: "receipt-1"}))
+
+	assert.Eventually(t, func() bool { return len(deliveries.List("hook", model.DeliverySucceeded)) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+	body := []byte(`{"id":"1"}`)
+	header := Sign(secret, now, body)
+
+	testCases := []struct {
+		id       int
+		useCase  string
+		secret   string
+		header   string
+		body     []byte
+		now      time.Time
+		expected bool
+	}{
+		{id: 1, useCase: "Positive case: valid signature", secret: secret, header: header, body: body, now: now, expected: true},
+		{id: 2, useCase: "Negative case: tampered body", secret: secret, header: header, body: []byte(`{"id":"2"}`), now: now, expected: false},
+		{id: 3, useCase: "Negative case: wrong secret", secret: "other", header: header, body: body, now: now, expected: false},
+		{id: 4, useCase: "Negative case: replayed after the tolerance", secret: secret, header: header, body: body, now: now.Add(10 * time.Minute), expected: false},
+		{id: 5, useCase: "Negative case: malformed header", secret: secret, header: "v1=abc", body: body, now: now, expected: false},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, Verify(tc.secret, tc.header, tc.body, tc.now, 5*time.Minute), fmt.Sprintf("Test %v Failed with use case %v", tc.id, tc.useCase))
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	// Registered URLs may resolve to internal addresses later, the client refuses to dial them.
+	_, err := NewClient().Post(server.URL, "application/json", nil)
+	assert.ErrorContains(t, err, "is an internal address")
+}
@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader carries the signature of a delivery, in the format t=<unix timestamp>,v1=<hex HMAC-SHA256>.
+// The HMAC is computed with the secret of the webhook over the timestamp, a dot and the raw body,
+// so that receivers can reject replayed deliveries by their timestamp.
+const SignatureHeader = "X-Webhook-Signature"
+
+// Sign returns the signature header value of a body sent at t with secret.
+func Sign(secret string, t time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+
+	return fmt.Sprintf("t=%v,v1=%v", timestamp, mac(secret, timestamp, body))
+}
+
+// Verify reports whether header is a valid signature of body with secret, sent within tolerance of now.
+func Verify(secret, header string, body []byte, now time.Time, tolerance time.Duration) bool {
+	var timestamp, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signature = value
+		}
+	}
+
+	sent, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || now.Sub(time.Unix(sent, 0)).Abs() > tolerance {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(mac(secret, timestamp, body)))
+}
+
+// mac returns the hex HMAC-SHA256 of the timestamp, a dot and the body.
+func mac(secret, timestamp string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(timestamp + "."))
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}